@@ -26,6 +26,7 @@ import (
 
 	"github.com/hyperledger/fabric/events/consumer"
 	pb "github.com/hyperledger/fabric/protos"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpevents"
 )
 
 type adapter struct {
@@ -125,6 +126,39 @@ func createEventClient(eventAddress string) *adapter {
 	return adapter
 }
 
+// prints the typed notifications of the invoke result events of the iot contract platform
+func newDispatcher() *iotcpevents.Dispatcher {
+	return iotcpevents.NewDispatcher().
+		Handle(iotcpevents.AlertRaised, func(env iotcpevents.Envelope, n iotcpevents.Notification) error {
+			var alert iotcpevents.AlertData
+			if err := n.DecodeData(&alert); err != nil {
+				return err
+			}
+			fmt.Printf("ALERT RAISED %s on %s %s\n", alert.Alert, n.Class, n.AssetID)
+			return nil
+		}).
+		Handle(iotcpevents.AlertCleared, func(env iotcpevents.Envelope, n iotcpevents.Notification) error {
+			var alert iotcpevents.AlertData
+			if err := n.DecodeData(&alert); err != nil {
+				return err
+			}
+			fmt.Printf("ALERT CLEARED %s on %s %s\n", alert.Alert, n.Class, n.AssetID)
+			return nil
+		}).
+		Handle(iotcpevents.GeofenceExit, func(env iotcpevents.Envelope, n iotcpevents.Notification) error {
+			var exit iotcpevents.GeofenceData
+			if err := n.DecodeData(&exit); err != nil {
+				return err
+			}
+			fmt.Printf("GEOFENCE EXIT %s %s is %.0fm from the center of a %.0fm fence\n", n.Class, n.AssetID, exit.Distance, exit.Radius)
+			return nil
+		}).
+		HandleOther(func(env iotcpevents.Envelope, n iotcpevents.Notification) error {
+			fmt.Printf("NOTIFICATION %s on %s %s: %s\n", n.Type, n.Class, n.AssetID, string(n.Data))
+			return nil
+		})
+}
+
 func main() {
 	var eventAddress string
 	flag.StringVar(&eventAddress, "events-address", "0.0.0.0:7053", "address of events server")
@@ -138,6 +172,7 @@ func main() {
 		return
 	}
 	fmt.Printf("Event client appears to have been succesfully created\n")
+	d := newDispatcher()
 
 	for {
 		select {
@@ -150,6 +185,14 @@ func main() {
 		case p := <-a.cc:
 			fmt.Printf("\nReceived chaincode event\n")
 			fmt.Printf("%+v\n\n", p)
+			if p.ChaincodeEvent.EventName == iotcpevents.EventName {
+				env, err := d.Dispatch(p.ChaincodeEvent.Payload)
+				if err != nil {
+					fmt.Printf("Invoke result event not understood: %s\n\n", err)
+				} else {
+					fmt.Printf("Invoke result %s %s\n\n", env.Status, env.Message)
+				}
+			}
 		}
 	}
 }
@@ -90,7 +90,7 @@ import (
 	"time"
 )
 
-//go:generate go run scripts/generate_go_schema.go
+//go:generate go run /local-dev/src/github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/scripts/processSchema.go -configFile scripts/generate.json
 
 //***************************************************
 //***************************************************
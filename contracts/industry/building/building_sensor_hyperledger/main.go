@@ -32,7 +32,7 @@ import (
 	"time"
 )
 
-// For now, We will not use go generate here : go:generate go run scripts/generate_go_schema.go
+// For now, We will not use go generate here : go:generate go run /local-dev/src/github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/scripts/processSchema.go -configFile scripts/generate.json
 // This is because there are different assets of various structures coming in. The only
 // certainity is that the assets should have an asset id and asset type. These will be extracted
 // and the asset data stored and manipulated as such
@@ -19,10 +19,14 @@ Kim Letkeman - Initial Contribution
 package main
 
 import (
+	"encoding/json"
+	"fmt"
 	"math"
+	"strings"
 
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpevents"
 )
 
 // SurgicalKitClass acts as the class of all SurgicalKits
@@ -46,125 +50,333 @@ func newSurgicalKit() iot.Asset {
 	}
 }
 
-var createAssetSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return SurgicalKitClass.CreateAsset(stub, args, "createAssetSurgicalKit", []iot.QPropNV{})
+var distanceFromFenceCenter = iot.ComputedProperty{
+	QProp: "surgicalkit.distanceFromFenceCenter",
+	DependsOn: []string{
+		"surgicalkit.sensors.endlocation.latitude",
+		"surgicalkit.sensors.endlocation.longitude",
+		"surgicalkit.hospital.fence.center.latitude",
+		"surgicalkit.hospital.fence.center.longitude",
+	},
+	Function: func(stub shim.ChaincodeStubInterface, SurgicalKit *iot.Asset) (interface{}, bool, error) {
+		lat, _ := iot.GetObjectAsNumber(SurgicalKit.State, "surgicalkit.sensors.endlocation.latitude")
+		long, _ := iot.GetObjectAsNumber(SurgicalKit.State, "surgicalkit.sensors.endlocation.longitude")
+		flat, _ := iot.GetObjectAsNumber(SurgicalKit.State, "surgicalkit.hospital.fence.center.latitude")
+		flong, _ := iot.GetObjectAsNumber(SurgicalKit.State, "surgicalkit.hospital.fence.center.longitude")
+		// convert to meters and round up
+		return math.Ceil(iot.Distance(lat, long, flat, flong) * 1000), true, nil
+	},
 }
 
-var replaceAssetSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return SurgicalKitClass.ReplaceAsset(stub, args, "replaceAssetSurgicalKit", []iot.QPropNV{})
+var outOfAreaAlert iot.AlertName = "OUTOFAREA"
+var outOfAreaRule iot.RuleFunc = func(stub shim.ChaincodeStubInterface, SurgicalKit *iot.Asset) error {
+	kit, err := SurgicalkitFromState(SurgicalKit.State)
+	if err != nil {
+		return err
+	}
+	status, found := kit.GetStatus()
+	if !found || status != StatusHospital {
+		return nil
+	}
+	distance, found := kit.GetDistanceFromFenceCenter()
+	if !found {
+		return nil
+	}
+	radius, found := kit.GetHospital().GetFence().GetRadius()
+	if !found {
+		return nil
+	}
+	if distance > radius {
+		if !iot.Contains(SurgicalKit.AlertsActive, outOfAreaAlert) {
+			lat, _ := kit.GetSensors().GetEndlocation().GetLatitude()
+			long, _ := kit.GetSensors().GetEndlocation().GetLongitude()
+			exit := iotcpevents.GeofenceData{Distance: distance, Radius: radius, Latitude: lat, Longitude: long}
+			if err := SurgicalKit.Notify(stub, iotcpevents.GeofenceExit, exit); err != nil {
+				return err
+			}
+		}
+		iot.RaiseAlert(SurgicalKit, outOfAreaAlert)
+	} else {
+		iot.ClearAlert(SurgicalKit, outOfAreaAlert)
+	}
+	return nil
 }
 
-var updateAssetSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	if len(args) > 0 {
-		log.Debugf("Payload received in updateAssetSurgicalKit: %s", args[0])
+// contractProperties are written only by the kit's own routes, the create, update and replace
+// routes reject events that contain them, except for a new kit's oem status and holder, replace
+// carries them into the kit's new state and deleteProperties cannot remove them
+var contractProperties = []struct {
+	qprop  string
+	routes string
+}{
+	{"surgicalkit.status", "the custody routes shipSurgicalKit and receiveSurgicalKit"},
+	{"surgicalkit.transit", "the custody routes shipSurgicalKit and receiveSurgicalKit"},
+	{"surgicalkit.holder", "the custody routes shipSurgicalKit and receiveSurgicalKit"},
+	{"surgicalkit.custody", "the custody routes shipSurgicalKit and receiveSurgicalKit"},
+	{"surgicalkit.sterilizations", "sterilizeSurgicalKit"},
+	{"surgicalkit.damage", "the excess force and tilt rules and inspectSurgicalKit"},
+}
+
+// the kit's last reported location, its sensors' end location or else its common location
+func kitLocation(kit *Surgicalkit) *Geo {
+	if location := kit.GetSensors().GetEndlocation(); location != nil {
+		return location
 	}
-	return SurgicalKitClass.UpdateAsset(stub, args, "updateAssetSurgicalKit", []iot.QPropNV{})
+	return kit.GetCommon().GetLocation()
 }
 
-var deleteAssetSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return SurgicalKitClass.DeleteAsset(stub, args)
+// reads the kit named by the surgicalkit object of a route's argument
+func getSurgicalKit(stub shim.ChaincodeStubInterface, caller string, arg Surgicalkit) (iot.Asset, *Surgicalkit, error) {
+	skitID, found := arg.GetSkitID()
+	if !found || skitID == "" {
+		err := fmt.Errorf("%s needs %s", caller, SurgicalKitClass.AssetIDPath)
+		log.Errorf(err.Error())
+		return iot.Asset{}, nil, err
+	}
+	a, exists, err := iot.GetAssetFromLedger(stub, SurgicalKitClass.Prefix+skitID)
+	if err != nil {
+		err = fmt.Errorf("%s failed to read kit %s: %s", caller, skitID, err)
+		log.Errorf(err.Error())
+		return iot.Asset{}, nil, err
+	}
+	if !exists {
+		err = fmt.Errorf("%s kit %s does not exist", caller, skitID)
+		log.Errorf(err.Error())
+		return iot.Asset{}, nil, err
+	}
+	kit, err := SurgicalkitFromState(a.State)
+	if err != nil {
+		err = fmt.Errorf("%s failed to read the state of kit %s: %s", caller, skitID, err)
+		log.Errorf(err.Error())
+		return iot.Asset{}, nil, err
+	}
+	return a, kit, nil
 }
 
-var deleteAssetStateHistorySurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return SurgicalKitClass.DeleteAssetStateHistory(stub, args)
+// writes an event built by one of the kit's own routes through the class's update, so that
+// the kit's history, provenance and rules see it like any other update
+func updateSurgicalKit(stub shim.ChaincodeStubInterface, caller string, kit *Surgicalkit) ([]byte, error) {
+	eventBytes, err := json.Marshal(map[string]interface{}{"surgicalkit": kit})
+	if err != nil {
+		err = fmt.Errorf("%s failed to marshal the event: %s", caller, err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	return SurgicalKitClass.UpdateAsset(stub, []string{string(eventBytes)}, caller, nil)
 }
 
-var deleteAllAssetsSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return SurgicalKitClass.DeleteAllAssets(stub, args)
+// rejects an update or replace event that writes a contract property, the event is nil
+// when it does not unmarshal, which the class's route reports
+func checkEvent(route string, args []string) (map[string]interface{}, error) {
+	var event map[string]interface{}
+	if len(args) == 0 || json.Unmarshal([]byte(args[0]), &event) != nil {
+		return nil, nil
+	}
+	for _, cp := range contractProperties {
+		if _, found := iot.GetObject(&event, cp.qprop); found {
+			err := fmt.Errorf("%s cannot write %s, which changes only through %s", route, cp.qprop, cp.routes)
+			log.Errorf(err.Error())
+			return nil, err
+		}
+	}
+	if err := checkInstruments(route, event); err != nil {
+		return nil, err
+	}
+	return event, nil
 }
 
-var deletePropertiesFromAssetSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return SurgicalKitClass.DeletePropertiesFromAsset(stub, args, "deletePropertiesFromAssetSurgicalKit", []iot.QPropNV{})
+// rejects a create event that writes a contract property, a new kit may only be given its
+// oem status and its holder at the oem, the event is nil when it does not unmarshal
+func checkCreateEvent(route string, args []string) (map[string]interface{}, error) {
+	var event map[string]interface{}
+	if len(args) == 0 || json.Unmarshal([]byte(args[0]), &event) != nil {
+		return nil, nil
+	}
+	for _, cp := range contractProperties {
+		v, found := iot.GetObject(&event, cp.qprop)
+		if !found {
+			continue
+		}
+		switch {
+		case cp.qprop == "surgicalkit.holder":
+			continue
+		case cp.qprop == "surgicalkit.status" && v == string(StatusOem):
+			continue
+		case cp.qprop == "surgicalkit.status":
+			err := fmt.Errorf("%s cannot create a kit with status %v, a new kit is with its %s and changes status only through %s", route, v, StatusOem, cp.routes)
+			log.Errorf(err.Error())
+			return nil, err
+		}
+		err := fmt.Errorf("%s cannot write %s, which changes only through %s", route, cp.qprop, cp.routes)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	if err := checkInstruments(route, event); err != nil {
+		return nil, err
+	}
+	return event, nil
 }
 
-var readAssetSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return SurgicalKitClass.ReadAsset(stub, args)
+// whether deleting qprop removes prop, because it names prop, one of its parents or one
+// of its children
+func deletesProperty(qprop string, prop string) bool {
+	return qprop == prop || strings.HasPrefix(prop, qprop+".") || strings.HasPrefix(qprop, prop+".")
 }
 
-var readAllAssetsSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return SurgicalKitClass.ReadAllAssets(stub, args)
+// rejects a deleteProperties event whose qprops remove a contract property or the kit's
+// instruments
+func checkDeleteEvent(route string, args []string) error {
+	var event map[string]interface{}
+	if len(args) == 0 || json.Unmarshal([]byte(args[0]), &event) != nil {
+		return nil
+	}
+	qprops, found := iot.GetObjectAsStringArray(&event, "qprops")
+	if !found {
+		qpropsm, _ := iot.GetObjectAsMap(&event, "qprops")
+		for _, v := range qpropsm {
+			if qprop, ok := v.(string); ok {
+				qprops = append(qprops, qprop)
+			}
+		}
+	}
+	for _, qprop := range qprops {
+		for _, cp := range contractProperties {
+			if deletesProperty(qprop, cp.qprop) {
+				err := fmt.Errorf("%s cannot delete %s, which changes only through %s", route, cp.qprop, cp.routes)
+				log.Errorf(err.Error())
+				return err
+			}
+		}
+	}
+	return checkDeleteInstruments(route, qprops)
 }
 
-var readAssetStateHistorySurgicalKit = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return SurgicalKitClass.ReadAssetStateHistory(stub, args)
+// the state of the kit that an event names, nil when the kit does not exist
+func eventKitState(stub shim.ChaincodeStubInterface, event map[string]interface{}) (*map[string]interface{}, error) {
+	skitID, found := iot.GetObjectAsString(&event, SurgicalKitClass.AssetIDPath)
+	if !found {
+		return nil, nil
+	}
+	a, exists, err := iot.GetAssetFromLedger(stub, SurgicalKitClass.Prefix+skitID)
+	if err != nil || !exists {
+		return nil, err
+	}
+	return a.State, nil
 }
 
-var excessForceAlert iot.AlertName = "EXCESSFORCE"
-var excessForceRule iot.RuleFunc = func(stub shim.ChaincodeStubInterface, SurgicalKit *iot.Asset) error {
-	force, found := iot.GetObjectAsNumber(SurgicalKit.State, "surgicalkit.sensors.maxgforce")
-	if found {
-		if force > 2 {
-			iot.RaiseAlert(SurgicalKit, excessForceAlert)
-		} else {
-			iot.ClearAlert(SurgicalKit, excessForceAlert)
-		}
+// the args of a class route with the event in place of the first
+func eventArgs(route string, event map[string]interface{}, args []string) ([]string, error) {
+	eventBytes, err := json.Marshal(event)
+	if err != nil {
+		err = fmt.Errorf("%s failed to marshal the event: %s", route, err)
+		log.Errorf(err.Error())
+		return nil, err
 	}
-	return nil
+	return append([]string{string(eventBytes)}, args[1:]...), nil
 }
 
-var excessTiltAlert iot.AlertName = "EXCESSTILT"
-var excessTiltRule iot.RuleFunc = func(stub shim.ChaincodeStubInterface, SurgicalKit *iot.Asset) error {
-	tilt, found := iot.GetObjectAsNumber(SurgicalKit.State, "surgicalkit.sensors.maxtilt")
-	if found {
-		if tilt > 90 || tilt < -90 {
-			iot.RaiseAlert(SurgicalKit, excessTiltAlert)
-		} else {
-			iot.ClearAlert(SurgicalKit, excessTiltAlert)
-		}
+// createAssetSurgicalKit is the class's create for events that do not write contract
+// properties, a new kit is with its oem and has no custody, sterilization or damage records
+var createAssetSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	if _, err := checkCreateEvent("createAssetSurgicalKit", args); err != nil {
+		return nil, err
 	}
-	return nil
+	return SurgicalKitClass.CreateAsset(stub, args, "createAssetSurgicalKit", nil)
 }
 
-var outOfAreaAlert iot.AlertName = "OUTOFAREA"
-var outOfAreaRule iot.RuleFunc = func(stub shim.ChaincodeStubInterface, SurgicalKit *iot.Asset) error {
-	status, found := iot.GetObjectAsString(SurgicalKit.State, "surgicalkit.status")
-	if !found || status != "hospital" {
-		return nil
+// updateAssetSurgicalKit is the class's update for events that do not write contract
+// properties, instruments that the kit holds keep their cycle counts and lots
+var updateAssetSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	event, err := checkEvent("updateAssetSurgicalKit", args)
+	if err != nil {
+		return nil, err
 	}
-	lat, found := iot.GetObjectAsNumber(SurgicalKit.State, "surgicalkit.sensors.endlocation.latitude")
-	if !found {
-		return nil
+	state, err := eventKitState(stub, event)
+	if err != nil {
+		return nil, err
 	}
-	long, found := iot.GetObjectAsNumber(SurgicalKit.State, "surgicalkit.sensors.endlocation.longitude")
-	if !found {
-		return nil
+	if state != nil {
+		if err := checkInstrumentLots("updateAssetSurgicalKit", event, state); err != nil {
+			return nil, err
+		}
 	}
-	flat, found := iot.GetObjectAsNumber(SurgicalKit.State, "surgicalkit.hospital.fence.center.latitude")
-	if !found {
-		return nil
+	if state != nil && carryInstrumentCycles(event, state) {
+		if args, err = eventArgs("updateAssetSurgicalKit", event, args); err != nil {
+			return nil, err
+		}
 	}
-	flong, found := iot.GetObjectAsNumber(SurgicalKit.State, "surgicalkit.hospital.fence.center.longitude")
-	if !found {
-		return nil
+	return SurgicalKitClass.UpdateAsset(stub, args, "updateAssetSurgicalKit", nil)
+}
+
+// replaceAssetSurgicalKit is the class's replace for events that do not write contract
+// properties, the kit's contract properties and cycle counts are carried into its new state
+var replaceAssetSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	event, err := checkEvent("replaceAssetSurgicalKit", args)
+	if err != nil {
+		return nil, err
 	}
-	radius, found := iot.GetObjectAsNumber(SurgicalKit.State, "surgicalkit.hospital.fence.radius")
-	if !found {
-		return nil
+	state, err := eventKitState(stub, event)
+	if err != nil {
+		return nil, err
 	}
-	// convert to meters and round up
-	distance := math.Ceil(iot.Distance(lat, long, flat, flong) * 1000)
-	if distance > radius {
-		iot.RaiseAlert(SurgicalKit, outOfAreaAlert)
-	} else {
-		iot.ClearAlert(SurgicalKit, outOfAreaAlert)
+	if state != nil {
+		if err := checkInstrumentLots("replaceAssetSurgicalKit", event, state); err != nil {
+			return nil, err
+		}
+		for _, cp := range contractProperties {
+			if v, found := iot.GetObject(state, cp.qprop); found {
+				iot.PutObject(&event, cp.qprop, v)
+			}
+		}
+		carryInstrumentCycles(event, state)
+		if args, err = eventArgs("replaceAssetSurgicalKit", event, args); err != nil {
+			return nil, err
+		}
 	}
-	_ = iot.PutObject(SurgicalKit.State, "distanceFromFenceCenter", distance)
-	return nil
+	return SurgicalKitClass.ReplaceAsset(stub, args, "replaceAssetSurgicalKit", nil)
+}
+
+// deletePropertiesFromAssetSurgicalKit is the class's deleteProperties for properties that
+// are neither contract properties nor the kit's instruments
+var deletePropertiesFromAssetSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	if err := checkDeleteEvent("deletePropertiesFromAssetSurgicalKit", args); err != nil {
+		return nil, err
+	}
+	return SurgicalKitClass.DeletePropertiesFromAsset(stub, args, "deletePropertiesFromAssetSurgicalKit", nil)
 }
 
 func init() {
-	iot.AddRule("Excess Force Alert", SurgicalKitClass, []iot.AlertName{excessForceAlert}, excessForceRule)
-	iot.AddRule("Excess Tilt Alert", SurgicalKitClass, []iot.AlertName{excessTiltAlert}, excessTiltRule)
-	iot.AddRule("Out Of Area Alert", SurgicalKitClass, []iot.AlertName{outOfAreaAlert}, outOfAreaRule)
-
-	iot.AddRoute("createAssetSurgicalKit", "invoke", SurgicalKitClass, createAssetSurgicalKit)
-	iot.AddRoute("replaceAssetSurgicalKit", "invoke", SurgicalKitClass, replaceAssetSurgicalKit)
-	iot.AddRoute("updateAssetSurgicalKit", "invoke", SurgicalKitClass, updateAssetSurgicalKit)
-	iot.AddRoute("deleteAssetSurgicalKit", "invoke", SurgicalKitClass, deleteAssetSurgicalKit)
-	iot.AddRoute("deleteAssetStateHistorySurgicalKit", "invoke", SurgicalKitClass, deleteAssetStateHistorySurgicalKit)
-	iot.AddRoute("deleteAllAssetsSurgicalKit", "invoke", SurgicalKitClass, deleteAllAssetsSurgicalKit)
-	iot.AddRoute("deletePropertiesFromAssetSurgicalKit", "invoke", SurgicalKitClass, deletePropertiesFromAssetSurgicalKit)
-	iot.AddRoute("readAssetSurgicalKit", "query", SurgicalKitClass, readAssetSurgicalKit)
-	iot.AddRoute("readAssetStateHistorySurgicalKit", "query", SurgicalKitClass, readAssetStateHistorySurgicalKit)
-	iot.AddRoute("readAllAssetsSurgicalKit", "query", SurgicalKitClass, readAllAssetsSurgicalKit)
+	if err := iot.AddUnitProperty(SurgicalKitClass, "surgicalkit.sensors.maxgforce", "g"); err != nil {
+		panic(err)
+	}
+	if err := iot.AddUnitProperty(SurgicalKitClass, "surgicalkit.hospital.fence.radius", "m"); err != nil {
+		panic(err)
+	}
+	if err := iot.AddComputedProperty(SurgicalKitClass, distanceFromFenceCenter); err != nil {
+		panic(err)
+	}
+	if err := iot.TrackProvenance(SurgicalKitClass, iot.ProvenanceOptions{QProps: []string{"surgicalkit.status", "surgicalkit.sensors", "surgicalkit.hospital", "surgicalkit.transit", "surgicalkit.holder", "surgicalkit.instruments"}}); err != nil {
+		panic(err)
+	}
+	if err := iot.AddRule("Out Of Area Alert", SurgicalKitClass, []iot.AlertName{outOfAreaAlert}, outOfAreaRule); err != nil {
+		panic(err)
+	}
+
+	// create, update, replace and deleteProperties are guarded against events that write or
+	// remove contract properties
+	if err := iot.RegisterClassRoutes(SurgicalKitClass, iot.ClassRouteOptions{Exclude: []iot.ClassRoute{iot.CreateAssetRoute, iot.UpdateAssetRoute, iot.ReplaceAssetRoute, iot.DeletePropertiesFromAssetRoute}}); err != nil {
+		panic(err)
+	}
+	if err := iot.AddRoute("createAssetSurgicalKit", "invoke", SurgicalKitClass, createAssetSurgicalKit); err != nil {
+		panic(err)
+	}
+	if err := iot.AddRoute("updateAssetSurgicalKit", "invoke", SurgicalKitClass, updateAssetSurgicalKit); err != nil {
+		panic(err)
+	}
+	if err := iot.AddRoute("replaceAssetSurgicalKit", "invoke", SurgicalKitClass, replaceAssetSurgicalKit); err != nil {
+		panic(err)
+	}
+	if err := iot.AddRoute("deletePropertiesFromAssetSurgicalKit", "invoke", SurgicalKitClass, deletePropertiesFromAssetSurgicalKit); err != nil {
+		panic(err)
+	}
 }
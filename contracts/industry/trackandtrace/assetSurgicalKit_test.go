@@ -0,0 +1,128 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpevents"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcptest"
+)
+
+func newSurgicalKitHarness(t *testing.T) *iotcptest.Harness {
+	h := iotcptest.New(t, new(SimpleChaincode))
+	h.Init(CONTRACTVERSION).ExpectOK()
+	return h
+}
+
+func TestSurgicalKitForceAndTilt(t *testing.T) {
+	h := newSurgicalKitHarness(t)
+	h.CreateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K1","status":"oem","sensors":{"maxgforce":1.5,"maxtilt":10}}}`).ExpectOK()
+	h.ExpectNoAlert(SurgicalKitClass, "K1", excessForceAlert).
+		ExpectNoAlert(SurgicalKitClass, "K1", excessTiltAlert).
+		ExpectCompliant(SurgicalKitClass, "K1", true)
+
+	h.UpdateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K1","sensors":{"maxgforce":3.2,"maxtilt":-95}}}`).ExpectOK()
+	h.ExpectState(SurgicalKitClass, "K1", "surgicalkit.status", "oem").
+		ExpectAlert(SurgicalKitClass, "K1", excessForceAlert).
+		ExpectAlert(SurgicalKitClass, "K1", excessTiltAlert).
+		ExpectCompliant(SurgicalKitClass, "K1", false)
+	h.ExpectEvent(iot.EVTCCINVRESULT, "status", "OK")
+
+	// a calm sample does not clear the alerts, only a passed inspection does
+	h.UpdateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K1","sensors":{"maxgforce":{"value":3.92266,"unit":"m/s2"},"maxtilt":5}}}`).ExpectOK()
+	h.ExpectState(SurgicalKitClass, "K1", "surgicalkit.sensors.maxgforce", 0.4).
+		ExpectAlert(SurgicalKitClass, "K1", excessForceAlert).
+		ExpectAlert(SurgicalKitClass, "K1", excessTiltAlert)
+
+	var history []iot.Asset
+	h.ReadAssetStateHistory(SurgicalKitClass, "K1").ExpectResult(&history)
+	if len(history) != 3 {
+		t.Fatalf("expected 3 history states, got %d", len(history))
+	}
+}
+
+func TestSurgicalKitOutOfArea(t *testing.T) {
+	h := newSurgicalKitHarness(t)
+	h.CreateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K2","status":"hospital"}}`).ExpectError("a new kit is with its oem")
+	h.CreateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K2",
+		"hospital":{"fence":{"center":{"latitude":40.7128,"longitude":-74.0060},"radius":{"value":0.5,"unit":"km"}}},
+		"sensors":{"endlocation":{"latitude":40.7130,"longitude":-74.0062}}}}`).ExpectOK()
+	deliverToHospital(h, "K2")
+	h.ExpectNoAlert(SurgicalKitClass, "K2", outOfAreaAlert).
+		ExpectState(SurgicalKitClass, "K2", "surgicalkit.hospital.fence.radius", 500).
+		ExpectState(SurgicalKitClass, "K2", "surgicalkit.distanceFromFenceCenter", 28)
+
+	// roughly 1.1km north of the fence center
+	h.UpdateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K2","sensors":{"endlocation":{"latitude":40.7228,"longitude":-74.0060}}}}`).ExpectOK()
+	h.ExpectAlert(SurgicalKitClass, "K2", outOfAreaAlert).
+		ExpectState(SurgicalKitClass, "K2", "surgicalkit.distanceFromFenceCenter", 1113)
+	var exit iotcpevents.GeofenceData
+	if err := h.ExpectNotification(iotcpevents.GeofenceExit, SurgicalKitClass, "K2").DecodeData(&exit); err != nil || exit.Distance != 1113 || exit.Radius != 500 {
+		t.Fatalf("unexpected geofence exit %+v (%v)", exit, err)
+	}
+	h.ExpectNotification(iotcpevents.AlertRaised, SurgicalKitClass, "K2")
+
+	// a kit that is still outside the fence does not exit it again
+	h.UpdateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K2","sensors":{"endlocation":{"latitude":40.7229,"longitude":-74.0060}}}}`).ExpectOK()
+	h.ExpectNoNotification(iotcpevents.GeofenceExit, SurgicalKitClass, "K2")
+
+	// the distance is computed by the contract
+	h.UpdateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K2","distanceFromFenceCenter":0}}`).ExpectError("computed")
+
+	// the status changes only through a custody handoff, so the alert stays active
+	h.UpdateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K2","status":"retailer"}}`).ExpectError("custody")
+	h.ExpectState(SurgicalKitClass, "K2", "surgicalkit.status", "hospital").
+		ExpectAlert(SurgicalKitClass, "K2", outOfAreaAlert)
+}
+
+func TestSurgicalKitDeleteAllNeedsConfirmation(t *testing.T) {
+	h := newSurgicalKitHarness(t)
+	h.CreateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K3"}}`).ExpectOK()
+	h.Invoke("deleteAllAssetsSurgicalKit", `{}`).ExpectError("confirm")
+	h.Asset(SurgicalKitClass, "K3")
+}
+
+// fixtures.json holds random events that processSchema generates from the schema
+func TestSurgicalKitFixtures(t *testing.T) {
+	var fixtures map[string][][]map[string]interface{}
+	b, err := ioutil.ReadFile("fixtures.json")
+	if err == nil {
+		err = json.Unmarshal(b, &fixtures)
+	}
+	if err != nil {
+		t.Fatalf("cannot read fixtures: %s", err)
+	}
+	h := newSurgicalKitHarness(t)
+	for _, args := range fixtures["createAssetSurgicalKit"] {
+		h.Invoke("createAssetSurgicalKit", args[0]).ExpectOK()
+	}
+	for _, args := range fixtures["updateAssetSurgicalKit"] {
+		kit, _ := args[0]["surgicalkit"].(map[string]interface{})
+		h.CreateAsset(SurgicalKitClass, map[string]interface{}{"surgicalkit": map[string]interface{}{"skitID": kit["skitID"]}}).ExpectOK()
+		h.Invoke("updateAssetSurgicalKit", args[0]).ExpectOK()
+	}
+}
+
+func TestSurgicalKitRoutesMatchSchema(t *testing.T) {
+	if err := iot.VerifyRoutes(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,395 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- two party chain of custody handoff for surgical kits
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpevents"
+)
+
+// custodyProgression is the order in which custodians hold a kit, a kit with no status
+// is still with its oem
+var custodyProgression = []Status{StatusOem, StatusWarehouse, StatusDealer, StatusRetailer, StatusHospital}
+
+// shipmentWindowSetting names the contract setting that holds the shipment window
+const shipmentWindowSetting = "SurgicalKit.ShipmentWindow"
+
+// DefaultShipmentWindowHours is how long a receiving party has to confirm a shipment when
+// no window has been set
+const DefaultShipmentWindowHours float64 = 72
+
+// partyAttribute names the attribute of the caller's enrollment certificate that holds the
+// party the caller acts for
+const partyAttribute = "party"
+
+// ShipmentWindow is the argument to setShipmentWindow and the stored setting
+type ShipmentWindow struct {
+	Hours float64 `json:"hours"`
+}
+
+// ShipmentArg is the argument to shipSurgicalKit
+type ShipmentArg struct {
+	Surgicalkit Surgicalkit `json:"surgicalkit"`
+	Shipment    struct {
+		To        Status `json:"to"`
+		Fromparty string `json:"fromparty"`
+		Toparty   string `json:"toparty"`
+		Carrier   string `json:"carrier"`
+	} `json:"shipment"`
+}
+
+// ReceiptArg is the argument to receiveSurgicalKit
+type ReceiptArg struct {
+	Surgicalkit Surgicalkit `json:"surgicalkit"`
+	Receipt     struct {
+		Party string `json:"party"`
+	} `json:"receipt"`
+}
+
+// the custodian after status, false when status is the last or not in the progression
+func nextCustodian(status Status) (Status, bool) {
+	if status == "" {
+		status = StatusOem
+	}
+	for i, s := range custodyProgression {
+		if s == status && i+1 < len(custodyProgression) {
+			return custodyProgression[i+1], true
+		}
+	}
+	return "", false
+}
+
+// the party that the caller's certificate names, blank when the peer runs without security
+// or the certificate has no party attribute, the parties of a handoff are then self-asserted
+// by the route's arguments
+func callerParty(stub shim.ChaincodeStubInterface) string {
+	party, err := stub.ReadCertAttribute(partyAttribute)
+	if err != nil {
+		return ""
+	}
+	return string(party)
+}
+
+func getShipmentWindow(stub shim.ChaincodeStubInterface) (time.Duration, error) {
+	var window = ShipmentWindow{DefaultShipmentWindowHours}
+	if _, err := iot.GETContractSetting(stub, shipmentWindowSetting, &window); err != nil {
+		return 0, err
+	}
+	return time.Duration(window.Hours * float64(time.Hour)), nil
+}
+
+// shipSurgicalKit is called by the holder of a kit to hand it to the next custodian. The
+// kit stays with the sender until the receiving party confirms the shipment. The sender is
+// the party of the caller's certificate when it has one, otherwise the fromparty argument
+// or the kit's holder is taken at its word.
+var shipSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	var arg ShipmentArg
+	if len(args) != 1 {
+		err := errors.New("shipSurgicalKit expects a JSON object with surgicalkit and shipment")
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(args[0]), &arg); err != nil {
+		err = fmt.Errorf("shipSurgicalKit failed to unmarshal arg: %s", err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	_, kit, err := getSurgicalKit(stub, "shipSurgicalKit", arg.Surgicalkit)
+	if err != nil {
+		return nil, err
+	}
+	skitID, _ := kit.GetSkitID()
+	if intransit, _ := kit.GetTransit().GetIntransit(); intransit {
+		receiverparty, _ := kit.GetTransit().GetReceiverparty()
+		err = fmt.Errorf("shipSurgicalKit kit %s is in transit until %s confirms its receipt", skitID, receiverparty)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	from, _ := kit.GetStatus()
+	if from == "" {
+		from = StatusOem
+	}
+	to, found := nextCustodian(from)
+	if !found {
+		err = fmt.Errorf("shipSurgicalKit kit %s cannot be shipped from %s", skitID, from)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	if arg.Shipment.To != "" && arg.Shipment.To != to {
+		err = fmt.Errorf("shipSurgicalKit kit %s held by %s can only be shipped to %s, not %s", skitID, from, to, arg.Shipment.To)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	caller := callerParty(stub)
+	fromparty := arg.Shipment.Fromparty
+	if fromparty == "" {
+		fromparty = caller
+	}
+	if caller != "" && fromparty != caller {
+		err = fmt.Errorf("shipSurgicalKit kit %s cannot be shipped by %s, the caller acts for %s", skitID, fromparty, caller)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	holder, found := kit.GetHolder()
+	switch {
+	case found && fromparty == "":
+		fromparty = holder
+	case found && fromparty != holder:
+		err = fmt.Errorf("shipSurgicalKit kit %s is held by %s, not %s", skitID, holder, fromparty)
+		log.Errorf(err.Error())
+		return nil, err
+	case fromparty == "":
+		err = fmt.Errorf("shipSurgicalKit kit %s has no holder, the shipment needs a fromparty", skitID)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	if arg.Shipment.Toparty == "" {
+		err = fmt.Errorf("shipSurgicalKit kit %s shipment needs a toparty", skitID)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	now, err := iot.GetTxnTimestamp(stub)
+	if err != nil {
+		err = fmt.Errorf("shipSurgicalKit kit %s: %s", skitID, err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	window, err := getShipmentWindow(stub)
+	if err != nil {
+		return nil, err
+	}
+
+	var event = Surgicalkit{SkitID: &skitID, Transit: &Transit{}}
+	event.SetStatus(from)
+	event.Transit.SetShipper(from)
+	event.Transit.SetShipperparty(fromparty)
+	event.Transit.SetReceiver(to)
+	event.Transit.SetReceiverparty(arg.Shipment.Toparty)
+	event.Transit.SetCarrier(arg.Shipment.Carrier)
+	event.Transit.SetBegintransit(now.Format(time.RFC3339))
+	event.Transit.SetDeadline(now.Add(window).Format(time.RFC3339))
+	event.Transit.SetEndtransit("")
+	event.Transit.SetIntransit(true)
+	return updateSurgicalKit(stub, "shipSurgicalKit", &event)
+}
+
+// receiveSurgicalKit is called by the receiving party of a shipment to take custody of
+// the kit, the change of custody is appended to the kit's custody record. The receiver is
+// the party of the caller's certificate when it has one, otherwise the party argument is
+// taken at its word.
+var receiveSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	var arg ReceiptArg
+	if len(args) != 1 {
+		err := errors.New("receiveSurgicalKit expects a JSON object with surgicalkit and receipt")
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(args[0]), &arg); err != nil {
+		err = fmt.Errorf("receiveSurgicalKit failed to unmarshal arg: %s", err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	a, kit, err := getSurgicalKit(stub, "receiveSurgicalKit", arg.Surgicalkit)
+	if err != nil {
+		return nil, err
+	}
+	skitID, _ := kit.GetSkitID()
+	transit := kit.GetTransit()
+	if intransit, _ := transit.GetIntransit(); !intransit {
+		err = fmt.Errorf("receiveSurgicalKit kit %s has not been shipped", skitID)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	caller := callerParty(stub)
+	party := arg.Receipt.Party
+	if party == "" {
+		party = caller
+	}
+	if caller != "" && party != caller {
+		err = fmt.Errorf("receiveSurgicalKit kit %s cannot be received by %s, the caller acts for %s", skitID, party, caller)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	receiverparty, _ := transit.GetReceiverparty()
+	if party != receiverparty {
+		err = fmt.Errorf("receiveSurgicalKit kit %s was shipped to %s, not %s", skitID, receiverparty, party)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	now, err := iot.GetTxnTimestamp(stub)
+	if err != nil {
+		err = fmt.Errorf("receiveSurgicalKit kit %s: %s", skitID, err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	var late bool
+	if deadline, found := transit.GetDeadline(); found {
+		due, err := time.Parse(time.RFC3339, deadline)
+		if err != nil {
+			err = fmt.Errorf("receiveSurgicalKit kit %s has an unreadable deadline %s: %s", skitID, deadline, err)
+			log.Errorf(err.Error())
+			return nil, err
+		}
+		late = now.After(due)
+	}
+	from, _ := transit.GetShipper()
+	to, _ := transit.GetReceiver()
+	received := now.Format(time.RFC3339)
+
+	var change = CustodyChange{From: transit.Shipper, Fromparty: transit.Shipperparty, To: transit.Receiver,
+		Toparty: transit.Receiverparty, Carrier: transit.Carrier, Shipped: transit.Begintransit}
+	change.SetReceived(received)
+	change.SetLate(late)
+	var event = Surgicalkit{SkitID: &skitID, Transit: &Transit{}, Custody: []CustodyChange{change}}
+	event.SetStatus(to)
+	event.SetHolder(receiverparty)
+	event.Transit.SetEndtransit(received)
+	event.Transit.SetIntransit(false)
+	result, err := updateSurgicalKit(stub, "receiveSurgicalKit", &event)
+	if err != nil {
+		return nil, err
+	}
+	a.TXNTS = &now
+	if err := a.Notify(stub, iotcpevents.StateTransition, iotcpevents.TransitionData{QProp: "surgicalkit.status", From: from, To: to}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// checkOverdueSurgicalKits writes each kit whose shipment deadline has passed without an
+// alert, so that shipments with no other update raise the overdue shipment alert
+var checkOverdueSurgicalKits iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	now, err := iot.GetTxnTimestamp(stub)
+	if err != nil {
+		err = fmt.Errorf("checkOverdueSurgicalKits: %s", err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	kits, err := SurgicalKitClass.ReadAllAssetsUnmarshalled(stub, []string{})
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range kits {
+		if iot.Contains(a.AlertsActive, overdueShipmentAlert) {
+			continue
+		}
+		kit, err := SurgicalkitFromState(a.State)
+		if err != nil {
+			return nil, err
+		}
+		overdue, err := shipmentOverdue(kit, now)
+		if err != nil {
+			return nil, err
+		}
+		if !overdue {
+			continue
+		}
+		skitID, _ := kit.GetSkitID()
+		if _, err := updateSurgicalKit(stub, "checkOverdueSurgicalKits", &Surgicalkit{SkitID: &skitID}); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// setShipmentWindow sets the hours that a receiving party has to confirm later shipments
+var setShipmentWindow iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	var window ShipmentWindow
+	if len(args) != 1 {
+		err := errors.New("setShipmentWindow expects a JSON object with hours")
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(args[0]), &window); err != nil {
+		err = fmt.Errorf("setShipmentWindow failed to unmarshal arg: %s", err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	if window.Hours <= 0 {
+		err := fmt.Errorf("setShipmentWindow hours %v must be positive", window.Hours)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	return nil, iot.PUTContractSetting(stub, shipmentWindowSetting, window)
+}
+
+// whether a kit is in transit after its shipment's deadline
+func shipmentOverdue(kit *Surgicalkit, now time.Time) (bool, error) {
+	if intransit, _ := kit.GetTransit().GetIntransit(); !intransit {
+		return false, nil
+	}
+	deadline, found := kit.GetTransit().GetDeadline()
+	if !found || deadline == "" {
+		return false, nil
+	}
+	due, err := time.Parse(time.RFC3339, deadline)
+	if err != nil {
+		return false, fmt.Errorf("transit deadline %s is not a timestamp: %s", deadline, err)
+	}
+	return now.After(due), nil
+}
+
+var overdueShipmentAlert iot.AlertName = "OVERDUESHIPMENT"
+var overdueShipmentRule iot.RuleFunc = func(stub shim.ChaincodeStubInterface, SurgicalKit *iot.Asset) error {
+	if SurgicalKit.TXNTS == nil {
+		return nil
+	}
+	kit, err := SurgicalkitFromState(SurgicalKit.State)
+	if err != nil {
+		return err
+	}
+	overdue, err := shipmentOverdue(kit, *SurgicalKit.TXNTS)
+	if err != nil {
+		return err
+	}
+	if overdue {
+		iot.RaiseAlert(SurgicalKit, overdueShipmentAlert)
+	} else {
+		iot.ClearAlert(SurgicalKit, overdueShipmentAlert)
+	}
+	return nil
+}
+
+func init() {
+	if err := iot.AddMergeStrategy(SurgicalKitClass, "surgicalkit.custody", iot.MergeStrategy{Kind: iot.MergeAppend}); err != nil {
+		panic(err)
+	}
+	if err := iot.AddRule("Overdue Shipment Alert", SurgicalKitClass, []iot.AlertName{overdueShipmentAlert}, overdueShipmentRule); err != nil {
+		panic(err)
+	}
+
+	if err := iot.AddRoute("shipSurgicalKit", "invoke", SurgicalKitClass, shipSurgicalKit); err != nil {
+		panic(err)
+	}
+	if err := iot.AddRoute("receiveSurgicalKit", "invoke", SurgicalKitClass, receiveSurgicalKit); err != nil {
+		panic(err)
+	}
+	if err := iot.AddRoute("checkOverdueSurgicalKits", "invoke", SurgicalKitClass, checkOverdueSurgicalKits); err != nil {
+		panic(err)
+	}
+	if err := iot.AddRoute("setShipmentWindow", "invoke", SurgicalKitClass, setShipmentWindow); err != nil {
+		panic(err)
+	}
+}
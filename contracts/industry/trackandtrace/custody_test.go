@@ -0,0 +1,176 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpevents"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcptest"
+)
+
+func ship(h *iotcptest.Harness, skitID string, shipment string) *iotcptest.Harness {
+	return h.Invoke("shipSurgicalKit", `{"surgicalkit":{"skitID":"`+skitID+`"},"shipment":`+shipment+`}`)
+}
+
+func receive(h *iotcptest.Harness, skitID string, party string) *iotcptest.Harness {
+	return h.Invoke("receiveSurgicalKit", `{"surgicalkit":{"skitID":"`+skitID+`"},"receipt":{"party":"`+party+`"}}`)
+}
+
+// hands a new kit from its oem along the progression to a hospital
+func deliverToHospital(h *iotcptest.Harness, skitID string) {
+	ship(h, skitID, `{"fromparty":"Acme Medical","toparty":"Central Depot"}`).ExpectOK()
+	receive(h, skitID, "Central Depot").ExpectOK()
+	ship(h, skitID, `{"toparty":"Main Dealer"}`).ExpectOK()
+	receive(h, skitID, "Main Dealer").ExpectOK()
+	ship(h, skitID, `{"toparty":"Corner Store"}`).ExpectOK()
+	receive(h, skitID, "Corner Store").ExpectOK()
+	ship(h, skitID, `{"toparty":"General Hospital"}`).ExpectOK()
+	receive(h, skitID, "General Hospital").ExpectOK()
+}
+
+func TestSurgicalKitHandoff(t *testing.T) {
+	h := newSurgicalKitHarness(t)
+	h.CreateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K1"}}`).ExpectOK()
+
+	ship(h, "K1", `{"toparty":"Central Depot"}`).ExpectError("needs a fromparty")
+	ship(h, "K1", `{"fromparty":"Acme Medical","toparty":"Central Depot","carrier":"UPS"}`).ExpectOK()
+	h.ExpectState(SurgicalKitClass, "K1", "surgicalkit.status", "oem").
+		ExpectState(SurgicalKitClass, "K1", "surgicalkit.transit.receiver", "warehouse").
+		ExpectState(SurgicalKitClass, "K1", "surgicalkit.transit.intransit", true).
+		ExpectNoState(SurgicalKitClass, "K1", "surgicalkit.holder")
+	ship(h, "K1", `{"fromparty":"Acme Medical","toparty":"Other Depot"}`).ExpectError("in transit")
+
+	receive(h, "K1", "Other Depot").ExpectError("was shipped to Central Depot")
+	receive(h, "K1", "Central Depot").ExpectOK()
+	var transition iotcpevents.TransitionData
+	if err := h.ExpectNotification(iotcpevents.StateTransition, SurgicalKitClass, "K1").DecodeData(&transition); err != nil || transition.From != "oem" || transition.To != "warehouse" {
+		t.Fatalf("unexpected state transition %+v (%v)", transition, err)
+	}
+	h.ExpectState(SurgicalKitClass, "K1", "surgicalkit.status", "warehouse").
+		ExpectState(SurgicalKitClass, "K1", "surgicalkit.holder", "Central Depot").
+		ExpectState(SurgicalKitClass, "K1", "surgicalkit.transit.intransit", false)
+	receive(h, "K1", "Central Depot").ExpectError("has not been shipped")
+
+	// the progression cannot be skipped and only the holder ships
+	ship(h, "K1", `{"to":"hospital","toparty":"General Hospital"}`).ExpectError("can only be shipped to dealer")
+	ship(h, "K1", `{"fromparty":"Acme Medical","toparty":"Main Dealer"}`).ExpectError("held by Central Depot")
+	ship(h, "K1", `{"to":"dealer","toparty":"Main Dealer","carrier":"FedEx"}`).ExpectOK()
+	receive(h, "K1", "Main Dealer").ExpectOK()
+	ship(h, "K1", `{"toparty":"Corner Store"}`).ExpectOK()
+	receive(h, "K1", "Corner Store").ExpectOK()
+	ship(h, "K1", `{"toparty":"General Hospital"}`).ExpectOK()
+	receive(h, "K1", "General Hospital").ExpectOK()
+	ship(h, "K1", `{"toparty":"Another Hospital"}`).ExpectError("cannot be shipped from hospital")
+
+	kit, err := SurgicalkitFromState(h.Asset(SurgicalKitClass, "K1").State)
+	if err != nil {
+		t.Fatal(err)
+	}
+	custody := kit.GetCustody()
+	if len(custody) != 4 {
+		t.Fatalf("expected 4 custody changes, got %+v", custody)
+	}
+	from, _ := custody[1].GetFrom()
+	fromparty, _ := custody[1].GetFromparty()
+	to, _ := custody[1].GetTo()
+	toparty, _ := custody[1].GetToparty()
+	carrier, _ := custody[1].GetCarrier()
+	late, _ := custody[1].GetLate()
+	if from != StatusWarehouse || fromparty != "Central Depot" || to != StatusDealer || toparty != "Main Dealer" || carrier != "FedEx" || late {
+		t.Fatalf("unexpected custody change %+v", custody[1])
+	}
+}
+
+func TestSurgicalKitHandoffCallerParty(t *testing.T) {
+	h := newSurgicalKitHarness(t)
+	h.CreateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K1"}}`).ExpectOK()
+
+	h.Stub.Attrs = map[string]string{"party": "Acme Medical"}
+	ship(h, "K1", `{"fromparty":"Other OEM","toparty":"Central Depot"}`).ExpectError("the caller acts for Acme Medical")
+	ship(h, "K1", `{"toparty":"Central Depot"}`).ExpectOK()
+	h.ExpectState(SurgicalKitClass, "K1", "surgicalkit.transit.shipperparty", "Acme Medical")
+
+	receive(h, "K1", "Central Depot").ExpectError("the caller acts for Acme Medical")
+	h.Stub.Attrs = map[string]string{"party": "Central Depot"}
+	receive(h, "K1", "").ExpectOK()
+	h.ExpectState(SurgicalKitClass, "K1", "surgicalkit.holder", "Central Depot")
+	ship(h, "K1", `{"fromparty":"Acme Medical","toparty":"Corner Store"}`).ExpectError("the caller acts for Central Depot")
+}
+
+func TestSurgicalKitOverdueShipment(t *testing.T) {
+	h := newSurgicalKitHarness(t)
+	h.Invoke("setShipmentWindow", `{"hours":0}`).ExpectError("must be positive")
+	h.Invoke("setShipmentWindow", `{"hours":24}`).ExpectOK()
+	h.CreateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K4"}}`).ExpectOK()
+	h.CreateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K5"}}`).ExpectOK()
+	ship(h, "K4", `{"fromparty":"Acme Medical","toparty":"Central Depot"}`).ExpectOK()
+	ship(h, "K5", `{"fromparty":"Acme Medical","toparty":"Central Depot"}`).ExpectOK()
+
+	h.Advance(23 * time.Hour).Invoke("checkOverdueSurgicalKits").ExpectOK()
+	h.ExpectNoAlert(SurgicalKitClass, "K4", overdueShipmentAlert)
+
+	// K4 has no update after its deadline, the check raises the alert
+	h.Advance(2 * time.Hour).Invoke("checkOverdueSurgicalKits").ExpectOK()
+	h.ExpectNotification(iotcpevents.AlertRaised, SurgicalKitClass, "K4")
+	h.ExpectAlert(SurgicalKitClass, "K4", overdueShipmentAlert).
+		ExpectAlert(SurgicalKitClass, "K5", overdueShipmentAlert)
+
+	// a sensor reading during transit sees the overdue shipment as well
+	h.UpdateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K5","sensors":{"maxgforce":1}}}`).ExpectOK()
+	h.ExpectAlert(SurgicalKitClass, "K5", overdueShipmentAlert)
+
+	receive(h, "K4", "Central Depot").ExpectOK()
+	h.ExpectNoAlert(SurgicalKitClass, "K4", overdueShipmentAlert)
+	kit, err := SurgicalkitFromState(h.Asset(SurgicalKitClass, "K4").State)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if custody := kit.GetCustody(); len(custody) != 1 || custody[0].Late == nil || !*custody[0].Late {
+		t.Fatalf("expected a late custody change, got %+v", custody)
+	}
+}
+
+func TestSurgicalKitCustodyGuard(t *testing.T) {
+	h := newSurgicalKitHarness(t)
+	h.CreateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K6","status":"warehouse"}}`).ExpectError("a new kit is with its oem")
+	h.CreateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K6","custody":[{"from":"oem","to":"hospital","toparty":"General Hospital"}]}}`).ExpectError("custody")
+	h.CreateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K6","transit":{"intransit":true}}}`).ExpectError("custody")
+	h.CreateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K6","status":"oem","holder":"Acme Medical"}}`).ExpectOK()
+	ship(h, "K6", `{"toparty":"Central Depot"}`).ExpectOK()
+	receive(h, "K6", "Central Depot").ExpectOK()
+	h.UpdateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K6","holder":"Main Dealer"}}`).ExpectError("custody")
+	h.UpdateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K6","transit":{"intransit":false}}}`).ExpectError("custody")
+	h.Invoke("replaceAssetSurgicalKit", `{"surgicalkit":{"skitID":"K6","custody":[]}}`).ExpectError("custody")
+
+	// a replace keeps the kit's custody
+	h.Invoke("replaceAssetSurgicalKit", `{"surgicalkit":{"skitID":"K6","sensors":{"maxtilt":5}}}`).ExpectOK()
+	h.ExpectState(SurgicalKitClass, "K6", "surgicalkit.status", "warehouse").
+		ExpectState(SurgicalKitClass, "K6", "surgicalkit.holder", "Central Depot").
+		ExpectState(SurgicalKitClass, "K6", "surgicalkit.sensors.maxtilt", 5)
+
+	// nor can its custody be deleted
+	for _, qprop := range []string{"surgicalkit.status", "surgicalkit.holder", "surgicalkit.transit.intransit", "surgicalkit.custody", "surgicalkit"} {
+		h.Invoke("deletePropertiesFromAssetSurgicalKit", `{"surgicalkit":{"skitID":"K6"},"qprops":["`+qprop+`"]}`).ExpectError("custody")
+	}
+	h.ExpectState(SurgicalKitClass, "K6", "surgicalkit.status", "warehouse").
+		ExpectState(SurgicalKitClass, "K6", "surgicalkit.transit.intransit", false)
+	h.Invoke("deletePropertiesFromAssetSurgicalKit", `{"surgicalkit":{"skitID":"K6"},"qprops":["surgicalkit.sensors"]}`).ExpectOK()
+	h.ExpectNoState(SurgicalKitClass, "K6", "surgicalkit.sensors").
+		ExpectState(SurgicalKitClass, "K6", "surgicalkit.holder", "Central Depot")
+}
@@ -0,0 +1,284 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- shock and tilt damage timeline for surgical kits
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
+)
+
+// MaxDamageEvents is how many of its most recent shocks, tilts and inspections a kit keeps,
+// the latest shock and tilt that wait for a passed inspection are kept however old
+const MaxDamageEvents int = 50
+
+// the kinds of damage event
+const (
+	damageShock      = "shock"
+	damageTilt       = "tilt"
+	damageInspection = "inspection"
+)
+
+// the limits beyond which a sensor reading is recorded as damage
+const (
+	maxGForce      float64 = 2
+	maxTiltDegrees float64 = 90
+)
+
+// InspectionArg is the argument to inspectSurgicalKit
+type InspectionArg struct {
+	Surgicalkit Surgicalkit `json:"surgicalkit"`
+	Inspection  struct {
+		Inspector string `json:"inspector"`
+		Passed    *bool  `json:"passed"`
+		Notes     string `json:"notes"`
+	} `json:"inspection"`
+}
+
+// DamageTimelineOut is the output of readDamageTimelineSurgicalKit
+type DamageTimelineOut struct {
+	SkitID  string          `json:"skitID"`
+	Latched []iot.AlertName `json:"latched"`
+	Damage  []DamageEvent   `json:"damage"`
+}
+
+// a damage event of the kind at the kit's location and the transaction's time
+func newDamageEvent(SurgicalKit *iot.Asset, kit *Surgicalkit, kind string) DamageEvent {
+	var e = DamageEvent{Location: kitLocation(kit)}
+	e.SetKind(kind)
+	if SurgicalKit.TXNTS != nil {
+		e.SetTimestamp(SurgicalKit.TXNTS.UTC().Format(time.RFC3339))
+	}
+	return e
+}
+
+// appends a damage event to the kit and its state
+func recordDamage(SurgicalKit *iot.Asset, kit *Surgicalkit, e DamageEvent) error {
+	kit.Damage = append(kit.Damage, e)
+	boundDamage(kit)
+	return (&Surgicalkit{Damage: kit.Damage}).ToState(SurgicalKit.State)
+}
+
+// drops the oldest damage events of the kit beyond MaxDamageEvents, except for those that
+// latch an alert, so that failed inspections cannot push a shock or tilt out of the
+// timeline, returns whether any was dropped
+func boundDamage(kit *Surgicalkit) bool {
+	excess := len(kit.Damage) - MaxDamageEvents
+	if excess <= 0 {
+		return false
+	}
+	latching := latchingDamage(kit)
+	var bounded = make([]DamageEvent, 0, MaxDamageEvents)
+	for i, e := range kit.Damage {
+		if excess > 0 && !latching[i] {
+			excess--
+			continue
+		}
+		bounded = append(bounded, e)
+	}
+	kit.Damage = bounded
+	return true
+}
+
+// the positions of the kit's latest shock and latest tilt since its last passed inspection
+func latchingDamage(kit *Surgicalkit) map[int]bool {
+	var latching = make(map[int]bool, 0)
+	var kinds = make(map[string]bool, 0)
+	for i := len(kit.Damage) - 1; i >= 0; i-- {
+		k, _ := kit.Damage[i].GetKind()
+		if passed, _ := kit.Damage[i].GetPassed(); k == damageInspection && passed {
+			break
+		}
+		if k != damageInspection && !kinds[k] {
+			kinds[k] = true
+			latching[i] = true
+		}
+	}
+	return latching
+}
+
+// whether the kit has a damage event of the kind since its last passed inspection
+func damageLatched(kit *Surgicalkit, kind string) bool {
+	for i := len(kit.Damage) - 1; i >= 0; i-- {
+		k, _ := kit.Damage[i].GetKind()
+		if k == kind {
+			return true
+		}
+		if passed, _ := kit.Damage[i].GetPassed(); k == damageInspection && passed {
+			return false
+		}
+	}
+	return false
+}
+
+// whether the incoming event carries a sensor reading, rules see the state that the
+// reading was merged into, in the class's units
+func sampled(SurgicalKit *iot.Asset, qprop string) bool {
+	_, found := iot.GetObject(SurgicalKit.EventIn, qprop)
+	return found
+}
+
+var excessForceAlert iot.AlertName = "EXCESSFORCE"
+var excessForceRule iot.RuleFunc = func(stub shim.ChaincodeStubInterface, SurgicalKit *iot.Asset) error {
+	kit, err := SurgicalkitFromState(SurgicalKit.State)
+	if err != nil {
+		return err
+	}
+	force, found := kit.GetSensors().GetMaxgforce()
+	if found && force > maxGForce && sampled(SurgicalKit, "surgicalkit.sensors.maxgforce") {
+		e := newDamageEvent(SurgicalKit, kit, damageShock)
+		e.SetGforce(force)
+		if err := recordDamage(SurgicalKit, kit, e); err != nil {
+			return err
+		}
+	} else if boundDamage(kit) {
+		if err := (&Surgicalkit{Damage: kit.Damage}).ToState(SurgicalKit.State); err != nil {
+			return err
+		}
+	}
+	if damageLatched(kit, damageShock) {
+		iot.RaiseAlert(SurgicalKit, excessForceAlert)
+	} else {
+		iot.ClearAlert(SurgicalKit, excessForceAlert)
+	}
+	return nil
+}
+
+var excessTiltAlert iot.AlertName = "EXCESSTILT"
+var excessTiltRule iot.RuleFunc = func(stub shim.ChaincodeStubInterface, SurgicalKit *iot.Asset) error {
+	kit, err := SurgicalkitFromState(SurgicalKit.State)
+	if err != nil {
+		return err
+	}
+	tilt, found := kit.GetSensors().GetMaxtilt()
+	if found && (tilt > maxTiltDegrees || tilt < -maxTiltDegrees) && sampled(SurgicalKit, "surgicalkit.sensors.maxtilt") {
+		e := newDamageEvent(SurgicalKit, kit, damageTilt)
+		e.SetTilt(tilt)
+		if err := recordDamage(SurgicalKit, kit, e); err != nil {
+			return err
+		}
+	} else if boundDamage(kit) {
+		if err := (&Surgicalkit{Damage: kit.Damage}).ToState(SurgicalKit.State); err != nil {
+			return err
+		}
+	}
+	if damageLatched(kit, damageTilt) {
+		iot.RaiseAlert(SurgicalKit, excessTiltAlert)
+	} else {
+		iot.ClearAlert(SurgicalKit, excessTiltAlert)
+	}
+	return nil
+}
+
+// inspectSurgicalKit records an inspection in the kit's damage timeline, the excess force
+// and tilt alerts stay active until the kit passes one
+var inspectSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	var arg InspectionArg
+	if len(args) != 1 {
+		err := errors.New("inspectSurgicalKit expects a JSON object with surgicalkit and inspection")
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(args[0]), &arg); err != nil {
+		err = fmt.Errorf("inspectSurgicalKit failed to unmarshal arg: %s", err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	a, kit, err := getSurgicalKit(stub, "inspectSurgicalKit", arg.Surgicalkit)
+	if err != nil {
+		return nil, err
+	}
+	skitID, _ := kit.GetSkitID()
+	if arg.Inspection.Inspector == "" || arg.Inspection.Passed == nil {
+		err = fmt.Errorf("inspectSurgicalKit kit %s inspection needs an inspector and passed", skitID)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	now, err := iot.GetTxnTimestamp(stub)
+	if err != nil {
+		err = fmt.Errorf("inspectSurgicalKit kit %s: %s", skitID, err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	a.TXNTS = &now
+	e := newDamageEvent(&a, kit, damageInspection)
+	e.SetInspector(arg.Inspection.Inspector)
+	e.SetPassed(*arg.Inspection.Passed)
+	if arg.Inspection.Notes != "" {
+		e.SetNotes(arg.Inspection.Notes)
+	}
+	return updateSurgicalKit(stub, "inspectSurgicalKit", &Surgicalkit{SkitID: &skitID, Damage: []DamageEvent{e}})
+}
+
+// readDamageTimelineSurgicalKit returns the kit's shocks, tilts and inspections, oldest
+// first, with the alerts that wait for a passed inspection
+var readDamageTimelineSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	var arg struct {
+		Surgicalkit Surgicalkit `json:"surgicalkit"`
+	}
+	if len(args) != 1 {
+		err := errors.New("readDamageTimelineSurgicalKit expects a JSON object with surgicalkit")
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(args[0]), &arg); err != nil {
+		err = fmt.Errorf("readDamageTimelineSurgicalKit failed to unmarshal arg: %s", err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	a, kit, err := getSurgicalKit(stub, "readDamageTimelineSurgicalKit", arg.Surgicalkit)
+	if err != nil {
+		return nil, err
+	}
+	var out = DamageTimelineOut{Latched: make([]iot.AlertName, 0), Damage: kit.GetDamage()}
+	out.SkitID, _ = kit.GetSkitID()
+	if out.Damage == nil {
+		out.Damage = make([]DamageEvent, 0)
+	}
+	for _, alert := range []iot.AlertName{excessForceAlert, excessTiltAlert} {
+		if iot.Contains(a.AlertsActive, alert) {
+			out.Latched = append(out.Latched, alert)
+		}
+	}
+	return json.Marshal(out)
+}
+
+func init() {
+	// the rules bound the timeline, an inspection is appended in full
+	if err := iot.AddMergeStrategy(SurgicalKitClass, "surgicalkit.damage", iot.MergeStrategy{Kind: iot.MergeAppend}); err != nil {
+		panic(err)
+	}
+	if err := iot.AddRule("Excess Force Alert", SurgicalKitClass, []iot.AlertName{excessForceAlert}, excessForceRule); err != nil {
+		panic(err)
+	}
+	if err := iot.AddRule("Excess Tilt Alert", SurgicalKitClass, []iot.AlertName{excessTiltAlert}, excessTiltRule); err != nil {
+		panic(err)
+	}
+
+	if err := iot.AddRoute("inspectSurgicalKit", "invoke", SurgicalKitClass, inspectSurgicalKit); err != nil {
+		panic(err)
+	}
+	if err := iot.AddRoute("readDamageTimelineSurgicalKit", "query", SurgicalKitClass, readDamageTimelineSurgicalKit); err != nil {
+		panic(err)
+	}
+}
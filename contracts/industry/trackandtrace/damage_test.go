@@ -0,0 +1,165 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpevents"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcptest"
+)
+
+func damageTimeline(t *testing.T, h *iotcptest.Harness, skitID string) DamageTimelineOut {
+	var timeline DamageTimelineOut
+	h.Query("readDamageTimelineSurgicalKit", `{"surgicalkit":{"skitID":"`+skitID+`"}}`).ExpectResult(&timeline)
+	return timeline
+}
+
+func TestSurgicalKitDamageTimeline(t *testing.T) {
+	h := newSurgicalKitHarness(t)
+	h.CreateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K11","sensors":{"maxgforce":1.2,"maxtilt":10}}}`).ExpectOK()
+	if timeline := damageTimeline(t, h, "K11"); timeline.SkitID != "K11" || len(timeline.Damage) != 0 || len(timeline.Latched) != 0 {
+		t.Fatalf("unexpected timeline %+v", timeline)
+	}
+
+	// a drop in one sample and a tip over in the next
+	h.UpdateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K11","sensors":{"maxgforce":4.5,"endlocation":{"latitude":43.6532,"longitude":-79.3832}}}}`).ExpectOK()
+	h.Advance(time.Hour)
+	h.UpdateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K11","sensors":{"maxgforce":1,"maxtilt":-120}}}`).ExpectOK()
+	h.Advance(time.Hour)
+	h.UpdateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K11","sensors":{"maxgforce":0.8,"maxtilt":3}}}`).ExpectOK()
+	h.ExpectAlert(SurgicalKitClass, "K11", excessForceAlert).
+		ExpectAlert(SurgicalKitClass, "K11", excessTiltAlert).
+		ExpectCompliant(SurgicalKitClass, "K11", false)
+
+	// samples without force and tilt readings do not record the last readings again
+	h.UpdateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K11","sensors":{"currtilt":4}}}`).ExpectOK()
+	timeline := damageTimeline(t, h, "K11")
+	if len(timeline.Damage) != 2 || len(timeline.Latched) != 2 {
+		t.Fatalf("unexpected timeline %+v", timeline)
+	}
+	shock, tilt := timeline.Damage[0], timeline.Damage[1]
+	if kind, _ := shock.GetKind(); kind != damageShock {
+		t.Fatalf("unexpected shock %+v", shock)
+	}
+	if g, _ := shock.GetGforce(); g != 4.5 {
+		t.Fatalf("unexpected shock force %v", g)
+	}
+	if lat, _ := shock.Location.GetLatitude(); lat != 43.6532 {
+		t.Fatalf("unexpected shock location %+v", shock.Location)
+	}
+	if kind, _ := tilt.GetKind(); kind != damageTilt {
+		t.Fatalf("unexpected tilt %+v", tilt)
+	}
+	if deg, _ := tilt.GetTilt(); deg != -120 {
+		t.Fatalf("unexpected tilt %v", deg)
+	}
+	first, _ := shock.GetTimestamp()
+	second, _ := tilt.GetTimestamp()
+	t1, err1 := time.Parse(time.RFC3339, first)
+	t2, err2 := time.Parse(time.RFC3339, second)
+	if err1 != nil || err2 != nil || t2.Sub(t1) < time.Hour {
+		t.Fatalf("unexpected timestamps %s and %s", first, second)
+	}
+
+	// the timeline is the contract's
+	h.UpdateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K11","damage":[]}}`).ExpectError("inspectSurgicalKit")
+	h.Invoke("replaceAssetSurgicalKit", `{"surgicalkit":{"skitID":"K11","sensors":{"maxgforce":0.5}}}`).ExpectOK()
+	if timeline := damageTimeline(t, h, "K11"); len(timeline.Damage) != 2 {
+		t.Fatal("replace dropped the damage timeline")
+	}
+	h.Invoke("deletePropertiesFromAssetSurgicalKit", `{"surgicalkit":{"skitID":"K11"},"qprops":["surgicalkit.damage"]}`).ExpectError("inspectSurgicalKit")
+	h.ExpectAlert(SurgicalKitClass, "K11", excessForceAlert).
+		ExpectAlert(SurgicalKitClass, "K11", excessTiltAlert)
+	h.Query("readDamageTimelineSurgicalKit", `{"surgicalkit":{"skitID":"K99"}}`).ExpectError("does not exist")
+}
+
+func TestSurgicalKitInspection(t *testing.T) {
+	h := newSurgicalKitHarness(t)
+	h.CreateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K12","sensors":{"maxgforce":3,"maxtilt":95}}}`).ExpectOK()
+	h.ExpectAlert(SurgicalKitClass, "K12", excessForceAlert).
+		ExpectAlert(SurgicalKitClass, "K12", excessTiltAlert)
+
+	h.Invoke("inspectSurgicalKit", `{"surgicalkit":{"skitID":"K12"},"inspection":{"passed":true}}`).ExpectError("needs an inspector")
+	h.Invoke("inspectSurgicalKit", `{"surgicalkit":{"skitID":"K12"},"inspection":{"inspector":"Sterile Processing","passed":false,"notes":"cracked tray"}}`).ExpectOK()
+	h.ExpectAlert(SurgicalKitClass, "K12", excessForceAlert).
+		ExpectAlert(SurgicalKitClass, "K12", excessTiltAlert)
+
+	h.Invoke("inspectSurgicalKit", `{"surgicalkit":{"skitID":"K12"},"inspection":{"inspector":"Sterile Processing","passed":true}}`).ExpectOK()
+	h.ExpectNotification(iotcpevents.AlertCleared, SurgicalKitClass, "K12")
+	h.ExpectNoAlert(SurgicalKitClass, "K12", excessForceAlert).
+		ExpectNoAlert(SurgicalKitClass, "K12", excessTiltAlert).
+		ExpectCompliant(SurgicalKitClass, "K12", true)
+
+	// a shock after the inspection latches the force alert again
+	h.UpdateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K12","sensors":{"maxgforce":2.5}}}`).ExpectOK()
+	h.ExpectAlert(SurgicalKitClass, "K12", excessForceAlert).
+		ExpectNoAlert(SurgicalKitClass, "K12", excessTiltAlert)
+	timeline := damageTimeline(t, h, "K12")
+	if len(timeline.Damage) != 5 || len(timeline.Latched) != 1 || timeline.Latched[0] != excessForceAlert {
+		t.Fatalf("unexpected timeline %+v", timeline)
+	}
+	if inspector, _ := timeline.Damage[3].GetInspector(); inspector != "Sterile Processing" {
+		t.Fatalf("unexpected inspection %+v", timeline.Damage[3])
+	}
+	if notes, _ := timeline.Damage[2].GetNotes(); notes != "cracked tray" {
+		t.Fatalf("unexpected inspection %+v", timeline.Damage[2])
+	}
+}
+
+func TestSurgicalKitDamageIsBounded(t *testing.T) {
+	h := newSurgicalKitHarness(t)
+	h.CreateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K13"}}`).ExpectOK()
+	for i := 0; i < MaxDamageEvents+2; i++ {
+		h.UpdateAsset(SurgicalKitClass, map[string]interface{}{"surgicalkit": map[string]interface{}{"skitID": "K13", "sensors": map[string]interface{}{"maxgforce": 3 + i}}}).ExpectOK()
+	}
+	timeline := damageTimeline(t, h, "K13")
+	if len(timeline.Damage) != MaxDamageEvents {
+		t.Fatalf("expected %d damage events, got %d", MaxDamageEvents, len(timeline.Damage))
+	}
+	if g, _ := timeline.Damage[0].GetGforce(); g != 5 {
+		t.Fatalf("the oldest events were not dropped, first is %v", g)
+	}
+}
+
+func TestSurgicalKitFailedInspectionsKeepTheLatch(t *testing.T) {
+	h := newSurgicalKitHarness(t)
+	h.CreateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K14","sensors":{"maxgforce":3}}}`).ExpectOK()
+	for i := 0; i < MaxDamageEvents+5; i++ {
+		h.Invoke("inspectSurgicalKit", `{"surgicalkit":{"skitID":"K14"},"inspection":{"inspector":"Sterile Processing","passed":false}}`).ExpectOK()
+	}
+	h.ExpectAlert(SurgicalKitClass, "K14", excessForceAlert)
+	timeline := damageTimeline(t, h, "K14")
+	if len(timeline.Damage) != MaxDamageEvents {
+		t.Fatalf("expected %d damage events, got %d", MaxDamageEvents, len(timeline.Damage))
+	}
+	if kind, _ := timeline.Damage[0].GetKind(); kind != damageShock {
+		t.Fatalf("the latched shock was dropped, first is %+v", timeline.Damage[0])
+	}
+	if kind, _ := timeline.Damage[1].GetKind(); kind != damageInspection {
+		t.Fatalf("unexpected damage event %+v", timeline.Damage[1])
+	}
+
+	// once the kit passes an inspection, the shock is dropped like any other event
+	h.Invoke("inspectSurgicalKit", `{"surgicalkit":{"skitID":"K14"},"inspection":{"inspector":"Sterile Processing","passed":true}}`).ExpectOK()
+	h.ExpectNoAlert(SurgicalKitClass, "K14", excessForceAlert)
+	timeline = damageTimeline(t, h, "K14")
+	if kind, _ := timeline.Damage[0].GetKind(); len(timeline.Damage) != MaxDamageEvents || kind != damageInspection {
+		t.Fatalf("unexpected timeline after the passed inspection %+v", timeline.Damage[0])
+	}
+}
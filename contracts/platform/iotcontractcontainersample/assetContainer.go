@@ -44,46 +44,6 @@ func newContainer() iot.Asset {
 	}
 }
 
-var createAssetContainer iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return ContainerClass.CreateAsset(stub, args, "createAssetContainer", []iot.QPropNV{})
-}
-
-var replaceAssetContainer iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return ContainerClass.ReplaceAsset(stub, args, "replaceAssetContainer", []iot.QPropNV{})
-}
-
-var updateAssetContainer iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return ContainerClass.UpdateAsset(stub, args, "updateAssetContainer", []iot.QPropNV{})
-}
-
-var deleteAssetContainer iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return ContainerClass.DeleteAsset(stub, args)
-}
-
-var deleteAssetStateHistoryContainer iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return ContainerClass.DeleteAssetStateHistory(stub, args)
-}
-
-var deleteAllAssetsContainer iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return ContainerClass.DeleteAllAssets(stub, args)
-}
-
-var deletePropertiesFromAssetContainer iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return ContainerClass.DeletePropertiesFromAsset(stub, args, "deletePropertiesFromAssetContainer", []iot.QPropNV{})
-}
-
-var readAssetContainer iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return ContainerClass.ReadAsset(stub, args)
-}
-
-var readAllAssetsContainer iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return ContainerClass.ReadAllAssets(stub, args)
-}
-
-var readAssetStateHistoryContainer = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return ContainerClass.ReadAssetStateHistory(stub, args)
-}
-
 var overtempAlert iot.AlertName = "OVERTEMP"
 var overtempRule iot.RuleFunc = func(stub shim.ChaincodeStubInterface, container *iot.Asset) error {
 	temp, found := iot.GetObjectAsNumber(container.State, "container.temperature")
@@ -98,15 +58,17 @@ var overtempRule iot.RuleFunc = func(stub shim.ChaincodeStubInterface, container
 }
 
 func init() {
+	if err := iot.AddUnitProperty(ContainerClass, "container.temperature", "C"); err != nil {
+		panic(err)
+	}
+	if err := iot.TrackProvenance(ContainerClass, iot.ProvenanceOptions{}); err != nil {
+		panic(err)
+	}
+	if err := iot.AddMergeStrategy(ContainerClass, "container.common.appdata", iot.MergeStrategy{Kind: iot.MergeUnionByKey, Key: "K"}); err != nil {
+		panic(err)
+	}
 	iot.AddRule("Over Temperature Alert", ContainerClass, []iot.AlertName{overtempAlert}, overtempRule)
-	iot.AddRoute("createAssetContainer", "invoke", ContainerClass, createAssetContainer)
-	iot.AddRoute("replaceAssetContainer", "invoke", ContainerClass, replaceAssetContainer)
-	iot.AddRoute("updateAssetContainer", "invoke", ContainerClass, updateAssetContainer)
-	iot.AddRoute("deleteAssetContainer", "invoke", ContainerClass, deleteAssetContainer)
-	iot.AddRoute("deleteAssetStateHistoryContainer", "invoke", ContainerClass, deleteAssetStateHistoryContainer)
-	iot.AddRoute("deleteAllAssetsContainer", "invoke", ContainerClass, deleteAllAssetsContainer)
-	iot.AddRoute("deletePropertiesFromAssetContainer", "invoke", ContainerClass, deletePropertiesFromAssetContainer)
-	iot.AddRoute("readAssetContainer", "query", ContainerClass, readAssetContainer)
-	iot.AddRoute("readAssetStateHistoryContainer", "query", ContainerClass, readAssetStateHistoryContainer)
-	iot.AddRoute("readAllAssetsContainer", "query", ContainerClass, readAllAssetsContainer)
+	if err := iot.RegisterClassRoutes(ContainerClass, iot.ClassRouteOptions{Suffix: "Container"}); err != nil {
+		panic(err)
+	}
 }
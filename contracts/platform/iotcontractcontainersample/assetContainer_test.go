@@ -0,0 +1,118 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+package main
+
+import (
+	"testing"
+
+	iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcptest"
+)
+
+func TestContainerOverTemperature(t *testing.T) {
+	h := iotcptest.New(t, new(SimpleChaincode))
+	h.Init(CONTRACTVERSION).ExpectOK()
+
+	h.CreateAsset(ContainerClass, `{"container":{"barcode":"C1","temperature":-4,"carrier":"ACME"}}`).ExpectOK()
+	h.ExpectState(ContainerClass, "C1", "container.carrier", "ACME").
+		ExpectNoAlert(ContainerClass, "C1", overtempAlert)
+
+	h.UpdateAsset(ContainerClass, `{"container":{"barcode":"C1","temperature":2}}`).ExpectOK()
+	h.ExpectState(ContainerClass, "C1", "container.temperature", 2).
+		ExpectState(ContainerClass, "C1", "container.carrier", "ACME").
+		ExpectAlert(ContainerClass, "C1", overtempAlert).
+		ExpectCompliant(ContainerClass, "C1", false)
+
+	h.UpdateAsset(ContainerClass, `{"container":{"barcode":"C1","temperature":-1}}`).ExpectOK()
+	h.ExpectNoAlert(ContainerClass, "C1", overtempAlert).
+		ExpectCompliant(ContainerClass, "C1", true)
+
+	// readings in other units are stored in Celsius, with the original kept in the record
+	h.UpdateAsset(ContainerClass, `{"container":{"barcode":"C1","temperature":{"value":35.6,"unit":"F"}}}`).ExpectOK()
+	h.ExpectState(ContainerClass, "C1", "container.temperature", 2).
+		ExpectAlert(ContainerClass, "C1", overtempAlert)
+	if r := h.Asset(ContainerClass, "C1").ReadingsIn["container.temperature"]; r.Value != 35.6 || r.Unit != "F" {
+		t.Fatalf("unexpected original reading %+v", r)
+	}
+
+	// a device that declares its units can send plain numbers
+	h.UpdateAsset(ContainerClass, `{"units":{"container.temperature":"F"},"container":{"barcode":"C1","temperature":23}}`).ExpectOK()
+	h.UpdateAsset(ContainerClass, `{"container":{"barcode":"C1","temperature":30.2}}`).ExpectOK()
+	h.ExpectState(ContainerClass, "C1", "container.temperature", -1).
+		ExpectNoAlert(ContainerClass, "C1", overtempAlert)
+	h.UpdateAsset(ContainerClass, `{"container":{"barcode":"C1","temperature":{"value":1,"unit":"km"}}}`).ExpectError("cannot convert")
+
+	var recent []iot.Asset
+	h.Query("readRecentStates", `{"class":"container"}`).ExpectResult(&recent)
+	if len(recent) != 1 || recent[0].AssetKey != "CONC1" {
+		t.Fatalf("unexpected recent states %v", recent)
+	}
+
+	h.DeleteAsset(ContainerClass, "C1").ExpectOK()
+	h.Query("readAssetContainer", `{"container":{"barcode":"C1"}}`).ExpectError("does not exist")
+}
+
+func TestContainerTemperatureProvenance(t *testing.T) {
+	h := iotcptest.New(t, new(SimpleChaincode))
+	h.Init(CONTRACTVERSION).ExpectOK()
+
+	h.CreateAsset(ContainerClass, `{"container":{"barcode":"C2","temperature":-4,"carrier":"ACME","common":{"deviceID":"shipper-probe"}}}`).ExpectOK()
+	created := h.Asset(ContainerClass, "C2").TXNID
+	h.UpdateAsset(ContainerClass, `{"container":{"barcode":"C2","temperature":{"value":30,"unit":"F"},"common":{"deviceID":"carrier-probe"}}}`).ExpectOK()
+	updated := h.Asset(ContainerClass, "C2").TXNID
+
+	var prov iot.ProvenanceOut
+	h.Query("readAssetProvenance", `{"class":"container","assetID":"C2","qprop":"container"}`).ExpectResult(&prov)
+	var byQProp = make(map[string]iot.PropertyProvenance)
+	for _, p := range prov.Properties {
+		byQProp[p.QProp] = p
+	}
+	if p := byQProp["container.temperature"]; p.TXNID != updated || p.DeviceID != "carrier-probe" || p.Function != "updateAssetContainer" {
+		t.Fatalf("unexpected temperature provenance %+v", p)
+	}
+	if p := byQProp["container.carrier"]; p.TXNID != created || p.DeviceID != "shipper-probe" {
+		t.Fatalf("unexpected carrier provenance %+v", p)
+	}
+
+	h.Invoke("deletePropertiesFromAssetContainer", `{"container":{"barcode":"C2"},"qprops":["container.temperature"]}`).ExpectOK()
+	h.Query("readAssetProvenance", `{"assetkey":"CONC2","qprop":"container.temperature"}`).ExpectResult(&prov)
+	if len(prov.Properties) != 0 {
+		t.Fatalf("deleted property still has provenance %+v", prov.Properties)
+	}
+
+	h.DeleteAsset(ContainerClass, "C2").ExpectOK()
+	h.Query("readAssetProvenance", `{"assetkey":"CONC2"}`).ExpectError("does not exist")
+	if b, _ := h.Stub.GetState(iot.PROVENANCEKEY + "CONC2"); len(b) != 0 {
+		t.Fatal("provenance survived the asset")
+	}
+}
+
+func TestContainerAppdataMerge(t *testing.T) {
+	h := iotcptest.New(t, new(SimpleChaincode))
+	h.Init(CONTRACTVERSION).ExpectOK()
+
+	h.CreateAsset(ContainerClass, `{"container":{"barcode":"C3","common":{"appdata":[{"K":"owner","V":"ACME"},{"K":"seal","V":"S1"}]}}}`).ExpectOK()
+	h.UpdateAsset(ContainerClass, `{"container":{"barcode":"C3","common":{"appdata":[{"K":"seal","V":"S2"}]}}}`).ExpectOK()
+	h.ExpectState(ContainerClass, "C3", "container.common.appdata", []map[string]string{{"K": "owner", "V": "ACME"}, {"K": "seal", "V": "S2"}})
+	h.UpdateAsset(ContainerClass, `{"container":{"barcode":"C3","common":{"appdata":[{"K":"port","V":"Rotterdam"}]}}}`).ExpectOK()
+	h.ExpectState(ContainerClass, "C3", "container.common.appdata", []map[string]string{{"K": "owner", "V": "ACME"}, {"K": "seal", "V": "S2"}, {"K": "port", "V": "Rotterdam"}})
+}
+
+func TestContainerRoutesMatchSchema(t *testing.T) {
+	if err := iot.VerifyRoutes(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -19,8 +19,11 @@ Kim Letkeman - Initial Contribution
 package main
 
 import (
+	"os"
+
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpreplay"
 )
 
 // Update the path to match your configuration
@@ -38,6 +41,13 @@ var log = shim.NewLogger("main")
 
 func main() {
 	iot.SetContractLogger(shim.NewLogger("iotcontractsample"))
+	if err := iot.VerifyRoutes(); err != nil {
+		log.Criticalf("ERROR the contract does not route its schema's API: %s", err)
+		os.Exit(1)
+	}
+	if iotcpreplay.Requested(os.Args) {
+		os.Exit(iotcpreplay.Main(new(SimpleChaincode), CONTRACTVERSION, os.Args[2:]))
+	}
 	err := shim.Start(new(SimpleChaincode))
 	if err != nil {
 		log.Infof("ERROR starting Simple Chaincode: %s", err)
@@ -0,0 +1,28 @@
+// Code generated by processSchema.go from container.json, DO NOT EDIT.
+
+package main
+
+import iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
+
+// the functions that the schema publishes, main calls iot.VerifyRoutes to check that each is routed
+func init() {
+	iot.ExpectRoutes(map[string]string{
+		"initContract":                       "deploy",
+		"createAssetContainer":               "invoke",
+		"replaceAssetContainer":              "invoke",
+		"updateAssetContainer":               "invoke",
+		"deleteAssetContainer":               "invoke",
+		"deleteAssetStateHistoryContainer":   "invoke",
+		"deletePropertiesFromAssetContainer": "invoke",
+		"deleteAllAssetsContainer":           "invoke",
+		"readAssetContainer":                 "query",
+		"readAllAssetsContainer":             "query",
+		"readAllRoutes":                      "query",
+		"readWorldState":                     "query",
+		"deleteWorldState":                   "invoke",
+		"readAssetStateHistoryContainer":     "query",
+		"readRecentStates":                   "query",
+		"setLoggingLevel":                    "invoke",
+		"setCreateOnFirstUpdate":             "invoke",
+	})
+}
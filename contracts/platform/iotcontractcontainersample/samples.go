@@ -24,10 +24,10 @@ var samples = `
                                 }
                             ],
                             "deviceID": "A unique identifier for the device that sent the current event",
-                            "devicetimestamp": "A timestamp recoded by the device that sent the current event",
+                            "devicetimestamp": "2016-11-20T00:00:00Z",
                             "location": {
-                                "latitude": 123.456,
-                                "longitude": 123.456
+                                "latitude": 45.4215,
+                                "longitude": -75.6972
                             }
                         },
                         "temperature": 123.456
@@ -50,10 +50,10 @@ var samples = `
                                 }
                             ],
                             "deviceID": "A unique identifier for the device that sent the current event",
-                            "devicetimestamp": "A timestamp recoded by the device that sent the current event",
+                            "devicetimestamp": "2016-11-20T00:00:00Z",
                             "location": {
-                                "latitude": 123.456,
-                                "longitude": 123.456
+                                "latitude": 45.4215,
+                                "longitude": -75.6972
                             }
                         },
                         "temperature": 123.456
@@ -75,10 +75,10 @@ var samples = `
                     }
                 ],
                 "deviceID": "A unique identifier for the device that sent the current event",
-                "devicetimestamp": "A timestamp recoded by the device that sent the current event",
+                "devicetimestamp": "2016-11-20T00:00:00Z",
                 "location": {
-                    "latitude": 123.456,
-                    "longitude": 123.456
+                    "latitude": 45.4215,
+                    "longitude": -75.6972
                 }
             },
             "temperature": 123.456
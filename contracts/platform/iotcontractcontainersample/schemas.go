@@ -70,8 +70,9 @@ var schemas = `
                                         "type": "object"
                                     },
                                     "temperature": {
-                                        "description": "Temperature of a container's contents in degrees Celsuis",
-                                        "type": "number"
+                                        "description": "Temperature of a container's contents in degrees Celsius, readings in other units are sent as {\"value\": 35.6, \"unit\": \"F\"}",
+                                        "type": "number",
+                                        "unit": "C"
                                     }
                                 },
                                 "required": [
@@ -256,12 +257,24 @@ var schemas = `
             "type": "object"
         },
         "deleteWorldState": {
-            "description": "**** WARNING *** Clears the entire contents of world state, redeploy the contract after using this, in debugging mode, will require a restart",
+            "description": "**** WARNING *** Clears the entire contents of world state except destructive guard and audit records, requires a confirm token from readConfirmationToken and is disabled in production mode. The plain \"reinit\" argument of earlier releases is confirmed as {\"reinit\": true}",
             "properties": {
                 "args": {
-                    "items": {},
-                    "maxItems": 0,
-                    "minItems": 0,
+                    "items": {
+                        "properties": {
+                            "confirm": {
+                                "description": "token returned by readConfirmationToken, valid for one call within two minutes",
+                                "type": "string"
+                            },
+                            "reinit": {
+                                "description": "reinitialize the contract state with the current version and nickname",
+                                "type": "boolean"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
                     "type": "array"
                 },
                 "function": {
@@ -364,7 +377,292 @@ var schemas = `
                     "items": {
                         "patternProperties": {
                             "^CON": {
-                                "description": "The external state of one container asset, named by its world state ID",
+                                "description": "A container's complete state",
+                                "properties": {
+                                    "AssetKey": {
+                                        "description": "This container's world state container ID",
+                                        "type": "string"
+                                    },
+                                    "alerts": {
+                                        "description": "An array of alert names",
+                                        "items": {
+                                            "description": "An alert name",
+                                            "type": "string"
+                                        },
+                                        "type": "array"
+                                    },
+                                    "assetIDpath": {
+                                        "description": "Qualified property path to the container's ID, declared in the contract code",
+                                        "type": "string"
+                                    },
+                                    "class": {
+                                        "description": "The container's asset class",
+                                        "type": "string"
+                                    },
+                                    "compliant": {
+                                        "description": "This container has no active alerts",
+                                        "type": "boolean"
+                                    },
+                                    "eventin": {
+                                        "description": "The contract event that created this state, for example updateAssetContainer",
+                                        "properties": {
+                                            "container": {
+                                                "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                                "properties": {
+                                                    "barcode": {
+                                                        "description": "A container's ID",
+                                                        "type": "string"
+                                                    },
+                                                    "carrier": {
+                                                        "description": "The carrier in possession of this container",
+                                                        "type": "string"
+                                                    },
+                                                    "common": {
+                                                        "description": "Common properties for all assets",
+                                                        "properties": {
+                                                            "appdata": {
+                                                                "description": "Application managed information as an array of key:value pairs",
+                                                                "items": {
+                                                                    "properties": {
+                                                                        "K": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "V": {
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "minItems": 0,
+                                                                "type": "array"
+                                                            },
+                                                            "deviceID": {
+                                                                "description": "A unique identifier for the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "devicetimestamp": {
+                                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "location": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "temperature": {
+                                                        "description": "Temperature of a container's contents in degrees Celsius, readings in other units are sent as {\"value\": 35.6, \"unit\": \"F\"}",
+                                                        "type": "number",
+                                                        "unit": "C"
+                                                    }
+                                                },
+                                                "required": [
+                                                    "barcode"
+                                                ],
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "eventout": {
+                                        "description": "The chaincode event emitted on invoke exit, if any",
+                                        "properties": {
+                                            "container": {
+                                                "description": "A chaincode event defining the standard platform-generated result event for a contract invoke, contains an array of contributed results",
+                                                "properties": {
+                                                    "name": {
+                                                        "default": "EVT.IOTCP.INVOKE.RESULT",
+                                                        "enum": [
+                                                            "EVT.IOTCP.INVOKE.RESULT"
+                                                        ],
+                                                        "type": "string"
+                                                    },
+                                                    "payload": {
+                                                        "description": "A map of contributed results",
+                                                        "properties": {
+                                                            "description": "the overall status of the invoke result, defined by err",
+                                                            "properties": {
+                                                                "activeAlerts": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "alertsCleared": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "alertsRaised": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "invokeresult": {
+                                                                    "description": "status: OK==txn succeeded, ERROR==txn failed",
+                                                                    "properties": {
+                                                                        "message": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "status": {
+                                                                            "enum": [
+                                                                                "OK",
+                                                                                "ERROR"
+                                                                            ],
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "notifications": {
+                                                                    "description": "typed notifications queued by rules and routes during the invoke, dropped when the invoke fails",
+                                                                    "items": {
+                                                                        "description": "A typed notification in the invoke result event",
+                                                                        "properties": {
+                                                                            "assetID": {
+                                                                                "type": "string"
+                                                                            },
+                                                                            "assetkey": {
+                                                                                "type": "string"
+                                                                            },
+                                                                            "class": {
+                                                                                "type": "string"
+                                                                            },
+                                                                            "data": {
+                                                                                "description": "data that depends on the type, e.g. {\"alert\": \"OVERTEMP\"} for alertRaised",
+                                                                                "type": "object"
+                                                                            },
+                                                                            "txnts": {
+                                                                                "format": "date-time",
+                                                                                "type": "string"
+                                                                            },
+                                                                            "type": {
+                                                                                "description": "alertRaised, alertCleared, geofenceExit, stateTransition or a contract defined type",
+                                                                                "type": "string"
+                                                                            }
+                                                                        },
+                                                                        "required": [
+                                                                            "type"
+                                                                        ],
+                                                                        "type": "object"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "version": {
+                                                                    "description": "version of the result envelope, decoded by the iotcpevents package",
+                                                                    "type": "integer"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "prefix": {
+                                        "description": "The container's asset class prefix in world state",
+                                        "type": "string"
+                                    },
+                                    "state": {
+                                        "description": "Properties that have been received or calculated for this container",
+                                        "properties": {
+                                            "container": {
+                                                "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                                "properties": {
+                                                    "barcode": {
+                                                        "description": "A container's ID",
+                                                        "type": "string"
+                                                    },
+                                                    "carrier": {
+                                                        "description": "The carrier in possession of this container",
+                                                        "type": "string"
+                                                    },
+                                                    "common": {
+                                                        "description": "Common properties for all assets",
+                                                        "properties": {
+                                                            "appdata": {
+                                                                "description": "Application managed information as an array of key:value pairs",
+                                                                "items": {
+                                                                    "properties": {
+                                                                        "K": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "V": {
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "minItems": 0,
+                                                                "type": "array"
+                                                            },
+                                                            "deviceID": {
+                                                                "description": "A unique identifier for the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "devicetimestamp": {
+                                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "location": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "temperature": {
+                                                        "description": "Temperature of a container's contents in degrees Celsius, readings in other units are sent as {\"value\": 35.6, \"unit\": \"F\"}",
+                                                        "type": "number",
+                                                        "unit": "C"
+                                                    }
+                                                },
+                                                "required": [
+                                                    "barcode"
+                                                ],
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "txnid": {
+                                        "description": "Transaction UUID matching the blockchain",
+                                        "type": "string"
+                                    },
+                                    "txnts": {
+                                        "description": "Transaction timestamp matching the blockchain",
+                                        "type": "string"
+                                    }
+                                },
                                 "type": "object"
                             }
                         },
@@ -406,6 +704,10 @@ var schemas = `
                                 },
                                 "type": "object"
                             },
+                            "destructive": {
+                                "description": "true when the route requires a confirm token",
+                                "type": "boolean"
+                            },
                             "functionname": {
                                 "type": "string"
                             },
@@ -534,8 +836,9 @@ var schemas = `
                                             "type": "object"
                                         },
                                         "temperature": {
-                                            "description": "Temperature of a container's contents in degrees Celsuis",
-                                            "type": "number"
+                                            "description": "Temperature of a container's contents in degrees Celsius, readings in other units are sent as {\"value\": 35.6, \"unit\": \"F\"}",
+                                            "type": "number",
+                                            "unit": "C"
                                         }
                                     },
                                     "required": [
@@ -603,6 +906,44 @@ var schemas = `
                                                             }
                                                         },
                                                         "type": "object"
+                                                    },
+                                                    "notifications": {
+                                                        "description": "typed notifications queued by rules and routes during the invoke, dropped when the invoke fails",
+                                                        "items": {
+                                                            "description": "A typed notification in the invoke result event",
+                                                            "properties": {
+                                                                "assetID": {
+                                                                    "type": "string"
+                                                                },
+                                                                "assetkey": {
+                                                                    "type": "string"
+                                                                },
+                                                                "class": {
+                                                                    "type": "string"
+                                                                },
+                                                                "data": {
+                                                                    "description": "data that depends on the type, e.g. {\"alert\": \"OVERTEMP\"} for alertRaised",
+                                                                    "type": "object"
+                                                                },
+                                                                "txnts": {
+                                                                    "format": "date-time",
+                                                                    "type": "string"
+                                                                },
+                                                                "type": {
+                                                                    "description": "alertRaised, alertCleared, geofenceExit, stateTransition or a contract defined type",
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "required": [
+                                                                "type"
+                                                            ],
+                                                            "type": "object"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "version": {
+                                                        "description": "version of the result envelope, decoded by the iotcpevents package",
+                                                        "type": "integer"
                                                     }
                                                 },
                                                 "type": "object"
@@ -676,8 +1017,9 @@ var schemas = `
                                             "type": "object"
                                         },
                                         "temperature": {
-                                            "description": "Temperature of a container's contents in degrees Celsuis",
-                                            "type": "number"
+                                            "description": "Temperature of a container's contents in degrees Celsius, readings in other units are sent as {\"value\": 35.6, \"unit\": \"F\"}",
+                                            "type": "number",
+                                            "unit": "C"
                                         }
                                     },
                                     "required": [
@@ -790,7 +1132,292 @@ var schemas = `
                     "items": {
                         "patternProperties": {
                             "^CON": {
-                                "description": "The external state of one container asset, named by its world state ID",
+                                "description": "A container's complete state",
+                                "properties": {
+                                    "AssetKey": {
+                                        "description": "This container's world state container ID",
+                                        "type": "string"
+                                    },
+                                    "alerts": {
+                                        "description": "An array of alert names",
+                                        "items": {
+                                            "description": "An alert name",
+                                            "type": "string"
+                                        },
+                                        "type": "array"
+                                    },
+                                    "assetIDpath": {
+                                        "description": "Qualified property path to the container's ID, declared in the contract code",
+                                        "type": "string"
+                                    },
+                                    "class": {
+                                        "description": "The container's asset class",
+                                        "type": "string"
+                                    },
+                                    "compliant": {
+                                        "description": "This container has no active alerts",
+                                        "type": "boolean"
+                                    },
+                                    "eventin": {
+                                        "description": "The contract event that created this state, for example updateAssetContainer",
+                                        "properties": {
+                                            "container": {
+                                                "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                                "properties": {
+                                                    "barcode": {
+                                                        "description": "A container's ID",
+                                                        "type": "string"
+                                                    },
+                                                    "carrier": {
+                                                        "description": "The carrier in possession of this container",
+                                                        "type": "string"
+                                                    },
+                                                    "common": {
+                                                        "description": "Common properties for all assets",
+                                                        "properties": {
+                                                            "appdata": {
+                                                                "description": "Application managed information as an array of key:value pairs",
+                                                                "items": {
+                                                                    "properties": {
+                                                                        "K": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "V": {
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "minItems": 0,
+                                                                "type": "array"
+                                                            },
+                                                            "deviceID": {
+                                                                "description": "A unique identifier for the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "devicetimestamp": {
+                                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "location": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "temperature": {
+                                                        "description": "Temperature of a container's contents in degrees Celsius, readings in other units are sent as {\"value\": 35.6, \"unit\": \"F\"}",
+                                                        "type": "number",
+                                                        "unit": "C"
+                                                    }
+                                                },
+                                                "required": [
+                                                    "barcode"
+                                                ],
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "eventout": {
+                                        "description": "The chaincode event emitted on invoke exit, if any",
+                                        "properties": {
+                                            "container": {
+                                                "description": "A chaincode event defining the standard platform-generated result event for a contract invoke, contains an array of contributed results",
+                                                "properties": {
+                                                    "name": {
+                                                        "default": "EVT.IOTCP.INVOKE.RESULT",
+                                                        "enum": [
+                                                            "EVT.IOTCP.INVOKE.RESULT"
+                                                        ],
+                                                        "type": "string"
+                                                    },
+                                                    "payload": {
+                                                        "description": "A map of contributed results",
+                                                        "properties": {
+                                                            "description": "the overall status of the invoke result, defined by err",
+                                                            "properties": {
+                                                                "activeAlerts": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "alertsCleared": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "alertsRaised": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "invokeresult": {
+                                                                    "description": "status: OK==txn succeeded, ERROR==txn failed",
+                                                                    "properties": {
+                                                                        "message": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "status": {
+                                                                            "enum": [
+                                                                                "OK",
+                                                                                "ERROR"
+                                                                            ],
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "notifications": {
+                                                                    "description": "typed notifications queued by rules and routes during the invoke, dropped when the invoke fails",
+                                                                    "items": {
+                                                                        "description": "A typed notification in the invoke result event",
+                                                                        "properties": {
+                                                                            "assetID": {
+                                                                                "type": "string"
+                                                                            },
+                                                                            "assetkey": {
+                                                                                "type": "string"
+                                                                            },
+                                                                            "class": {
+                                                                                "type": "string"
+                                                                            },
+                                                                            "data": {
+                                                                                "description": "data that depends on the type, e.g. {\"alert\": \"OVERTEMP\"} for alertRaised",
+                                                                                "type": "object"
+                                                                            },
+                                                                            "txnts": {
+                                                                                "format": "date-time",
+                                                                                "type": "string"
+                                                                            },
+                                                                            "type": {
+                                                                                "description": "alertRaised, alertCleared, geofenceExit, stateTransition or a contract defined type",
+                                                                                "type": "string"
+                                                                            }
+                                                                        },
+                                                                        "required": [
+                                                                            "type"
+                                                                        ],
+                                                                        "type": "object"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "version": {
+                                                                    "description": "version of the result envelope, decoded by the iotcpevents package",
+                                                                    "type": "integer"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "prefix": {
+                                        "description": "The container's asset class prefix in world state",
+                                        "type": "string"
+                                    },
+                                    "state": {
+                                        "description": "Properties that have been received or calculated for this container",
+                                        "properties": {
+                                            "container": {
+                                                "description": "The changeable properties for a container, also considered its 'event' as a partial state",
+                                                "properties": {
+                                                    "barcode": {
+                                                        "description": "A container's ID",
+                                                        "type": "string"
+                                                    },
+                                                    "carrier": {
+                                                        "description": "The carrier in possession of this container",
+                                                        "type": "string"
+                                                    },
+                                                    "common": {
+                                                        "description": "Common properties for all assets",
+                                                        "properties": {
+                                                            "appdata": {
+                                                                "description": "Application managed information as an array of key:value pairs",
+                                                                "items": {
+                                                                    "properties": {
+                                                                        "K": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "V": {
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "minItems": 0,
+                                                                "type": "array"
+                                                            },
+                                                            "deviceID": {
+                                                                "description": "A unique identifier for the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "devicetimestamp": {
+                                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "location": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "temperature": {
+                                                        "description": "Temperature of a container's contents in degrees Celsius, readings in other units are sent as {\"value\": 35.6, \"unit\": \"F\"}",
+                                                        "type": "number",
+                                                        "unit": "C"
+                                                    }
+                                                },
+                                                "required": [
+                                                    "barcode"
+                                                ],
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "txnid": {
+                                        "description": "Transaction UUID matching the blockchain",
+                                        "type": "string"
+                                    },
+                                    "txnts": {
+                                        "description": "Transaction timestamp matching the blockchain",
+                                        "type": "string"
+                                    }
+                                },
                                 "type": "object"
                             }
                         },
@@ -803,7 +1430,7 @@ var schemas = `
             "type": "object"
         },
         "readRecentStates": {
-            "description": "Returns the state of recently updated assets",
+            "description": "Returns the state of recently updated assets for one class, or for all classes merged newest first",
             "properties": {
                 "args": {
                     "items": {
@@ -812,6 +1439,10 @@ var schemas = `
                                 "description": "zero based beginning of range",
                                 "type": "integer"
                             },
+                            "class": {
+                                "description": "asset class name, absence means all classes",
+                                "type": "string"
+                            },
                             "end": {
                                 "description": "zero based end of range, absence means to end",
                                 "type": "integer"
@@ -819,7 +1450,7 @@ var schemas = `
                         },
                         "type": "object"
                     },
-                    "maxItems": 0,
+                    "maxItems": 1,
                     "minItems": 0,
                     "type": "array"
                 },
@@ -986,6 +1617,44 @@ var schemas = `
                                                                 }
                                                             },
                                                             "type": "object"
+                                                        },
+                                                        "notifications": {
+                                                            "description": "typed notifications queued by rules and routes during the invoke, dropped when the invoke fails",
+                                                            "items": {
+                                                                "description": "A typed notification in the invoke result event",
+                                                                "properties": {
+                                                                    "assetID": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "assetkey": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "class": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "data": {
+                                                                        "description": "data that depends on the type, e.g. {\"alert\": \"OVERTEMP\"} for alertRaised",
+                                                                        "type": "object"
+                                                                    },
+                                                                    "txnts": {
+                                                                        "format": "date-time",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": {
+                                                                        "description": "alertRaised, alertCleared, geofenceExit, stateTransition or a contract defined type",
+                                                                        "type": "string"
+                                                                    }
+                                                                },
+                                                                "required": [
+                                                                    "type"
+                                                                ],
+                                                                "type": "object"
+                                                            },
+                                                            "type": "array"
+                                                        },
+                                                        "version": {
+                                                            "description": "version of the result envelope, decoded by the iotcpevents package",
+                                                            "type": "integer"
                                                         }
                                                     },
                                                     "type": "object"
@@ -998,6 +1667,39 @@ var schemas = `
                                 },
                                 "type": "object"
                             },
+                            "eventreadings": {
+                                "additionalProperties": {
+                                    "description": "A reading with its unit, which can be sent in an event in place of a number for any property that has a unit",
+                                    "properties": {
+                                        "unit": {
+                                            "description": "A unit of measure for a reading",
+                                            "enum": [
+                                                "C",
+                                                "F",
+                                                "K",
+                                                "m",
+                                                "km",
+                                                "mi",
+                                                "ft",
+                                                "g",
+                                                "m/s2",
+                                                "m/s²"
+                                            ],
+                                            "type": "string"
+                                        },
+                                        "value": {
+                                            "type": "number"
+                                        }
+                                    },
+                                    "required": [
+                                        "value",
+                                        "unit"
+                                    ],
+                                    "type": "object"
+                                },
+                                "description": "The original value and unit of each reading in the event that was converted to the class's unit, by qualified property name",
+                                "type": "object"
+                            },
                             "state": {
                                 "description": "Properties that have been received or calculated for this asset",
                                 "properties": {
@@ -1167,8 +1869,9 @@ var schemas = `
                                         "type": "object"
                                     },
                                     "temperature": {
-                                        "description": "Temperature of a container's contents in degrees Celsuis",
-                                        "type": "number"
+                                        "description": "Temperature of a container's contents in degrees Celsius, readings in other units are sent as {\"value\": 35.6, \"unit\": \"F\"}",
+                                        "type": "number",
+                                        "unit": "C"
                                     }
                                 },
                                 "required": [
@@ -1221,7 +1924,7 @@ var schemas = `
             "type": "object"
         },
         "setLoggingLevel": {
-            "description": "Sets the logging level for the contract",
+            "description": "Sets the logging level for the contract, or for one module of the platform",
             "properties": {
                 "args": {
                     "items": {
@@ -1236,6 +1939,37 @@ var schemas = `
                                     "DEBUG"
                                 ],
                                 "type": "string"
+                            },
+                            "module": {
+                                "description": "optional module, the platform file that logs without the ct prefix",
+                                "enum": [
+                                    "alerts",
+                                    "asset",
+                                    "classes",
+                                    "classroutes",
+                                    "computed",
+                                    "config",
+                                    "contractstate",
+                                    "crud",
+                                    "expression",
+                                    "filters",
+                                    "geo",
+                                    "guard",
+                                    "history",
+                                    "log",
+                                    "maps",
+                                    "merge",
+                                    "metrics",
+                                    "notify",
+                                    "provenance",
+                                    "recent",
+                                    "router",
+                                    "rulerouter",
+                                    "snapshot",
+                                    "units",
+                                    "verify"
+                                ],
+                                "type": "string"
                             }
                         },
                         "type": "object"
@@ -1314,8 +2048,9 @@ var schemas = `
                                         "type": "object"
                                     },
                                     "temperature": {
-                                        "description": "Temperature of a container's contents in degrees Celsuis",
-                                        "type": "number"
+                                        "description": "Temperature of a container's contents in degrees Celsius, readings in other units are sent as {\"value\": 35.6, \"unit\": \"F\"}",
+                                        "type": "number",
+                                        "unit": "C"
                                     }
                                 },
                                 "required": [
@@ -1396,8 +2131,9 @@ var schemas = `
                     "type": "object"
                 },
                 "temperature": {
-                    "description": "Temperature of a container's contents in degrees Celsuis",
-                    "type": "number"
+                    "description": "Temperature of a container's contents in degrees Celsius, readings in other units are sent as {\"value\": 35.6, \"unit\": \"F\"}",
+                    "type": "number",
+                    "unit": "C"
                 }
             },
             "required": [
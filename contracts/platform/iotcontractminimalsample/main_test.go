@@ -0,0 +1,47 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+package main
+
+import (
+	"testing"
+
+	iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcptest"
+)
+
+func TestMinimalContractDefaultRoutes(t *testing.T) {
+	h := iotcptest.New(t, new(SimpleChaincode))
+	h.Init(CONTRACTVERSION).ExpectOK()
+
+	h.Invoke("createAsset", `{"asset":{"assetID":"M1","temperature":1}}`).ExpectOK()
+	h.ExpectAlert(iot.DefaultClass, "M1", "OVERTEMP")
+	h.Invoke("updateAsset", `{"asset":{"assetID":"M1","temperature":0}}`).ExpectOK()
+	h.ExpectNoAlert(iot.DefaultClass, "M1", "OVERTEMP")
+	h.Invoke("deletePropertiesFromAsset", `{"asset":{"assetID":"M1"},"qprops":["asset.temperature"]}`).ExpectOK()
+	h.ExpectNoState(iot.DefaultClass, "M1", "asset.temperature")
+
+	var contractState iot.ContractState
+	h.Query("readContractState").ExpectResult(&contractState)
+	if contractState.Version != CONTRACTVERSION {
+		t.Fatalf("contract version is %s, expected %s", contractState.Version, CONTRACTVERSION)
+	}
+}
+
+func TestMinimalContractRoutesMatchSchema(t *testing.T) {
+	if err := iot.VerifyRoutes(); err != nil {
+		t.Fatal(err)
+	}
+}
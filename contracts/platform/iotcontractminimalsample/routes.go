@@ -0,0 +1,29 @@
+// Code generated by processSchema.go from minimal.json, DO NOT EDIT.
+
+package main
+
+import iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
+
+// the functions that the schema publishes, main calls iot.VerifyRoutes to check that each is routed
+func init() {
+	iot.ExpectRoutes(map[string]string{
+		"initContract":              "deploy",
+		"createAsset":               "invoke",
+		"replaceAsset":              "invoke",
+		"updateAsset":               "invoke",
+		"deleteAsset":               "invoke",
+		"deleteAssetStateHistory":   "invoke",
+		"deletePropertiesFromAsset": "invoke",
+		"deleteAllAssets":           "invoke",
+		"readAsset":                 "query",
+		"readAllAssets":             "query",
+		"readAssetStateHistory":     "query",
+		"readAllRoutes":             "query",
+		"readAllRules":              "query",
+		"readWorldState":            "query",
+		"deleteWorldState":          "invoke",
+		"readRecentStates":          "query",
+		"setLoggingLevel":           "invoke",
+		"setCreateOnFirstUpdate":    "invoke",
+	})
+}
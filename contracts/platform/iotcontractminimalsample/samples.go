@@ -24,10 +24,10 @@ var samples = `
                                 }
                             ],
                             "deviceID": "A unique identifier for the device that sent the current event",
-                            "devicetimestamp": "A timestamp recoded by the device that sent the current event",
+                            "devicetimestamp": "2016-11-20T00:00:00Z",
                             "location": {
-                                "latitude": 123.456,
-                                "longitude": 123.456
+                                "latitude": 45.4215,
+                                "longitude": -75.6972
                             }
                         },
                         "temperature": 123.456
@@ -49,10 +49,10 @@ var samples = `
                     }
                 ],
                 "deviceID": "A unique identifier for the device that sent the current event",
-                "devicetimestamp": "A timestamp recoded by the device that sent the current event",
+                "devicetimestamp": "2016-11-20T00:00:00Z",
                 "location": {
-                    "latitude": 123.456,
-                    "longitude": 123.456
+                    "latitude": 45.4215,
+                    "longitude": -75.6972
                 }
             },
             "temperature": 123.456
@@ -76,10 +76,10 @@ var samples = `
                             }
                         ],
                         "deviceID": "A unique identifier for the device that sent the current event",
-                        "devicetimestamp": "A timestamp recoded by the device that sent the current event",
+                        "devicetimestamp": "2016-11-20T00:00:00Z",
                         "location": {
-                            "latitude": 123.456,
-                            "longitude": 123.456
+                            "latitude": 45.4215,
+                            "longitude": -75.6972
                         }
                     },
                     "temperature": 123.456
@@ -93,6 +93,7 @@ var samples = `
                     }
                 }
             },
+            "eventreadings": {},
             "state": {
                 "asset": {
                     "assetID": "An asset's unique ID, e.g. barcode, VIN, etc.",
@@ -105,10 +106,10 @@ var samples = `
                             }
                         ],
                         "deviceID": "A unique identifier for the device that sent the current event",
-                        "devicetimestamp": "A timestamp recoded by the device that sent the current event",
+                        "devicetimestamp": "2016-11-20T00:00:00Z",
                         "location": {
-                            "latitude": 123.456,
-                            "longitude": 123.456
+                            "latitude": 45.4215,
+                            "longitude": -75.6972
                         }
                     },
                     "temperature": 123.456
@@ -137,10 +138,10 @@ var samples = `
                                 }
                             ],
                             "deviceID": "A unique identifier for the device that sent the current event",
-                            "devicetimestamp": "A timestamp recoded by the device that sent the current event",
+                            "devicetimestamp": "2016-11-20T00:00:00Z",
                             "location": {
-                                "latitude": 123.456,
-                                "longitude": 123.456
+                                "latitude": 45.4215,
+                                "longitude": -75.6972
                             }
                         },
                         "temperature": 123.456
@@ -154,6 +155,7 @@ var samples = `
                         }
                     }
                 },
+                "eventreadings": {},
                 "state": {
                     "asset": {
                         "assetID": "An asset's unique ID, e.g. barcode, VIN, etc.",
@@ -166,10 +168,10 @@ var samples = `
                                 }
                             ],
                             "deviceID": "A unique identifier for the device that sent the current event",
-                            "devicetimestamp": "A timestamp recoded by the device that sent the current event",
+                            "devicetimestamp": "2016-11-20T00:00:00Z",
                             "location": {
-                                "latitude": 123.456,
-                                "longitude": 123.456
+                                "latitude": 45.4215,
+                                "longitude": -75.6972
                             }
                         },
                         "temperature": 123.456
@@ -199,10 +201,10 @@ var samples = `
                                 }
                             ],
                             "deviceID": "A unique identifier for the device that sent the current event",
-                            "devicetimestamp": "A timestamp recoded by the device that sent the current event",
+                            "devicetimestamp": "2016-11-20T00:00:00Z",
                             "location": {
-                                "latitude": 123.456,
-                                "longitude": 123.456
+                                "latitude": 45.4215,
+                                "longitude": -75.6972
                             }
                         },
                         "temperature": 123.456
@@ -216,6 +218,7 @@ var samples = `
                         }
                     }
                 },
+                "eventreadings": {},
                 "state": {
                     "asset": {
                         "assetID": "An asset's unique ID, e.g. barcode, VIN, etc.",
@@ -228,10 +231,10 @@ var samples = `
                                 }
                             ],
                             "deviceID": "A unique identifier for the device that sent the current event",
-                            "devicetimestamp": "A timestamp recoded by the device that sent the current event",
+                            "devicetimestamp": "2016-11-20T00:00:00Z",
                             "location": {
-                                "latitude": 123.456,
-                                "longitude": 123.456
+                                "latitude": 45.4215,
+                                "longitude": -75.6972
                             }
                         },
                         "temperature": 123.456
@@ -260,7 +263,18 @@ var samples = `
             "invokeresult": {
                 "message": "carpe noctem",
                 "status": "ERROR"
-            }
+            },
+            "notifications": [
+                {
+                    "assetID": "carpe noctem",
+                    "assetkey": "carpe noctem",
+                    "class": "carpe noctem",
+                    "data": {},
+                    "txnts": "2016-11-20T00:00:00Z",
+                    "type": "alertRaised, alertCleared, geofenceExit, stateTransition or a contract defined type"
+                }
+            ],
+            "version": 789
         },
         "ioteventcommon": {
             "appdata": [
@@ -270,10 +284,10 @@ var samples = `
                 }
             ],
             "deviceID": "A unique identifier for the device that sent the current event",
-            "devicetimestamp": "A timestamp recoded by the device that sent the current event",
+            "devicetimestamp": "2016-11-20T00:00:00Z",
             "location": {
-                "latitude": 123.456,
-                "longitude": 123.456
+                "latitude": 45.4215,
+                "longitude": -75.6972
             }
         },
         "stateFilter": {
@@ -97,11 +97,15 @@ var schemas = `
             "type": "object"
         },
         "deleteAllAssets": {
-            "description": "Delete all assets from world state, supports filters",
+            "description": "Delete all assets from world state, supports filters, requires a confirm token from readConfirmationToken and is disabled in production mode",
             "properties": {
                 "args": {
                     "items": {
                         "properties": {
+                            "confirm": {
+                                "description": "token returned by readConfirmationToken, valid for one call within two minutes",
+                                "type": "string"
+                            },
                             "filter": {
                                 "description": "Filter asset states",
                                 "properties": {
@@ -256,12 +260,24 @@ var schemas = `
             "type": "object"
         },
         "deleteWorldState": {
-            "description": "**** WARNING *** Clears the entire contents of world state, redeploy the contract after using this, in debugging mode, will require a restart",
+            "description": "**** WARNING *** Clears the entire contents of world state except destructive guard and audit records, requires a confirm token from readConfirmationToken and is disabled in production mode. The plain \"reinit\" argument of earlier releases is confirmed as {\"reinit\": true}",
             "properties": {
                 "args": {
-                    "items": {},
-                    "maxItems": 0,
-                    "minItems": 0,
+                    "items": {
+                        "properties": {
+                            "confirm": {
+                                "description": "token returned by readConfirmationToken, valid for one call within two minutes",
+                                "type": "string"
+                            },
+                            "reinit": {
+                                "description": "reinitialize the contract state with the current version and nickname",
+                                "type": "boolean"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
                     "type": "array"
                 },
                 "function": {
@@ -515,6 +531,44 @@ var schemas = `
                                                                 }
                                                             },
                                                             "type": "object"
+                                                        },
+                                                        "notifications": {
+                                                            "description": "typed notifications queued by rules and routes during the invoke, dropped when the invoke fails",
+                                                            "items": {
+                                                                "description": "A typed notification in the invoke result event",
+                                                                "properties": {
+                                                                    "assetID": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "assetkey": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "class": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "data": {
+                                                                        "description": "data that depends on the type, e.g. {\"alert\": \"OVERTEMP\"} for alertRaised",
+                                                                        "type": "object"
+                                                                    },
+                                                                    "txnts": {
+                                                                        "format": "date-time",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": {
+                                                                        "description": "alertRaised, alertCleared, geofenceExit, stateTransition or a contract defined type",
+                                                                        "type": "string"
+                                                                    }
+                                                                },
+                                                                "required": [
+                                                                    "type"
+                                                                ],
+                                                                "type": "object"
+                                                            },
+                                                            "type": "array"
+                                                        },
+                                                        "version": {
+                                                            "description": "version of the result envelope, decoded by the iotcpevents package",
+                                                            "type": "integer"
                                                         }
                                                     },
                                                     "type": "object"
@@ -527,6 +581,39 @@ var schemas = `
                                 },
                                 "type": "object"
                             },
+                            "eventreadings": {
+                                "additionalProperties": {
+                                    "description": "A reading with its unit, which can be sent in an event in place of a number for any property that has a unit",
+                                    "properties": {
+                                        "unit": {
+                                            "description": "A unit of measure for a reading",
+                                            "enum": [
+                                                "C",
+                                                "F",
+                                                "K",
+                                                "m",
+                                                "km",
+                                                "mi",
+                                                "ft",
+                                                "g",
+                                                "m/s2",
+                                                "m/s²"
+                                            ],
+                                            "type": "string"
+                                        },
+                                        "value": {
+                                            "type": "number"
+                                        }
+                                    },
+                                    "required": [
+                                        "value",
+                                        "unit"
+                                    ],
+                                    "type": "object"
+                                },
+                                "description": "The original value and unit of each reading in the event that was converted to the class's unit, by qualified property name",
+                                "type": "object"
+                            },
                             "state": {
                                 "description": "Properties that have been received or calculated for this asset",
                                 "properties": {
@@ -643,6 +730,10 @@ var schemas = `
                                 },
                                 "type": "object"
                             },
+                            "destructive": {
+                                "description": "true when the route requires a confirm token",
+                                "type": "boolean"
+                            },
                             "functionname": {
                                 "type": "string"
                             },
@@ -891,6 +982,44 @@ var schemas = `
                                                             }
                                                         },
                                                         "type": "object"
+                                                    },
+                                                    "notifications": {
+                                                        "description": "typed notifications queued by rules and routes during the invoke, dropped when the invoke fails",
+                                                        "items": {
+                                                            "description": "A typed notification in the invoke result event",
+                                                            "properties": {
+                                                                "assetID": {
+                                                                    "type": "string"
+                                                                },
+                                                                "assetkey": {
+                                                                    "type": "string"
+                                                                },
+                                                                "class": {
+                                                                    "type": "string"
+                                                                },
+                                                                "data": {
+                                                                    "description": "data that depends on the type, e.g. {\"alert\": \"OVERTEMP\"} for alertRaised",
+                                                                    "type": "object"
+                                                                },
+                                                                "txnts": {
+                                                                    "format": "date-time",
+                                                                    "type": "string"
+                                                                },
+                                                                "type": {
+                                                                    "description": "alertRaised, alertCleared, geofenceExit, stateTransition or a contract defined type",
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "required": [
+                                                                "type"
+                                                            ],
+                                                            "type": "object"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "version": {
+                                                        "description": "version of the result envelope, decoded by the iotcpevents package",
+                                                        "type": "integer"
                                                     }
                                                 },
                                                 "type": "object"
@@ -903,6 +1032,39 @@ var schemas = `
                             },
                             "type": "object"
                         },
+                        "eventreadings": {
+                            "additionalProperties": {
+                                "description": "A reading with its unit, which can be sent in an event in place of a number for any property that has a unit",
+                                "properties": {
+                                    "unit": {
+                                        "description": "A unit of measure for a reading",
+                                        "enum": [
+                                            "C",
+                                            "F",
+                                            "K",
+                                            "m",
+                                            "km",
+                                            "mi",
+                                            "ft",
+                                            "g",
+                                            "m/s2",
+                                            "m/s²"
+                                        ],
+                                        "type": "string"
+                                    },
+                                    "value": {
+                                        "type": "number"
+                                    }
+                                },
+                                "required": [
+                                    "value",
+                                    "unit"
+                                ],
+                                "type": "object"
+                            },
+                            "description": "The original value and unit of each reading in the event that was converted to the class's unit, by qualified property name",
+                            "type": "object"
+                        },
                         "state": {
                             "description": "Properties that have been received or calculated for this asset",
                             "properties": {
@@ -1222,6 +1384,44 @@ var schemas = `
                                                                 }
                                                             },
                                                             "type": "object"
+                                                        },
+                                                        "notifications": {
+                                                            "description": "typed notifications queued by rules and routes during the invoke, dropped when the invoke fails",
+                                                            "items": {
+                                                                "description": "A typed notification in the invoke result event",
+                                                                "properties": {
+                                                                    "assetID": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "assetkey": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "class": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "data": {
+                                                                        "description": "data that depends on the type, e.g. {\"alert\": \"OVERTEMP\"} for alertRaised",
+                                                                        "type": "object"
+                                                                    },
+                                                                    "txnts": {
+                                                                        "format": "date-time",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": {
+                                                                        "description": "alertRaised, alertCleared, geofenceExit, stateTransition or a contract defined type",
+                                                                        "type": "string"
+                                                                    }
+                                                                },
+                                                                "required": [
+                                                                    "type"
+                                                                ],
+                                                                "type": "object"
+                                                            },
+                                                            "type": "array"
+                                                        },
+                                                        "version": {
+                                                            "description": "version of the result envelope, decoded by the iotcpevents package",
+                                                            "type": "integer"
                                                         }
                                                     },
                                                     "type": "object"
@@ -1234,6 +1434,39 @@ var schemas = `
                                 },
                                 "type": "object"
                             },
+                            "eventreadings": {
+                                "additionalProperties": {
+                                    "description": "A reading with its unit, which can be sent in an event in place of a number for any property that has a unit",
+                                    "properties": {
+                                        "unit": {
+                                            "description": "A unit of measure for a reading",
+                                            "enum": [
+                                                "C",
+                                                "F",
+                                                "K",
+                                                "m",
+                                                "km",
+                                                "mi",
+                                                "ft",
+                                                "g",
+                                                "m/s2",
+                                                "m/s²"
+                                            ],
+                                            "type": "string"
+                                        },
+                                        "value": {
+                                            "type": "number"
+                                        }
+                                    },
+                                    "required": [
+                                        "value",
+                                        "unit"
+                                    ],
+                                    "type": "object"
+                                },
+                                "description": "The original value and unit of each reading in the event that was converted to the class's unit, by qualified property name",
+                                "type": "object"
+                            },
                             "state": {
                                 "description": "Properties that have been received or calculated for this asset",
                                 "properties": {
@@ -1321,7 +1554,7 @@ var schemas = `
             "type": "object"
         },
         "readRecentStates": {
-            "description": "Returns the state of recently updated assets",
+            "description": "Returns the state of recently updated assets for one class, or for all classes merged newest first",
             "properties": {
                 "args": {
                     "items": {
@@ -1330,6 +1563,10 @@ var schemas = `
                                 "description": "zero based beginning of range",
                                 "type": "integer"
                             },
+                            "class": {
+                                "description": "asset class name, absence means all classes",
+                                "type": "string"
+                            },
                             "end": {
                                 "description": "zero based end of range, absence means to end",
                                 "type": "integer"
@@ -1337,7 +1574,7 @@ var schemas = `
                         },
                         "type": "object"
                     },
-                    "maxItems": 0,
+                    "maxItems": 1,
                     "minItems": 0,
                     "type": "array"
                 },
@@ -1504,6 +1741,44 @@ var schemas = `
                                                                 }
                                                             },
                                                             "type": "object"
+                                                        },
+                                                        "notifications": {
+                                                            "description": "typed notifications queued by rules and routes during the invoke, dropped when the invoke fails",
+                                                            "items": {
+                                                                "description": "A typed notification in the invoke result event",
+                                                                "properties": {
+                                                                    "assetID": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "assetkey": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "class": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "data": {
+                                                                        "description": "data that depends on the type, e.g. {\"alert\": \"OVERTEMP\"} for alertRaised",
+                                                                        "type": "object"
+                                                                    },
+                                                                    "txnts": {
+                                                                        "format": "date-time",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": {
+                                                                        "description": "alertRaised, alertCleared, geofenceExit, stateTransition or a contract defined type",
+                                                                        "type": "string"
+                                                                    }
+                                                                },
+                                                                "required": [
+                                                                    "type"
+                                                                ],
+                                                                "type": "object"
+                                                            },
+                                                            "type": "array"
+                                                        },
+                                                        "version": {
+                                                            "description": "version of the result envelope, decoded by the iotcpevents package",
+                                                            "type": "integer"
                                                         }
                                                     },
                                                     "type": "object"
@@ -1516,6 +1791,39 @@ var schemas = `
                                 },
                                 "type": "object"
                             },
+                            "eventreadings": {
+                                "additionalProperties": {
+                                    "description": "A reading with its unit, which can be sent in an event in place of a number for any property that has a unit",
+                                    "properties": {
+                                        "unit": {
+                                            "description": "A unit of measure for a reading",
+                                            "enum": [
+                                                "C",
+                                                "F",
+                                                "K",
+                                                "m",
+                                                "km",
+                                                "mi",
+                                                "ft",
+                                                "g",
+                                                "m/s2",
+                                                "m/s²"
+                                            ],
+                                            "type": "string"
+                                        },
+                                        "value": {
+                                            "type": "number"
+                                        }
+                                    },
+                                    "required": [
+                                        "value",
+                                        "unit"
+                                    ],
+                                    "type": "object"
+                                },
+                                "description": "The original value and unit of each reading in the event that was converted to the class's unit, by qualified property name",
+                                "type": "object"
+                            },
                             "state": {
                                 "description": "Properties that have been received or calculated for this asset",
                                 "properties": {
@@ -1739,7 +2047,7 @@ var schemas = `
             "type": "object"
         },
         "setLoggingLevel": {
-            "description": "Sets the logging level for the contract",
+            "description": "Sets the logging level for the contract, or for one module of the platform",
             "properties": {
                 "args": {
                     "items": {
@@ -1754,6 +2062,37 @@ var schemas = `
                                     "DEBUG"
                                 ],
                                 "type": "string"
+                            },
+                            "module": {
+                                "description": "optional module, the platform file that logs without the ct prefix",
+                                "enum": [
+                                    "alerts",
+                                    "asset",
+                                    "classes",
+                                    "classroutes",
+                                    "computed",
+                                    "config",
+                                    "contractstate",
+                                    "crud",
+                                    "expression",
+                                    "filters",
+                                    "geo",
+                                    "guard",
+                                    "history",
+                                    "log",
+                                    "maps",
+                                    "merge",
+                                    "metrics",
+                                    "notify",
+                                    "provenance",
+                                    "recent",
+                                    "router",
+                                    "rulerouter",
+                                    "snapshot",
+                                    "units",
+                                    "verify"
+                                ],
+                                "type": "string"
                             }
                         },
                         "type": "object"
@@ -52,7 +52,7 @@ type AssetClass struct {
 // NewAsset create an instance of an asset class
 func (c AssetClass) NewAsset() Asset {
 	var a = Asset{
-		c, "", nil, nil, "", "", nil, &InvokeResultEvent{"EVT.IOTCP.INVOKE.RESULT", make(map[string]interface{}, 0)}, AlertNameArray(make([]AlertName, 0)), true,
+		c, "", nil, nil, "", "", nil, nil, &InvokeResultEvent{"EVT.IOTCP.INVOKE.RESULT", make(map[string]interface{}, 0)}, AlertNameArray(make([]AlertName, 0)), true,
 	}
 	return a
 }
@@ -67,16 +67,17 @@ func (c AssetClass) String() string {
 // Asset is a type that holds all information about an asset, including its name,
 // its world state prefix, and the qualified property name that is its assetID
 type Asset struct {
-	Class        AssetClass              `json:"assetclass"`         // asset's classifier with metadata
-	AssetKey     string                  `json:"assetkey"`           // asset's world state key
-	State        *map[string]interface{} `json:"assetstate"`         // asset's current state
-	EventIn      *map[string]interface{} `json:"eventpayload"`       // most recent event body
-	FunctionIn   string                  `json:"eventfunction"`      // most recent event function
-	TXNID        string                  `json:"txnid"`              // transaction UUID matching blockchain
-	TXNTS        *time.Time              `json:"txnts,omitempty"`    // transaction timestamp matching blockchain
-	EventOut     *InvokeResultEvent      `json:"eventout,omitempty"` // event emitted upon exit from an invoke
-	AlertsActive AlertNameArray          `json:"alerts,omitempty"`   // array of active alerts
-	Compliant    bool                    `json:"compliant"`          // true if the asset complies with the contract terms
+	Class        AssetClass              `json:"assetclass"`              // asset's classifier with metadata
+	AssetKey     string                  `json:"assetkey"`                // asset's world state key
+	State        *map[string]interface{} `json:"assetstate"`              // asset's current state
+	EventIn      *map[string]interface{} `json:"eventpayload"`            // most recent event body
+	FunctionIn   string                  `json:"eventfunction"`           // most recent event function
+	TXNID        string                  `json:"txnid"`                   // transaction UUID matching blockchain
+	TXNTS        *time.Time              `json:"txnts,omitempty"`         // transaction timestamp matching blockchain
+	ReadingsIn   map[string]Reading      `json:"eventreadings,omitempty"` // original readings converted to the class's units
+	EventOut     *InvokeResultEvent      `json:"eventout,omitempty"`      // event emitted upon exit from an invoke
+	AlertsActive AlertNameArray          `json:"alerts,omitempty"`        // array of active alerts
+	Compliant    bool                    `json:"compliant"`               // true if the asset complies with the contract terms
 }
 
 // AssetArray is an array of assets, used by read all, recent states, history, etc.
@@ -93,12 +94,14 @@ func (aa AssetArray) String() string {
 // PUTAsset stores an asset into world state after performing property injection,
 // rule execution, and JSON marshaling
 func (a *Asset) PUTAsset(stub shim.ChaincodeStubInterface, caller string, inject []QPropNV) ([]byte, error) {
+	log := logFor(stub).With("class", a.Class.Name, "assetkey", a.AssetKey)
 
 	// save original asset function in the asset
 	a.FunctionIn = caller
 
-	// make a copy of the alerts for later comparison
-	alertsIn := a.AlertsActive
+	// make a copy of the alerts for later comparison, as rules sort and clear alerts in place
+	alertsIn := make(AlertNameArray, len(a.AlertsActive))
+	copy(alertsIn, a.AlertsActive)
 
 	if len(inject) > 0 {
 		err := a.injectProps(inject)
@@ -109,12 +112,26 @@ func (a *Asset) PUTAsset(stub shim.ChaincodeStubInterface, caller string, inject
 		}
 	}
 
+	if err := a.ComputeProperties(stub); err != nil {
+		err = fmt.Errorf("PUTAsset for class %s failed to compute properties for %s, err is %s", a.Class.Name, a.AssetKey, err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+
 	if err := a.ExecuteRules(stub); err != nil {
 		err = fmt.Errorf("PUTAsset for class %s failed in rules engine for %s, err is %s", a.Class.Name, a.AssetKey, err)
 		log.Errorf(err.Error())
 		return nil, err
 	}
 
+	if err := a.notifyAlerts(stub, alertsIn); err != nil {
+		err = fmt.Errorf("PUTAsset for class %s failed to notify alerts for %s, err is %s", a.Class.Name, a.AssetKey, err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+
+	a.countAlerts(stub, alertsIn)
+
 	alertsDeltas := GetAlertsAndDeltas(alertsIn, a.AlertsActive)
 	alertsDeltasBytes, err := json.Marshal(alertsDeltas)
 	if err != nil {
@@ -134,6 +151,7 @@ func (a *Asset) PUTAsset(stub shim.ChaincodeStubInterface, caller string, inject
 
 // CreateAsset inializes a new asset and stores it in world state
 func (c *AssetClass) CreateAsset(stub shim.ChaincodeStubInterface, args []string, caller string, inject []QPropNV) ([]byte, error) {
+	log := logFor(stub).With("class", c.Name)
 
 	var a = c.NewAsset()
 
@@ -148,6 +166,11 @@ func (c *AssetClass) CreateAsset(stub shim.ChaincodeStubInterface, args []string
 		log.Errorf(err.Error())
 		return nil, err
 	}
+	if err := a.checkReadOnlyProperties(); err != nil {
+		err = fmt.Errorf("CreateAsset for class %s asset %s rejected, err is %s", c.Name, assetKey, err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
 	_, exists, err := c.getAssetFromWorldState(stub, assetKey)
 	if err != nil {
 		err := fmt.Errorf("CreateAsset for class %s asset %s read from world state returned error %s", c.Name, a.AssetKey, err)
@@ -160,8 +183,13 @@ func (c *AssetClass) CreateAsset(stub shim.ChaincodeStubInterface, args []string
 		return nil, err
 	}
 
-	// copy the event into a new state
-	astate := DeepCopyMap(*a.EventIn)
+	// copy the event into a new state with readings in the class's units
+	astate, err := a.normalizedEvent(nil)
+	if err != nil {
+		err = fmt.Errorf("CreateAsset for class %s asset %s rejected, err is %s", c.Name, assetKey, err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
 	a.State = &astate
 	if err := a.addTXNTimestampToState(stub); err != nil {
 		err = fmt.Errorf("CreateAsset for class %s failed to add txn timestamp for %s, err is %s", c.Name, a.AssetKey, err)
@@ -169,11 +197,19 @@ func (c *AssetClass) CreateAsset(stub shim.ChaincodeStubInterface, args []string
 		return nil, err
 	}
 
+	if err := a.updateProvenance(stub, caller, true, a.eventProperties(), nil); err != nil {
+		err = fmt.Errorf("CreateAsset for class %s failed to update provenance for %s, err is %s", c.Name, a.AssetKey, err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+
+	countMetric(stub, metricAssets, c.Name, 1)
 	return a.PUTAsset(stub, caller, inject)
 }
 
 // ReplaceAsset replaces an asset completely in world state
 func (c *AssetClass) ReplaceAsset(stub shim.ChaincodeStubInterface, args []string, caller string, inject []QPropNV) ([]byte, error) {
+	log := logFor(stub).With("class", c.Name)
 
 	var a = c.NewAsset()
 
@@ -188,7 +224,12 @@ func (c *AssetClass) ReplaceAsset(stub shim.ChaincodeStubInterface, args []strin
 		log.Errorf(err.Error())
 		return nil, err
 	}
-	_, exists, err := c.getAssetFromWorldState(stub, assetKey)
+	if err := a.checkReadOnlyProperties(); err != nil {
+		err = fmt.Errorf("ReplaceAsset for class %s asset %s rejected, err is %s", c.Name, assetKey, err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	assetBytes, exists, err := c.getAssetFromWorldState(stub, assetKey)
 	if err != nil {
 		err := fmt.Errorf("ReplaceAsset for class %s asset %s read from world state returned error %s", c.Name, a.AssetKey, err)
 		log.Errorf(err.Error())
@@ -199,9 +240,23 @@ func (c *AssetClass) ReplaceAsset(stub shim.ChaincodeStubInterface, args []strin
 		log.Errorf(err.Error())
 		return nil, err
 	}
+	// the replacement inherits the stored asset's alerts, so that the rules raise or clear
+	// them against the new state and the alert metrics and notifications see the change
+	var stored = c.NewAsset()
+	if err := json.Unmarshal(assetBytes, &stored); err != nil {
+		err := fmt.Errorf("ReplaceAsset for class %s asset %s Unmarshal failed with err %s", c.Name, assetKey, err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	a.AlertsActive = stored.AlertsActive
 
-	// copy the event into a new state
-	astate := DeepCopyMap(*a.EventIn)
+	// copy the event into a new state with readings in the class's units
+	astate, err := a.normalizedEvent(nil)
+	if err != nil {
+		err = fmt.Errorf("ReplaceAsset for class %s asset %s rejected, err is %s", c.Name, assetKey, err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
 	a.State = &astate
 	if err := a.addTXNTimestampToState(stub); err != nil {
 		err = fmt.Errorf("CreateAsset for class %s failed to add txn timestamp for %s, err is %s", c.Name, a.AssetKey, err)
@@ -209,11 +264,18 @@ func (c *AssetClass) ReplaceAsset(stub shim.ChaincodeStubInterface, args []strin
 		return nil, err
 	}
 
+	if err := a.updateProvenance(stub, caller, true, a.eventProperties(), nil); err != nil {
+		err = fmt.Errorf("ReplaceAsset for class %s failed to update provenance for %s, err is %s", c.Name, a.AssetKey, err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+
 	return a.PUTAsset(stub, caller, inject)
 }
 
 // UpdateAsset updates an asset and stores it in world state
 func (c *AssetClass) UpdateAsset(stub shim.ChaincodeStubInterface, args []string, caller string, inject []QPropNV) ([]byte, error) {
+	log := logFor(stub).With("class", c.Name)
 
 	var arg = c.NewAsset()
 	var a = c.NewAsset()
@@ -229,6 +291,11 @@ func (c *AssetClass) UpdateAsset(stub shim.ChaincodeStubInterface, args []string
 		log.Errorf(err.Error())
 		return nil, err
 	}
+	if err := arg.checkReadOnlyProperties(); err != nil {
+		err = fmt.Errorf("UpdateAsset for class %s asset %s rejected, err is %s", c.Name, assetKey, err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
 	assetBytes, exists, err := c.getAssetFromWorldState(stub, assetKey)
 	if err != nil {
 		err := fmt.Errorf("UpdateAsset for class %s asset %s read from world state returned error %s", c.Name, assetKey, err)
@@ -253,8 +320,15 @@ func (c *AssetClass) UpdateAsset(stub shim.ChaincodeStubInterface, args []string
 	a.EventIn = arg.EventIn
 	a.FunctionIn = arg.FunctionIn
 
-	// merge the event into the state
-	astate := DeepMergeMap(*a.EventIn, *a.State)
+	// merge the event into the state with readings in the class's units and arrays
+	// merged by the class's strategies
+	event, err := a.normalizedEvent(a.State)
+	if err != nil {
+		err = fmt.Errorf("UpdateAsset for class %s asset %s rejected, err is %s", c.Name, assetKey, err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	astate := DeepMergeMapWith(event, *a.State, classMergeStrategies(a.Class))
 	a.State = &astate
 
 	if err := a.addTXNTimestampToState(stub); err != nil {
@@ -263,11 +337,18 @@ func (c *AssetClass) UpdateAsset(stub shim.ChaincodeStubInterface, args []string
 		return nil, err
 	}
 
+	if err := a.updateProvenance(stub, caller, false, a.eventProperties(), nil); err != nil {
+		err = fmt.Errorf("UpdateAsset for class %s failed to update provenance for %s, err is %s", c.Name, a.AssetKey, err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+
 	return a.PUTAsset(stub, caller, inject)
 }
 
 // DeleteAsset deletes an asset from world state
 func (c *AssetClass) DeleteAsset(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	log := logFor(stub).With("class", c.Name)
 	var arg = c.NewAsset()
 
 	if err := arg.unmarshallEventIn(stub, args); err != nil {
@@ -290,8 +371,10 @@ func (c *AssetClass) DeleteAsset(stub shim.ChaincodeStubInterface, args []string
 	return nil, nil
 }
 
-// DeleteAllAssets reletes all asstes of a specific asset class from world state
+// DeleteAllAssets reletes all asstes of a specific asset class from world state, register
+// it with AddDestructiveRoute so that it requires confirmation
 func (c *AssetClass) DeleteAllAssets(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	log := logFor(stub).With("class", c.Name)
 	var filter StateFilter
 
 	filter, err := getUnmarshalledStateFilter(args)
@@ -300,6 +383,9 @@ func (c *AssetClass) DeleteAllAssets(stub shim.ChaincodeStubInterface, args []st
 		log.Error(err)
 		return nil, err
 	}
+	if len(filter.Select) == 0 {
+		log.Noticef("DeleteAllAssets for class %s has no filter and will delete every asset in the class", c.Name)
+	}
 	iter, err := stub.RangeQueryState(c.Prefix, c.Prefix+"}")
 	if err != nil {
 		err = fmt.Errorf("DeleteAllAssets failed to get a range query iterator: %s", err)
@@ -336,6 +422,7 @@ func (c *AssetClass) DeleteAllAssets(stub shim.ChaincodeStubInterface, args []st
 
 // DeletePropertiesFromAsset removes specific properties from an asset in world state
 func (c *AssetClass) DeletePropertiesFromAsset(stub shim.ChaincodeStubInterface, args []string, caller string, inject []QPropNV) ([]byte, error) {
+	log := logFor(stub).With("class", c.Name)
 
 	var arg = c.NewAsset()
 	var a = c.NewAsset()
@@ -387,6 +474,13 @@ func (c *AssetClass) DeletePropertiesFromAsset(stub shim.ChaincodeStubInterface,
 	}
 
 	// remove qualified properties from state
+	for _, p := range qprops {
+		if _, found := findComputedProperty(*c, p); found {
+			err = fmt.Errorf("deletePropertiesFromAsset asset %s cannot delete computed property %s", assetKey, p)
+			log.Errorf(err.Error())
+			return nil, err
+		}
+	}
 	for _, p := range qprops {
 		_ = RemoveObject(a.State, p)
 	}
@@ -408,11 +502,21 @@ func (c *AssetClass) DeletePropertiesFromAsset(stub shim.ChaincodeStubInterface,
 			return nil, err
 		}
 	}
+	if err := a.ComputeProperties(stub); err != nil {
+		err = fmt.Errorf("deletePropertiesFromAsset for class %s failed to compute properties for %s, err is %s", c.Name, a.AssetKey, err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
 	if err := a.ExecuteRules(stub); err != nil {
 		err = fmt.Errorf("CreateAsset for class %s failed in rules engine for %s, err is %s", c.Name, a.AssetKey, err)
 		log.Errorf(err.Error())
 		return nil, err
 	}
+	if err := a.updateProvenance(stub, caller, false, nil, qprops); err != nil {
+		err = fmt.Errorf("deletePropertiesFromAsset for class %s failed to update provenance for %s, err is %s", c.Name, a.AssetKey, err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
 	jsonBytes, err := a.putMarshalledState(stub)
 	if err != nil {
 		err = fmt.Errorf("CreateAsset for class %s failed to marshall for %s, err is %s", c.Name, a.AssetKey, err)
@@ -425,6 +529,7 @@ func (c *AssetClass) DeletePropertiesFromAsset(stub shim.ChaincodeStubInterface,
 
 // ReadAsset returns an asset from world state, intended to be returned directly to a client
 func (c *AssetClass) ReadAsset(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	log := logFor(stub).With("class", c.Name)
 	var arg = c.NewAsset()
 
 	if err := arg.unmarshallEventIn(stub, args); err != nil {
@@ -454,6 +559,7 @@ func (c *AssetClass) ReadAsset(stub shim.ChaincodeStubInterface, args []string)
 
 // ReadAllAssets returns all assets of a specific class from world state as an array
 func (c AssetClass) ReadAllAssets(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	log := logFor(stub).With("class", c.Name)
 	results, err := c.ReadAllAssetsUnmarshalled(stub, args)
 	if err != nil {
 		return nil, err
@@ -469,6 +575,7 @@ func (c AssetClass) ReadAllAssets(stub shim.ChaincodeStubInterface, args []strin
 
 // ReadAllAssetsUnmarshalled returns all assets of a specific class from world state as an object, intended for internal use
 func (c AssetClass) ReadAllAssetsUnmarshalled(stub shim.ChaincodeStubInterface, args []string) (AssetArray, error) {
+	log := logFor(stub).With("class", c.Name)
 	var assets AssetArray
 	var err error
 	var filter StateFilter
@@ -524,60 +631,13 @@ var DefaultClass = AssetClass{
 	AssetIDPath: "asset.assetID",
 }
 
-var createAssetDefault ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return DefaultClass.CreateAsset(stub, args, "createAsset", []QPropNV{})
-}
-
-var replaceAssetDefault ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return DefaultClass.ReplaceAsset(stub, args, "replaceAsset", []QPropNV{})
-}
-
-var updateAssetDefault ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return DefaultClass.UpdateAsset(stub, args, "updateAsset", []QPropNV{})
-}
-
-var deleteAssetDefault ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return DefaultClass.DeleteAsset(stub, args)
-}
-
-var deleteAssetStateHistoryDefault ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return DefaultClass.DeleteAssetStateHistory(stub, args)
-}
-
-var deleteAllAssetsDefault ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return DefaultClass.DeleteAllAssets(stub, args)
-}
-
-var deletePropertiesFromAssetDefault ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return DefaultClass.DeletePropertiesFromAsset(stub, args, "deletePropertiesFromAsset", []QPropNV{})
-}
-
-var readAssetDefault ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return DefaultClass.ReadAsset(stub, args)
-}
-
-var readAllAssetsDefault ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return DefaultClass.ReadAllAssets(stub, args)
-}
-
-var readAssetStateHistoryDefault = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	return DefaultClass.ReadAssetStateHistory(stub, args)
-}
-
 // RegisterDefaultRoutes registers the basic crud API for the simplest possible contract
-func RegisterDefaultRoutes() {
-	AddRoute("createAsset", "invoke", DefaultClass, createAssetDefault)
-	AddRoute("replaceAsset", "invoke", DefaultClass, replaceAssetDefault)
-	AddRoute("updateAsset", "invoke", DefaultClass, updateAssetDefault)
-	AddRoute("deleteAsset", "invoke", DefaultClass, deleteAssetDefault)
-	AddRoute("deleteAssetStateHistory", "invoke", DefaultClass, deleteAssetStateHistoryDefault)
-	AddRoute("deleteAllAssets", "invoke", DefaultClass, deleteAllAssetsDefault)
-	AddRoute("deletePropertiesFromAsset", "invoke", DefaultClass, deletePropertiesFromAssetDefault)
-	AddRoute("readAsset", "query", DefaultClass, readAssetDefault)
-	AddRoute("readAssetStateHistory", "query", DefaultClass, readAssetStateHistoryDefault)
-	AddRoute("readAllAssets", "query", DefaultClass, readAllAssetsDefault)
-
-	AddRule("Over Temperature Alert", DefaultClass, []AlertName{overtempAlert}, overtempRule)
+func RegisterDefaultRoutes() error {
+	err := RegisterClassRoutes(DefaultClass, ClassRouteOptions{NoSuffix: true})
+	if err != nil {
+		return err
+	}
+	return AddRule("Over Temperature Alert", DefaultClass, []AlertName{overtempAlert}, overtempRule)
 }
 
 //********** default temperature rule
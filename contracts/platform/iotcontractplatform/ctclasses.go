@@ -0,0 +1,339 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- asset classes defined at runtime, persisted in world state and routed
+//            after a restart without writing any Go
+
+package iotcontractplatform
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// ASSETCLASSESKEY stores the definitions of all asset classes created with defineAssetClass
+const ASSETCLASSESKEY string = "IOTCP:AssetClasses"
+
+// AssetClassDefinition is an asset class defined at runtime, with an optional JSON
+// schema for the asset that is stored for clients and not enforced by the contract,
+// optional computed properties, which must be expressions, optional provenance
+// tracking and optional array merge strategies by qualified property name
+type AssetClassDefinition struct {
+	Class      AssetClass               `json:"class"`
+	Schema     map[string]interface{}   `json:"schema,omitempty"`
+	Computed   []ComputedProperty       `json:"computed,omitempty"`
+	Provenance *ProvenanceOptions       `json:"provenance,omitempty"`
+	Merge      map[string]MergeStrategy `json:"merge,omitempty"`
+}
+
+// AssetClassDefinitions is stored in world state by class name
+type AssetClassDefinitions map[string]AssetClassDefinition
+
+// AssetClassDefinitionArray is the output of readAssetClasses
+type AssetClassDefinitionArray []AssetClassDefinition
+
+func (aa AssetClassDefinitionArray) Len() int      { return len(aa) }
+func (aa AssetClassDefinitionArray) Swap(i, j int) { aa[i], aa[j] = aa[j], aa[i] }
+func (aa AssetClassDefinitionArray) Less(i, j int) bool {
+	return aa[i].Class.Name < aa[j].Class.Name
+}
+
+// class names are appended to route names, so they must be simple identifiers
+var assetClassNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`)
+
+// loaded once per chaincode process, on the first message that can read world state,
+// and again after deleteWorldState
+var assetClassesLoaded = false
+
+// assetClassesLock serializes loading, defining and unloading runtime asset classes
+var assetClassesLock sync.Mutex
+
+// the runtime asset classes that are routed, by name
+var runtimeClasses = make(map[string]AssetClass, 0)
+
+// GETAssetClassDefinitionsFromLedger returns the runtime asset class definitions
+func GETAssetClassDefinitionsFromLedger(stub shim.ChaincodeStubInterface) (AssetClassDefinitions, error) {
+	log := logFor(stub)
+	var defs = make(AssetClassDefinitions, 0)
+	defsBytes, err := stub.GetState(ASSETCLASSESKEY)
+	if err != nil {
+		err = fmt.Errorf("GETAssetClassDefinitionsFromLedger failed GETSTATE: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	if len(defsBytes) == 0 {
+		return defs, nil
+	}
+	err = json.Unmarshal(defsBytes, &defs)
+	if err != nil {
+		err = fmt.Errorf("GETAssetClassDefinitionsFromLedger unmarshal failed: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	return defs, nil
+}
+
+// PUTAssetClassDefinitionsToLedger marshals and writes the runtime asset class definitions
+func PUTAssetClassDefinitionsToLedger(stub shim.ChaincodeStubInterface, defs AssetClassDefinitions) error {
+	log := logFor(stub)
+	defsBytes, err := json.Marshal(defs)
+	if err != nil {
+		err = fmt.Errorf("PUTAssetClassDefinitionsToLedger marshal failed: %s", err)
+		log.Error(err)
+		return err
+	}
+	err = stub.PutState(ASSETCLASSESKEY, defsBytes)
+	if err != nil {
+		err = fmt.Errorf("PUTAssetClassDefinitionsToLedger failed PUTSTATE: %s", err)
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// loadAssetClassRoutes registers routes for every runtime asset class in world state that
+// is not yet routed. The router calls this before dispatching until a load succeeds, so
+// classes defined before a restart are available again.
+func loadAssetClassRoutes(stub shim.ChaincodeStubInterface) {
+	log := logFor(stub)
+	assetClassesLock.Lock()
+	defer assetClassesLock.Unlock()
+	if assetClassesLoaded {
+		return
+	}
+	defs, err := GETAssetClassDefinitionsFromLedger(stub)
+	if err != nil {
+		log.Warningf("loadAssetClassRoutes will retry on next message: %s", err)
+		return
+	}
+	assetClassesLoaded = true
+	var names = make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, found := getRoute(string(CreateAssetRoute) + name); found {
+			continue
+		}
+		err = registerAssetClass(defs[name])
+		if err != nil {
+			log.Errorf("loadAssetClassRoutes failed to route class %s: %s", name, err)
+			continue
+		}
+		log.Noticef("loadAssetClassRoutes routed asset class %s", defs[name].Class)
+	}
+}
+
+// returns all classes known to the router, whether compiled in or defined at runtime
+func routedClasses() map[string]AssetClass {
+	var classes = make(map[string]AssetClass, 0)
+	routerLock.RLock()
+	defer routerLock.RUnlock()
+	for _, r := range router {
+		classes[r.Class.Name] = r.Class
+	}
+	return classes
+}
+
+// routes a runtime asset class and registers its computed properties, provenance and
+// array merge strategies, or leaves nothing of the class behind when one of them fails,
+// the caller holds assetClassesLock
+func registerAssetClass(def AssetClassDefinition) error {
+	// whatever is registered under the name is then removed when registration fails
+	if _, found := routedClasses()[def.Class.Name]; found {
+		return fmt.Errorf("class %s is already routed", def.Class.Name)
+	}
+	var err error
+	for _, cp := range def.Computed {
+		if err == nil {
+			err = AddComputedProperty(def.Class, cp)
+		}
+	}
+	if err == nil && def.Provenance != nil {
+		err = TrackProvenance(def.Class, *def.Provenance)
+	}
+	for qprop, strategy := range def.Merge {
+		if err == nil {
+			err = AddMergeStrategy(def.Class, qprop, strategy)
+		}
+	}
+	if err == nil {
+		err = RegisterClassRoutes(def.Class, ClassRouteOptions{})
+	}
+	if err != nil {
+		unregisterAssetClass(def.Class)
+		return err
+	}
+	runtimeClasses[def.Class.Name] = def.Class
+	return nil
+}
+
+// removes the routes, computed properties, provenance and merge strategies of a runtime
+// asset class, the caller holds assetClassesLock
+func unregisterAssetClass(class AssetClass) {
+	routerLock.Lock()
+	defer routerLock.Unlock()
+	for name, r := range router {
+		if r.Class.Name == class.Name {
+			delete(router, name)
+		}
+	}
+	delete(computedrouter, class)
+	delete(provenancerouter, class)
+	delete(mergerouter, class)
+	delete(runtimeClasses, class.Name)
+}
+
+// unloadAssetClassRoutes removes every runtime asset class from the router once their
+// definitions are deleted from world state, the next message loads whatever definitions
+// world state still holds
+func unloadAssetClassRoutes() {
+	assetClassesLock.Lock()
+	defer assetClassesLock.Unlock()
+	for _, class := range runtimeClasses {
+		unregisterAssetClass(class)
+		log.Noticef("unloadAssetClassRoutes removed asset class %s", class)
+	}
+	assetClassesLoaded = false
+}
+
+// computed properties of runtime classes are stored in world state, so they cannot
+// be functions
+func validateComputedProperties(def AssetClassDefinition) error {
+	var checked = make([]ComputedProperty, 0, len(def.Computed))
+	for _, cp := range def.Computed {
+		if cp.Expression == "" {
+			return fmt.Errorf("computed property %s must have an expression", cp.QProp)
+		}
+		cp, err := checkComputedProperty(def.Class, cp, checked)
+		if err != nil {
+			return err
+		}
+		checked = append(checked, cp)
+	}
+	return nil
+}
+
+func validateAssetClass(class AssetClass, defs AssetClassDefinitions) error {
+	if !assetClassNamePattern.MatchString(class.Name) {
+		return fmt.Errorf("class name '%s' must start with a letter and contain only letters and digits", class.Name)
+	}
+	if class.Prefix == "" {
+		return errors.New("class prefix must not be blank")
+	}
+	if strings.HasPrefix(class.Prefix, "IOTCP") {
+		return fmt.Errorf("class prefix '%s' is reserved for the platform", class.Prefix)
+	}
+	if class.AssetIDPath == "" {
+		return errors.New("class assetIDpath must not be blank")
+	}
+	var classes = routedClasses()
+	for _, d := range defs {
+		classes[d.Class.Name] = d.Class
+	}
+	for _, c := range classes {
+		if c.Name == class.Name {
+			return fmt.Errorf("class %s is already defined", class.Name)
+		}
+		// ReadAllAssets selects by prefix, so no prefix may contain another
+		if c.Prefix != "" && (strings.HasPrefix(c.Prefix, class.Prefix) || strings.HasPrefix(class.Prefix, c.Prefix)) {
+			return fmt.Errorf("class prefix '%s' overlaps prefix '%s' of class %s", class.Prefix, c.Prefix, c.Name)
+		}
+	}
+	return checkClassRouteOptions(class, ClassRouteOptions{})
+}
+
+// defineAssetClass creates a new asset class, stores it in world state and routes
+// the standard CRUD, history and read all functions with the class name appended
+var defineAssetClass ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	var def AssetClassDefinition
+	var err error
+
+	if len(args) != 1 {
+		err = errors.New("defineAssetClass expects one argument, a JSON object with class and optional schema, computed properties and provenance")
+		log.Error(err)
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(args[0]), &def)
+	if err != nil {
+		err = fmt.Errorf("defineAssetClass failed to unmarshal arg: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	assetClassesLock.Lock()
+	defer assetClassesLock.Unlock()
+	defs, err := GETAssetClassDefinitionsFromLedger(stub)
+	if err != nil {
+		return nil, err
+	}
+	err = validateAssetClass(def.Class, defs)
+	if err == nil {
+		err = validateComputedProperties(def)
+	}
+	if err == nil && def.Provenance != nil {
+		err = checkProvenanceOptions(*def.Provenance)
+	}
+	for qprop, strategy := range def.Merge {
+		if err == nil {
+			err = checkMergeStrategy(qprop, strategy)
+		}
+	}
+	if err != nil {
+		err = fmt.Errorf("defineAssetClass: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	defs[def.Class.Name] = def
+	err = PUTAssetClassDefinitionsToLedger(stub, defs)
+	if err != nil {
+		return nil, err
+	}
+	err = registerAssetClass(def)
+	if err != nil {
+		err = fmt.Errorf("defineAssetClass: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	log.Noticef("defineAssetClass defined asset class %s", def.Class)
+	return nil, nil
+}
+
+// readAssetClasses returns the asset classes defined at runtime, sorted by name
+var readAssetClasses ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	defs, err := GETAssetClassDefinitionsFromLedger(stub)
+	if err != nil {
+		return nil, err
+	}
+	var out = make(AssetClassDefinitionArray, 0, len(defs))
+	for _, d := range defs {
+		out = append(out, d)
+	}
+	sort.Sort(out)
+	return json.Marshal(out)
+}
+
+func init() {
+	AddRoute("defineAssetClass", "invoke", SystemClass, defineAssetClass)
+	AddRoute("readAssetClasses", "query", SystemClass, readAssetClasses)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package iotcontractplatform
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpstub"
+)
+
+func TestValidateAssetClass(t *testing.T) {
+	var defs = AssetClassDefinitions{
+		"Pump": AssetClassDefinition{Class: AssetClass{"Pump", "PMP", "pump.id"}},
+	}
+	var bad = []AssetClass{
+		{"", "VLV", "valve.id"},
+		{"my valve", "VLV", "valve.id"},
+		{"Valve", "", "valve.id"},
+		{"Valve", "IOTCP.V", "valve.id"},
+		{"Valve", "VLV", ""},
+		{"Pump", "VLV", "valve.id"},
+		{"Valve", "PM", "valve.id"},
+		{"Valve", "PMPX", "valve.id"},
+		{"Valve", "SYSV", "valve.id"},
+	}
+	for _, c := range bad {
+		if err := validateAssetClass(c, defs); err == nil {
+			t.Errorf("invalid class %s was accepted", c)
+		}
+	}
+	if err := validateAssetClass(AssetClass{"Valve", "VLV", "valve.id"}, defs); err != nil {
+		t.Fatalf("valid class rejected: %s", err)
+	}
+}
+
+func TestClassRouteNames(t *testing.T) {
+	names := classRouteNames(AssetClass{"Valve", "VLV", "valve.id"}, ClassRouteOptions{})
+	if len(names) != 10 || names[0] != "createAssetValve" || names[9] != "readAllAssetsValve" {
+		t.Fatalf("unexpected route names %v", names)
+	}
+}
+
+func TestRegisterAssetClassFailureLeavesNothingRouted(t *testing.T) {
+	var class = AssetClass{"Gauge", "GGE", "gauge.id"}
+	var def = AssetClassDefinition{
+		Class:    class,
+		Computed: []ComputedProperty{{QProp: "gauge.f", Expression: "gauge.c * 9 / 5 + 32"}},
+		Merge:    map[string]MergeStrategy{"gauge..readings": {Kind: MergeAppend}},
+	}
+	assetClassesLock.Lock()
+	defer assetClassesLock.Unlock()
+	if err := registerAssetClass(def); err == nil {
+		t.Fatal("a class with an invalid merge strategy was registered")
+	}
+	if _, found := routedClasses()[class.Name]; found {
+		t.Fatal("a class that failed to register is routed")
+	}
+	if len(classComputedProperties(class)) != 0 || runtimeClasses[class.Name] != (AssetClass{}) {
+		t.Fatal("a class that failed to register left its computed properties behind")
+	}
+	def.Merge = nil
+	if err := registerAssetClass(def); err != nil {
+		t.Fatalf("the corrected class failed to register: %s", err)
+	}
+	unregisterAssetClass(class)
+	if _, found := getRoute("createAssetGauge"); found {
+		t.Fatal("an unregistered class is still routed")
+	}
+}
+
+func TestRuntimeClassesRouteConcurrently(t *testing.T) {
+	var wg sync.WaitGroup
+	var done = make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				getRoute("createAssetRace0")
+				readConfirmationToken(iotcpstub.NewStub(""), []string{`{"function":"deleteAllAssetsRace0","args":{}}`})
+				routedClasses()
+				classMergeStrategies(AssetClass{"Race0", "RCA", "race.id"})
+			}
+		}()
+	}
+	for i := 0; i < 50; i++ {
+		class := AssetClass{fmt.Sprintf("Race%d", i%2), fmt.Sprintf("RC%c", 'A'+i%2), "race.id"}
+		assetClassesLock.Lock()
+		err := registerAssetClass(AssetClassDefinition{Class: class, Merge: map[string]MergeStrategy{"race.log": {Kind: MergeAppend}}})
+		if err == nil {
+			unregisterAssetClass(class)
+		}
+		assetClassesLock.Unlock()
+		if err != nil {
+			close(done)
+			t.Fatalf("registerAssetClass %s failed: %s", class, err)
+		}
+	}
+	close(done)
+	wg.Wait()
+}
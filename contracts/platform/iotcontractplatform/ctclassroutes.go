@@ -0,0 +1,210 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- one call registers the standard asset class API
+
+package iotcontractplatform
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// ClassRoute is one of the standard asset class routes, the function name is the
+// class route followed by the route suffix, e.g. createAssetSurgicalKit
+type ClassRoute string
+
+// The standard asset class routes
+const (
+	CreateAssetRoute               ClassRoute = "createAsset"
+	ReplaceAssetRoute              ClassRoute = "replaceAsset"
+	UpdateAssetRoute               ClassRoute = "updateAsset"
+	DeleteAssetRoute               ClassRoute = "deleteAsset"
+	DeleteAssetStateHistoryRoute   ClassRoute = "deleteAssetStateHistory"
+	DeleteAllAssetsRoute           ClassRoute = "deleteAllAssets"
+	DeletePropertiesFromAssetRoute ClassRoute = "deletePropertiesFromAsset"
+	ReadAssetRoute                 ClassRoute = "readAsset"
+	ReadAssetStateHistoryRoute     ClassRoute = "readAssetStateHistory"
+	ReadAllAssetsRoute             ClassRoute = "readAllAssets"
+)
+
+// ClassRoutes lists the standard asset class routes in registration order
+var ClassRoutes = []ClassRoute{
+	CreateAssetRoute,
+	ReplaceAssetRoute,
+	UpdateAssetRoute,
+	DeleteAssetRoute,
+	DeleteAssetStateHistoryRoute,
+	DeleteAllAssetsRoute,
+	DeletePropertiesFromAssetRoute,
+	ReadAssetRoute,
+	ReadAssetStateHistoryRoute,
+	ReadAllAssetsRoute,
+}
+
+// ClassRouteOptions tailors the routes registered by RegisterClassRoutes
+type ClassRouteOptions struct {
+	Suffix   string                   // appended to each route name, the class name when blank
+	NoSuffix bool                     // use the bare route names, for contracts with a single class
+	Exclude  []ClassRoute             // routes that are not registered
+	Inject   map[ClassRoute][]QPropNV // properties injected into the state by the create, replace, update and deleteProperties routes
+}
+
+func (o ClassRouteOptions) functionName(class AssetClass, r ClassRoute) string {
+	if o.NoSuffix {
+		return string(r)
+	}
+	if o.Suffix != "" {
+		return string(r) + o.Suffix
+	}
+	return string(r) + class.Name
+}
+
+func (o ClassRouteOptions) excludes(r ClassRoute) bool {
+	for _, x := range o.Exclude {
+		if x == r {
+			return true
+		}
+	}
+	return false
+}
+
+func isClassRoute(r ClassRoute) bool {
+	for _, cr := range ClassRoutes {
+		if cr == r {
+			return true
+		}
+	}
+	return false
+}
+
+// returns the function names that RegisterClassRoutes would register, in order
+func classRouteNames(class AssetClass, options ClassRouteOptions) []string {
+	var names = make([]string, 0, len(ClassRoutes))
+	for _, r := range ClassRoutes {
+		if !options.excludes(r) {
+			names = append(names, options.functionName(class, r))
+		}
+	}
+	return names
+}
+
+func checkClassRouteOptions(class AssetClass, options ClassRouteOptions) error {
+	for _, r := range options.Exclude {
+		if !isClassRoute(r) {
+			return fmt.Errorf("cannot exclude unknown class route %s", r)
+		}
+	}
+	for r := range options.Inject {
+		switch r {
+		case CreateAssetRoute, ReplaceAssetRoute, UpdateAssetRoute, DeletePropertiesFromAssetRoute:
+		default:
+			return fmt.Errorf("class route %s does not accept injected properties", r)
+		}
+		if options.excludes(r) {
+			return fmt.Errorf("class route %s has injected properties but is excluded", r)
+		}
+	}
+	for _, name := range classRouteNames(class, options) {
+		if _, found := getRoute(name); found {
+			return fmt.Errorf("route %s for class %s is already registered", name, class.Name)
+		}
+	}
+	return nil
+}
+
+// returns the chaincode function implementing a class route
+func classRouteFunction(c AssetClass, r ClassRoute, name string, inject []QPropNV) ChaincodeFunc {
+	if inject == nil {
+		inject = []QPropNV{}
+	}
+	switch r {
+	case CreateAssetRoute:
+		return func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+			return c.CreateAsset(stub, args, name, inject)
+		}
+	case ReplaceAssetRoute:
+		return func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+			return c.ReplaceAsset(stub, args, name, inject)
+		}
+	case UpdateAssetRoute:
+		return func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+			return c.UpdateAsset(stub, args, name, inject)
+		}
+	case DeleteAssetRoute:
+		return func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+			return c.DeleteAsset(stub, args)
+		}
+	case DeleteAssetStateHistoryRoute:
+		return func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+			return c.DeleteAssetStateHistory(stub, args)
+		}
+	case DeleteAllAssetsRoute:
+		return func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+			return c.DeleteAllAssets(stub, args)
+		}
+	case DeletePropertiesFromAssetRoute:
+		return func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+			return c.DeletePropertiesFromAsset(stub, args, name, inject)
+		}
+	case ReadAssetRoute:
+		return func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+			return c.ReadAsset(stub, args)
+		}
+	case ReadAssetStateHistoryRoute:
+		return func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+			return c.ReadAssetStateHistory(stub, args)
+		}
+	case ReadAllAssetsRoute:
+		return func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+			return c.ReadAllAssets(stub, args)
+		}
+	}
+	return nil
+}
+
+// RegisterClassRoutes registers the standard CRUD, history and read all routes for an
+// asset class. deleteAllAssets is registered as a destructive route. Options and route
+// names are checked before anything is registered, so on error no route for the class
+// has been added.
+func RegisterClassRoutes(class AssetClass, options ClassRouteOptions) error {
+	err := checkClassRouteOptions(class, options)
+	if err != nil {
+		err = fmt.Errorf("RegisterClassRoutes for class %s: %s", class.Name, err)
+		log.Error(err)
+		return err
+	}
+	for _, r := range ClassRoutes {
+		if options.excludes(r) {
+			continue
+		}
+		name := options.functionName(class, r)
+		f := classRouteFunction(class, r, name, options.Inject[r])
+		switch r {
+		case DeleteAllAssetsRoute:
+			err = AddDestructiveRoute(name, class, f)
+		case ReadAssetRoute, ReadAssetStateHistoryRoute, ReadAllAssetsRoute:
+			err = AddRoute(name, "query", class, f)
+		default:
+			err = AddRoute(name, "invoke", class, f)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
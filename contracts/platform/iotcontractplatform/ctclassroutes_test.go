@@ -0,0 +1,62 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package iotcontractplatform
+
+import "testing"
+
+var testRouteClass = AssetClass{"gauge", "GAU", "gauge.id"}
+
+func TestClassRouteNamesOptions(t *testing.T) {
+	names := classRouteNames(testRouteClass, ClassRouteOptions{Suffix: "Gauge", Exclude: []ClassRoute{DeleteAllAssetsRoute}})
+	if len(names) != 9 || names[0] != "createAssetGauge" {
+		t.Fatalf("unexpected route names %v", names)
+	}
+	for _, n := range names {
+		if n == "deleteAllAssetsGauge" {
+			t.Fatal("excluded route is named")
+		}
+	}
+	names = classRouteNames(testRouteClass, ClassRouteOptions{NoSuffix: true})
+	if names[9] != "readAllAssets" {
+		t.Fatalf("unexpected unsuffixed route name %s", names[9])
+	}
+}
+
+func TestRegisterClassRoutesRejectsBadOptions(t *testing.T) {
+	var bad = []ClassRouteOptions{
+		{Exclude: []ClassRoute{"readEverything"}},
+		{Inject: map[ClassRoute][]QPropNV{ReadAssetRoute: {}}},
+		{Exclude: []ClassRoute{UpdateAssetRoute}, Inject: map[ClassRoute][]QPropNV{UpdateAssetRoute: {}}},
+		{Suffix: "", NoSuffix: false, Exclude: nil, Inject: nil},
+	}
+	// the last one collides with the routes registered below
+	err := RegisterClassRoutes(testRouteClass, ClassRouteOptions{Exclude: []ClassRoute{DeleteAllAssetsRoute}})
+	if err != nil {
+		t.Fatalf("RegisterClassRoutes failed: %s", err)
+	}
+	for _, o := range bad {
+		if err := RegisterClassRoutes(testRouteClass, o); err == nil {
+			t.Errorf("options %+v were accepted", o)
+		}
+	}
+	if _, found := router["deleteAllAssetsgauge"]; found {
+		t.Fatal("failed registration left a route behind")
+	}
+	if router["readAssetgauge"].Method != "query" || router["updateAssetgauge"].Method != "invoke" {
+		t.Fatal("routes registered with the wrong method")
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- computed properties, recalculated before rules and read only for clients
+
+package iotcontractplatform
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// ComputeFunc calculates the value of a computed property from the asset's state,
+// returning false when the property has no value in this state
+type ComputeFunc func(stub shim.ChaincodeStubInterface, a *Asset) (interface{}, bool, error)
+
+// ComputedProperty is a property of an asset's state that the platform calculates
+// from other properties before the rules run. Exactly one of Expression and Function
+// must be set. The dependencies of an expression are the properties it names, and the
+// dependencies of a function must be declared. The property is removed from the state
+// when any dependency is missing.
+type ComputedProperty struct {
+	QProp      string      `json:"qprop"`
+	DependsOn  []string    `json:"dependsOn,omitempty"`
+	Expression string      `json:"expression,omitempty"`
+	Function   ComputeFunc `json:"-"`
+	expr       exprNode
+}
+
+var computedrouter = make(map[AssetClass][]ComputedProperty, 0)
+
+func classComputedProperties(c AssetClass) []ComputedProperty {
+	routerLock.RLock()
+	defer routerLock.RUnlock()
+	cps := computedrouter[c]
+	if cps == nil {
+		return []ComputedProperty{}
+	}
+	return cps
+}
+
+func findComputedProperty(c AssetClass, qprop string) (ComputedProperty, bool) {
+	routerLock.RLock()
+	defer routerLock.RUnlock()
+	for _, cp := range computedrouter[c] {
+		if cp.QProp == qprop {
+			return cp, true
+		}
+	}
+	return ComputedProperty{}, false
+}
+
+// validates a computed property against those already registered for the class and
+// returns it with its expression parsed and its dependencies filled in
+func checkComputedProperty(class AssetClass, cp ComputedProperty, registered []ComputedProperty) (ComputedProperty, error) {
+	if cp.QProp == "" {
+		return cp, errors.New("computed property must have a qprop")
+	}
+	if cp.QProp == class.AssetIDPath {
+		return cp, fmt.Errorf("computed property %s cannot be the asset id", cp.QProp)
+	}
+	if (cp.Expression == "") == (cp.Function == nil) {
+		return cp, fmt.Errorf("computed property %s must have either an expression or a function", cp.QProp)
+	}
+	if cp.Expression != "" {
+		expr, err := parseExpression(cp.Expression)
+		if err != nil {
+			return cp, fmt.Errorf("computed property %s: %s", cp.QProp, err)
+		}
+		cp.expr = expr
+		cp.DependsOn = exprProperties(expr, make([]string, 0))
+	}
+	for _, r := range registered {
+		if r.QProp == cp.QProp {
+			return cp, fmt.Errorf("computed property %s is already registered", cp.QProp)
+		}
+		// properties are computed in the order they are registered
+		if Contains(r.DependsOn, cp.QProp) {
+			return cp, fmt.Errorf("computed property %s must be registered before %s, which depends on it", cp.QProp, r.QProp)
+		}
+	}
+	if Contains(cp.DependsOn, cp.QProp) {
+		return cp, fmt.Errorf("computed property %s depends on itself", cp.QProp)
+	}
+	return cp, nil
+}
+
+// AddComputedProperty registers a computed property for a class. Properties are
+// computed in the order they are registered, so a property that depends on another
+// computed property must be registered after it.
+func AddComputedProperty(class AssetClass, cp ComputedProperty) error {
+	routerLock.Lock()
+	defer routerLock.Unlock()
+	cp, err := checkComputedProperty(class, cp, computedrouter[class])
+	if err != nil {
+		err = fmt.Errorf("AddComputedProperty for class %s failed: %s", class.Name, err)
+		log.Error(err)
+		return err
+	}
+	computedrouter[class] = append(computedrouter[class], cp)
+	log.Debugf("Class %s added computed property %s depending on %v", class.Name, cp.QProp, cp.DependsOn)
+	return nil
+}
+
+// ComputeProperties recalculates all computed properties for the asset's class, and is
+// called before the rules so that rules can read the computed values
+func (a *Asset) ComputeProperties(stub shim.ChaincodeStubInterface) error {
+	log := logFor(stub)
+	for _, cp := range classComputedProperties(a.Class) {
+		var value interface{}
+		var found = true
+		for _, d := range cp.DependsOn {
+			if _, found = GetObject(a.State, d); !found {
+				break
+			}
+		}
+		if found {
+			if cp.Function != nil {
+				var err error
+				value, found, err = cp.Function(stub, a)
+				if err != nil {
+					err = fmt.Errorf("ComputeProperties for class %s failed to compute %s for %s, err is %s", a.Class.Name, cp.QProp, a.AssetKey, err)
+					log.Error(err)
+					return err
+				}
+			} else {
+				value, found = cp.expr.eval(a.State)
+			}
+		}
+		if !found {
+			_ = RemoveObject(a.State, cp.QProp)
+			continue
+		}
+		if !PutObject(a.State, cp.QProp, value) {
+			err := fmt.Errorf("ComputeProperties for class %s could not write %s for %s", a.Class.Name, cp.QProp, a.AssetKey)
+			log.Error(err)
+			return err
+		}
+	}
+	return nil
+}
+
+// checkReadOnlyProperties rejects an incoming event that writes a computed property
+func (a *Asset) checkReadOnlyProperties() error {
+	for _, cp := range classComputedProperties(a.Class) {
+		if _, found := GetObject(a.EventIn, cp.QProp); found {
+			return fmt.Errorf("property %s is computed and cannot be written", cp.QProp)
+		}
+	}
+	return nil
+}
+
+// ComputedPropertyOut is the output of readComputedProperties
+type ComputedPropertyOut struct {
+	Class      string   `json:"class"`
+	QProp      string   `json:"qprop"`
+	DependsOn  []string `json:"dependsOn,omitempty"`
+	Expression string   `json:"expression,omitempty"`
+	Function   bool     `json:"function"`
+}
+
+// readComputedProperties shows all registered computed properties by class, in the
+// order that they are computed
+var readComputedProperties ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	routerLock.RLock()
+	defer routerLock.RUnlock()
+	var classes = make([]string, 0, len(computedrouter))
+	var byName = make(map[string]AssetClass, len(computedrouter))
+	for c := range computedrouter {
+		classes = append(classes, c.Name)
+		byName[c.Name] = c
+	}
+	sort.Strings(classes)
+	var out = make([]ComputedPropertyOut, 0)
+	for _, name := range classes {
+		for _, cp := range computedrouter[byName[name]] {
+			out = append(out, ComputedPropertyOut{name, cp.QProp, cp.DependsOn, cp.Expression, cp.Function != nil})
+		}
+	}
+	return json.Marshal(out)
+}
+
+func init() {
+	AddRoute("readComputedProperties", "query", SystemClass, readComputedProperties)
+}
@@ -0,0 +1,133 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package iotcontractplatform
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+func TestExpressions(t *testing.T) {
+	var state = map[string]interface{}{
+		"tank": map[string]interface{}{"celsius": 20.0, "level": 3.0, "name": "T1"},
+	}
+	var good = map[string]float64{
+		"1 + 2 * 3":                       7,
+		"(1 + 2) * 3":                     9,
+		"-tank.level + 10":                7,
+		"tank.celsius * 9 / 5 + 32":       68,
+		"max(tank.level, 5, -1)":          5,
+		"min(tank.level, 5)":              3,
+		"round(2.5) + floor(1.9) + .5":    4.5,
+		"abs(-tank.level) - ceil(0.1)":    2,
+		"tank.celsius / (tank.level - 1)": 10,
+	}
+	for src, want := range good {
+		expr, err := parseExpression(src)
+		if err != nil {
+			t.Errorf("%s failed to parse: %s", src, err)
+			continue
+		}
+		got, ok := expr.eval(&state)
+		if !ok || got != want {
+			t.Errorf("%s is %v (%t), expected %v", src, got, ok, want)
+		}
+	}
+	for _, src := range []string{"tank.missing + 1", "tank.name * 2", "tank.level / (tank.level - 3)"} {
+		expr, err := parseExpression(src)
+		if err != nil {
+			t.Fatalf("%s failed to parse: %s", src, err)
+		}
+		if v, ok := expr.eval(&state); ok {
+			t.Errorf("%s has value %v, expected none", src, v)
+		}
+	}
+	for _, src := range []string{"", "1 +", "(1", "sqrt(2)", "abs(1, 2)", "tank.", "1 $ 2", "2 3"} {
+		if _, err := parseExpression(src); err == nil {
+			t.Errorf("%s parsed", src)
+		}
+	}
+}
+
+func TestCheckComputedProperty(t *testing.T) {
+	var class = AssetClass{"Tank", "TNK", "tank.id"}
+	var f ComputeFunc = func(stub shim.ChaincodeStubInterface, a *Asset) (interface{}, bool, error) { return 1, true, nil }
+	cp, err := checkComputedProperty(class, ComputedProperty{QProp: "tank.f", Expression: "tank.c * 9 / 5 + 32 + tank.c"}, nil)
+	if err != nil {
+		t.Fatalf("valid computed property rejected: %s", err)
+	}
+	if !reflect.DeepEqual(cp.DependsOn, []string{"tank.c"}) {
+		t.Fatalf("dependencies are %v", cp.DependsOn)
+	}
+	var registered = []ComputedProperty{cp}
+	var bad = []ComputedProperty{
+		{QProp: "", Expression: "1"},
+		{QProp: "tank.id", Expression: "1"},
+		{QProp: "tank.x"},
+		{QProp: "tank.x", Expression: "1", Function: f},
+		{QProp: "tank.x", Expression: "1 +"},
+		{QProp: "tank.f", Expression: "1"},
+		{QProp: "tank.c", Expression: "1"},
+		{QProp: "tank.x", Expression: "tank.x + 1"},
+	}
+	for _, cp := range bad {
+		if _, err := checkComputedProperty(class, cp, registered); err == nil {
+			t.Errorf("invalid computed property %+v was accepted", cp)
+		}
+	}
+}
+
+func TestComputePropertiesAndReadOnly(t *testing.T) {
+	var class = AssetClass{"ComputedTank", "CTNK", "tank.id"}
+	if err := AddComputedProperty(class, ComputedProperty{QProp: "tank.fahrenheit", Expression: "tank.celsius * 9 / 5 + 32"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddComputedProperty(class, ComputedProperty{QProp: "tank.hot", Expression: "max(tank.fahrenheit - 100, 0)"}); err != nil {
+		t.Fatal(err)
+	}
+	a := class.NewAsset()
+	a.State = &map[string]interface{}{"tank": map[string]interface{}{"id": "T1", "celsius": 50.0}}
+	if err := a.ComputeProperties(nil); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := GetObjectAsNumber(a.State, "tank.hot"); v != 22 {
+		t.Fatalf("tank.hot is %v, expected 22", v)
+	}
+
+	// properties are removed when a dependency goes missing
+	RemoveObject(a.State, "tank.celsius")
+	if err := a.ComputeProperties(nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, found := GetObject(a.State, "tank.fahrenheit"); found {
+		t.Fatal("tank.fahrenheit was not removed")
+	}
+	if _, found := GetObject(a.State, "tank.hot"); found {
+		t.Fatal("tank.hot was not removed")
+	}
+
+	a.EventIn = &map[string]interface{}{"tank": map[string]interface{}{"id": "T1", "celsius": 10.0}}
+	if err := a.checkReadOnlyProperties(); err != nil {
+		t.Fatalf("event without computed properties rejected: %s", err)
+	}
+	a.EventIn = &map[string]interface{}{"tank": map[string]interface{}{"id": "T1", "fahrenheit": 10.0}}
+	if err := a.checkReadOnlyProperties(); err == nil {
+		t.Fatal("event writing a computed property was accepted")
+	}
+}
@@ -22,22 +22,18 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"time"
+	"strings"
 
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 )
 
-var log = shim.NewLogger("iotcontractplatform")
-
-// SetContractLogger allows the whole package to be loaded at startup and to share a
-// single chaincode logger
-func SetContractLogger(logger *shim.ChaincodeLogger) {
-	log = logger
-}
-
 // CREATEONFIRSTUPDATEKEY is used to store can create on update status, which if true by default
 const CREATEONFIRSTUPDATEKEY string = "IOTCP:CreateOnFirstUpdate"
 
+// CONTRACTSETTINGKEY is the key prefix of the settings that contracts store with
+// PUTContractSetting, the setting's name is appended
+const CONTRACTSETTINGKEY string = "IOTCP:Setting."
+
 // readWorldState read everything in the database for debugging purposes ...
 var readWorldState ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
 	var err error
@@ -80,8 +76,49 @@ var readWorldState ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args [
 	return resultsBytes, nil
 }
 
+// deleteWorldStateLegacyArgs accepts the plain "reinit" argument of earlier releases,
+// optionally followed by the JSON object that carries the confirm token
+func deleteWorldStateLegacyArgs(args []string) []string {
+	if len(args) == 0 || strings.TrimSpace(args[0]) != "reinit" {
+		return args
+	}
+	var arg = make(map[string]interface{}, 0)
+	var rest = args[1:]
+	if len(args) > 1 {
+		rest = args[2:]
+	}
+	if len(args) > 1 && strings.TrimSpace(args[1]) != "" {
+		if err := json.Unmarshal([]byte(args[1]), &arg); err != nil {
+			// the guard reports the arguments as they were given
+			return args
+		}
+	}
+	arg["reinit"] = true
+	argBytes, err := json.Marshal(arg)
+	if err != nil {
+		return args
+	}
+	return append([]string{string(argBytes)}, rest...)
+}
+
 // deleteWorldState clear everything out from the database for DEBUGGING purposes ...
+// This is a destructive route, so the arguments are a JSON object that the guard has
+// already verified, e.g. {"reinit": true}, or the plain "reinit" argument of earlier
+// releases. The guard state and audit records survive.
 var deleteWorldState ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	type DeleteWorldStateArg struct {
+		Reinit bool `json:"reinit"`
+	}
+	var arg DeleteWorldStateArg
+	if len(args) > 0 {
+		err := json.Unmarshal([]byte(args[0]), &arg)
+		if err != nil {
+			err = fmt.Errorf("deleteWorldState failed to unmarshal arg: %s", err)
+			log.Errorf(err.Error())
+			return nil, err
+		}
+	}
+
 	// obtain the current contract config and reinitialize the contract later as if just
 	// deployed (saves developer time)
 	cstate, _ := GETContractStateFromLedger(stub)
@@ -100,6 +137,9 @@ var deleteWorldState ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args
 			log.Errorf(err.Error())
 			return nil, err
 		}
+		if isGuardKey(assetID) {
+			continue
+		}
 		// Delete the key / asset from the ledger
 		err = stub.DelState(assetID)
 		if err != nil {
@@ -108,17 +148,26 @@ var deleteWorldState ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args
 		}
 	}
 	log.Debugf("\n\n********** WORLD STATE CLEARED *************\n\n")
-	if len(args) > 0 && args[0] == "reinit" {
-		time.Sleep(300)
-		InitializeContractState(stub, cstate.Version, cstate.Version, cstate.Nickname)
+	// the runtime asset class definitions are gone, so their routes must go too
+	unloadAssetClassRoutes()
+	if arg.Reinit {
+		err = InitializeContractState(stub, cstate.Version, cstate.Nickname, cstate.Version)
+		if err != nil {
+			err = fmt.Errorf("deleteWorldState failed to reinitialize contract state: %s", err)
+			log.Errorf(err.Error())
+			return nil, err
+		}
 		log.Debugf("\n\n********** WORLD STATE REINITIALIZED *************\n\n")
 	}
 	return nil, nil
 }
 
+// setLoggingLevel sets the level of the platform, or of one of its modules when the
+// argument names one, e.g. {"logLevel": "DEBUG", "module": "rulerouter"}
 var setLoggingLevel ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
 	type LogLevelArg struct {
-		Level string `json:"logLevel"`
+		Level  string `json:"logLevel"`
+		Module string `json:"module,omitempty"`
 	}
 	var level LogLevelArg
 	var err error
@@ -134,28 +183,43 @@ var setLoggingLevel ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args
 		return nil, err
 	}
 
+	var ll shim.LoggingLevel
 	switch level.Level {
 	case "DEBUG":
-		log.SetLevel(shim.LogDebug)
+		ll = shim.LogDebug
 	case "INFO":
-		log.SetLevel(shim.LogInfo)
+		ll = shim.LogInfo
 	case "NOTICE":
-		log.SetLevel(shim.LogNotice)
+		ll = shim.LogNotice
 	case "WARNING":
-		log.SetLevel(shim.LogWarning)
+		ll = shim.LogWarning
 	case "ERROR":
-		log.SetLevel(shim.LogError)
+		ll = shim.LogError
 	case "CRITICAL":
-		log.SetLevel(shim.LogCritical)
+		ll = shim.LogCritical
 	default:
 		err = fmt.Errorf("setLoggingLevel failed with unknown arg: %s", level.Level)
 		log.Errorf(err.Error())
 		return nil, err
 	}
-
+	if level.Module == "" {
+		log.SetLevel(ll)
+		return nil, nil
+	}
+	if err = SetModuleLoggingLevel(level.Module, ll); err != nil {
+		err = fmt.Errorf("setLoggingLevel failed: %s", err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
 	return nil, nil
 }
 
+// readLoggingLevels returns the level of the platform and of the modules that have
+// their own level
+var readLoggingLevels ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	return json.Marshal(loggingLevels())
+}
+
 // CreateOnFirstUpdate is a shared parameter structure for the use of
 // the createonupdate feature
 type CreateOnFirstUpdate struct {
@@ -190,6 +254,7 @@ var setCreateOnFirstUpdate ChaincodeFunc = func(stub shim.ChaincodeStubInterface
 
 // PUTcreateOnFirstUpdate marshals the new setting and writes it to the ledger
 func PUTcreateOnFirstUpdate(stub shim.ChaincodeStubInterface, createOnFirstUpdate CreateOnFirstUpdate) (err error) {
+	log := logFor(stub)
 	createOnFirstUpdateBytes, err := json.Marshal(createOnFirstUpdate)
 	if err != nil {
 		err = errors.New("PUTcreateOnFirstUpdate failed to marshal")
@@ -207,6 +272,7 @@ func PUTcreateOnFirstUpdate(stub shim.ChaincodeStubInterface, createOnFirstUpdat
 
 // CanCreateOnFirstUpdate retrieves the setting from the ledger and returns it to the calling function
 func CanCreateOnFirstUpdate(stub shim.ChaincodeStubInterface) bool {
+	log := logFor(stub)
 	var createOnFirstUpdate CreateOnFirstUpdate
 	createOnFirstUpdateBytes, err := stub.GetState(CREATEONFIRSTUPDATEKEY)
 	if err != nil {
@@ -223,9 +289,53 @@ func CanCreateOnFirstUpdate(stub shim.ChaincodeStubInterface) bool {
 	return createOnFirstUpdate.SetCreateOnFirstUpdate
 }
 
+// PUTContractSetting marshals a setting that belongs to the contract rather than to the
+// platform and writes it to the ledger under its name. Settings share the platform's key
+// prefix so that they are not mistaken for assets.
+func PUTContractSetting(stub shim.ChaincodeStubInterface, name string, setting interface{}) error {
+	log := logFor(stub)
+	settingBytes, err := json.Marshal(setting)
+	if err != nil {
+		err = fmt.Errorf("PUTContractSetting failed to marshal %s: %s", name, err)
+		log.Errorf(err.Error())
+		return err
+	}
+	err = stub.PutState(CONTRACTSETTINGKEY+name, settingBytes)
+	if err != nil {
+		err = fmt.Errorf("PUTSTATE contract setting %s failed: %s", name, err)
+		log.Errorf(err.Error())
+		return err
+	}
+	return nil
+}
+
+// GETContractSetting unmarshals a setting stored by PUTContractSetting into setting and
+// returns whether it has been set, setting is left alone when it has not
+func GETContractSetting(stub shim.ChaincodeStubInterface, name string, setting interface{}) (bool, error) {
+	log := logFor(stub)
+	settingBytes, err := stub.GetState(CONTRACTSETTINGKEY + name)
+	if err != nil {
+		err = fmt.Errorf("GETSTATE contract setting %s failed: %s", name, err)
+		log.Errorf(err.Error())
+		return false, err
+	}
+	if len(settingBytes) == 0 {
+		return false, nil
+	}
+	err = json.Unmarshal(settingBytes, setting)
+	if err != nil {
+		err = fmt.Errorf("GETContractSetting failed to unmarshal %s: %s", name, err)
+		log.Errorf(err.Error())
+		return true, err
+	}
+	return true, nil
+}
+
 func init() {
-	AddRoute("deleteWorldState", "invoke", SystemClass, deleteWorldState)
+	legacyDestructiveArgs["deleteWorldState"] = deleteWorldStateLegacyArgs
+	AddDestructiveRoute("deleteWorldState", SystemClass, deleteWorldState)
 	AddRoute("readWorldState", "query", SystemClass, readWorldState)
 	AddRoute("setLoggingLevel", "invoke", SystemClass, setLoggingLevel)
+	AddRoute("readLoggingLevels", "query", SystemClass, readLoggingLevels)
 	AddRoute("setCreateOnFirstUpdate", "invoke", SystemClass, setCreateOnFirstUpdate)
 }
@@ -38,6 +38,7 @@ type ContractState struct {
 
 // GETContractStateFromLedger retrieves state from ledger and returns to caller
 func GETContractStateFromLedger(stub shim.ChaincodeStubInterface) (ContractState, error) {
+	log := logFor(stub)
 	var err error
 	var state ContractState
 	contractStateBytes, err := stub.GetState(CONTRACTSTATEKEY)
@@ -63,6 +64,7 @@ func GETContractStateFromLedger(stub shim.ChaincodeStubInterface) (ContractState
 
 // PUTContractStateToLedger writes a contract state into the ledger
 func PUTContractStateToLedger(stub shim.ChaincodeStubInterface, state ContractState) error {
+	log := logFor(stub)
 	var contractStateJSON []byte
 	var err error
 	contractStateJSON, err = json.Marshal(state)
@@ -82,6 +84,7 @@ func PUTContractStateToLedger(stub shim.ChaincodeStubInterface, state ContractSt
 
 // InitializeContractState sets version and nickname back to defaults
 func InitializeContractState(stub shim.ChaincodeStubInterface, contractversion string, nicknamearg string, versionarg string) error {
+	log := logFor(stub)
 	var state ContractState
 	var err error
 	if versionarg != contractversion {
@@ -153,6 +156,12 @@ var initContract = func(stub shim.ChaincodeStubInterface, args []string) ([]byte
 		return nil, err
 	}
 
+	// earlier releases kept one recent states list for the whole contract
+	err = migrateLegacyRecentStates(stub)
+	if err != nil {
+		return nil, err
+	}
+
 	log.Infof("initContract - contract initialized")
 	return nil, nil
 }
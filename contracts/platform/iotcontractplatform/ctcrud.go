@@ -63,6 +63,7 @@ func (a *Asset) getAssetKey() (string, error) {
 
 // Class convenience method to retrieve the asset by key, checks for consistency
 func (c AssetClass) getAssetFromWorldState(stub shim.ChaincodeStubInterface, assetKey string) (assetBytes []byte, exists bool, err error) {
+	log := logFor(stub)
 	if !strings.HasPrefix(assetKey, c.Prefix) {
 		// inconsistency
 		err := fmt.Errorf("getAssetFromWorldState: asset key is %s is onconsistent with class prefix %s", assetKey, c)
@@ -91,6 +92,7 @@ func (c AssetClass) getAssetFromWorldState(stub shim.ChaincodeStubInterface, ass
 
 // GetAssetFromLedger accepts an assetKey and returns an Asset structure
 func GetAssetFromLedger(stub shim.ChaincodeStubInterface, assetKey string) (assetOut Asset, exists bool, err error) {
+	log := logFor(stub)
 	assetBytes, err := stub.GetState(assetKey)
 	if err != nil {
 		err := fmt.Errorf("GetAssetFromLedger: GetState of %s returned error %s", assetKey, err)
@@ -121,6 +123,7 @@ func GetAssetFromLedger(stub shim.ChaincodeStubInterface, assetKey string) (asse
 // a partial state containing one or more direct readings for specific state
 // properties (e.g. gForce, temperature, location, etc.)
 func (a *Asset) unmarshallEventIn(stub shim.ChaincodeStubInterface, args []string) error {
+	log := logFor(stub)
 	var event interface{}
 	var err error
 
@@ -185,6 +188,7 @@ func (a *Asset) unmarshallEventIn(stub shim.ChaincodeStubInterface, args []strin
 
 // Pushes state to the ledger using assetID, which is expected to be prefixed.
 func (a *Asset) putMarshalledState(stub shim.ChaincodeStubInterface) ([]byte, error) {
+	log := logFor(stub)
 	// Write the new state to the ledger
 	stateJSON, err := json.Marshal(a)
 	if err != nil {
@@ -218,18 +222,35 @@ func (a *Asset) putMarshalledState(stub shim.ChaincodeStubInterface) ([]byte, er
 
 // RemoveOneAssetFromWorldState remove the asset from world state
 func (a *Asset) removeOneAssetFromWorldState(stub shim.ChaincodeStubInterface) error {
-	err := stub.DelState(a.AssetKey)
+	log := logFor(stub)
+	stored, exists, err := GetAssetFromLedger(stub, a.AssetKey)
+	if err != nil {
+		err = fmt.Errorf("removeOneAssetFromWorldState: asset %s could not be read: %s", a.AssetKey, err)
+		log.Error(err)
+		return err
+	}
+	err = stub.DelState(a.AssetKey)
 	if err != nil {
 		err = fmt.Errorf("removeOneAssetFromWorldState: asset %s failed", a.AssetKey)
 		log.Error(err)
 		return err
 	}
+	if exists {
+		countMetric(stub, metricAssets, a.Class.Name, -1)
+		for _, alert := range stored.AlertsActive {
+			countMetric(stub, metricAlerts, string(alert), -1)
+		}
+	}
 	err = a.RemoveAssetFromRecentStates(stub)
 	if err != nil {
 		err = fmt.Errorf("removeOneAssetFromWorldState: asset %s could not be removed from recent states: %s", a.AssetKey, err)
 		log.Error(err)
 		return err
 	}
+	err = deleteAssetProvenance(stub, a.AssetKey)
+	if err != nil {
+		return err
+	}
 	// delete history must be executed separately
 	return nil
 }
@@ -238,17 +259,32 @@ func (a *Asset) removeOneAssetFromWorldState(stub shim.ChaincodeStubInterface) e
 func (a *Asset) addTXNTimestampToState(stub shim.ChaincodeStubInterface) error {
 	// add transaction uuid and timestamp
 	a.TXNID = stub.GetTxID()
-	txnunixtime, err := stub.GetTxTimestamp()
+	txntimestamp, err := GetTxnTimestamp(stub)
 	if err != nil {
-		err = fmt.Errorf("error getting transaction timestamp, err is %s", err)
-		log.Errorf(err.Error())
 		return err
 	}
-	txntimestamp := time.Unix(txnunixtime.Seconds, int64(txnunixtime.Nanos))
 	a.TXNTS = &txntimestamp
 	return nil
 }
 
+// GetTxnTimestamp returns the current transaction timestamp as a time in UTC, which is the
+// only deterministic notion of "now" that all peers share
+func GetTxnTimestamp(stub shim.ChaincodeStubInterface) (time.Time, error) {
+	log := logFor(stub)
+	txnunixtime, err := stub.GetTxTimestamp()
+	if err != nil {
+		err = fmt.Errorf("error getting transaction timestamp, err is %s", err)
+		log.Errorf(err.Error())
+		return time.Time{}, err
+	}
+	if txnunixtime == nil {
+		err = errors.New("error getting transaction timestamp, stub returned no timestamp")
+		log.Errorf(err.Error())
+		return time.Time{}, err
+	}
+	return time.Unix(txnunixtime.Seconds, int64(txnunixtime.Nanos)).UTC(), nil
+}
+
 // ********** property injection implementation
 func (a *Asset) injectProps(qprops []QPropNV) error {
 	var ok bool
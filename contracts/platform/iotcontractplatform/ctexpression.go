@@ -0,0 +1,295 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- simple arithmetic expressions over qualified state properties
+
+package iotcontractplatform
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// An expression is numbers and qualified property names combined with + - * / and
+// parentheses, and the functions abs, ceil, floor, round, min and max, for example
+//     (container.temperature * 9 / 5) + 32
+// A property that is missing or not a number leaves the expression without a value.
+
+type exprNode interface {
+	eval(state *map[string]interface{}) (float64, bool)
+}
+
+type exprNumber float64
+
+type exprProperty string
+
+type exprUnary struct {
+	operand exprNode
+}
+
+type exprBinary struct {
+	op          byte
+	left, right exprNode
+}
+
+type exprCall struct {
+	name string
+	args []exprNode
+}
+
+func (n exprNumber) eval(state *map[string]interface{}) (float64, bool) {
+	return float64(n), true
+}
+
+func (n exprProperty) eval(state *map[string]interface{}) (float64, bool) {
+	return GetObjectAsNumber(state, string(n))
+}
+
+func (n exprUnary) eval(state *map[string]interface{}) (float64, bool) {
+	v, ok := n.operand.eval(state)
+	return -v, ok
+}
+
+func (n exprBinary) eval(state *map[string]interface{}) (float64, bool) {
+	l, ok := n.left.eval(state)
+	if !ok {
+		return 0, false
+	}
+	r, ok := n.right.eval(state)
+	if !ok {
+		return 0, false
+	}
+	switch n.op {
+	case '+':
+		return l + r, true
+	case '-':
+		return l - r, true
+	case '*':
+		return l * r, true
+	}
+	if r == 0 {
+		// no value rather than infinity, which cannot be marshaled
+		return 0, false
+	}
+	return l / r, true
+}
+
+var exprFunctions = map[string]int{"abs": 1, "ceil": 1, "floor": 1, "round": 1, "min": -1, "max": -1}
+
+func (n exprCall) eval(state *map[string]interface{}) (float64, bool) {
+	var vals = make([]float64, len(n.args))
+	for i, a := range n.args {
+		v, ok := a.eval(state)
+		if !ok {
+			return 0, false
+		}
+		vals[i] = v
+	}
+	switch n.name {
+	case "abs":
+		return math.Abs(vals[0]), true
+	case "ceil":
+		return math.Ceil(vals[0]), true
+	case "floor":
+		return math.Floor(vals[0]), true
+	case "round":
+		return math.Floor(vals[0] + 0.5), true
+	}
+	r := vals[0]
+	for _, v := range vals[1:] {
+		if n.name == "min" {
+			r = math.Min(r, v)
+		} else {
+			r = math.Max(r, v)
+		}
+	}
+	return r, true
+}
+
+// collects the property names used by an expression
+func exprProperties(n exprNode, props []string) []string {
+	switch e := n.(type) {
+	case exprProperty:
+		if !Contains(props, string(e)) {
+			props = append(props, string(e))
+		}
+	case exprUnary:
+		props = exprProperties(e.operand, props)
+	case exprBinary:
+		props = exprProperties(e.left, props)
+		props = exprProperties(e.right, props)
+	case exprCall:
+		for _, a := range e.args {
+			props = exprProperties(a, props)
+		}
+	}
+	return props
+}
+
+type exprParser struct {
+	src string
+	pos int
+}
+
+// parseExpression compiles an expression, reporting the position of a syntax error
+func parseExpression(src string) (exprNode, error) {
+	p := &exprParser{src, 0}
+	n, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		return nil, fmt.Errorf("unexpected '%c' at position %d in expression '%s'", p.src[p.pos], p.pos, src)
+	}
+	return n, nil
+}
+
+func (p *exprParser) skipSpace() {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *exprParser) peek() byte {
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		return p.src[p.pos]
+	}
+	return 0
+}
+
+func (p *exprParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%s at position %d in expression '%s'", fmt.Sprintf(format, args...), p.pos, p.src)
+}
+
+func (p *exprParser) sum() (exprNode, error) {
+	n, err := p.product()
+	if err != nil {
+		return nil, err
+	}
+	for c := p.peek(); c == '+' || c == '-'; c = p.peek() {
+		p.pos++
+		r, err := p.product()
+		if err != nil {
+			return nil, err
+		}
+		n = exprBinary{c, n, r}
+	}
+	return n, nil
+}
+
+func (p *exprParser) product() (exprNode, error) {
+	n, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for c := p.peek(); c == '*' || c == '/'; c = p.peek() {
+		p.pos++
+		r, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		n = exprBinary{c, n, r}
+	}
+	return n, nil
+}
+
+func (p *exprParser) unary() (exprNode, error) {
+	if p.peek() == '-' {
+		p.pos++
+		n, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return exprUnary{n}, nil
+	}
+	return p.primary()
+}
+
+func isNameByte(c byte, first bool) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (!first && (c == '.' || (c >= '0' && c <= '9')))
+}
+
+func (p *exprParser) primary() (exprNode, error) {
+	c := p.peek()
+	switch {
+	case c == 0:
+		return nil, p.errorf("unexpected end")
+	case c == '(':
+		p.pos++
+		n, err := p.sum()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, p.errorf("missing ')'")
+		}
+		p.pos++
+		return n, nil
+	case c == '.' || (c >= '0' && c <= '9'):
+		start := p.pos
+		for p.pos < len(p.src) && (p.src[p.pos] == '.' || (p.src[p.pos] >= '0' && p.src[p.pos] <= '9')) {
+			p.pos++
+		}
+		v, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+		if err != nil {
+			return nil, p.errorf("bad number '%s'", p.src[start:p.pos])
+		}
+		return exprNumber(v), nil
+	case isNameByte(c, true):
+		start := p.pos
+		for p.pos < len(p.src) && isNameByte(p.src[p.pos], false) {
+			p.pos++
+		}
+		name := p.src[start:p.pos]
+		if p.peek() != '(' {
+			if strings.HasSuffix(name, ".") || strings.Contains(name, "..") {
+				return nil, p.errorf("bad property name '%s'", name)
+			}
+			return exprProperty(name), nil
+		}
+		arity, found := exprFunctions[name]
+		if !found {
+			return nil, p.errorf("unknown function '%s'", name)
+		}
+		p.pos++
+		var args = make([]exprNode, 0)
+		for {
+			a, err := p.sum()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, a)
+			if p.peek() != ',' {
+				break
+			}
+			p.pos++
+		}
+		if p.peek() != ')' {
+			return nil, p.errorf("missing ')' after arguments to %s", name)
+		}
+		p.pos++
+		if arity > 0 && len(args) != arity {
+			return nil, p.errorf("%s takes %d argument(s)", name, arity)
+		}
+		return exprCall{name, args}, nil
+	}
+	return nil, p.errorf("unexpected '%c'", c)
+}
@@ -137,7 +137,7 @@ func (a *Asset) matchNone(filter StateFilter) bool {
 }
 
 func findJSONPropInStruct(p string, v reflect.Value) (reflect.Value, interface{}, reflect.Kind, bool) {
-	dump("findJSONPropInStruct", p, nil, nil, nil)
+	log.Debugf("findJSONPropInStruct looking for %s", p)
 	if v.Kind() != reflect.Struct {
 		return reflect.Value{}, nil, reflect.Invalid, false
 	}
@@ -152,12 +152,8 @@ func findJSONPropInStruct(p string, v reflect.Value) (reflect.Value, interface{}
 	return reflect.Value{}, nil, reflect.Invalid, false
 }
 
-func dump(s string, i interface{}, j interface{}, k interface{}, l interface{}) {
-	fmt.Printf("%s: first: %+v || second: %+v || third: %+v || fourth: %+v\n", s, i, j, k, l)
-}
-
 func (a *Asset) performOneMatch(prop QPropNV) bool {
-	dump("performOneMatch", a, prop, nil, nil)
+	log.With("assetkey", a.AssetKey).Debugf("performOneMatch %+v", prop)
 	var levels []string
 	var found = false
 	var kind reflect.Kind
@@ -169,7 +165,7 @@ func (a *Asset) performOneMatch(prop QPropNV) bool {
 	levels = strings.SplitAfterN(prop.QProp, ".", 2)
 	ar := reflect.ValueOf(a).Elem()
 	v, o, kind, found = findJSONPropInStruct(strings.TrimSuffix(levels[0], ","), ar)
-	dump("JSON prop in struct returned", v, o, kind, found)
+	log.Debugf("findJSONPropInStruct returned %+v kind %s found %t", o, kind, found)
 
 	if found {
 		if len(levels) == 2 {
@@ -0,0 +1,436 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- destructive routes require a confirmation token, can be disabled by
+//            production mode, and leave an audit record
+
+package iotcontractplatform
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// DESTRUCTIVEGUARDKEY stores production mode and the destructive call sequence number
+const DESTRUCTIVEGUARDKEY string = "IOTCP:DestructiveGuard"
+
+// DESTRUCTIVEAUDITKEY is prepended to the zero padded sequence number of each audit record
+const DESTRUCTIVEAUDITKEY string = "IOTCP.AUDIT."
+
+// ConfirmationTokenLifetime is how long a confirmation token remains valid, measured
+// between the transaction timestamps of the query and the invoke
+const ConfirmationTokenLifetime = 120 * time.Second
+
+// DestructiveGuard is the contract-level state of the destructive route guard. The
+// sequence is incremented by every destructive call, so each token is single use.
+type DestructiveGuard struct {
+	ProductionMode bool `json:"productionMode"`
+	Sequence       int  `json:"sequence"`
+}
+
+// DestructiveAuditRecord is written for every successful destructive call. Failed calls
+// leave no record in world state as their transactions do not commit.
+type DestructiveAuditRecord struct {
+	Sequence int        `json:"sequence"`
+	Function string     `json:"function"`
+	Args     string     `json:"args"`
+	TXNID    string     `json:"txnid"`
+	TXNTS    *time.Time `json:"txnts,omitempty"`
+}
+
+// DestructiveAuditRecordArray is the output of readAuditLog
+type DestructiveAuditRecordArray []DestructiveAuditRecord
+
+// ConfirmationToken is returned by readConfirmationToken
+type ConfirmationToken struct {
+	Function string `json:"function"`
+	Token    string `json:"token"`
+	Expires  string `json:"expires"`
+}
+
+// setProductionMode is not a destructive route, but turning production mode off needs a token
+const setProductionModeFunction = "setProductionMode"
+
+// GETDestructiveGuardFromLedger returns the guard state, which defaults to
+// development mode with no destructive calls made
+func GETDestructiveGuardFromLedger(stub shim.ChaincodeStubInterface) (DestructiveGuard, error) {
+	log := logFor(stub)
+	var guard DestructiveGuard
+	guardBytes, err := stub.GetState(DESTRUCTIVEGUARDKEY)
+	if err != nil {
+		err = fmt.Errorf("GETDestructiveGuardFromLedger failed GETSTATE: %s", err)
+		log.Error(err)
+		return DestructiveGuard{}, err
+	}
+	if len(guardBytes) == 0 {
+		return DestructiveGuard{}, nil
+	}
+	err = json.Unmarshal(guardBytes, &guard)
+	if err != nil {
+		err = fmt.Errorf("GETDestructiveGuardFromLedger unmarshal failed: %s", err)
+		log.Error(err)
+		return DestructiveGuard{}, err
+	}
+	return guard, nil
+}
+
+// PUTDestructiveGuardToLedger marshals and writes the guard state
+func PUTDestructiveGuardToLedger(stub shim.ChaincodeStubInterface, guard DestructiveGuard) error {
+	log := logFor(stub)
+	guardBytes, err := json.Marshal(guard)
+	if err != nil {
+		err = fmt.Errorf("PUTDestructiveGuardToLedger marshal failed: %s", err)
+		log.Error(err)
+		return err
+	}
+	err = stub.PutState(DESTRUCTIVEGUARDKEY, guardBytes)
+	if err != nil {
+		err = fmt.Errorf("PUTDestructiveGuardToLedger failed PUTSTATE: %s", err)
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// IsProductionMode returns true when destructive routes are disabled
+func IsProductionMode(stub shim.ChaincodeStubInterface) bool {
+	guard, err := GETDestructiveGuardFromLedger(stub)
+	if err != nil {
+		// fail safe
+		return true
+	}
+	return guard.ProductionMode
+}
+
+// isGuardKey returns true for keys that must survive deleteWorldState
+func isGuardKey(key string) bool {
+	return key == DESTRUCTIVEGUARDKEY || strings.HasPrefix(key, DESTRUCTIVEAUDITKEY)
+}
+
+// legacyDestructiveArgs converts the arguments that earlier releases of a destructive
+// route accepted into the JSON object that the guard expects, by function name, it is
+// filled by init functions only and read without a lock
+var legacyDestructiveArgs = make(map[string]func(args []string) []string, 0)
+
+// AddDestructiveRoute registers an invoke route that can only be executed with a
+// confirmation token from readConfirmationToken, and never in production mode
+func AddDestructiveRoute(functionName string, class AssetClass, function ChaincodeFunc) error {
+	return addRoute(ChaincodeRoute{
+		FunctionName: functionName,
+		Method:       "invoke",
+		Class:        class,
+		Function:     guardDestructiveRoute(functionName, function),
+		Destructive:  true,
+	})
+}
+
+// addNonProductionRoute registers an invoke route that is refused in production mode but
+// needs no confirmation token, for routes like importWorldState whose arguments are too
+// large to confirm call by call
+func addNonProductionRoute(functionName string, class AssetClass, function ChaincodeFunc) error {
+	return AddRoute(functionName, "invoke", class, func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+		guard, err := GETDestructiveGuardFromLedger(stub)
+		if err != nil {
+			return nil, err
+		}
+		if guard.ProductionMode {
+			err = fmt.Errorf("%s is disabled in production mode", functionName)
+			log.Error(err)
+			return nil, err
+		}
+		return function(stub, args)
+	})
+}
+
+// splits the optional JSON object in args[0] into its confirm token and the canonical
+// form of the remaining properties, which is what the token is bound to
+func getConfirmationArgs(functionName string, args []string) (string, string, error) {
+	var arg = make(map[string]interface{}, 0)
+	if len(args) > 1 {
+		return "", "", fmt.Errorf("%s expects at most one argument, a JSON object with a confirm token", functionName)
+	}
+	if len(args) == 1 && strings.TrimSpace(args[0]) != "" {
+		err := json.Unmarshal([]byte(args[0]), &arg)
+		if err != nil {
+			return "", "", fmt.Errorf("%s argument must be a JSON object: %s", functionName, err)
+		}
+	}
+	token, _ := GetObjectAsString(&arg, "confirm")
+	delete(arg, "confirm")
+	// map keys marshal in sorted order, which makes this canonical
+	canonical, err := json.Marshal(arg)
+	if err != nil {
+		return "", "", fmt.Errorf("%s argument failed to marshal: %s", functionName, err)
+	}
+	return token, string(canonical), nil
+}
+
+// the token is bound to the function, its arguments, the contract state and the
+// destructive sequence number, and carries its own expiry. It is an unkeyed hash of
+// values that any caller can read, so it confirms that the caller meant this call in
+// this state and is not an authorization, access to destructive routes must be
+// controlled by the peer's membership services or production mode.
+func calculateConfirmationToken(stub shim.ChaincodeStubInterface, guard DestructiveGuard, functionName string, canonical string, expires int64) string {
+	// a missing contract state (e.g. after deleteWorldState) still produces a valid token
+	cstate, _ := GETContractStateFromLedger(stub)
+	h := sha256.New()
+	for _, f := range []string{functionName, canonical, strconv.Itoa(guard.Sequence), cstate.Version, cstate.Nickname, strconv.FormatInt(expires, 10)} {
+		h.Write([]byte(f))
+		h.Write([]byte{0})
+	}
+	return strconv.FormatInt(expires, 10) + "." + hex.EncodeToString(h.Sum(nil))
+}
+
+func verifyConfirmationToken(stub shim.ChaincodeStubInterface, guard DestructiveGuard, functionName string, token string, canonical string) error {
+	if token == "" {
+		return fmt.Errorf("%s requires a confirm token, obtain one with readConfirmationToken", functionName)
+	}
+	parts := strings.SplitN(token, ".", 2)
+	expires, err := strconv.ParseInt(parts[0], 10, 64)
+	if len(parts) != 2 || err != nil {
+		return fmt.Errorf("%s confirm token is malformed", functionName)
+	}
+	now, err := GetTxnTimestamp(stub)
+	if err != nil {
+		return err
+	}
+	if now.Unix() > expires {
+		return fmt.Errorf("%s confirm token expired at %s", functionName, time.Unix(expires, 0).UTC().Format(time.RFC3339))
+	}
+	// a token that outlives ConfirmationTokenLifetime was not issued by readConfirmationToken
+	if expires > now.Add(ConfirmationTokenLifetime).Unix() {
+		return fmt.Errorf("%s confirm token expires at %s, later than readConfirmationToken allows", functionName, time.Unix(expires, 0).UTC().Format(time.RFC3339))
+	}
+	if calculateConfirmationToken(stub, guard, functionName, canonical, expires) != token {
+		return fmt.Errorf("%s confirm token does not match this call, its arguments or the current contract state", functionName)
+	}
+	return nil
+}
+
+// writes the audit record and consumes the sequence number, which invalidates all
+// outstanding tokens
+func auditDestructiveCall(stub shim.ChaincodeStubInterface, functionName string, canonical string) error {
+	log := logFor(stub)
+	guard, err := GETDestructiveGuardFromLedger(stub)
+	if err != nil {
+		return err
+	}
+	var record = DestructiveAuditRecord{
+		Sequence: guard.Sequence,
+		Function: functionName,
+		Args:     canonical,
+		TXNID:    stub.GetTxID(),
+	}
+	if ts, err := GetTxnTimestamp(stub); err == nil {
+		record.TXNTS = &ts
+	}
+	recordBytes, err := json.Marshal(record)
+	if err != nil {
+		err = fmt.Errorf("auditDestructiveCall marshal failed: %s", err)
+		log.Error(err)
+		return err
+	}
+	err = stub.PutState(fmt.Sprintf("%s%010d", DESTRUCTIVEAUDITKEY, guard.Sequence), recordBytes)
+	if err != nil {
+		err = fmt.Errorf("auditDestructiveCall failed PUTSTATE: %s", err)
+		log.Error(err)
+		return err
+	}
+	guard.Sequence++
+	log.Noticef("Destructive call %s audited with sequence %d in txn %s", functionName, record.Sequence, record.TXNID)
+	return PUTDestructiveGuardToLedger(stub, guard)
+}
+
+func guardDestructiveRoute(functionName string, function ChaincodeFunc) ChaincodeFunc {
+	return func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+		guard, err := GETDestructiveGuardFromLedger(stub)
+		if err != nil {
+			return nil, err
+		}
+		if guard.ProductionMode {
+			err = fmt.Errorf("%s is disabled in production mode", functionName)
+			log.Error(err)
+			return nil, err
+		}
+		if legacy, found := legacyDestructiveArgs[functionName]; found {
+			args = legacy(args)
+		}
+		token, canonical, err := getConfirmationArgs(functionName, args)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		err = verifyConfirmationToken(stub, guard, functionName, token, canonical)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		result, err := function(stub, []string{canonical})
+		if err != nil {
+			return nil, err
+		}
+		err = auditDestructiveCall(stub, functionName, canonical)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+}
+
+// readConfirmationToken returns a short-lived token that allows exactly one call to
+// a destructive route with exactly the arguments given here, the token confirms the
+// call and does not authorize the caller
+var readConfirmationToken = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	type ConfirmationArg struct {
+		Function string          `json:"function"`
+		Args     json.RawMessage `json:"args"`
+	}
+	var arg ConfirmationArg
+	var err error
+
+	if len(args) != 1 {
+		err = errors.New("readConfirmationToken expects a JSON object with function and args")
+		log.Error(err)
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(args[0]), &arg)
+	if err != nil {
+		err = fmt.Errorf("readConfirmationToken failed to unmarshal arg: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	if r, found := getRoute(arg.Function); !(found && r.Destructive) && arg.Function != setProductionModeFunction {
+		err = fmt.Errorf("readConfirmationToken: %s is not a destructive route", arg.Function)
+		log.Error(err)
+		return nil, err
+	}
+	guard, err := GETDestructiveGuardFromLedger(stub)
+	if err != nil {
+		return nil, err
+	}
+	if guard.ProductionMode && arg.Function != setProductionModeFunction {
+		err = fmt.Errorf("readConfirmationToken: %s is disabled in production mode", arg.Function)
+		log.Error(err)
+		return nil, err
+	}
+	_, canonical, err := getConfirmationArgs(arg.Function, []string{string(arg.Args)})
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	now, err := GetTxnTimestamp(stub)
+	if err != nil {
+		return nil, err
+	}
+	expires := now.Add(ConfirmationTokenLifetime).Unix()
+	return json.Marshal(ConfirmationToken{
+		Function: arg.Function,
+		Token:    calculateConfirmationToken(stub, guard, arg.Function, canonical, expires),
+		Expires:  time.Unix(expires, 0).UTC().Format(time.RFC3339),
+	})
+}
+
+// setProductionMode turns production mode on immediately, turning it off again
+// requires a confirmation token
+var setProductionMode = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	var err error
+	token, canonical, err := getConfirmationArgs(setProductionModeFunction, args)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	var arg DestructiveGuard
+	err = json.Unmarshal([]byte(canonical), &arg)
+	if err != nil {
+		err = fmt.Errorf("setProductionMode failed to unmarshal arg: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	guard, err := GETDestructiveGuardFromLedger(stub)
+	if err != nil {
+		return nil, err
+	}
+	if guard.ProductionMode == arg.ProductionMode {
+		return nil, nil
+	}
+	if !arg.ProductionMode {
+		err = verifyConfirmationToken(stub, guard, setProductionModeFunction, token, canonical)
+		if err != nil {
+			log.Error(err)
+			return nil, err
+		}
+	}
+	guard.ProductionMode = arg.ProductionMode
+	err = PUTDestructiveGuardToLedger(stub, guard)
+	if err != nil {
+		return nil, err
+	}
+	return nil, auditDestructiveCall(stub, setProductionModeFunction, canonical)
+}
+
+// readAuditLog returns all destructive call audit records, newest first
+var readAuditLog = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	var records = make(DestructiveAuditRecordArray, 0)
+	iter, err := stub.RangeQueryState(DESTRUCTIVEAUDITKEY, DESTRUCTIVEAUDITKEY+"}")
+	if err != nil {
+		err = fmt.Errorf("readAuditLog failed to get a range query iterator: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	defer iter.Close()
+	for iter.HasNext() {
+		key, recordBytes, err := iter.Next()
+		if err != nil {
+			err = fmt.Errorf("readAuditLog iter.Next() failed: %s", err)
+			log.Error(err)
+			return nil, err
+		}
+		if !strings.HasPrefix(key, DESTRUCTIVEAUDITKEY) {
+			continue
+		}
+		var record DestructiveAuditRecord
+		err = json.Unmarshal(recordBytes, &record)
+		if err != nil {
+			err = fmt.Errorf("readAuditLog unmarshal %s failed: %s", key, err)
+			log.Error(err)
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	sort.Sort(sort.Reverse(records))
+	return json.Marshal(records)
+}
+
+func init() {
+	AddRoute("readConfirmationToken", "query", SystemClass, readConfirmationToken)
+	AddRoute("setProductionMode", "invoke", SystemClass, setProductionMode)
+	AddRoute("readAuditLog", "query", SystemClass, readAuditLog)
+}
+
+//********** sort interface for DestructiveAuditRecordArray
+
+func (ra DestructiveAuditRecordArray) Len() int           { return len(ra) }
+func (ra DestructiveAuditRecordArray) Swap(i, j int)      { ra[i], ra[j] = ra[j], ra[i] }
+func (ra DestructiveAuditRecordArray) Less(i, j int) bool { return ra[i].Sequence < ra[j].Sequence }
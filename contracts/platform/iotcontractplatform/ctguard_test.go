@@ -0,0 +1,73 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package iotcontractplatform
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpstub"
+)
+
+func TestConfirmationArgsAreCanonical(t *testing.T) {
+	token, c1, err := getConfirmationArgs("f", []string{`{"filter":{"match":"all","select":[]}, "confirm":"1.abc", "a":1}`})
+	if err != nil || token != "1.abc" {
+		t.Fatalf("confirm token not extracted: [%s] err %v", token, err)
+	}
+	_, c2, err := getConfirmationArgs("f", []string{`{"a":1,"filter":{"select":[],"match":"all"}}`})
+	if err != nil || c1 != c2 {
+		t.Fatalf("canonical args differ: [%s] [%s] err %v", c1, c2, err)
+	}
+}
+
+func TestConfirmationArgsEmpty(t *testing.T) {
+	token, c, err := getConfirmationArgs("f", []string{})
+	if err != nil || token != "" || c != "{}" {
+		t.Fatalf("empty args gave token [%s] canonical [%s] err %v", token, c, err)
+	}
+	if _, _, err = getConfirmationArgs("f", []string{"reinit"}); err == nil {
+		t.Fatal("non JSON argument was accepted")
+	}
+}
+
+func TestConfirmationTokenLifetime(t *testing.T) {
+	stub := iotcpstub.NewStub("guard")
+	stub.Begin(false)
+	defer stub.End(false)
+	var guard DestructiveGuard
+	now := stub.Clock.Unix()
+	var tests = []struct {
+		expires int64
+		problem string
+	}{
+		{now + int64(ConfirmationTokenLifetime/time.Second), ""},
+		{now - 1, "expired"},
+		{now + int64(ConfirmationTokenLifetime/time.Second) + 1, "later than readConfirmationToken allows"},
+		{now + 365*24*3600, "later than readConfirmationToken allows"},
+	}
+	for _, test := range tests {
+		token := calculateConfirmationToken(stub, guard, "deleteWorldState", "{}", test.expires)
+		err := verifyConfirmationToken(stub, guard, "deleteWorldState", token, "{}")
+		switch {
+		case test.problem == "" && err != nil:
+			t.Fatalf("token expiring at %d was refused: %s", test.expires, err)
+		case test.problem != "" && (err == nil || !strings.Contains(err.Error(), test.problem)):
+			t.Fatalf("token expiring at %d gave %v, expected %s", test.expires, err, test.problem)
+		}
+	}
+}
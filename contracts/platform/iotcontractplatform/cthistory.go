@@ -59,6 +59,7 @@ type DateRange struct {
 
 // PUTAssetStateHistory write an Asset state with history key
 func (a *Asset) PUTAssetStateHistory(stub shim.ChaincodeStubInterface) error {
+	log := logFor(stub)
 	historyKey := STATEHISTORYKEY + a.AssetKey + "." + a.TXNTS.Format(time.RFC3339Nano)
 	assetBytes, err := json.Marshal(a)
 	if err != nil {
@@ -72,11 +73,13 @@ func (a *Asset) PUTAssetStateHistory(stub shim.ChaincodeStubInterface) error {
 		log.Error(err)
 		return err
 	}
+	countMetric(stub, metricHistory, a.Class.Name, 1)
 	return nil
 }
 
 // DeleteAssetStateHistory deletes all history for an asset
 func (c *AssetClass) DeleteAssetStateHistory(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	log := logFor(stub)
 	var err error
 	var arg = c.NewAsset()
 
@@ -114,6 +117,7 @@ func (c *AssetClass) DeleteAssetStateHistory(stub shim.ChaincodeStubInterface, a
 			log.Errorf(err.Error())
 			return nil, err
 		}
+		countMetric(stub, metricHistory, c.Name, -1)
 	}
 
 	return nil, nil
@@ -121,6 +125,7 @@ func (c *AssetClass) DeleteAssetStateHistory(stub shim.ChaincodeStubInterface, a
 
 // ReadAssetStateHistory gets the state history for an asset.
 func (c *AssetClass) ReadAssetStateHistory(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	log := logFor(stub)
 	var assets = make(AssetArray, 0)
 	var err error
 	var filter StateFilter
@@ -199,6 +204,7 @@ func (c *AssetClass) ReadAssetStateHistory(stub shim.ChaincodeStubInterface, arg
 
 // Returns a date range found in the json object in args[0]
 func getUnmarshalledDateRange(stub shim.ChaincodeStubInterface, args []string) (DateRange, error) {
+	log := logFor(stub)
 	var dr DateRange
 	var err error
 
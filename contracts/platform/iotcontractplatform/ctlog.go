@@ -0,0 +1,291 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- leveled logging with key=value fields, transaction correlation and module levels
+
+package iotcontractplatform
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// LOGGERNAME is the name of the platform's chaincode logger, module loggers are named
+// LOGGERNAME + "." + module
+const LOGGERNAME string = "iotcontractplatform"
+
+// logModules are the parts of the platform whose levels can be set on their own. The
+// module of a log line is the name of the platform file that logs it, without the ct
+// prefix, e.g. rulerouter for ctrulerouter.go.
+var logModules = []string{
+	"alerts", "asset", "classes", "classroutes", "computed", "config", "contractstate", "crud",
+	"expression", "filters", "geo", "guard", "history", "log", "maps", "merge", "metrics", "notify",
+	"provenance", "recent", "router", "rulerouter", "snapshot", "units", "verify",
+}
+
+// platformLogger writes each line through the chaincode logger of its module, followed
+// by the fields of the logger, e.g.
+//     UpdateAsset failed | txnid=1a2b function=updateAssetContainer class=Container assetkey=CONC1
+type platformLogger struct {
+	fields []interface{}
+}
+
+// the loggers shared by every platformLogger
+type logRegistry struct {
+	sync.Mutex
+	base    *shim.ChaincodeLogger
+	modules map[string]*shim.ChaincodeLogger
+}
+
+var logs = &logRegistry{
+	base:    shim.NewLogger(LOGGERNAME),
+	modules: make(map[string]*shim.ChaincodeLogger),
+}
+
+var log = &platformLogger{}
+
+// SetContractLogger allows the whole package to be loaded at startup and to share a
+// single chaincode logger
+func SetContractLogger(logger *shim.ChaincodeLogger) {
+	logs.Lock()
+	defer logs.Unlock()
+	logs.base = logger
+}
+
+// SetContractLoggingLevel sets the level of the shared chaincode logger, for tools that
+// run a contract in process and cannot invoke setLoggingLevel
+func SetContractLoggingLevel(level shim.LoggingLevel) {
+	log.SetLevel(level)
+}
+
+// SetModuleLoggingLevel sets the level of one module of the platform, which then logs
+// through its own chaincode logger
+func SetModuleLoggingLevel(module string, level shim.LoggingLevel) error {
+	if !Contains(logModules, module) {
+		err := fmt.Errorf("SetModuleLoggingLevel: unknown module %s, expecting one of %v", module, logModules)
+		log.Error(err)
+		return err
+	}
+	logs.Lock()
+	defer logs.Unlock()
+	lg, found := logs.modules[module]
+	if !found {
+		lg = shim.NewLogger(LOGGERNAME + "." + module)
+		logs.modules[module] = lg
+	}
+	lg.SetLevel(level)
+	return nil
+}
+
+// logFor returns the package logger with the ID of the stub's transaction, which the shim
+// runs on its own goroutine. A function that has a stub logs through it, so that the lines
+// of a query that overlaps an invoke carry their own transaction, e.g.
+//     log := logFor(stub).With("class", c.Name)
+func logFor(stub shim.ChaincodeStubInterface) *platformLogger {
+	if stub == nil {
+		return log
+	}
+	return log.With("txnid", stub.GetTxID())
+}
+
+// With returns a logger that adds key value pairs to every line, e.g.
+//     log.With("class", c.Name, "assetkey", assetKey).Error(err)
+func (l *platformLogger) With(kv ...interface{}) *platformLogger {
+	var fields = make([]interface{}, 0, len(l.fields)+len(kv))
+	fields = append(fields, l.fields...)
+	return &platformLogger{append(fields, kv...)}
+}
+
+// SetLevel sets the level of the platform and of every module that has its own level
+func (l *platformLogger) SetLevel(level shim.LoggingLevel) {
+	logs.Lock()
+	defer logs.Unlock()
+	logs.base.SetLevel(level)
+	for _, lg := range logs.modules {
+		lg.SetLevel(level)
+	}
+}
+
+// module of the platform file that called a logging method
+func callerModule() string {
+	_, file, _, ok := runtime.Caller(3)
+	if !ok {
+		return ""
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(filepath.Base(file), "ct"), ".go")
+}
+
+func formatFields(kv []interface{}) string {
+	var parts = make([]string, 0, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		parts = append(parts, fmt.Sprintf("%v=%v", kv[i], kv[i+1]))
+	}
+	if len(kv)%2 == 1 {
+		parts = append(parts, fmt.Sprintf("%v", kv[len(kv)-1]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// finds the logger of the calling module and returns it with the line to log, or false
+// when the level is not enabled
+func (l *platformLogger) line(level shim.LoggingLevel, msg func() string) (*shim.ChaincodeLogger, string, bool) {
+	module := callerModule()
+	logs.Lock()
+	lg, found := logs.modules[module]
+	if !found {
+		lg = logs.base
+	}
+	logs.Unlock()
+	if level != shim.LogCritical && !lg.IsEnabledFor(level) {
+		return nil, "", false
+	}
+	if len(l.fields) == 0 {
+		return lg, msg(), true
+	}
+	return lg, msg() + " | " + formatFields(l.fields), true
+}
+
+func sprint(args []interface{}) func() string {
+	return func() string { return strings.TrimSuffix(fmt.Sprintln(args...), "\n") }
+}
+
+func sprintf(format string, args []interface{}) func() string {
+	return func() string { return fmt.Sprintf(format, args...) }
+}
+
+// Debug logs at LogDebug
+func (l *platformLogger) Debug(args ...interface{}) {
+	if lg, s, ok := l.line(shim.LogDebug, sprint(args)); ok {
+		lg.Debug(s)
+	}
+}
+
+// Debugf logs at LogDebug
+func (l *platformLogger) Debugf(format string, args ...interface{}) {
+	if lg, s, ok := l.line(shim.LogDebug, sprintf(format, args)); ok {
+		lg.Debug(s)
+	}
+}
+
+// Info logs at LogInfo
+func (l *platformLogger) Info(args ...interface{}) {
+	if lg, s, ok := l.line(shim.LogInfo, sprint(args)); ok {
+		lg.Info(s)
+	}
+}
+
+// Infof logs at LogInfo
+func (l *platformLogger) Infof(format string, args ...interface{}) {
+	if lg, s, ok := l.line(shim.LogInfo, sprintf(format, args)); ok {
+		lg.Info(s)
+	}
+}
+
+// Notice logs at LogNotice
+func (l *platformLogger) Notice(args ...interface{}) {
+	if lg, s, ok := l.line(shim.LogNotice, sprint(args)); ok {
+		lg.Notice(s)
+	}
+}
+
+// Noticef logs at LogNotice
+func (l *platformLogger) Noticef(format string, args ...interface{}) {
+	if lg, s, ok := l.line(shim.LogNotice, sprintf(format, args)); ok {
+		lg.Notice(s)
+	}
+}
+
+// Warning logs at LogWarning
+func (l *platformLogger) Warning(args ...interface{}) {
+	if lg, s, ok := l.line(shim.LogWarning, sprint(args)); ok {
+		lg.Warning(s)
+	}
+}
+
+// Warningf logs at LogWarning
+func (l *platformLogger) Warningf(format string, args ...interface{}) {
+	if lg, s, ok := l.line(shim.LogWarning, sprintf(format, args)); ok {
+		lg.Warning(s)
+	}
+}
+
+// Error logs at LogError
+func (l *platformLogger) Error(args ...interface{}) {
+	if lg, s, ok := l.line(shim.LogError, sprint(args)); ok {
+		lg.Error(s)
+	}
+}
+
+// Errorf logs at LogError
+func (l *platformLogger) Errorf(format string, args ...interface{}) {
+	if lg, s, ok := l.line(shim.LogError, sprintf(format, args)); ok {
+		lg.Error(s)
+	}
+}
+
+// Critical logs always
+func (l *platformLogger) Critical(args ...interface{}) {
+	if lg, s, ok := l.line(shim.LogCritical, sprint(args)); ok {
+		lg.Critical(s)
+	}
+}
+
+// Criticalf logs always
+func (l *platformLogger) Criticalf(format string, args ...interface{}) {
+	if lg, s, ok := l.line(shim.LogCritical, sprintf(format, args)); ok {
+		lg.Critical(s)
+	}
+}
+
+// LoggingLevelsOut is the output of readLoggingLevels
+type LoggingLevelsOut struct {
+	Level   string            `json:"logLevel"`
+	Modules map[string]string `json:"modules,omitempty"`
+}
+
+func levelName(lg *shim.ChaincodeLogger) string {
+	for _, level := range []shim.LoggingLevel{shim.LogDebug, shim.LogInfo, shim.LogNotice, shim.LogWarning, shim.LogError} {
+		if lg.IsEnabledFor(level) {
+			return loggingLevelNames[level]
+		}
+	}
+	return loggingLevelNames[shim.LogCritical]
+}
+
+var loggingLevelNames = map[shim.LoggingLevel]string{
+	shim.LogDebug:    "DEBUG",
+	shim.LogInfo:     "INFO",
+	shim.LogNotice:   "NOTICE",
+	shim.LogWarning:  "WARNING",
+	shim.LogError:    "ERROR",
+	shim.LogCritical: "CRITICAL",
+}
+
+// loggingLevels returns the level of the platform and of the modules that have their own
+func loggingLevels() LoggingLevelsOut {
+	logs.Lock()
+	defer logs.Unlock()
+	var out = LoggingLevelsOut{levelName(logs.base), make(map[string]string, len(logs.modules))}
+	for module, lg := range logs.modules {
+		out.Modules[module] = levelName(lg)
+	}
+	return out
+}
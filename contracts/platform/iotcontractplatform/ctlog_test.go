@@ -0,0 +1,94 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package iotcontractplatform
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpstub"
+)
+
+func TestLogModules(t *testing.T) {
+	files, err := filepath.Glob("ct*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		if strings.HasSuffix(f, "_test.go") {
+			continue
+		}
+		module := strings.TrimSuffix(strings.TrimPrefix(f, "ct"), ".go")
+		if !Contains(logModules, module) {
+			t.Errorf("%s logs as module %s, which is missing from logModules", f, module)
+		}
+	}
+}
+
+func TestLogFields(t *testing.T) {
+	l := log.With("class", "Container").With("assetkey", "CONC1")
+	if got := formatFields(l.fields); got != "class=Container assetkey=CONC1" {
+		t.Fatalf("fields are '%s'", got)
+	}
+	if len(log.fields) != 0 {
+		t.Fatal("With changed the package logger")
+	}
+
+	if err := SetModuleLoggingLevel("rulerouter", shim.LogDebug); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetModuleLoggingLevel("rules", shim.LogDebug); err == nil {
+		t.Fatal("unknown module accepted")
+	}
+	if levels := loggingLevels(); levels.Modules["rulerouter"] != "DEBUG" {
+		t.Fatalf("unexpected levels %+v", levels)
+	}
+	log.SetLevel(shim.LogWarning)
+	if levels := loggingLevels(); levels.Level != "WARNING" || levels.Modules["rulerouter"] != "WARNING" {
+		t.Fatalf("unexpected levels %+v", levels)
+	}
+}
+
+func TestLogForOverlappingTransactions(t *testing.T) {
+	invoke := iotcpstub.NewStub("invoke")
+	invoke.Begin(true)
+	defer invoke.End(true)
+	l := logFor(invoke).With("function", "updateAsset")
+
+	// a query that logs on another goroutine during the invoke
+	var wg sync.WaitGroup
+	var queryFields string
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		queryFields = formatFields(logFor(iotcpstub.NewStub("query")).With("function", "readAsset").fields)
+	}()
+	wg.Wait()
+
+	if queryFields != "txnid= function=readAsset" {
+		t.Fatalf("the query logged with fields '%s'", queryFields)
+	}
+	if got := formatFields(l.fields); got != "txnid="+invoke.TxID+" function=updateAsset" {
+		t.Fatalf("after the query the invoke logs with fields '%s'", got)
+	}
+	if logFor(nil) != log {
+		t.Fatal("a nil stub did not log through the package logger")
+	}
+}
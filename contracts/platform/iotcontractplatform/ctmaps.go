@@ -370,29 +370,37 @@ func DeepCopyMap(srcIn map[string]interface{}) map[string]interface{} {
 	return DeepMergeMap(srcIn, make(map[string]interface{}, 0))
 }
 
-// DeepMergeMap all levels of a src map into a dst map and return dst
+// DeepMergeMap all levels of a src map into a dst map and return dst. String arrays are
+// merged as sets and other arrays are replaced, see DeepMergeMapWith for other strategies.
 func DeepMergeMap(srcIn map[string]interface{}, dstIn map[string]interface{}) map[string]interface{} {
+	return deepMergeMap("", srcIn, dstIn, nil)
+}
+
+// DeepMergeMapWith merges all levels of a src map into a dst map and returns dst, merging
+// arrays with the strategies registered by qualified property name
+func DeepMergeMapWith(srcIn map[string]interface{}, dstIn map[string]interface{}, strategies map[string]MergeStrategy) map[string]interface{} {
+	return deepMergeMap("", srcIn, dstIn, strategies)
+}
+
+func deepMergeMap(prefix string, srcIn map[string]interface{}, dstIn map[string]interface{}, strategies map[string]MergeStrategy) map[string]interface{} {
 	for k, v := range srcIn {
+		qprop := k
+		if prefix != "" {
+			qprop = prefix + "." + k
+		}
 		switch v.(type) {
 		case map[string]interface{}:
-			dstv, found := GetObject(&dstIn, k)
+			dstv, found := dstIn[k].(map[string]interface{})
 			if found {
 				// recursive DeepMerge into existing key
-				dstIn[k] = DeepMergeMap(v.(map[string]interface{}), dstv.(map[string]interface{}))
+				dstIn[k] = deepMergeMap(qprop, v.(map[string]interface{}), dstv, strategies)
 			} else {
-				// copy src to dst at same key
-				dstIn[k] = v
+				// copy src to dst at same key, as a copy so that dst does not share
+				// nested maps with src
+				dstIn[k] = deepMergeMap(qprop, v.(map[string]interface{}), make(map[string]interface{}, 0), strategies)
 			}
 		case []interface{}:
-			// stringarray
-			dstv, found := GetObjectAsStringArray(&dstIn, k)
-			if found {
-				varr, ok := AsStringArray(v)
-				if ok {
-					AddToStringArray(varr, &dstv)
-					dstIn[k] = dstv
-				}
-			}
+			dstIn[k] = mergeArray(v.([]interface{}), dstIn[k], strategies[qprop])
 		default:
 			// copy discrete type
 			dstIn[k] = v
@@ -401,6 +409,66 @@ func DeepMergeMap(srcIn map[string]interface{}, dstIn map[string]interface{}) ma
 	return dstIn
 }
 
+// StateToStruct unmarshals the object at a qualified name in an asset state into v,
+// usually a struct generated from the contract's schema by processSchema. v is left
+// alone when the state does not have the object.
+func StateToStruct(state *map[string]interface{}, qname string, v interface{}) error {
+	if state == nil {
+		return nil
+	}
+	obj, found := GetObject(state, qname)
+	if !found {
+		return nil
+	}
+	objBytes, err := json.Marshal(obj)
+	if err != nil {
+		err = fmt.Errorf("StateToStruct: %s failed to marshal: %s", qname, err)
+		log.Error(err)
+		return err
+	}
+	err = json.Unmarshal(objBytes, v)
+	if err != nil {
+		err = fmt.Errorf("StateToStruct: %s does not unmarshal into %T: %s", qname, v, err)
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// StructToState merges v into the object at a qualified name in an asset state, the
+// properties that v omits are left alone
+func StructToState(v interface{}, state *map[string]interface{}, qname string) error {
+	if state == nil || *state == nil {
+		err := fmt.Errorf("StructToState: no state to write %s into", qname)
+		log.Error(err)
+		return err
+	}
+	vBytes, err := json.Marshal(v)
+	if err != nil {
+		err = fmt.Errorf("StructToState: %T failed to marshal: %s", v, err)
+		log.Error(err)
+		return err
+	}
+	var vmap map[string]interface{}
+	err = json.Unmarshal(vBytes, &vmap)
+	if err != nil {
+		err = fmt.Errorf("StructToState: %T is not an object: %s", v, err)
+		log.Error(err)
+		return err
+	}
+	if existing, found := GetObject(state, qname); found {
+		if dst, ok := existing.(map[string]interface{}); ok {
+			vmap = DeepMergeMap(vmap, dst)
+		}
+	}
+	if !PutObject(state, qname, vmap) {
+		err = fmt.Errorf("StructToState: %s cannot be written into the state", qname)
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
 // PrettyPrint returns a string that is a nicely indented representation
 // of js object (map); if json fails for some reason, returns the %#v representation
 func PrettyPrint(m interface{}) string {
@@ -430,3 +430,40 @@ func TestRemoveFromStringArray(t *testing.T) {
 	// fmt.Printf("Object after: %+v\n\n", o)
 
 }
+
+func TestStateStructRoundTrip(t *testing.T) {
+	type sensors struct {
+		MaxGForce *float64 `json:"maxgforce,omitempty"`
+		MaxTilt   *float64 `json:"maxtilt,omitempty"`
+	}
+	state := map[string]interface{}{"kit": map[string]interface{}{"sensors": map[string]interface{}{"maxgforce": 1.5, "currtilt": 3.0}}}
+	var s sensors
+	if err := StateToStruct(&state, "kit.sensors", &s); err != nil || s.MaxGForce == nil || *s.MaxGForce != 1.5 || s.MaxTilt != nil {
+		t.Fatalf("unexpected struct %+v (%v)", s, err)
+	}
+	var missing sensors
+	if err := StateToStruct(&state, "kit.hospital", &missing); err != nil || missing.MaxGForce != nil {
+		t.Fatalf("expected an untouched struct for a missing object, got %+v (%v)", missing, err)
+	}
+	if err := StateToStruct(&state, "kit", new(string)); err == nil {
+		t.Fatal("expected an error unmarshaling an object into a string")
+	}
+
+	tilt := 45.0
+	s.MaxTilt = &tilt
+	if err := StructToState(s, &state, "kit.sensors"); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := GetObjectAsNumber(&state, "kit.sensors.maxtilt"); v != 45 {
+		t.Fatalf("maxtilt is %v, expected 45", v)
+	}
+	if v, _ := GetObjectAsNumber(&state, "kit.sensors.currtilt"); v != 3 {
+		t.Fatalf("currtilt is %v, expected the property that the struct omits to be kept", v)
+	}
+	if err := StructToState(s, &state, "kit.transit.sensors"); err != nil {
+		t.Fatal(err)
+	}
+	if _, found := GetObject(&state, "kit.transit.sensors.maxgforce"); !found {
+		t.Fatal("expected a missing object to be created")
+	}
+}
@@ -0,0 +1,259 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- per class strategies for merging array properties into asset state
+
+package iotcontractplatform
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// MergeKind names the way an incoming array is merged with the array in state
+type MergeKind string
+
+const (
+	// MergeReplace replaces the array in state with the incoming array
+	MergeReplace MergeKind = "replace"
+	// MergeAppend appends the incoming entries to the array in state
+	MergeAppend MergeKind = "append"
+	// MergeUnion keeps the sorted set of the string entries in both arrays
+	MergeUnion MergeKind = "union"
+	// MergeUnionByKey replaces the objects in state that have the same value at Key as an
+	// incoming object, and appends the others
+	MergeUnionByKey MergeKind = "unionByKey"
+	// MergeBoundedAppend appends the incoming entries and keeps the last Limit entries
+	MergeBoundedAppend MergeKind = "boundedAppend"
+)
+
+// MergeStrategy is the strategy for one array property. Without a strategy, string arrays
+// are merged as a union and other arrays are replaced.
+type MergeStrategy struct {
+	Kind  MergeKind `json:"kind"`
+	Key   string    `json:"key,omitempty"`
+	Limit int       `json:"limit,omitempty"`
+}
+
+var mergerouter = make(map[AssetClass]map[string]MergeStrategy, 0)
+
+// AddMergeStrategy registers the strategy that UpdateAsset uses to merge an array property
+func AddMergeStrategy(class AssetClass, qprop string, strategy MergeStrategy) error {
+	routerLock.Lock()
+	defer routerLock.Unlock()
+	if _, found := mergerouter[class][qprop]; found {
+		err := fmt.Errorf("AddMergeStrategy: class %s property %s already has a merge strategy", class.Name, qprop)
+		log.Error(err)
+		return err
+	}
+	if err := checkMergeStrategy(qprop, strategy); err != nil {
+		err = fmt.Errorf("AddMergeStrategy: class %s %s", class.Name, err)
+		log.Error(err)
+		return err
+	}
+	if _, found := mergerouter[class]; !found {
+		mergerouter[class] = make(map[string]MergeStrategy, 0)
+	}
+	mergerouter[class][qprop] = strategy
+	log.Debugf("Class %s merges %s with %+v", class.Name, qprop, strategy)
+	return nil
+}
+
+// returns a copy of the merge strategies of a class
+func classMergeStrategies(class AssetClass) map[string]MergeStrategy {
+	routerLock.RLock()
+	defer routerLock.RUnlock()
+	var strategies = make(map[string]MergeStrategy, len(mergerouter[class]))
+	for qprop, strategy := range mergerouter[class] {
+		strategies[qprop] = strategy
+	}
+	return strategies
+}
+
+func checkMergeStrategy(qprop string, strategy MergeStrategy) error {
+	if qprop == "" || strings.HasPrefix(qprop, ".") || strings.HasSuffix(qprop, ".") || strings.Contains(qprop, "..") {
+		return fmt.Errorf("has invalid qualified property '%s' in merge strategy", qprop)
+	}
+	switch strategy.Kind {
+	case MergeReplace, MergeAppend, MergeUnion:
+	case MergeUnionByKey:
+		if strategy.Key == "" {
+			return fmt.Errorf("merge strategy for %s needs a key", qprop)
+		}
+	case MergeBoundedAppend:
+		if strategy.Limit <= 0 {
+			return fmt.Errorf("merge strategy for %s needs a positive limit", qprop)
+		}
+	default:
+		return fmt.Errorf("merge strategy for %s has unknown kind '%s'", qprop, strategy.Kind)
+	}
+	return nil
+}
+
+// copies nested maps and arrays so that state does not share them with the event
+func copyValue(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case map[string]interface{}:
+		return DeepCopyMap(vv)
+	case []interface{}:
+		arr := make([]interface{}, 0, len(vv))
+		for _, e := range vv {
+			arr = append(arr, copyValue(e))
+		}
+		return arr
+	default:
+		return v
+	}
+}
+
+// merges an incoming array with the value in state according to the strategy
+func mergeArray(src []interface{}, dstIn interface{}, strategy MergeStrategy) interface{} {
+	var incoming = copyValue(src).([]interface{})
+	var dst, isArray = dstIn.([]interface{})
+	if strs, ok := dstIn.([]string); ok {
+		// string arrays merged by AddToStringArray
+		isArray = true
+		dst = make([]interface{}, 0, len(strs))
+		for _, s := range strs {
+			dst = append(dst, s)
+		}
+	}
+	switch strategy.Kind {
+	case "", MergeUnion:
+		from, ok := stringArray(src)
+		if !ok {
+			return incoming
+		}
+		to, found := stringArray(dstIn)
+		if !found && strategy.Kind == "" {
+			return incoming
+		}
+		AddToStringArray(from, &to)
+		return to
+	case MergeAppend:
+		if !isArray {
+			return incoming
+		}
+		return append(dst, incoming...)
+	case MergeBoundedAppend:
+		if isArray {
+			incoming = append(dst, incoming...)
+		}
+		if len(incoming) > strategy.Limit {
+			incoming = incoming[len(incoming)-strategy.Limit:]
+		}
+		return incoming
+	case MergeUnionByKey:
+		if !isArray {
+			return incoming
+		}
+		for _, e := range incoming {
+			key, found := keyOf(e, strategy.Key)
+			replaced := false
+			for i, d := range dst {
+				if dkey, dfound := keyOf(d, strategy.Key); found && dfound && dkey == key {
+					dst[i] = e
+					replaced = true
+					break
+				}
+			}
+			if !replaced {
+				dst = append(dst, e)
+			}
+		}
+		return dst
+	default:
+		return incoming
+	}
+}
+
+// a string array from state or an event, without the logging of AsStringArray since
+// arrays of objects are expected here
+func stringArray(v interface{}) ([]string, bool) {
+	if strs, ok := v.([]string); ok {
+		return strs, true
+	}
+	arr, ok := v.([]interface{})
+	if !ok {
+		return make([]string, 0), false
+	}
+	var strs = make([]string, 0, len(arr))
+	for _, e := range arr {
+		s, ok := e.(string)
+		if !ok {
+			return make([]string, 0), false
+		}
+		strs = append(strs, s)
+	}
+	return strs, true
+}
+
+// the value of an object's key as JSON so that keys of any type compare
+func keyOf(e interface{}, key string) (string, bool) {
+	m, ok := e.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	v, found := GetObject(&m, key)
+	if !found {
+		return "", false
+	}
+	kbytes, err := json.Marshal(v)
+	if err != nil {
+		return "", false
+	}
+	return string(kbytes), true
+}
+
+// MergeStrategyOut is one registered strategy in the output of readMergeStrategies
+type MergeStrategyOut struct {
+	Class    string        `json:"class"`
+	QProp    string        `json:"qprop"`
+	Strategy MergeStrategy `json:"strategy"`
+}
+
+// readMergeStrategies lists the array merge strategies of all classes
+var readMergeStrategies ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	var out = make([]MergeStrategyOut, 0)
+	routerLock.RLock()
+	defer routerLock.RUnlock()
+	for class, strategies := range mergerouter {
+		for qprop, strategy := range strategies {
+			out = append(out, MergeStrategyOut{class.Name, qprop, strategy})
+		}
+	}
+	sort.Sort(mergeStrategyOutArray(out))
+	return json.Marshal(out)
+}
+
+type mergeStrategyOutArray []MergeStrategyOut
+
+func (ma mergeStrategyOutArray) Len() int      { return len(ma) }
+func (ma mergeStrategyOutArray) Swap(i, j int) { ma[i], ma[j] = ma[j], ma[i] }
+func (ma mergeStrategyOutArray) Less(i, j int) bool {
+	if ma[i].Class != ma[j].Class {
+		return ma[i].Class < ma[j].Class
+	}
+	return ma[i].QProp < ma[j].QProp
+}
+
+func init() {
+	AddRoute("readMergeStrategies", "query", SystemClass, readMergeStrategies)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package iotcontractplatform
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func mergeMaps(t *testing.T, event string, state string, strategies map[string]MergeStrategy) string {
+	var src, dst map[string]interface{}
+	if err := json.Unmarshal([]byte(event), &src); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(state), &dst); err != nil {
+		t.Fatal(err)
+	}
+	merged, err := json.Marshal(DeepMergeMapWith(src, dst, strategies))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(merged)
+}
+
+func TestMergeStrategies(t *testing.T) {
+	var state = `{"tank":{"tags":["b","a"],"appdata":[{"K":"owner","V":"A"},{"K":"site","V":"X"}],"log":[1,2,3]}}`
+	var cases = []struct {
+		event      string
+		strategies map[string]MergeStrategy
+		want       string
+	}{
+		// defaults: string arrays are a union, other arrays are replaced
+		{`{"tank":{"tags":["c"],"log":[4]}}`, nil,
+			`{"tank":{"appdata":[{"K":"owner","V":"A"},{"K":"site","V":"X"}],"log":[4],"tags":["a","b","c"]}}`},
+		{`{"tank":{"tags":["c"],"new":[{"x":1}]}}`, map[string]MergeStrategy{"tank.tags": {Kind: MergeReplace}},
+			`{"tank":{"appdata":[{"K":"owner","V":"A"},{"K":"site","V":"X"}],"log":[1,2,3],"new":[{"x":1}],"tags":["c"]}}`},
+		{`{"tank":{"log":[4,5]}}`, map[string]MergeStrategy{"tank.log": {Kind: MergeAppend}},
+			`{"tank":{"appdata":[{"K":"owner","V":"A"},{"K":"site","V":"X"}],"log":[1,2,3,4,5],"tags":["b","a"]}}`},
+		{`{"tank":{"log":[4,5]}}`, map[string]MergeStrategy{"tank.log": {Kind: MergeBoundedAppend, Limit: 3}},
+			`{"tank":{"appdata":[{"K":"owner","V":"A"},{"K":"site","V":"X"}],"log":[3,4,5],"tags":["b","a"]}}`},
+		{`{"tank":{"appdata":[{"K":"site","V":"Y"},{"K":"route","V":"R1"}]}}`, map[string]MergeStrategy{"tank.appdata": {Kind: MergeUnionByKey, Key: "K"}},
+			`{"tank":{"appdata":[{"K":"owner","V":"A"},{"K":"site","V":"Y"},{"K":"route","V":"R1"}],"log":[1,2,3],"tags":["b","a"]}}`},
+	}
+	for _, c := range cases {
+		if got := mergeMaps(t, c.event, state, c.strategies); got != c.want {
+			t.Errorf("merging %s gives %s, expected %s", c.event, got, c.want)
+		}
+	}
+}
+
+func TestCheckMergeStrategy(t *testing.T) {
+	var class = AssetClass{"MergeTank", "MTNK", "tank.id"}
+	if err := AddMergeStrategy(class, "tank.appdata", MergeStrategy{Kind: MergeUnionByKey, Key: "K"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddMergeStrategy(class, "tank.appdata", MergeStrategy{Kind: MergeReplace}); err == nil {
+		t.Fatal("strategy registered twice")
+	}
+	for qprop, bad := range map[string]MergeStrategy{
+		"tank.a": {Kind: MergeUnionByKey},
+		"tank.b": {Kind: MergeBoundedAppend},
+		"tank.c": {Kind: "shuffle"},
+		"tank.":  {Kind: MergeAppend},
+	} {
+		if err := AddMergeStrategy(class, qprop, bad); err == nil {
+			t.Errorf("strategy %+v for %s accepted", bad, qprop)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- contract wide usage counters and a health check
+
+package iotcontractplatform
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// CONTRACTMETRICSKEY is the key prefix of the contract's usage counters. Each counter has
+// its own key, CONTRACTMETRICSKEY.group.name, e.g. IOTCP:ContractMetrics.assets.Container,
+// so that transactions only write the counters of the routes and classes they touch.
+const CONTRACTMETRICSKEY string = "IOTCP:ContractMetrics"
+
+// ContractMetrics are counters kept in world state. They are updated at the end of
+// every successful deploy and invoke, with the changes collected during the transaction.
+// Errors is not kept in world state, see ContractMetricsOut.
+type ContractMetrics struct {
+	Invokes map[string]int `json:"invokes"` // successful transactions by function
+	Assets  map[string]int `json:"assets"`  // assets by class
+	Alerts  map[string]int `json:"alerts"`  // assets with the alert active by alert name
+	History map[string]int `json:"history"` // history records by class
+	Errors  map[string]int `json:"errors"`  // failed transactions by function
+}
+
+// ContractMetricsOut is the output of readContractMetrics. A failed transaction cannot
+// write world state and no other transaction may write what one peer remembers of it, so
+// errors holds the failed transactions that the peer answering the query has seen since
+// its chaincode started, and differs from peer to peer.
+type ContractMetricsOut ContractMetrics
+
+// metric groups
+const (
+	metricInvokes = "invokes"
+	metricAssets  = "assets"
+	metricAlerts  = "alerts"
+	metricHistory = "history"
+)
+
+// changes collected by each running transaction, by transaction ID, and the errors of
+// failed transactions that this peer has seen, by function
+var pendingMetrics = struct {
+	sync.Mutex
+	changes map[string]map[string]map[string]int
+	errors  map[string]int
+}{changes: make(map[string]map[string]map[string]int), errors: make(map[string]int)}
+
+func newContractMetrics() ContractMetrics {
+	return ContractMetrics{make(map[string]int), make(map[string]int), make(map[string]int), make(map[string]int), make(map[string]int)}
+}
+
+// the counters of each group by group name
+func (m ContractMetrics) groups() map[string]map[string]int {
+	return map[string]map[string]int{
+		metricInvokes: m.Invokes,
+		metricAssets:  m.Assets,
+		metricAlerts:  m.Alerts,
+		metricHistory: m.History,
+	}
+}
+
+func metricKey(group string, name string) string {
+	return CONTRACTMETRICSKEY + "." + group + "." + name
+}
+
+// GETContractMetricsFromLedger returns the contract's counters, which are empty before
+// the first transaction
+func GETContractMetricsFromLedger(stub shim.ChaincodeStubInterface) (ContractMetrics, error) {
+	log := logFor(stub)
+	var metrics = newContractMetrics()
+	var groups = metrics.groups()
+	prefix := CONTRACTMETRICSKEY + "."
+	iter, err := stub.RangeQueryState(prefix, prefix+"}")
+	if err != nil {
+		err = fmt.Errorf("GETContractMetricsFromLedger failed to get a range query iterator: %s", err)
+		log.Error(err)
+		return metrics, err
+	}
+	defer iter.Close()
+	for iter.HasNext() {
+		key, countBytes, err := iter.Next()
+		if err != nil {
+			err = fmt.Errorf("GETContractMetricsFromLedger iter.Next() failed: %s", err)
+			log.Error(err)
+			return newContractMetrics(), err
+		}
+		parts := strings.SplitN(strings.TrimPrefix(key, prefix), ".", 2)
+		m, found := groups[parts[0]]
+		if !strings.HasPrefix(key, prefix) || !found || len(parts) != 2 {
+			continue
+		}
+		var count int
+		err = json.Unmarshal(countBytes, &count)
+		if err != nil {
+			err = fmt.Errorf("GETContractMetricsFromLedger unmarshal of %s failed: %s", key, err)
+			log.Error(err)
+			return newContractMetrics(), err
+		}
+		m[parts[1]] = count
+	}
+	return metrics, nil
+}
+
+// adds to one counter in world state, a counter that reaches zero is removed
+func addMetricToLedger(stub shim.ChaincodeStubInterface, group string, name string, delta int) error {
+	log := logFor(stub)
+	key := metricKey(group, name)
+	countBytes, err := stub.GetState(key)
+	if err != nil {
+		err = fmt.Errorf("addMetricToLedger failed GETSTATE %s: %s", key, err)
+		log.Error(err)
+		return err
+	}
+	var count int
+	if len(countBytes) > 0 {
+		err = json.Unmarshal(countBytes, &count)
+		if err != nil {
+			err = fmt.Errorf("addMetricToLedger unmarshal of %s failed: %s", key, err)
+			log.Error(err)
+			return err
+		}
+	}
+	count += delta
+	if count <= 0 {
+		err = stub.DelState(key)
+	} else {
+		err = stub.PutState(key, []byte(strconv.Itoa(count)))
+	}
+	if err != nil {
+		err = fmt.Errorf("addMetricToLedger failed to write %s: %s", key, err)
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// countMetric collects a change to a counter for the end of the transaction
+func countMetric(stub shim.ChaincodeStubInterface, group string, name string, delta int) {
+	txid := stub.GetTxID()
+	pendingMetrics.Lock()
+	defer pendingMetrics.Unlock()
+	if _, found := pendingMetrics.changes[txid]; !found {
+		pendingMetrics.changes[txid] = make(map[string]map[string]int)
+	}
+	if _, found := pendingMetrics.changes[txid][group]; !found {
+		pendingMetrics.changes[txid][group] = make(map[string]int)
+	}
+	pendingMetrics.changes[txid][group][name] += delta
+}
+
+// counts the alerts raised and cleared by a write of the asset
+func (a *Asset) countAlerts(stub shim.ChaincodeStubInterface, alertsIn AlertNameArray) {
+	for _, alert := range a.AlertsActive {
+		if !Contains(alertsIn, alert) {
+			countMetric(stub, metricAlerts, string(alert), 1)
+		}
+	}
+	for _, alert := range alertsIn {
+		if !Contains(a.AlertsActive, alert) {
+			countMetric(stub, metricAlerts, string(alert), -1)
+		}
+	}
+}
+
+// applies the changes collected by a successful transaction and its invoke to the
+// counters in world state
+func commitMetrics(stub shim.ChaincodeStubInterface, function string) error {
+	countMetric(stub, metricInvokes, function, 1)
+	txid := stub.GetTxID()
+	pendingMetrics.Lock()
+	changes := pendingMetrics.changes[txid]
+	delete(pendingMetrics.changes, txid)
+	pendingMetrics.Unlock()
+	for group, counts := range changes {
+		for name, delta := range counts {
+			if delta == 0 {
+				continue
+			}
+			if err := addMetricToLedger(stub, group, name, delta); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// drops the changes collected by a failed transaction and counts its error in this
+// peer's memory
+func discardMetrics(stub shim.ChaincodeStubInterface, function string) {
+	pendingMetrics.Lock()
+	defer pendingMetrics.Unlock()
+	delete(pendingMetrics.changes, stub.GetTxID())
+	pendingMetrics.errors[function]++
+}
+
+// readContractMetrics returns the usage counters of the contract and the errors seen by
+// this peer
+var readContractMetrics ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	metrics, err := GETContractMetricsFromLedger(stub)
+	if err != nil {
+		return nil, err
+	}
+	pendingMetrics.Lock()
+	for function, count := range pendingMetrics.errors {
+		metrics.Errors[function] += count
+	}
+	pendingMetrics.Unlock()
+	return json.Marshal(ContractMetricsOut(metrics))
+}
+
+// HealthCheck is the outcome of one check made by readContractHealth
+type HealthCheck struct {
+	Name    string   `json:"name"`
+	OK      bool     `json:"ok"`
+	Details []string `json:"details,omitempty"`
+}
+
+// ContractHealthOut is the output of readContractHealth
+type ContractHealthOut struct {
+	Healthy bool          `json:"healthy"`
+	Checks  []HealthCheck `json:"checks"`
+}
+
+func checkContractState(stub shim.ChaincodeStubInterface) HealthCheck {
+	var check = HealthCheck{Name: "contractState", OK: true}
+	state, err := GETContractStateFromLedger(stub)
+	if err != nil {
+		check.OK = false
+		check.Details = append(check.Details, err.Error())
+		return check
+	}
+	if state.Version == "" {
+		check.OK = false
+		check.Details = append(check.Details, "contract state has no version")
+	}
+	return check
+}
+
+// every recent state must be an existing asset of its class, at most once and within the
+// configured capacity
+func checkRecentStates(stub shim.ChaincodeStubInterface) HealthCheck {
+	var check = HealthCheck{Name: "recentStates", OK: true}
+	var problem = func(format string, args ...interface{}) {
+		check.OK = false
+		check.Details = append(check.Details, fmt.Sprintf(format, args...))
+	}
+	config, err := GETRecentStatesConfigFromLedger(stub)
+	if err != nil {
+		problem("recent states configuration: %s", err)
+	}
+	classes := routedClasses()
+	var names = make([]string, 0, len(classes))
+	for name, class := range classes {
+		if class != SystemClass {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		class := classes[name]
+		rstates, err := GETRecentStatesFromLedger(stub, name)
+		if err != nil {
+			problem("class %s recent states: %s", name, err)
+			continue
+		}
+		if len(rstates.States) > config.ClassCapacity(name) {
+			problem("class %s has %d recent states, capacity is %d", name, len(rstates.States), config.ClassCapacity(name))
+		}
+		var seen = make(map[string]bool)
+		for _, key := range rstates.States {
+			if seen[key] {
+				problem("class %s recent state %s appears more than once", name, key)
+			}
+			seen[key] = true
+			_, exists, err := class.getAssetFromWorldState(stub, key)
+			if err != nil || !exists {
+				problem("class %s recent state %s is not an asset of the class", name, key)
+			}
+		}
+	}
+	return check
+}
+
+// readContractHealth checks that the contract state is present and that the recent
+// states of every class are consistent with world state
+var readContractHealth ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	var out = ContractHealthOut{true, []HealthCheck{checkContractState(stub), checkRecentStates(stub)}}
+	for _, check := range out.Checks {
+		out.Healthy = out.Healthy && check.OK
+	}
+	return json.Marshal(out)
+}
+
+func init() {
+	AddRoute("readContractMetrics", "query", SystemClass, readContractMetrics)
+	AddRoute("readContractHealth", "query", SystemClass, readContractHealth)
+}
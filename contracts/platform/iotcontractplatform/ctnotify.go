@@ -0,0 +1,105 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- typed notifications queued by an invoke and emitted in its result event
+
+package iotcontractplatform
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpevents"
+)
+
+// notifications queued by each running invoke, by transaction ID, the shim runs
+// messages concurrently
+var pendingNotifications = struct {
+	sync.Mutex
+	queued map[string][]iotcpevents.Notification
+}{queued: make(map[string][]iotcpevents.Notification)}
+
+// Notify queues a notification that is not about an asset for the result event of the
+// current invoke. Notifications are dropped when the invoke fails.
+func Notify(stub shim.ChaincodeStubInterface, t iotcpevents.Type, data interface{}) error {
+	log := logFor(stub)
+	n, err := iotcpevents.NewNotification(t, data)
+	if err != nil {
+		err = fmt.Errorf("Notify failed: %s", err)
+		log.Error(err)
+		return err
+	}
+	queueNotification(stub, n)
+	return nil
+}
+
+// Notify queues a notification about an asset for the result event of the current invoke,
+// e.g. from a rule
+func (a *Asset) Notify(stub shim.ChaincodeStubInterface, t iotcpevents.Type, data interface{}) error {
+	log := logFor(stub)
+	n, err := iotcpevents.NewNotification(t, data)
+	if err != nil {
+		err = fmt.Errorf("Notify for class %s asset %s failed: %s", a.Class.Name, a.AssetKey, err)
+		log.Error(err)
+		return err
+	}
+	n.Class = a.Class.Name
+	n.AssetKey = a.AssetKey
+	n.AssetID = strings.TrimPrefix(a.AssetKey, a.Class.Prefix)
+	n.TXNTS = a.TXNTS
+	queueNotification(stub, n)
+	return nil
+}
+
+func queueNotification(stub shim.ChaincodeStubInterface, n iotcpevents.Notification) {
+	log := logFor(stub)
+	txid := stub.GetTxID()
+	pendingNotifications.Lock()
+	pendingNotifications.queued[txid] = append(pendingNotifications.queued[txid], n)
+	pendingNotifications.Unlock()
+	log.Debugf("Notification %s queued for %s in %s", n.Type, n.AssetKey, txid)
+}
+
+// notifies the alerts raised and cleared by a write of the asset
+func (a *Asset) notifyAlerts(stub shim.ChaincodeStubInterface, alertsIn AlertNameArray) error {
+	for _, alert := range a.AlertsActive {
+		if !Contains(alertsIn, alert) {
+			if err := a.Notify(stub, iotcpevents.AlertRaised, iotcpevents.AlertData{Alert: string(alert)}); err != nil {
+				return err
+			}
+		}
+	}
+	for _, alert := range alertsIn {
+		if !Contains(a.AlertsActive, alert) {
+			if err := a.Notify(stub, iotcpevents.AlertCleared, iotcpevents.AlertData{Alert: string(alert)}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// removes and returns the notifications queued by the current invoke
+func takeNotifications(stub shim.ChaincodeStubInterface) []iotcpevents.Notification {
+	txid := stub.GetTxID()
+	pendingNotifications.Lock()
+	defer pendingNotifications.Unlock()
+	notifications := pendingNotifications.queued[txid]
+	delete(pendingNotifications.queued, txid)
+	return notifications
+}
@@ -0,0 +1,57 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package iotcontractplatform
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpevents"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpstub"
+)
+
+func TestNotificationsAreQueuedPerTransaction(t *testing.T) {
+	var wg sync.WaitGroup
+	var failures = make(chan string, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			stub := iotcpstub.NewStub(fmt.Sprintf("notify%d", i))
+			for n := 0; n < 100; n++ {
+				stub.Begin(true)
+				for k := 0; k <= i; k++ {
+					if err := Notify(stub, iotcpevents.AlertRaised, iotcpevents.AlertData{Alert: "A"}); err != nil {
+						failures <- err.Error()
+						return
+					}
+				}
+				if got := len(takeNotifications(stub)); got != i+1 {
+					failures <- fmt.Sprintf("transaction %s took %d notifications, expected %d", stub.TxID, got, i+1)
+					return
+				}
+				stub.End(true)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(failures)
+	for f := range failures {
+		t.Error(f)
+	}
+}
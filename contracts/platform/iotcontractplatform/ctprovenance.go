@@ -0,0 +1,297 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- the transaction, function and device that last wrote each property
+
+package iotcontractplatform
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// PROVENANCEKEY is prepended to the asset key to store an asset's provenance
+const PROVENANCEKEY string = "IOTCP.PROV." // + assetKey
+
+// ProvenanceOptions selects the properties whose provenance is tracked for a class.
+// QProps may name objects such as "surgicalkit.sensors", in which case any write below
+// the object is recorded against it. When QProps is empty, every property written by
+// an event is tracked by its full qualified name.
+type ProvenanceOptions struct {
+	QProps []string `json:"qprops,omitempty"`
+}
+
+// PropertyProvenance records the transaction that last wrote a property
+type PropertyProvenance struct {
+	QProp    string     `json:"qprop"`
+	TXNID    string     `json:"txnid"`
+	TXNTS    *time.Time `json:"txnts"`
+	Function string     `json:"function"`
+	DeviceID string     `json:"deviceID,omitempty"`
+}
+
+// AssetProvenance is stored in world state by qualified property name
+type AssetProvenance map[string]PropertyProvenance
+
+// PropertyProvenanceArray is sorted by qualified property name
+type PropertyProvenanceArray []PropertyProvenance
+
+func (pa PropertyProvenanceArray) Len() int           { return len(pa) }
+func (pa PropertyProvenanceArray) Swap(i, j int)      { pa[i], pa[j] = pa[j], pa[i] }
+func (pa PropertyProvenanceArray) Less(i, j int) bool { return pa[i].QProp < pa[j].QProp }
+
+var provenancerouter = make(map[AssetClass]ProvenanceOptions, 0)
+
+// TrackProvenance turns on provenance tracking for a class
+func TrackProvenance(class AssetClass, options ProvenanceOptions) error {
+	routerLock.Lock()
+	defer routerLock.Unlock()
+	if _, found := provenancerouter[class]; found {
+		err := fmt.Errorf("TrackProvenance: class %s is already tracked", class.Name)
+		log.Error(err)
+		return err
+	}
+	if err := checkProvenanceOptions(options); err != nil {
+		err = fmt.Errorf("TrackProvenance: class %s %s", class.Name, err)
+		log.Error(err)
+		return err
+	}
+	provenancerouter[class] = options
+	log.Debugf("Class %s tracks provenance of %v", class.Name, options.QProps)
+	return nil
+}
+
+func checkProvenanceOptions(options ProvenanceOptions) error {
+	for _, qprop := range options.QProps {
+		if qprop == "" || strings.HasPrefix(qprop, ".") || strings.HasSuffix(qprop, ".") || strings.Contains(qprop, "..") {
+			return fmt.Errorf("has invalid qualified property '%s' in provenance options", qprop)
+		}
+	}
+	return nil
+}
+
+func provenanceKey(assetKey string) string {
+	return PROVENANCEKEY + assetKey
+}
+
+// GETAssetProvenanceFromLedger returns the provenance of an asset, which is empty when the
+// asset's class is not tracked
+func GETAssetProvenanceFromLedger(stub shim.ChaincodeStubInterface, assetKey string) (AssetProvenance, error) {
+	log := logFor(stub)
+	var prov = make(AssetProvenance)
+	provBytes, err := stub.GetState(provenanceKey(assetKey))
+	if err != nil {
+		err = fmt.Errorf("GETAssetProvenanceFromLedger failed GETSTATE for %s: %s", assetKey, err)
+		log.Error(err)
+		return nil, err
+	}
+	if len(provBytes) == 0 {
+		return prov, nil
+	}
+	err = json.Unmarshal(provBytes, &prov)
+	if err != nil {
+		err = fmt.Errorf("GETAssetProvenanceFromLedger unmarshal failed for %s: %s", assetKey, err)
+		log.Error(err)
+		return nil, err
+	}
+	return prov, nil
+}
+
+// PUTAssetProvenanceToLedger marshals and writes the provenance of an asset
+func PUTAssetProvenanceToLedger(stub shim.ChaincodeStubInterface, assetKey string, prov AssetProvenance) error {
+	log := logFor(stub)
+	provBytes, err := json.Marshal(prov)
+	if err != nil {
+		err = fmt.Errorf("PUTAssetProvenanceToLedger marshal failed for %s: %s", assetKey, err)
+		log.Error(err)
+		return err
+	}
+	err = stub.PutState(provenanceKey(assetKey), provBytes)
+	if err != nil {
+		err = fmt.Errorf("PUTAssetProvenanceToLedger failed PUTSTATE for %s: %s", assetKey, err)
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// collects the qualified names of the leaves of an event, where readings with units
+// and arrays are leaves
+func eventLeaves(class AssetClass, prefix string, obj map[string]interface{}, leaves []string) []string {
+	for k, v := range obj {
+		qprop := k
+		if prefix != "" {
+			qprop = prefix + "." + k
+		}
+		m, isMap := v.(map[string]interface{})
+		if _, isReading := unitrouter[class][qprop]; isMap && !isReading && len(m) > 0 {
+			leaves = eventLeaves(class, qprop, m, leaves)
+			continue
+		}
+		leaves = append(leaves, qprop)
+	}
+	return leaves
+}
+
+// the tracked property that a written property is recorded against
+func trackedQProp(options ProvenanceOptions, qprop string) (string, bool) {
+	if len(options.QProps) == 0 {
+		return qprop, true
+	}
+	for _, t := range options.QProps {
+		if qprop == t || strings.HasPrefix(qprop, t+".") {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+// the qualified properties written by the incoming event
+func (a *Asset) eventProperties() []string {
+	return eventLeaves(a.Class, "", *a.EventIn, make([]string, 0))
+}
+
+// the device that sent the event, from the common properties under the asset's root
+func (a *Asset) eventDeviceID() string {
+	root := strings.Split(a.Class.AssetIDPath, ".")[0]
+	deviceID, _ := GetObjectAsString(a.EventIn, root+".common.deviceID")
+	return deviceID
+}
+
+// updateProvenance records the current transaction against every tracked property that
+// is written and forgets the removed properties. A created or replaced asset starts with
+// fresh provenance.
+func (a *Asset) updateProvenance(stub shim.ChaincodeStubInterface, caller string, replace bool, written []string, removed []string) error {
+	routerLock.RLock()
+	options, found := provenancerouter[a.Class]
+	routerLock.RUnlock()
+	if !found {
+		return nil
+	}
+	var prov = make(AssetProvenance)
+	if !replace {
+		var err error
+		prov, err = GETAssetProvenanceFromLedger(stub, a.AssetKey)
+		if err != nil {
+			return err
+		}
+	}
+	for _, r := range removed {
+		for qprop := range prov {
+			if qprop == r || strings.HasPrefix(qprop, r+".") {
+				delete(prov, qprop)
+			}
+		}
+		// removing part of a tracked object is a write to the object
+		if t, found := trackedQProp(options, r); found && t != r {
+			written = append(written, r)
+		}
+	}
+	var deviceID = a.eventDeviceID()
+	for _, w := range written {
+		if w == a.Class.AssetIDPath && !replace {
+			continue
+		}
+		t, found := trackedQProp(options, w)
+		if !found {
+			continue
+		}
+		prov[t] = PropertyProvenance{t, a.TXNID, a.TXNTS, caller, deviceID}
+	}
+	return PUTAssetProvenanceToLedger(stub, a.AssetKey, prov)
+}
+
+// removes the provenance of a deleted asset
+func deleteAssetProvenance(stub shim.ChaincodeStubInterface, assetKey string) error {
+	log := logFor(stub)
+	err := stub.DelState(provenanceKey(assetKey))
+	if err != nil {
+		err = fmt.Errorf("deleteAssetProvenance failed DELSTATE for %s: %s", assetKey, err)
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// ProvenanceArg selects an asset by key, or by class name and asset ID, and optionally
+// a qualified property and everything below it
+type ProvenanceArg struct {
+	AssetKey string `json:"assetkey,omitempty"`
+	Class    string `json:"class,omitempty"`
+	AssetID  string `json:"assetID,omitempty"`
+	QProp    string `json:"qprop,omitempty"`
+}
+
+// ProvenanceOut is the output of readAssetProvenance
+type ProvenanceOut struct {
+	AssetKey   string                  `json:"assetkey"`
+	Properties PropertyProvenanceArray `json:"properties"`
+}
+
+// readAssetProvenance returns the transaction, function and device that last wrote each
+// tracked property of an asset
+var readAssetProvenance ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	var arg ProvenanceArg
+	var err error
+	if len(args) != 1 {
+		err = errors.New("readAssetProvenance expects one argument, a JSON object with assetkey or class and assetID")
+		log.Error(err)
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(args[0]), &arg)
+	if err != nil {
+		err = fmt.Errorf("readAssetProvenance failed to unmarshal arg: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	if arg.AssetKey == "" {
+		class, found := routedClasses()[arg.Class]
+		if !found || arg.AssetID == "" {
+			err = fmt.Errorf("readAssetProvenance needs an assetkey or a known class and an assetID, got %+v", arg)
+			log.Error(err)
+			return nil, err
+		}
+		arg.AssetKey = class.Prefix + arg.AssetID
+	}
+	assetBytes, err := stub.GetState(arg.AssetKey)
+	if err != nil || len(assetBytes) == 0 {
+		err = fmt.Errorf("readAssetProvenance asset %s does not exist", arg.AssetKey)
+		log.Error(err)
+		return nil, err
+	}
+	prov, err := GETAssetProvenanceFromLedger(stub, arg.AssetKey)
+	if err != nil {
+		return nil, err
+	}
+	var out = ProvenanceOut{arg.AssetKey, make(PropertyProvenanceArray, 0, len(prov))}
+	for qprop, p := range prov {
+		if arg.QProp == "" || qprop == arg.QProp || strings.HasPrefix(qprop, arg.QProp+".") {
+			out.Properties = append(out.Properties, p)
+		}
+	}
+	sort.Sort(out.Properties)
+	return json.Marshal(out)
+}
+
+func init() {
+	AddRoute("readAssetProvenance", "query", SystemClass, readAssetProvenance)
+}
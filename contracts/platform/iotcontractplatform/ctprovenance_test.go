@@ -0,0 +1,67 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package iotcontractplatform
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestEventLeaves(t *testing.T) {
+	var class = AssetClass{"ProvTank", "PTNK", "tank.id"}
+	if err := AddUnitProperty(class, "tank.temperature", "C"); err != nil {
+		t.Fatal(err)
+	}
+	var event map[string]interface{}
+	err := json.Unmarshal([]byte(`{"tank":{"id":"T1","temperature":{"value":50,"unit":"F"},"sensors":{"level":3,"tags":["a"]},"empty":{}}}`), &event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaves := eventLeaves(class, "", event, make([]string, 0))
+	sort.Strings(leaves)
+	want := []string{"tank.empty", "tank.id", "tank.sensors.level", "tank.sensors.tags", "tank.temperature"}
+	if !reflect.DeepEqual(leaves, want) {
+		t.Fatalf("leaves are %v, expected %v", leaves, want)
+	}
+}
+
+func TestTrackedQProp(t *testing.T) {
+	var options = ProvenanceOptions{QProps: []string{"tank.sensors", "tank.status"}}
+	var cases = map[string]string{
+		"tank.sensors.level": "tank.sensors",
+		"tank.sensors":       "tank.sensors",
+		"tank.status":        "tank.status",
+		"tank.statusText":    "",
+		"tank.id":            "",
+	}
+	for qprop, want := range cases {
+		got, found := trackedQProp(options, qprop)
+		if got != want || found != (want != "") {
+			t.Errorf("%s is tracked as '%s' (%t), expected '%s'", qprop, got, found, want)
+		}
+	}
+	if got, found := trackedQProp(ProvenanceOptions{}, "tank.id"); !found || got != "tank.id" {
+		t.Errorf("all properties should be tracked without options")
+	}
+	for _, bad := range []string{"", ".tank", "tank.", "tank..level"} {
+		if err := checkProvenanceOptions(ProvenanceOptions{QProps: []string{bad}}); err == nil {
+			t.Errorf("invalid qprop '%s' accepted", bad)
+		}
+	}
+}
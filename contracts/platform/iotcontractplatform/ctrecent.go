@@ -18,12 +18,18 @@ Kim Letkeman - Initial Contribution
 // v0.2 KL -- dramatic reduction in memory and disk space by storing only the keys
 //            and reading the states only when queried, raised limit to 100, added
 //            range to query
+// v0.3 KL -- one recent states list per asset class with capacity configurable in
+//            world state, a write is skipped when the asset is already the most recent,
+//            the single list of earlier releases is migrated on deploy
 
 package iotcontractplatform
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 )
@@ -38,12 +44,26 @@ import (
 // This module stores in memory only, as recent states make no sense after
 // a begin.
 
-// RECENTSTATESKEY is used as key for recent states bucket
+// RECENTSTATESKEY is used as key prefix for the recent states bucket of each class,
+// the class name is appended after a period
 const RECENTSTATESKEY string = "IOTCP.RecentStates"
 
-// MaxRecentStates is an arbitrary limit on how many asset states we track across the
-// entire contract
-const MaxRecentStates int = 40
+// RECENTSTATESCONFIGKEY stores the configured recent states capacities
+const RECENTSTATESCONFIGKEY string = "IOTCP:RecentStatesConfig"
+
+// DefaultRecentStatesCapacity is how many asset states we track per class when no
+// capacity has been configured
+const DefaultRecentStatesCapacity int = 40
+
+// MaxRecentStates was the limit on how many asset states we tracked across the
+// entire contract.
+//
+// Deprecated: the capacity is now per class and configurable, MaxRecentStates is
+// the default, use DefaultRecentStatesCapacity.
+const MaxRecentStates = DefaultRecentStatesCapacity
+
+// MaxRecentStatesCapacity is an arbitrary upper limit on a configured capacity
+const MaxRecentStatesCapacity int = 500
 
 // RecentStates in world state
 type RecentStates struct {
@@ -53,11 +73,62 @@ type RecentStates struct {
 // RecentStatesOut is query output format
 type RecentStatesOut AssetArray
 
-// GETRecentStatesFromLedger returns the unmarshaled recent states
-func GETRecentStatesFromLedger(stub shim.ChaincodeStubInterface) (RecentStates, error) {
-	var rstates = RecentStates{make([]string, 0, MaxRecentStates+1)}
+// RecentStatesConfig holds the default capacity and optional per class capacities
+type RecentStatesConfig struct {
+	Capacity int            `json:"capacity"`
+	Classes  map[string]int `json:"classes,omitempty"`
+}
+
+// RecentStatesCapacityArg is the argument to setRecentStatesCapacity, a blank class
+// sets the default capacity for all classes without their own setting
+type RecentStatesCapacityArg struct {
+	Class    string `json:"class"`
+	Capacity int    `json:"capacity"`
+}
+
+func recentStatesKey(className string) string {
+	return RECENTSTATESKEY + "." + className
+}
+
+// GETRecentStatesConfigFromLedger returns the configured capacities, or the defaults
+func GETRecentStatesConfigFromLedger(stub shim.ChaincodeStubInterface) (RecentStatesConfig, error) {
+	log := logFor(stub)
+	var config = RecentStatesConfig{DefaultRecentStatesCapacity, nil}
+	configBytes, err := stub.GetState(RECENTSTATESCONFIGKEY)
+	if err != nil {
+		err = fmt.Errorf("Failed to get recent states config from world state: %s", err)
+		log.Errorf(err.Error())
+		return config, err
+	}
+	if len(configBytes) == 0 {
+		return config, nil
+	}
+	err = json.Unmarshal(configBytes, &config)
+	if err != nil {
+		err = fmt.Errorf("Failed to unmarshal recent states config: %s", err)
+		log.Errorf(err.Error())
+		return RecentStatesConfig{DefaultRecentStatesCapacity, nil}, err
+	}
+	return config, nil
+}
+
+// ClassCapacity returns the configured capacity for a class
+func (config RecentStatesConfig) ClassCapacity(className string) int {
+	if c, found := config.Classes[className]; found && c > 0 {
+		return c
+	}
+	if config.Capacity > 0 {
+		return config.Capacity
+	}
+	return DefaultRecentStatesCapacity
+}
+
+// GETRecentStatesFromLedger returns the unmarshaled recent states for a class
+func GETRecentStatesFromLedger(stub shim.ChaincodeStubInterface, className string) (RecentStates, error) {
+	log := logFor(stub)
+	var rstates = RecentStates{make([]string, 0)}
 	var err error
-	recentStatesBytes, err := stub.GetState(RECENTSTATESKEY)
+	recentStatesBytes, err := stub.GetState(recentStatesKey(className))
 	if err != nil {
 		err = fmt.Errorf("Failed to get recent states from world state: %s", err)
 		log.Errorf(err.Error())
@@ -65,12 +136,13 @@ func GETRecentStatesFromLedger(stub shim.ChaincodeStubInterface) (RecentStates,
 	}
 	// this MUST be here
 	if recentStatesBytes == nil || len(recentStatesBytes) == 0 {
-		log.Debugf("GETRecentStatesFromLedger: returning empty recent states")
+		log.Debugf("GETRecentStatesFromLedger: returning empty recent states for class %s", className)
 		return rstates, nil
 	}
 	err = json.Unmarshal(recentStatesBytes, &rstates)
 	if err != nil {
-		err = PUTRecentStatesToLedger(stub, rstates)
+		rstates = RecentStates{make([]string, 0)}
+		err = PUTRecentStatesToLedger(stub, className, rstates)
 		if err != nil {
 			err = fmt.Errorf("Failed to store empty recent states: %s", err)
 			log.Errorf(err.Error())
@@ -80,8 +152,9 @@ func GETRecentStatesFromLedger(stub shim.ChaincodeStubInterface) (RecentStates,
 	return rstates, nil
 }
 
-// PUTRecentStatesToLedger marshals and writes the recent states
-func PUTRecentStatesToLedger(stub shim.ChaincodeStubInterface, rstates RecentStates) error {
+// PUTRecentStatesToLedger marshals and writes the recent states for a class
+func PUTRecentStatesToLedger(stub shim.ChaincodeStubInterface, className string, rstates RecentStates) error {
+	log := logFor(stub)
 	var recentStatesJSON []byte
 	var err error
 	recentStatesJSON, err = json.Marshal(rstates)
@@ -89,7 +162,7 @@ func PUTRecentStatesToLedger(stub shim.ChaincodeStubInterface, rstates RecentSta
 		log.Criticalf("Failed to marshal recent states: %s", err)
 		return err
 	}
-	err = stub.PutState(RECENTSTATESKEY, recentStatesJSON)
+	err = stub.PutState(recentStatesKey(className), recentStatesJSON)
 	if err != nil {
 		log.Criticalf("Failed to PUTSTATE recent states: %s", err)
 		return err
@@ -97,22 +170,31 @@ func PUTRecentStatesToLedger(stub shim.ChaincodeStubInterface, rstates RecentSta
 	return nil
 }
 
-// ClearRecentStates resets recent states to an empty array
-func ClearRecentStates(stub shim.ChaincodeStubInterface) error {
-	var rstates RecentStates
-	rstates = RecentStates{make([]string, 0, MaxRecentStates)}
-	return PUTRecentStatesToLedger(stub, rstates)
+// ClearRecentStates resets recent states for a class to an empty array
+func ClearRecentStates(stub shim.ChaincodeStubInterface, className string) error {
+	return PUTRecentStatesToLedger(stub, className, RecentStates{make([]string, 0)})
 }
 
 // PushRecentState pushes the state to the first entry, or moves it to
 // the first entry if this asset already shows up
 func (a *Asset) PushRecentState(stub shim.ChaincodeStubInterface) error {
+	log := logFor(stub)
 	var err error
 
-	rstates, err := GETRecentStatesFromLedger(stub)
+	rstates, err := GETRecentStatesFromLedger(stub, a.Class.Name)
+	if err != nil {
+		return err
+	}
+	config, err := GETRecentStatesConfigFromLedger(stub)
 	if err != nil {
 		return err
 	}
+	capacity := config.ClassCapacity(a.Class.Name)
+
+	// a device streaming events is usually already at the front, so leave the key alone
+	if len(rstates.States) > 0 && len(rstates.States) <= capacity && rstates.States[0] == a.AssetKey {
+		return nil
+	}
 
 	// shift slice to the right
 	assetPosn := findAssetInRecent(a.AssetKey, rstates)
@@ -126,8 +208,11 @@ func (a *Asset) PushRecentState(stub shim.ChaincodeStubInterface) error {
 	}
 	// insert at the front
 	rstates.States[0] = a.AssetKey
+	if len(rstates.States) > capacity {
+		rstates.States = rstates.States[:capacity]
+	}
 	log.Debugf("pushRecentStates succeeded for asset %s", a.AssetKey)
-	return PUTRecentStatesToLedger(stub, rstates)
+	return PUTRecentStatesToLedger(stub, a.Class.Name, rstates)
 }
 
 // RemoveAssetFromRecentStates is called when an asset is deleted
@@ -135,15 +220,55 @@ func (a *Asset) RemoveAssetFromRecentStates(stub shim.ChaincodeStubInterface) er
 	var rstates RecentStates
 	var err error
 
-	rstates, err = GETRecentStatesFromLedger(stub)
+	rstates, err = GETRecentStatesFromLedger(stub, a.Class.Name)
 	if err != nil {
 		return err
 	}
 	posn := findAssetInRecent(a.AssetKey, rstates)
-	if posn >= 0 {
-		rstates.States = append(rstates.States[:posn], rstates.States[posn+1:]...)
+	if posn < 0 {
+		return nil
+	}
+	rstates.States = append(rstates.States[:posn], rstates.States[posn+1:]...)
+	return PUTRecentStatesToLedger(stub, a.Class.Name, rstates)
+}
+
+// migrateLegacyRecentStates moves the keys in the single recent states list of earlier
+// releases, stored under RECENTSTATESKEY itself, into the list of each asset's class,
+// keeping their order, and then deletes it. Assets that no longer exist are dropped.
+func migrateLegacyRecentStates(stub shim.ChaincodeStubInterface) error {
+	log := logFor(stub)
+	legacyBytes, err := stub.GetState(RECENTSTATESKEY)
+	if err != nil {
+		err = fmt.Errorf("Failed to get legacy recent states from world state: %s", err)
+		log.Errorf(err.Error())
+		return err
+	}
+	if len(legacyBytes) == 0 {
+		return nil
+	}
+	var legacy RecentStates
+	if err = json.Unmarshal(legacyBytes, &legacy); err != nil {
+		log.Warningf("migrateLegacyRecentStates: discarding legacy recent states that do not unmarshal: %s", err)
+		legacy.States = nil
+	}
+	// oldest first, so that the most recent ends up at the front of its class
+	for i := len(legacy.States) - 1; i >= 0; i-- {
+		a, exists, err := GetAssetFromLedger(stub, legacy.States[i])
+		if err != nil || !exists || a.Class.Name == "" {
+			log.Warningf("migrateLegacyRecentStates: dropping recent state %s", legacy.States[i])
+			continue
+		}
+		if err = a.PushRecentState(stub); err != nil {
+			return err
+		}
+	}
+	if err = stub.DelState(RECENTSTATESKEY); err != nil {
+		err = fmt.Errorf("Failed to delete legacy recent states: %s", err)
+		log.Errorf(err.Error())
+		return err
 	}
-	return PUTRecentStatesToLedger(stub, rstates)
+	log.Noticef("migrateLegacyRecentStates: moved %d legacy recent states to their classes", len(legacy.States))
+	return nil
 }
 
 func findAssetInRecent(assetID string, rstates RecentStates) int {
@@ -159,23 +284,72 @@ func findAssetInRecent(assetID string, rstates RecentStates) int {
 	return -1
 }
 
+// returns the recent asset states of one class in recency order, or of all
+// classes merged by transaction timestamp when the class name is blank
+func getRecentAssets(stub shim.ChaincodeStubInterface, className string) (RecentStatesOut, error) {
+	log := logFor(stub)
+	var keys = make([]string, 0)
+	if className != "" {
+		r, err := GETRecentStatesFromLedger(stub, className)
+		if err != nil {
+			return nil, err
+		}
+		keys = r.States
+	} else {
+		prefix := recentStatesKey("")
+		iter, err := stub.RangeQueryState(prefix, prefix+"}")
+		if err != nil {
+			err = fmt.Errorf("readRecentStates failed to get a range query iterator: %s", err)
+			log.Error(err)
+			return nil, err
+		}
+		defer iter.Close()
+		for iter.HasNext() {
+			key, rsBytes, err := iter.Next()
+			if err != nil {
+				err = fmt.Errorf("readRecentStates iter.Next() failed: %s", err)
+				log.Error(err)
+				return nil, err
+			}
+			if !strings.HasPrefix(key, prefix) {
+				continue
+			}
+			var r RecentStates
+			err = json.Unmarshal(rsBytes, &r)
+			if err != nil {
+				err = fmt.Errorf("readRecentStates failed to unmarshal recent states %s: %s", key, err)
+				log.Error(err)
+				return nil, err
+			}
+			keys = append(keys, r.States...)
+		}
+	}
+	var rstatesout = make(RecentStatesOut, 0, len(keys))
+	for _, key := range keys {
+		a, exists, err := GetAssetFromLedger(stub, key)
+		if err != nil {
+			err = fmt.Errorf("readRecentStates: failed to get asset from ledger: %s", err)
+			log.Errorf(err.Error())
+			return nil, err
+		}
+		if !exists {
+			// a dangling entry, which repairWorldState removes
+			log.Warningf("readRecentStates: recent asset state does not exist: %s", key)
+			continue
+		}
+		rstatesout = append(rstatesout, a)
+	}
+	if className == "" {
+		sort.Stable(sort.Reverse(ByTimestamp(rstatesout)))
+	}
+	return rstatesout, nil
+}
+
 // readRecentStates returns the marshaled recent states from the ledger
 var readRecentStates = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-	var begin, end, count int
+	var begin, end int
 	var found bool
-
-	var rstatesout = make(RecentStatesOut, 0, count)
-
-	r, err := GETRecentStatesFromLedger(stub)
-	if err != nil {
-		err = fmt.Errorf("readRecentStates: failed to get recent states from ledger: %s", err)
-		log.Error(err)
-		return nil, err
-	}
-
-	if len(r.States) == 0 {
-		return []byte("[]"), nil
-	}
+	var className string
 
 	if len(args) > 0 {
 		var arg map[string]interface{}
@@ -186,60 +360,101 @@ var readRecentStates = func(stub shim.ChaincodeStubInterface, args []string) ([]
 			log.Error(err)
 			return nil, err
 		}
+		className, _ = GetObjectAsString(&arg, "class")
 		begin, found = GetObjectAsInteger(&arg, "begin")
 		if !found {
 			begin = 0
-		} else {
-			if begin < 0 || begin > (MaxRecentStates-1) {
-				err := fmt.Errorf("readRecentStates: invalid begin argument %d, should be between 0 and %d inclusive", begin, MaxRecentStates-1)
-				log.Error(err)
-				return nil, err
-			}
+		} else if begin < 0 {
+			err := fmt.Errorf("readRecentStates: invalid begin argument %d, should be 0 or greater", begin)
+			log.Error(err)
+			return nil, err
 		}
 		end, found = GetObjectAsInteger(&arg, "end")
 		if !found {
-			end = MaxRecentStates - begin - 1
-		} else {
-			if end < begin {
-				err := fmt.Errorf("readRecentStates: invalid end argument %d, should be > begin arg (%d)", end, begin)
-				log.Error(err)
-				return nil, err
-			}
+			end = -1
+		} else if end < begin {
+			err := fmt.Errorf("readRecentStates: invalid end argument %d, should be > begin arg (%d)", end, begin)
+			log.Error(err)
+			return nil, err
 		}
 	} else {
 		begin = 0
-		end = MaxRecentStates - 1
+		end = -1
 	}
 
-	if begin >= len(r.States) {
-		err := fmt.Errorf("readRecentStates: begin position %d beyond end of recent states, last state is position %d", begin, len(r.States)-1)
+	r, err := getRecentAssets(stub, className)
+	if err != nil {
+		err = fmt.Errorf("readRecentStates: failed to get recent states from ledger: %s", err)
 		log.Error(err)
 		return nil, err
 	}
 
-	if end >= len(r.States) {
-		end = len(r.States) - 1
+	if len(r) == 0 {
+		return []byte("[]"), nil
 	}
 
-	count = end - begin + 1
+	if begin >= len(r) {
+		err := fmt.Errorf("readRecentStates: begin position %d beyond end of recent states, last state is position %d", begin, len(r)-1)
+		log.Error(err)
+		return nil, err
+	}
 
-	for i := begin; i <= end; i++ {
-		a, exists, err := GetAssetFromLedger(stub, r.States[i])
-		if err != nil {
-			err = fmt.Errorf("readRecentStates: failed to get asset from ledger: %s", err)
-			log.Errorf(err.Error())
-			return nil, err
-		}
-		if !exists {
-			err = fmt.Errorf("readRecentStates: recent asset state does not exist: %s", err)
-			log.Errorf(err.Error())
-			return nil, err
+	if end == -1 || end >= len(r) {
+		end = len(r) - 1
+	}
+
+	return json.Marshal(r[begin : end+1])
+}
+
+// setRecentStatesCapacity sets the capacity of one class, or the default capacity, the
+// list is trimmed on the next write to the class
+var setRecentStatesCapacity = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	var arg RecentStatesCapacityArg
+	var err error
+	if len(args) != 1 {
+		err = errors.New("setRecentStatesCapacity expects a JSON object with class and capacity")
+		log.Error(err)
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(args[0]), &arg)
+	if err != nil {
+		err = fmt.Errorf("setRecentStatesCapacity failed to unmarshal arg: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	if arg.Capacity < 1 || arg.Capacity > MaxRecentStatesCapacity {
+		err = fmt.Errorf("setRecentStatesCapacity capacity %d must be between 1 and %d", arg.Capacity, MaxRecentStatesCapacity)
+		log.Error(err)
+		return nil, err
+	}
+	config, err := GETRecentStatesConfigFromLedger(stub)
+	if err != nil {
+		return nil, err
+	}
+	if arg.Class == "" {
+		config.Capacity = arg.Capacity
+	} else {
+		if config.Classes == nil {
+			config.Classes = make(map[string]int, 0)
 		}
-		rstatesout = append(rstatesout, a)
+		config.Classes[arg.Class] = arg.Capacity
+	}
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		err = fmt.Errorf("setRecentStatesCapacity failed to marshal config: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	err = stub.PutState(RECENTSTATESCONFIGKEY, configBytes)
+	if err != nil {
+		err = fmt.Errorf("setRecentStatesCapacity failed PUTSTATE: %s", err)
+		log.Error(err)
+		return nil, err
 	}
-	return json.Marshal(rstatesout)
+	return nil, nil
 }
 
 func init() {
 	AddRoute("readRecentStates", "query", SystemClass, readRecentStates)
+	AddRoute("setRecentStatesCapacity", "invoke", SystemClass, setRecentStatesCapacity)
 }
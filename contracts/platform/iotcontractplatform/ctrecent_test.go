@@ -0,0 +1,135 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package iotcontractplatform
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpstub"
+)
+
+func TestRecentStatesClassCapacity(t *testing.T) {
+	var config RecentStatesConfig
+	if c := config.ClassCapacity("kit"); c != DefaultRecentStatesCapacity {
+		t.Fatalf("empty config capacity is %d, expected default %d", c, DefaultRecentStatesCapacity)
+	}
+	config = RecentStatesConfig{10, map[string]int{"kit": 3}}
+	if c := config.ClassCapacity("kit"); c != 3 {
+		t.Fatalf("class capacity is %d, expected 3", c)
+	}
+	if c := config.ClassCapacity("container"); c != 10 {
+		t.Fatalf("unconfigured class capacity is %d, expected 10", c)
+	}
+}
+
+func TestRecentStatesKeyIsPerClass(t *testing.T) {
+	if recentStatesKey("kit") == recentStatesKey("container") {
+		t.Fatal("two classes share one recent states key")
+	}
+	if recentStatesKey("kit") != RECENTSTATESKEY+".kit" {
+		t.Fatalf("unexpected recent states key %s", recentStatesKey("kit"))
+	}
+}
+
+func pushRecent(t *testing.T, stub *iotcpstub.Stub, className string, keys ...string) {
+	stub.Begin(true)
+	defer stub.End(true)
+	for _, key := range keys {
+		a := Asset{Class: AssetClass{Name: className}, AssetKey: key}
+		if err := a.PushRecentState(stub); err != nil {
+			t.Fatalf("PushRecentState %s failed: %s", key, err)
+		}
+	}
+}
+
+func expectRecent(t *testing.T, stub *iotcpstub.Stub, className string, expected ...string) {
+	r, err := GETRecentStatesFromLedger(stub, className)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(r.States, expected) {
+		t.Fatalf("recent states of %s are %v, expected %v", className, r.States, expected)
+	}
+}
+
+func TestPushRecentStateTrimsToCapacity(t *testing.T) {
+	stub := iotcpstub.NewStub("recent")
+	stub.Begin(true)
+	if _, err := setRecentStatesCapacity(stub, []string{`{"class":"kit","capacity":3}`}); err != nil {
+		t.Fatal(err)
+	}
+	stub.End(true)
+	pushRecent(t, stub, "kit", "K1", "K2", "K3", "K4", "K5")
+	expectRecent(t, stub, "kit", "K5", "K4", "K3")
+
+	// a lower capacity trims the list on the next write
+	stub.Begin(true)
+	if _, err := setRecentStatesCapacity(stub, []string{`{"class":"kit","capacity":2}`}); err != nil {
+		t.Fatal(err)
+	}
+	stub.End(true)
+	pushRecent(t, stub, "kit", "K4")
+	expectRecent(t, stub, "kit", "K4", "K5")
+}
+
+func TestPushRecentStateMovesToFront(t *testing.T) {
+	stub := iotcpstub.NewStub("recent")
+	pushRecent(t, stub, "kit", "K1", "K2", "K3")
+	expectRecent(t, stub, "kit", "K3", "K2", "K1")
+	pushRecent(t, stub, "kit", "K1")
+	expectRecent(t, stub, "kit", "K1", "K3", "K2")
+	pushRecent(t, stub, "kit", "K2")
+	expectRecent(t, stub, "kit", "K2", "K1", "K3")
+
+	// an asset already at the front is not written again
+	stub.Begin(true)
+	a := Asset{Class: AssetClass{Name: "kit"}, AssetKey: "K2"}
+	if err := a.PushRecentState(stub); err != nil {
+		t.Fatal(err)
+	}
+	if len(stub.Writes) != 0 {
+		t.Fatalf("pushing the front asset wrote %v", stub.Writes)
+	}
+	stub.End(true)
+	expectRecent(t, stub, "kit", "K2", "K1", "K3")
+}
+
+func TestMigrateLegacyRecentStates(t *testing.T) {
+	stub := iotcpstub.NewStub("recent")
+	stub.Begin(true)
+	for key, className := range map[string]string{"K1": "kit", "K2": "kit", "C1": "container"} {
+		b, _ := json.Marshal(Asset{Class: AssetClass{Name: className}, AssetKey: key})
+		if err := stub.PutState(key, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+	legacy, _ := json.Marshal(RecentStates{[]string{"K2", "C1", "GONE", "K1"}})
+	if err := stub.PutState(RECENTSTATESKEY, legacy); err != nil {
+		t.Fatal(err)
+	}
+	if err := migrateLegacyRecentStates(stub); err != nil {
+		t.Fatalf("migrateLegacyRecentStates failed: %s", err)
+	}
+	stub.End(true)
+	expectRecent(t, stub, "kit", "K2", "K1")
+	expectRecent(t, stub, "container", "C1")
+	if _, found := stub.State[RECENTSTATESKEY]; found {
+		t.Fatal("the legacy recent states key survived the migration")
+	}
+}
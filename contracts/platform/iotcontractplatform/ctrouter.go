@@ -21,8 +21,12 @@ package iotcontractplatform
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpevents"
 )
 
 // ChaincodeRoute stores a route for an asset class or event
@@ -31,6 +35,7 @@ type ChaincodeRoute struct {
 	Method       string
 	Class        AssetClass
 	Function     func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error)
+	Destructive  bool
 }
 
 // SimpleChaincode is the receiver for all shim API
@@ -41,30 +46,87 @@ type ChaincodeFunc func(stub shim.ChaincodeStubInterface, args []string) ([]byte
 
 var router = make(map[string]ChaincodeRoute, 0)
 
+// routerLock guards the router and the computed, merge and provenance registries, which
+// asset classes defined at runtime add to while the shim runs messages concurrently
+var routerLock sync.RWMutex
+
+// getRoute returns the route registered for a function name
+func getRoute(functionName string) (ChaincodeRoute, bool) {
+	routerLock.RLock()
+	defer routerLock.RUnlock()
+	r, found := router[functionName]
+	return r, found
+}
+
 // AddRoute allows a class definition to register its payload API, one route at a time
 // functionName is the function that will appear in a rest or gRPC message
 // method is one of deploy, invoke or query
 // class is the asset class that created the route
 // function is the actual function to be executed when the router is triggered
 func AddRoute(functionName string, method string, class AssetClass, function ChaincodeFunc) error {
-	if r, found := router[functionName]; found {
-		err := fmt.Errorf("AddRoute: function name %s attempt to register against class %s as method %s but is already registered against class %s as method %s", class.Name, method, r.FunctionName, r.Class.Name, r.Method)
-		log.Error(err)
-		return err
-	}
-	r := ChaincodeRoute{
+	return addRoute(ChaincodeRoute{
 		FunctionName: functionName,
 		Method:       method,
 		Class:        class,
 		Function:     function,
+	})
+}
+
+// registers a route unless its function name is taken
+func addRoute(r ChaincodeRoute) error {
+	routerLock.Lock()
+	defer routerLock.Unlock()
+	if found, exists := router[r.FunctionName]; exists {
+		err := fmt.Errorf("AddRoute: function name %s attempt to register against class %s as method %s but is already registered against class %s as method %s", r.FunctionName, r.Class.Name, r.Method, found.Class.Name, found.Method)
+		log.Error(err)
+		return err
 	}
-	router[functionName] = r
+	router[r.FunctionName] = r
 	log.Debugf("Class %s added route with function name %s as method %s", r.Class.Name, r.FunctionName, r.Method)
 	return nil
 }
 
+// the method of each function that the contract's schema publishes
+var expectedRoutes = make(map[string]string, 0)
+
+// ExpectRoutes records the functions that the contract's schema publishes and their
+// methods, it is called by the routes file that the schema generator writes
+func ExpectRoutes(routes map[string]string) {
+	for functionName, method := range routes {
+		expectedRoutes[functionName] = method
+	}
+}
+
+// VerifyRoutes returns an error that names each function passed to ExpectRoutes that is
+// not registered as a route or is registered with another method. Contracts call it at
+// startup, after every init function has added its routes, so that a function missing
+// from the contract is found before it is deployed rather than by a caller.
+func VerifyRoutes() error {
+	var problems []string
+	routerLock.RLock()
+	defer routerLock.RUnlock()
+	for functionName, method := range expectedRoutes {
+		r, found := router[functionName]
+		switch {
+		case !found:
+			problems = append(problems, fmt.Sprintf("%s is not registered", functionName))
+		case r.Method != method:
+			problems = append(problems, fmt.Sprintf("%s is registered as %s but the schema says %s", functionName, r.Method, method))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		err := fmt.Errorf("VerifyRoutes found functions in the schema that the contract does not route: %s", strings.Join(problems, ", "))
+		log.Error(err)
+		return err
+	}
+	return nil
+}
+
 func getDeployFunctions() []ChaincodeFunc {
 	var results = make([]ChaincodeFunc, 0)
+	routerLock.RLock()
+	defer routerLock.RUnlock()
 	for _, r := range router {
 		if r.Method == "deploy" {
 			results = append(results, r.Function)
@@ -76,11 +138,14 @@ func getDeployFunctions() []ChaincodeFunc {
 // EVTCCINVRESULT is a chaincode event ID to be emitted always at the end of an invoke
 // The platform defines this as an event with a payload that is an array of objects that
 // can be added to along the way. If an error occurs, the array is wiped and only the
-// error appears in order to avoid confusion
+// error appears in order to avoid confusion. The payload is the versioned envelope that
+// is described and decoded by the iotcpevents package, with the notifications that were
+// queued by the invoke.
 // TODO: What about using it as a debugging mechanism? COOL!!!
-const EVTCCINVRESULT string = "EVT.IOTCP.INVOKE.RESULT"
+const EVTCCINVRESULT string = iotcpevents.EventName
 
 func setStubEvent(stub shim.ChaincodeStubInterface, err error, info map[string]interface{}) {
+	log := logFor(stub)
 	log.Debugf("SetStubEvent called with err %+v and info %+v", err, info)
 	var ire InvokeResultEvent
 	if info == nil {
@@ -89,8 +154,13 @@ func setStubEvent(stub shim.ChaincodeStubInterface, err error, info map[string]i
 		ire = InvokeResultEvent{EVTCCINVRESULT, info}
 	}
 	log.Debugf("SetStubEvent after deepmergemap %+v", ire)
+	ire.Payload["version"] = iotcpevents.Version
+	notifications := takeNotifications(stub)
 	if err == nil {
 		ire.Payload["status"] = "OK"
+		if len(notifications) > 0 {
+			ire.Payload["notifications"] = notifications
+		}
 	} else {
 		ire.Payload["status"] = "ERROR"
 		ire.Payload["message"] = err.Error()
@@ -102,19 +172,23 @@ func setStubEvent(stub shim.ChaincodeStubInterface, err error, info map[string]i
 
 // Init is called by deploy messages
 func Init(stub shim.ChaincodeStubInterface, function string, args []string, ContractVersion string) ([]byte, error) {
+	log := logFor(stub).With("function", function)
 	var iargs = make([]string, 2)
 	if len(args) == 0 {
 		err := fmt.Errorf("Init received no args, expecting a json object in args[0]")
 		log.Error(err)
+		discardMetrics(stub, function)
 		setStubEvent(stub, err, nil)
 		return nil, err
 	}
 	iargs[0] = args[0]
 	iargs[1] = ContractVersion
+	loadAssetClassRoutes(stub)
 	fs := getDeployFunctions()
 	if len(fs) == 0 {
 		err := fmt.Errorf("Init found no registered functions '%s'", function)
 		log.Error(err)
+		discardMetrics(stub, function)
 		setStubEvent(stub, err, nil)
 		return nil, err
 	}
@@ -123,21 +197,29 @@ func Init(stub shim.ChaincodeStubInterface, function string, args []string, Cont
 		if err != nil {
 			err := fmt.Errorf("Init (%s) failed with error %s", function, err)
 			log.Error(err)
+			discardMetrics(stub, function)
 			setStubEvent(stub, err, nil)
 			return nil, err
 		}
 	}
+	if err := commitMetrics(stub, function); err != nil {
+		setStubEvent(stub, err, nil)
+		return nil, err
+	}
 	setStubEvent(stub, nil, nil)
 	return nil, nil
 }
 
 // Invoke is called when an invoke message is received
 func Invoke(stub shim.ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+	log := logFor(stub).With("function", function)
 	var r ChaincodeRoute
-	r, found := router[function]
+	loadAssetClassRoutes(stub)
+	r, found := getRoute(function)
 	if !found {
 		err := fmt.Errorf("Invoke did not find registered invoke function %s", function)
 		log.Error(err)
+		discardMetrics(stub, function)
 		setStubEvent(stub, err, nil)
 		return nil, err
 	}
@@ -145,6 +227,14 @@ func Invoke(stub shim.ChaincodeStubInterface, function string, args []string) ([
 	if err != nil {
 		err := fmt.Errorf("Invoke (%s) failed with error %s", function, err)
 		log.Error(err)
+		discardMetrics(stub, function)
+		setStubEvent(stub, err, nil)
+		return nil, err
+	}
+	if err := commitMetrics(stub, function); err != nil {
+		err = fmt.Errorf("Invoke (%s) failed to update contract metrics with error %s", function, err)
+		log.Error(err)
+		discardMetrics(stub, function)
 		setStubEvent(stub, err, nil)
 		return nil, err
 	}
@@ -156,6 +246,7 @@ func Invoke(stub shim.ChaincodeStubInterface, function string, args []string) ([
 		if err != nil {
 			err := fmt.Errorf("Invoke (%s) failed to marshal returned event to report with error %s, remember that chaincode events should be maps", function, err)
 			log.Error(err)
+			discardMetrics(stub, function)
 			setStubEvent(stub, err, nil)
 			return nil, err
 		}
@@ -166,8 +257,10 @@ func Invoke(stub shim.ChaincodeStubInterface, function string, args []string) ([
 
 // Query is called when a query message is received
 func Query(stub shim.ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+	log := logFor(stub).With("function", function)
 	var r ChaincodeRoute
-	r, found := router[function]
+	loadAssetClassRoutes(stub)
+	r, found := getRoute(function)
 	if !found {
 		err := fmt.Errorf("Query did not find registered query function %s", function)
 		log.Error(err)
@@ -188,13 +281,17 @@ var readAllRoutes = func(stub shim.ChaincodeStubInterface, args []string) ([]byt
 		FunctionName string     `json:"functionname"`
 		Method       string     `json:"method"`
 		Class        AssetClass `json:"class"`
+		Destructive  bool       `json:"destructive,omitempty"`
 	}
+	routerLock.RLock()
+	defer routerLock.RUnlock()
 	var r = make([]RoutesOut, 0, len(router))
 	for _, route := range router {
 		ro := RoutesOut{
 			route.FunctionName,
 			route.Method,
 			route.Class,
+			route.Destructive,
 		}
 		r = append(r, ro)
 	}
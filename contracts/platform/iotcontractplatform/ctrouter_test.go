@@ -0,0 +1,39 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package iotcontractplatform
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVerifyRoutes(t *testing.T) {
+	defer func() { expectedRoutes = make(map[string]string, 0) }()
+	ExpectRoutes(map[string]string{"readAllRoutes": "query"})
+	if err := VerifyRoutes(); err != nil {
+		t.Fatalf("VerifyRoutes failed for a registered route: %s", err)
+	}
+	ExpectRoutes(map[string]string{"readAllRoutes": "invoke", "shipVerified": "invoke"})
+	err := VerifyRoutes()
+	if err == nil {
+		t.Fatal("VerifyRoutes passed with a missing route and a wrong method")
+	}
+	if !strings.Contains(err.Error(), "readAllRoutes is registered as query but the schema says invoke") ||
+		!strings.Contains(err.Error(), "shipVerified is not registered") {
+		t.Fatalf("unexpected error %s", err)
+	}
+}
@@ -109,13 +109,14 @@ func AddComplianceRule(class AssetClass, rule RuleFunc) error {
 
 // ExecuteRules executes all registered rules for the Asset's class
 func (a *Asset) ExecuteRules(stub shim.ChaincodeStubInterface) error {
+	log := logFor(stub)
 	log.Debugf("Executing rules input: %+v", a.AlertsActive)
 	rules := classRules(a.Class)
 	for _, rule := range rules {
 		err := rule.Function(stub, a)
 		if err != nil {
 			err := fmt.Errorf("Rule (%v) failed with error %s", rule, err)
-			log.Error(err)
+			log.With("class", a.Class.Name, "assetkey", a.AssetKey, "rule", rule.RuleName).Error(err)
 			return err
 		}
 	}
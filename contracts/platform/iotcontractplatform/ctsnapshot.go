@@ -0,0 +1,283 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- world state snapshots, paginated export and chunked import with checksums
+
+package iotcontractplatform
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// SNAPSHOTFORMAT identifies a world state snapshot chunk
+const SNAPSHOTFORMAT string = "IOTCP.SNAPSHOT"
+
+// SNAPSHOTFORMATVERSION is bumped whenever the snapshot layout changes incompatibly
+const SNAPSHOTFORMATVERSION string = "1.0"
+
+// DefaultSnapshotChunkSize is the number of keys exported per chunk when no limit is given
+const DefaultSnapshotChunkSize int = 100
+
+// MaxSnapshotChunkSize bounds the number of keys in one chunk so that a single
+// import transaction stays reasonably small
+const MaxSnapshotChunkSize int = 1000
+
+// SnapshotEntry is one world state key and its raw value, the checksum is the
+// hex encoded sha256 of the value
+type SnapshotEntry struct {
+	Key      string `json:"key"`
+	Value    []byte `json:"value"`
+	Checksum string `json:"checksum"`
+}
+
+// WorldStateSnapshot is one chunk of an exported world state. Export the whole
+// world state by calling exportWorldState with begin set to the previous chunk's
+// next until next is empty. Chunks can be imported in any order.
+type WorldStateSnapshot struct {
+	Format          string          `json:"format"`
+	FormatVersion   string          `json:"formatversion"`
+	ContractVersion string          `json:"contractversion"`
+	Nickname        string          `json:"nickname"`
+	Begin           string          `json:"begin"`
+	Next            string          `json:"next,omitempty"`
+	Entries         []SnapshotEntry `json:"entries"`
+	Checksum        string          `json:"checksum"`
+}
+
+// SnapshotExportArg selects a chunk of world state for export
+type SnapshotExportArg struct {
+	Begin string `json:"begin"`
+	Limit int    `json:"limit"`
+}
+
+// SnapshotImportOptions is the optional second argument to importWorldState
+type SnapshotImportOptions struct {
+	AllowVersionMismatch bool `json:"allowVersionMismatch"`
+}
+
+func snapshotValueChecksum(value []byte) string {
+	sum := sha256.Sum256(value)
+	return hex.EncodeToString(sum[:])
+}
+
+// the chunk checksum covers the header and every key with its value checksum, so
+// entries cannot be added, removed, renamed or moved between chunks unnoticed
+func (s *WorldStateSnapshot) calculateChecksum() string {
+	h := sha256.New()
+	for _, f := range []string{s.Format, s.FormatVersion, s.ContractVersion, s.Nickname, s.Begin, s.Next} {
+		h.Write([]byte(f))
+		h.Write([]byte{0})
+	}
+	for _, e := range s.Entries {
+		h.Write([]byte(e.Key))
+		h.Write([]byte{0})
+		h.Write([]byte(e.Checksum))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Verify checks format, format version, every entry checksum and the chunk checksum
+func (s *WorldStateSnapshot) Verify() error {
+	if s.Format != SNAPSHOTFORMAT {
+		return fmt.Errorf("snapshot format is '%s', expecting '%s'", s.Format, SNAPSHOTFORMAT)
+	}
+	if s.FormatVersion != SNAPSHOTFORMATVERSION {
+		return fmt.Errorf("snapshot format version is '%s', this contract understands '%s'", s.FormatVersion, SNAPSHOTFORMATVERSION)
+	}
+	for _, e := range s.Entries {
+		if e.Key == "" {
+			return errors.New("snapshot contains an entry with a blank key")
+		}
+		if snapshotValueChecksum(e.Value) != e.Checksum {
+			return fmt.Errorf("snapshot entry %s failed checksum verification", e.Key)
+		}
+	}
+	if s.calculateChecksum() != s.Checksum {
+		return errors.New("snapshot chunk failed checksum verification")
+	}
+	return nil
+}
+
+// exportWorldState returns one chunk of world state in snapshot format, keys are
+// exported in lexical order starting at begin, contract state is carried in the
+// header and is never exported as an entry, nor are the destructive guard and audit
+// records, which belong to the contract instance
+var exportWorldState ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	var arg = SnapshotExportArg{"", DefaultSnapshotChunkSize}
+	var err error
+
+	if len(args) > 1 {
+		err = errors.New("exportWorldState expects at most one argument, a JSON object with begin and limit")
+		log.Error(err)
+		return nil, err
+	}
+	if len(args) == 1 {
+		err = json.Unmarshal([]byte(args[0]), &arg)
+		if err != nil {
+			err = fmt.Errorf("exportWorldState failed to unmarshal arg: %s", err)
+			log.Error(err)
+			return nil, err
+		}
+	}
+	if arg.Limit <= 0 {
+		arg.Limit = DefaultSnapshotChunkSize
+	}
+	if arg.Limit > MaxSnapshotChunkSize {
+		err = fmt.Errorf("exportWorldState limit %d exceeds maximum chunk size %d", arg.Limit, MaxSnapshotChunkSize)
+		log.Error(err)
+		return nil, err
+	}
+
+	cstate, err := GETContractStateFromLedger(stub)
+	if err != nil {
+		err = fmt.Errorf("exportWorldState cannot export without contract state: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+
+	var snapshot = WorldStateSnapshot{
+		Format:          SNAPSHOTFORMAT,
+		FormatVersion:   SNAPSHOTFORMATVERSION,
+		ContractVersion: cstate.Version,
+		Nickname:        cstate.Nickname,
+		Begin:           arg.Begin,
+		Entries:         make([]SnapshotEntry, 0, arg.Limit),
+	}
+
+	// the range query returns keys in lexical order, so the chunk ends at the first key
+	// past the limit, which begins the next chunk
+	iter, err := stub.RangeQueryState(arg.Begin, "")
+	if err != nil {
+		err = fmt.Errorf("exportWorldState failed to get a range query iterator: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	defer iter.Close()
+	for iter.HasNext() {
+		key, value, err := iter.Next()
+		if err != nil {
+			err = fmt.Errorf("exportWorldState iter.Next() failed: %s", err)
+			log.Error(err)
+			return nil, err
+		}
+		if key < arg.Begin || key == CONTRACTSTATEKEY || isGuardKey(key) {
+			continue
+		}
+		if len(snapshot.Entries) == arg.Limit {
+			snapshot.Next = key
+			break
+		}
+		snapshot.Entries = append(snapshot.Entries, SnapshotEntry{key, value, snapshotValueChecksum(value)})
+	}
+	snapshot.Checksum = snapshot.calculateChecksum()
+
+	return json.Marshal(snapshot)
+}
+
+// importWorldState restores one exported chunk into world state, overwriting keys that
+// already exist. The chunk must verify and must come from the same contract version
+// unless the options argument allows a mismatch. It is disabled in production mode.
+var importWorldState ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	var snapshot WorldStateSnapshot
+	var options SnapshotImportOptions
+	var err error
+
+	if len(args) != 1 && len(args) != 2 {
+		err = errors.New("importWorldState expects a snapshot chunk and optional import options")
+		log.Error(err)
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(args[0]), &snapshot)
+	if err != nil {
+		err = fmt.Errorf("importWorldState failed to unmarshal snapshot: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	if len(args) == 2 {
+		err = json.Unmarshal([]byte(args[1]), &options)
+		if err != nil {
+			err = fmt.Errorf("importWorldState failed to unmarshal options: %s", err)
+			log.Error(err)
+			return nil, err
+		}
+	}
+	if len(snapshot.Entries) > MaxSnapshotChunkSize {
+		err = fmt.Errorf("importWorldState chunk has %d entries, maximum is %d", len(snapshot.Entries), MaxSnapshotChunkSize)
+		log.Error(err)
+		return nil, err
+	}
+	if err = snapshot.Verify(); err != nil {
+		err = fmt.Errorf("importWorldState rejected chunk beginning at '%s': %s", snapshot.Begin, err)
+		log.Error(err)
+		return nil, err
+	}
+
+	cstate, err := GETContractStateFromLedger(stub)
+	if err != nil {
+		err = fmt.Errorf("importWorldState cannot import without contract state: %s", err)
+		log.Error(err)
+		return nil, err
+	}
+	if snapshot.ContractVersion != cstate.Version && !options.AllowVersionMismatch {
+		err = fmt.Errorf("importWorldState snapshot contract version %s does not match contract version %s", snapshot.ContractVersion, cstate.Version)
+		log.Error(err)
+		return nil, err
+	}
+
+	for _, e := range snapshot.Entries {
+		if e.Key == CONTRACTSTATEKEY || isGuardKey(e.Key) {
+			err = fmt.Errorf("importWorldState snapshot must not contain contract state or guard key %s", e.Key)
+			log.Error(err)
+			return nil, err
+		}
+	}
+	for _, e := range snapshot.Entries {
+		err = stub.PutState(e.Key, e.Value)
+		if err != nil {
+			err = fmt.Errorf("importWorldState PUTSTATE for key %s failed: %s", e.Key, err)
+			log.Error(err)
+			return nil, err
+		}
+	}
+	for _, e := range snapshot.Entries {
+		if e.Key == ASSETCLASSESKEY {
+			// route any imported runtime asset classes on the next message
+			assetClassesLock.Lock()
+			assetClassesLoaded = false
+			assetClassesLock.Unlock()
+		}
+	}
+	log.Noticef("importWorldState imported %d keys from chunk beginning at '%s'", len(snapshot.Entries), snapshot.Begin)
+
+	var result = map[string]interface{}{
+		"imported": len(snapshot.Entries),
+		"begin":    snapshot.Begin,
+		"next":     snapshot.Next,
+	}
+	return json.Marshal(result)
+}
+
+func init() {
+	AddRoute("exportWorldState", "query", SystemClass, exportWorldState)
+	addNonProductionRoute("importWorldState", SystemClass, importWorldState)
+}
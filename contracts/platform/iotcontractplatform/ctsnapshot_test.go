@@ -0,0 +1,180 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package iotcontractplatform
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpstub"
+)
+
+func newTestSnapshot() WorldStateSnapshot {
+	var s = WorldStateSnapshot{
+		Format:          SNAPSHOTFORMAT,
+		FormatVersion:   SNAPSHOTFORMATVERSION,
+		ContractVersion: "0.1",
+		Nickname:        "TEST",
+		Begin:           "",
+		Next:            "SKT003",
+	}
+	for _, kv := range [][]string{{"SKT001", `{"a":1}`}, {"SKT002", `{"b":2}`}} {
+		s.Entries = append(s.Entries, SnapshotEntry{kv[0], []byte(kv[1]), snapshotValueChecksum([]byte(kv[1]))})
+	}
+	s.Checksum = s.calculateChecksum()
+	return s
+}
+
+func TestSnapshotVerifyRoundTrip(t *testing.T) {
+	s := newTestSnapshot()
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("marshal snapshot failed: %s", err)
+	}
+	var s2 WorldStateSnapshot
+	if err = json.Unmarshal(b, &s2); err != nil {
+		t.Fatalf("unmarshal snapshot failed: %s", err)
+	}
+	if err = s2.Verify(); err != nil {
+		t.Fatalf("round tripped snapshot failed verification: %s", err)
+	}
+}
+
+func TestSnapshotVerifyDetectsTampering(t *testing.T) {
+	s := newTestSnapshot()
+	s.Entries[0].Value = []byte(`{"a":2}`)
+	if s.Verify() == nil {
+		t.Error("changed value passed verification")
+	}
+
+	s = newTestSnapshot()
+	s.Entries = s.Entries[:1]
+	if s.Verify() == nil {
+		t.Error("removed entry passed verification")
+	}
+
+	s = newTestSnapshot()
+	s.Next = ""
+	if s.Verify() == nil {
+		t.Error("changed header passed verification")
+	}
+
+	s = newTestSnapshot()
+	s.FormatVersion = "0.9"
+	if s.Verify() == nil {
+		t.Error("unknown format version passed verification")
+	}
+}
+
+// a stub with contract state, a destructive guard and n asset keys
+func newSnapshotStub(t *testing.T, n int) *iotcpstub.Stub {
+	stub := iotcpstub.NewStub("snapshot")
+	stub.Begin(true)
+	if err := PUTContractStateToLedger(stub, ContractState{"0.1", "TEST"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := PUTDestructiveGuardToLedger(stub, DestructiveGuard{}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i <= n; i++ {
+		if err := stub.PutState(fmt.Sprintf("SKT%03d", i), []byte(fmt.Sprintf(`{"n":%d}`, i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stub.End(true)
+	return stub
+}
+
+func exportChunk(t *testing.T, stub *iotcpstub.Stub, begin string, limit int) WorldStateSnapshot {
+	arg, _ := json.Marshal(SnapshotExportArg{begin, limit})
+	b, err := exportWorldState(stub, []string{string(arg)})
+	if err != nil {
+		t.Fatalf("export from '%s' failed: %s", begin, err)
+	}
+	var chunk WorldStateSnapshot
+	if err := json.Unmarshal(b, &chunk); err != nil {
+		t.Fatal(err)
+	}
+	if err := chunk.Verify(); err != nil {
+		t.Fatalf("exported chunk from '%s' failed verification: %s", begin, err)
+	}
+	return chunk
+}
+
+func TestExportWorldStatePaging(t *testing.T) {
+	stub := newSnapshotStub(t, 5)
+	var keys []string
+	var begin string
+	for chunks := 1; ; chunks++ {
+		chunk := exportChunk(t, stub, begin, 2)
+		if len(chunk.Entries) > 2 {
+			t.Fatalf("chunk from '%s' has %d entries, limit is 2", begin, len(chunk.Entries))
+		}
+		for _, e := range chunk.Entries {
+			keys = append(keys, e.Key)
+		}
+		if chunk.Next == "" {
+			if chunks != 3 {
+				t.Fatalf("expected 3 chunks, got %d", chunks)
+			}
+			break
+		}
+		begin = chunk.Next
+	}
+	if !reflect.DeepEqual(keys, []string{"SKT001", "SKT002", "SKT003", "SKT004", "SKT005"}) {
+		t.Fatalf("unexpected exported keys %v", keys)
+	}
+	if chunk := exportChunk(t, stub, "SKT005", 5); len(chunk.Entries) != 1 || chunk.Next != "" {
+		t.Fatalf("unexpected last chunk %+v", chunk)
+	}
+}
+
+func TestImportWorldStateRoundTrip(t *testing.T) {
+	from := newSnapshotStub(t, 5)
+	to := newSnapshotStub(t, 0)
+	for begin := ""; ; {
+		chunk := exportChunk(t, from, begin, 2)
+		b, _ := json.Marshal(chunk)
+		to.Begin(true)
+		if _, err := importWorldState(to, []string{string(b)}); err != nil {
+			t.Fatalf("import of chunk from '%s' failed: %s", begin, err)
+		}
+		to.End(true)
+		if begin = chunk.Next; begin == "" {
+			break
+		}
+	}
+	if !reflect.DeepEqual(from.State, to.State) {
+		t.Fatalf("imported world state differs\n%v\nfrom\n%v", to.State, from.State)
+	}
+
+	// a chunk from another contract version needs the options argument
+	chunk := exportChunk(t, from, "", 1)
+	chunk.ContractVersion = "0.2"
+	chunk.Checksum = chunk.calculateChecksum()
+	b, _ := json.Marshal(chunk)
+	to.Begin(true)
+	if _, err := importWorldState(to, []string{string(b)}); err == nil {
+		t.Fatal("imported a chunk from another contract version")
+	}
+	if _, err := importWorldState(to, []string{string(b), `{"allowVersionMismatch":true}`}); err != nil {
+		t.Fatalf("import with allowVersionMismatch failed: %s", err)
+	}
+	to.End(true)
+}
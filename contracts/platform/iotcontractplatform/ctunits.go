@@ -0,0 +1,245 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- sensor readings with units, normalized to the class's units
+
+package iotcontractplatform
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// UNITSPROPERTY is the root property of an event or state in which a device declares the
+// units of its readings by qualified property name, e.g.
+//     {"units": {"container.temperature": "F"}, "container": {"barcode": "C1", "temperature": 40}}
+// The declaration is merged into the state like any other property, so it applies to
+// later events from the device until it is changed.
+const UNITSPROPERTY string = "units"
+
+// Unit is a unit of measure for a sensor reading
+type Unit string
+
+// Reading is a sensor reading with its unit, and can be sent in an event in place of
+// a number, e.g. {"container": {"barcode": "C1", "temperature": {"value": 40, "unit": "F"}}}
+type Reading struct {
+	Value float64 `json:"value"`
+	Unit  Unit    `json:"unit"`
+}
+
+// a unit converts to the base unit of its dimension as (value + offset) * scale
+type unitConversion struct {
+	dimension string
+	scale     float64
+	offset    float64
+}
+
+var unitConversions = map[Unit]unitConversion{
+	"C":    {"temperature", 1, 0},
+	"F":    {"temperature", 5.0 / 9.0, -32},
+	"K":    {"temperature", 1, -273.15},
+	"m":    {"distance", 1, 0},
+	"km":   {"distance", 1000, 0},
+	"mi":   {"distance", 1609.344, 0},
+	"ft":   {"distance", 0.3048, 0},
+	"g":    {"acceleration", 9.80665, 0},
+	"m/s2": {"acceleration", 1, 0},
+	"m/s²": {"acceleration", 1, 0},
+}
+
+// ConvertUnit converts a value between units of the same dimension. Results are rounded
+// to 9 decimal places so that conversions are stable across peers and round trips.
+func ConvertUnit(value float64, from Unit, to Unit) (float64, error) {
+	f, found := unitConversions[from]
+	if !found {
+		return 0, fmt.Errorf("unknown unit '%s'", from)
+	}
+	t, found := unitConversions[to]
+	if !found {
+		return 0, fmt.Errorf("unknown unit '%s'", to)
+	}
+	if f.dimension != t.dimension {
+		return 0, fmt.Errorf("cannot convert %s from %s to %s", f.dimension, from, t.dimension)
+	}
+	if from == to {
+		return value, nil
+	}
+	v := (value+f.offset)*f.scale/t.scale - t.offset
+	return math.Floor(v*1e9+0.5) / 1e9, nil
+}
+
+var unitrouter = make(map[AssetClass]map[string]Unit, 0)
+
+// AddUnitProperty registers the unit in which a class stores a numeric reading. Events
+// may send the reading in any unit of the same dimension, and the platform converts it
+// before merging the event into the state and running the rules.
+func AddUnitProperty(class AssetClass, qprop string, unit Unit) error {
+	if _, found := unitConversions[unit]; !found {
+		err := fmt.Errorf("AddUnitProperty for class %s property %s has unknown unit '%s'", class.Name, qprop, unit)
+		log.Error(err)
+		return err
+	}
+	if u, found := unitrouter[class][qprop]; found {
+		err := fmt.Errorf("AddUnitProperty for class %s property %s is already registered with unit %s", class.Name, qprop, u)
+		log.Error(err)
+		return err
+	}
+	if unitrouter[class] == nil {
+		unitrouter[class] = make(map[string]Unit)
+	}
+	unitrouter[class][qprop] = unit
+	log.Debugf("Class %s added property %s with unit %s", class.Name, qprop, unit)
+	return nil
+}
+
+// reads a device's unit declaration and checks it against the class
+func declaredUnits(class AssetClass, state *map[string]interface{}) (map[string]Unit, error) {
+	var declared = make(map[string]Unit)
+	if state == nil {
+		return declared, nil
+	}
+	obj, found := (*state)[UNITSPROPERTY]
+	if !found {
+		return declared, nil
+	}
+	m, ok := obj.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s must be an object of qualified property names and units", UNITSPROPERTY)
+	}
+	for qprop, u := range m {
+		s, ok := u.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s declares a unit for %s that is not a string", UNITSPROPERTY, qprop)
+		}
+		unit, found := unitrouter[class][qprop]
+		if !found {
+			return nil, fmt.Errorf("%s declares a unit for %s, which has no unit in class %s", UNITSPROPERTY, qprop, class.Name)
+		}
+		if _, err := ConvertUnit(0, Unit(s), unit); err != nil {
+			return nil, fmt.Errorf("%s declares a unit for %s: %s", UNITSPROPERTY, qprop, err)
+		}
+		declared[qprop] = Unit(s)
+	}
+	return declared, nil
+}
+
+// normalizedEvent returns a copy of the incoming event with every reading converted to
+// the class's unit, and records the original value and unit of each converted reading
+// in the asset. A device's declaration in the event overrides its declaration in the
+// previous state.
+func (a *Asset) normalizedEvent(previous *map[string]interface{}) (map[string]interface{}, error) {
+	event := DeepCopyMap(*a.EventIn)
+	a.ReadingsIn = nil
+	classUnits := unitrouter[a.Class]
+	if len(classUnits) == 0 {
+		return event, nil
+	}
+	declared, err := declaredUnits(a.Class, previous)
+	if err != nil {
+		// a declaration accepted before the class changed must not block the device
+		log.Warningf("normalizedEvent for class %s ignores the previous declaration for %s: %s", a.Class.Name, a.AssetKey, err)
+		declared = make(map[string]Unit)
+	}
+	eventDeclared, err := declaredUnits(a.Class, &event)
+	if err != nil {
+		return nil, err
+	}
+	for qprop, unit := range eventDeclared {
+		declared[qprop] = unit
+	}
+	var qprops = make([]string, 0, len(classUnits))
+	for qprop := range classUnits {
+		qprops = append(qprops, qprop)
+	}
+	sort.Strings(qprops)
+	for _, qprop := range qprops {
+		obj, found := GetObject(&event, qprop)
+		if !found {
+			continue
+		}
+		var r Reading
+		var withUnit = false
+		switch v := obj.(type) {
+		case float64:
+			r = Reading{v, declared[qprop]}
+			if r.Unit == "" {
+				continue
+			}
+		case map[string]interface{}:
+			value, vfound := v["value"].(float64)
+			unit, ufound := v["unit"].(string)
+			if !vfound || !ufound {
+				return nil, fmt.Errorf("reading %s must have a numeric value and a unit", qprop)
+			}
+			r = Reading{value, Unit(unit)}
+			withUnit = true
+		default:
+			return nil, fmt.Errorf("reading %s must be a number or an object with value and unit", qprop)
+		}
+		value, err := ConvertUnit(r.Value, r.Unit, classUnits[qprop])
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %s", qprop, err)
+		}
+		PutObject(&event, qprop, value)
+		if withUnit || r.Unit != classUnits[qprop] {
+			if a.ReadingsIn == nil {
+				a.ReadingsIn = make(map[string]Reading)
+			}
+			a.ReadingsIn[qprop] = r
+		}
+	}
+	return event, nil
+}
+
+// UnitPropertyOut is the output of readUnitProperties
+type UnitPropertyOut struct {
+	Class     string `json:"class"`
+	QProp     string `json:"qprop"`
+	Unit      Unit   `json:"unit"`
+	Dimension string `json:"dimension"`
+}
+
+// readUnitProperties shows the unit of every registered reading, sorted by class and
+// qualified property name
+var readUnitProperties ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	var out = make([]UnitPropertyOut, 0)
+	for class, units := range unitrouter {
+		for qprop, unit := range units {
+			out = append(out, UnitPropertyOut{class.Name, qprop, unit, unitConversions[unit].dimension})
+		}
+	}
+	sort.Sort(unitPropertyOutArray(out))
+	return json.Marshal(out)
+}
+
+type unitPropertyOutArray []UnitPropertyOut
+
+func (aa unitPropertyOutArray) Len() int      { return len(aa) }
+func (aa unitPropertyOutArray) Swap(i, j int) { aa[i], aa[j] = aa[j], aa[i] }
+func (aa unitPropertyOutArray) Less(i, j int) bool {
+	if aa[i].Class != aa[j].Class {
+		return aa[i].Class < aa[j].Class
+	}
+	return aa[i].QProp < aa[j].QProp
+}
+
+func init() {
+	AddRoute("readUnitProperties", "query", SystemClass, readUnitProperties)
+}
@@ -0,0 +1,123 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package iotcontractplatform
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestConvertUnit(t *testing.T) {
+	var cases = []struct {
+		value    float64
+		from, to Unit
+		want     float64
+	}{
+		{68, "F", "C", 20},
+		{20, "C", "F", 68},
+		{-40, "C", "F", -40},
+		{0, "C", "K", 273.15},
+		{1, "mi", "km", 1.609344},
+		{5, "km", "m", 5000},
+		{2, "g", "m/s2", 19.6133},
+		{9.80665, "m/s²", "g", 1},
+	}
+	for _, c := range cases {
+		got, err := ConvertUnit(c.value, c.from, c.to)
+		if err != nil || got != c.want {
+			t.Errorf("%v %s in %s is %v (%v), expected %v", c.value, c.from, c.to, got, err, c.want)
+		}
+	}
+	if _, err := ConvertUnit(1, "km", "C"); err == nil {
+		t.Error("converted distance to temperature")
+	}
+	if _, err := ConvertUnit(1, "furlong", "m"); err == nil {
+		t.Error("converted an unknown unit")
+	}
+}
+
+func unitsEvent(t *testing.T, class AssetClass, event string) *Asset {
+	a := class.NewAsset()
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(event), &m); err != nil {
+		t.Fatal(err)
+	}
+	a.EventIn = &m
+	return &a
+}
+
+func TestNormalizedEvent(t *testing.T) {
+	var class = AssetClass{"UnitsTank", "UTNK", "tank.id"}
+	if err := AddUnitProperty(class, "tank.temperature", "C"); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddUnitProperty(class, "tank.temperature", "F"); err == nil {
+		t.Fatal("property registered twice")
+	}
+	if err := AddUnitProperty(class, "tank.depth", "fathoms"); err == nil {
+		t.Fatal("unknown unit registered")
+	}
+
+	a := unitsEvent(t, class, `{"tank":{"id":"T1","temperature":{"value":50,"unit":"F"}}}`)
+	event, err := a.normalizedEvent(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := GetObjectAsNumber(&event, "tank.temperature"); v != 10 {
+		t.Fatalf("temperature is %v, expected 10", v)
+	}
+	if r := a.ReadingsIn["tank.temperature"]; r.Value != 50 || r.Unit != "F" {
+		t.Fatalf("original reading is %+v", r)
+	}
+	if _, ok := (*a.EventIn)["tank"].(map[string]interface{})["temperature"].(map[string]interface{}); !ok {
+		t.Fatal("the event was modified")
+	}
+
+	// a device declaration in the previous state applies to plain numbers
+	previous := map[string]interface{}{"units": map[string]interface{}{"tank.temperature": "K"}}
+	a = unitsEvent(t, class, `{"tank":{"id":"T1","temperature":283.15}}`)
+	event, err = a.normalizedEvent(&previous)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := GetObjectAsNumber(&event, "tank.temperature"); v != 10 {
+		t.Fatalf("temperature is %v, expected 10", v)
+	}
+
+	// and the event's declaration overrides it
+	a = unitsEvent(t, class, `{"units":{"tank.temperature":"C"},"tank":{"id":"T1","temperature":12}}`)
+	event, err = a.normalizedEvent(&previous)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := GetObjectAsNumber(&event, "tank.temperature"); v != 12 || a.ReadingsIn != nil {
+		t.Fatalf("temperature is %v with readings %v, expected 12 and no readings", v, a.ReadingsIn)
+	}
+
+	for _, bad := range []string{
+		`{"tank":{"id":"T1","temperature":{"value":50,"unit":"km"}}}`,
+		`{"tank":{"id":"T1","temperature":{"value":"50","unit":"F"}}}`,
+		`{"tank":{"id":"T1","temperature":"50"}}`,
+		`{"units":{"tank.level":"m"},"tank":{"id":"T1"}}`,
+		`{"units":{"tank.temperature":"mi"},"tank":{"id":"T1"}}`,
+		`{"units":"F","tank":{"id":"T1"}}`,
+	} {
+		if _, err := unitsEvent(t, class, bad).normalizedEvent(nil); err == nil {
+			t.Errorf("event %s was accepted", bad)
+		}
+	}
+}
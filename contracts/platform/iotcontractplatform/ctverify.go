@@ -0,0 +1,351 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- consistency checks and batched repair of the platform's bookkeeping keys
+
+package iotcontractplatform
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// DefaultRepairBatchSize is the number of problems repaired per transaction when no
+// limit is given
+const DefaultRepairBatchSize int = 100
+
+// MaxRepairBatchSize bounds the number of problems repaired in one transaction
+const MaxRepairBatchSize int = 1000
+
+// WorldStateProblemKind names a kind of inconsistency in world state
+type WorldStateProblemKind string
+
+const (
+	// OrphanHistory is a history record of an asset that no longer exists
+	OrphanHistory WorldStateProblemKind = "orphanHistory"
+	// OrphanProvenance is the provenance of an asset that no longer exists
+	OrphanProvenance WorldStateProblemKind = "orphanProvenance"
+	// DanglingRecentState is a recent state entry for an asset that does not exist, that
+	// belongs to another class or that appears more than once
+	DanglingRecentState WorldStateProblemKind = "danglingRecentState"
+	// ClassMismatch is an asset whose class is unknown or whose key does not start with
+	// the prefix of its class
+	ClassMismatch WorldStateProblemKind = "classMismatch"
+	// Unparsable is a record that does not unmarshal
+	Unparsable WorldStateProblemKind = "unparsable"
+	// LegacyRecentStates is the single recent states list of earlier releases, which
+	// initContract moves to the list of each class
+	LegacyRecentStates WorldStateProblemKind = "legacyRecentStates"
+)
+
+// WorldStateProblem is one inconsistency found by verifyWorldState. Repairable problems
+// are fixed by repairWorldState, the others need an operator.
+type WorldStateProblem struct {
+	Kind       WorldStateProblemKind `json:"kind"`
+	Key        string                `json:"key"`
+	Entry      string                `json:"entry,omitempty"` // the recent state entry
+	Detail     string                `json:"detail"`
+	Repairable bool                  `json:"repairable"`
+}
+
+// WorldStateReport is the output of verifyWorldState and repairWorldState, which lists
+// only the problems it repaired. Remaining counts the repairable problems that
+// verifyWorldState found, and Next is the key where the next repairWorldState begins,
+// absent when it reached the end of world state.
+type WorldStateReport struct {
+	Keys      int                 `json:"keys"`
+	Problems  []WorldStateProblem `json:"problems"`
+	Repaired  int                 `json:"repaired"`
+	Remaining int                 `json:"remaining,omitempty"`
+	Next      string              `json:"next,omitempty"`
+}
+
+// RepairWorldStateArg bounds the number of problems repaired by one repairWorldState
+// and gives the key to begin at, the next of the previous batch
+type RepairWorldStateArg struct {
+	Begin string `json:"begin,omitempty"`
+	Limit int    `json:"limit"`
+}
+
+// the class whose prefix is the longest match for an asset key
+func classOfAssetKey(classes map[string]AssetClass, key string) (AssetClass, bool) {
+	var found AssetClass
+	var ok bool
+	for _, c := range classes {
+		if c != SystemClass && c.Prefix != "" && strings.HasPrefix(key, c.Prefix) && len(c.Prefix) > len(found.Prefix) {
+			found, ok = c, true
+		}
+	}
+	return found, ok
+}
+
+func assetExists(stub shim.ChaincodeStubInterface, key string) (bool, error) {
+	assetBytes, err := stub.GetState(key)
+	if err != nil {
+		return false, err
+	}
+	return len(assetBytes) > 0, nil
+}
+
+// scans world state in key order from begin and returns the problems found, stopping
+// at the first key after limit repairable problems when limit is not 0
+func verifyWorldStateKeys(stub shim.ChaincodeStubInterface, begin string, limit int) (WorldStateReport, error) {
+	log := logFor(stub)
+	var report = WorldStateReport{Problems: make([]WorldStateProblem, 0)}
+	var problem = func(kind WorldStateProblemKind, key string, entry string, repairable bool, format string, args ...interface{}) {
+		report.Problems = append(report.Problems, WorldStateProblem{kind, key, entry, fmt.Sprintf(format, args...), repairable})
+		if repairable {
+			report.Remaining++
+		}
+	}
+
+	iter, err := stub.RangeQueryState(begin, "")
+	if err != nil {
+		err = fmt.Errorf("verifyWorldState failed to get a range query iterator: %s", err)
+		log.Error(err)
+		return report, err
+	}
+	defer iter.Close()
+
+	classes := routedClasses()
+	for iter.HasNext() {
+		key, value, err := iter.Next()
+		if err != nil {
+			err = fmt.Errorf("verifyWorldState iter.Next() failed: %s", err)
+			log.Error(err)
+			return report, err
+		}
+		if limit > 0 && report.Remaining >= limit {
+			report.Next = key
+			break
+		}
+		report.Keys++
+		switch {
+		case strings.HasPrefix(key, STATEHISTORYKEY):
+			var a Asset
+			if err := json.Unmarshal(value, &a); err != nil || a.AssetKey == "" {
+				problem(Unparsable, key, "", true, "history record does not unmarshal to an asset")
+				continue
+			}
+			if !strings.HasPrefix(key, STATEHISTORYKEY+a.AssetKey+".") {
+				problem(Unparsable, key, "", true, "history record is for asset %s", a.AssetKey)
+				continue
+			}
+			exists, err := assetExists(stub, a.AssetKey)
+			if err != nil {
+				return report, err
+			}
+			if !exists {
+				problem(OrphanHistory, key, "", true, "asset %s does not exist", a.AssetKey)
+			}
+		case strings.HasPrefix(key, PROVENANCEKEY):
+			var prov AssetProvenance
+			if err := json.Unmarshal(value, &prov); err != nil {
+				problem(Unparsable, key, "", true, "provenance does not unmarshal")
+				continue
+			}
+			assetKey := strings.TrimPrefix(key, PROVENANCEKEY)
+			exists, err := assetExists(stub, assetKey)
+			if err != nil {
+				return report, err
+			}
+			if !exists {
+				problem(OrphanProvenance, key, "", true, "asset %s does not exist", assetKey)
+			}
+		case strings.HasPrefix(key, recentStatesKey("")):
+			className := strings.TrimPrefix(key, recentStatesKey(""))
+			var rstates RecentStates
+			if err := json.Unmarshal(value, &rstates); err != nil {
+				problem(Unparsable, key, "", true, "recent states of class %s do not unmarshal", className)
+				continue
+			}
+			class, routed := classes[className]
+			var seen = make(map[string]bool)
+			for _, entry := range rstates.States {
+				if seen[entry] {
+					problem(DanglingRecentState, key, entry, true, "asset %s appears more than once", entry)
+					continue
+				}
+				seen[entry] = true
+				if routed && !strings.HasPrefix(entry, class.Prefix) {
+					problem(DanglingRecentState, key, entry, true, "asset %s is not of class %s", entry, className)
+					continue
+				}
+				exists, err := assetExists(stub, entry)
+				if err != nil {
+					return report, err
+				}
+				if !exists {
+					problem(DanglingRecentState, key, entry, true, "asset %s does not exist", entry)
+				}
+			}
+		case key == RECENTSTATESKEY:
+			problem(LegacyRecentStates, key, "", true, "recent states of an earlier release are not migrated to their classes")
+		case strings.HasPrefix(key, "IOTCP"):
+			// platform configuration, contract state and the audit log
+		default:
+			var a Asset
+			if err := json.Unmarshal(value, &a); err != nil {
+				problem(Unparsable, key, "", false, "asset does not unmarshal: %s", err)
+				continue
+			}
+			class, routed := classes[a.Class.Name]
+			if routed && class != SystemClass && strings.HasPrefix(key, class.Prefix) && a.AssetKey == key {
+				continue
+			}
+			if keyClass, found := classOfAssetKey(classes, key); found {
+				problem(ClassMismatch, key, "", true, "asset of class '%s' with key %s belongs to class %s", a.Class.Name, a.AssetKey, keyClass.Name)
+			} else {
+				problem(ClassMismatch, key, "", false, "asset of class '%s' with key %s does not match a class prefix", a.Class.Name, a.AssetKey)
+			}
+		}
+	}
+	return report, nil
+}
+
+// removes the entries of a recent states list that are duplicated, of another class or
+// of assets that do not exist
+func repairRecentStates(stub shim.ChaincodeStubInterface, className string) error {
+	rstates, err := GETRecentStatesFromLedger(stub, className)
+	if err != nil {
+		return err
+	}
+	class, routed := routedClasses()[className]
+	var seen = make(map[string]bool)
+	var states = make([]string, 0, len(rstates.States))
+	for _, entry := range rstates.States {
+		if seen[entry] || (routed && !strings.HasPrefix(entry, class.Prefix)) {
+			continue
+		}
+		exists, err := assetExists(stub, entry)
+		if err != nil {
+			return err
+		}
+		if exists {
+			seen[entry] = true
+			states = append(states, entry)
+		}
+	}
+	return PUTRecentStatesToLedger(stub, className, RecentStates{states})
+}
+
+// rewrites an asset with the class that its key belongs to, without touching its
+// recent states or history
+func repairAssetClass(stub shim.ChaincodeStubInterface, key string) error {
+	a, _, err := GetAssetFromLedger(stub, key)
+	if err != nil {
+		return err
+	}
+	class, found := classOfAssetKey(routedClasses(), key)
+	if !found {
+		return fmt.Errorf("asset %s does not match a class prefix", key)
+	}
+	countMetric(stub, metricAssets, a.Class.Name, -1)
+	countMetric(stub, metricAssets, class.Name, 1)
+	a.Class = class
+	a.AssetKey = key
+	assetBytes, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	return stub.PutState(key, assetBytes)
+}
+
+func repairWorldStateProblem(stub shim.ChaincodeStubInterface, p WorldStateProblem) error {
+	switch {
+	case p.Kind == LegacyRecentStates:
+		return migrateLegacyRecentStates(stub)
+	case p.Kind == ClassMismatch:
+		return repairAssetClass(stub, p.Key)
+	case strings.HasPrefix(p.Key, recentStatesKey("")):
+		className := strings.TrimPrefix(p.Key, recentStatesKey(""))
+		if p.Kind == Unparsable {
+			return ClearRecentStates(stub, className)
+		}
+		return repairRecentStates(stub, className)
+	case p.Kind == OrphanHistory:
+		a, _, err := GetAssetFromLedger(stub, p.Key)
+		if err == nil {
+			countMetric(stub, metricHistory, a.Class.Name, -1)
+		}
+		return stub.DelState(p.Key)
+	default:
+		// orphan provenance and unparsable history or provenance
+		return stub.DelState(p.Key)
+	}
+}
+
+// verifyWorldState scans world state for orphan history and provenance, recent states of
+// deleted assets, assets that do not match their class and records that do not unmarshal
+var verifyWorldState ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	report, err := verifyWorldStateKeys(stub, "", 0)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(report)
+}
+
+// repairWorldState repairs the problems that verifyWorldState finds from begin until it
+// has repaired limit of them, and reports the problems repaired and the key to begin the
+// next batch at in its result event, call it again with begin set to next until next is
+// absent. Asset records are never deleted. It is a destructive route, so each batch
+// needs a confirm token and none run in production mode.
+var repairWorldState ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	var arg = RepairWorldStateArg{Limit: DefaultRepairBatchSize}
+	if len(args) > 0 && strings.TrimSpace(args[0]) != "" {
+		if err := json.Unmarshal([]byte(args[0]), &arg); err != nil {
+			err = fmt.Errorf("repairWorldState failed to unmarshal arg: %s", err)
+			log.Error(err)
+			return nil, err
+		}
+	}
+	if arg.Limit < 1 || arg.Limit > MaxRepairBatchSize {
+		err := fmt.Errorf("repairWorldState limit %d must be between 1 and %d", arg.Limit, MaxRepairBatchSize)
+		log.Error(err)
+		return nil, err
+	}
+	// the batch is found before it is repaired, so no key is written while the range
+	// query is open
+	report, err := verifyWorldStateKeys(stub, arg.Begin, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	problems := report.Problems
+	report.Problems = make([]WorldStateProblem, 0, arg.Limit)
+	report.Remaining = 0
+	for _, p := range problems {
+		if !p.Repairable {
+			continue
+		}
+		if err := repairWorldStateProblem(stub, p); err != nil {
+			err = fmt.Errorf("repairWorldState failed to repair %s %s: %s", p.Kind, p.Key, err)
+			log.Error(err)
+			return nil, err
+		}
+		log.Infof("repairWorldState repaired %s %s %s", p.Kind, p.Key, p.Entry)
+		report.Problems = append(report.Problems, p)
+		report.Repaired++
+	}
+	return json.Marshal(report)
+}
+
+func init() {
+	AddRoute("verifyWorldState", "query", SystemClass, verifyWorldState)
+	AddDestructiveRoute("repairWorldState", SystemClass, repairWorldState)
+}
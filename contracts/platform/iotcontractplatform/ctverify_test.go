@@ -0,0 +1,36 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package iotcontractplatform
+
+import "testing"
+
+func TestClassOfAssetKeyLongestPrefix(t *testing.T) {
+	var classes = map[string]AssetClass{
+		"container": {Name: "container", Prefix: "CON"},
+		"contract":  {Name: "contract", Prefix: "CONT"},
+		"system":    SystemClass,
+	}
+	if c, found := classOfAssetKey(classes, "CONTX1"); !found || c.Name != "contract" {
+		t.Fatalf("CONTX1 matched %+v, expected contract", c)
+	}
+	if c, found := classOfAssetKey(classes, "CONX1"); !found || c.Name != "container" {
+		t.Fatalf("CONX1 matched %+v, expected container", c)
+	}
+	if c, found := classOfAssetKey(classes, "XYZ1"); found {
+		t.Fatalf("XYZ1 matched %+v, expected no class", c)
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- JSON-RPC requests to the fabric's chaincode endpoint
+
+// Package iotcpclient builds and sends the JSON-RPC 2.0 requests that call a contract's
+// functions through the fabric's REST /chaincode endpoint, e.g.
+//     {"jsonrpc": "2.0", "method": "invoke", "id": 1, "params": {"type": 1,
+//         "chaincodeID": {"name": "mycc"}, "secureContext": "user_type1_0",
+//         "ctorMsg": {"function": "createAssetSurgicalKit", "args": ["{\"surgicalkit\": {...}}"]}}}
+// The generator writes a client package for each contract, with one method per function of
+// the schema's API, that builds its requests with a Client from this package. The package
+// depends only on the standard library.
+package iotcpclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync/atomic"
+)
+
+// ChaincodeID names a deployed contract, or its path when it is deployed
+type ChaincodeID struct {
+	Name string `json:"name,omitempty"`
+	Path string `json:"path,omitempty"`
+}
+
+// CtorMsg is the function to call and its args, each a JSON encoded string
+type CtorMsg struct {
+	Function string   `json:"function"`
+	Args     []string `json:"args"`
+}
+
+// Params are the params of a chaincode request
+type Params struct {
+	Type          int         `json:"type"`
+	ChaincodeID   ChaincodeID `json:"chaincodeID"`
+	CtorMsg       CtorMsg     `json:"ctorMsg"`
+	SecureContext string      `json:"secureContext,omitempty"`
+}
+
+// Request is one JSON-RPC request, the method is deploy, invoke or query
+type Request struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  Params `json:"params"`
+	ID      int64  `json:"id"`
+}
+
+// Response is the fabric's reply to a request. The message of a query's result is the
+// query's JSON encoded output, that of an invoke is the transaction ID.
+type Response struct {
+	JSONRPC string `json:"jsonrpc"`
+	Result  *struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	} `json:"result,omitempty"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    string `json:"data"`
+	} `json:"error,omitempty"`
+	ID int64 `json:"id"`
+}
+
+// Client builds requests for one contract and sends them to URL, the base of the fabric's
+// REST API such as http://localhost:7050. HTTP is http.DefaultClient when nil.
+type Client struct {
+	URL           string
+	ChaincodeID   ChaincodeID
+	SecureContext string
+	HTTP          *http.Client
+	lastID        int64
+}
+
+// NewClient returns a client for the contract deployed with the given name
+func NewClient(url string, name string, secureContext string) *Client {
+	return &Client{URL: url, ChaincodeID: ChaincodeID{Name: name}, SecureContext: secureContext}
+}
+
+// Request builds the request that calls function with args, each of which is JSON encoded
+// unless it is already a string
+func (c *Client) Request(method string, function string, args ...interface{}) (Request, error) {
+	var ctorArgs = make([]string, 0, len(args))
+	for i, arg := range args {
+		if s, ok := arg.(string); ok {
+			ctorArgs = append(ctorArgs, s)
+			continue
+		}
+		argBytes, err := json.Marshal(arg)
+		if err != nil {
+			return Request{}, fmt.Errorf("%s arg %d cannot be encoded: %s", function, i, err)
+		}
+		ctorArgs = append(ctorArgs, string(argBytes))
+	}
+	return Request{
+		JSONRPC: "2.0",
+		Method:  method,
+		Params: Params{
+			Type:          1,
+			ChaincodeID:   c.ChaincodeID,
+			CtorMsg:       CtorMsg{function, ctorArgs},
+			SecureContext: c.SecureContext,
+		},
+		ID: atomic.AddInt64(&c.lastID, 1),
+	}, nil
+}
+
+// Send posts a request to the chaincode endpoint, a JSON-RPC error is returned as an error
+func (c *Client) Send(req Request) (*Response, error) {
+	reqBytes, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s request cannot be encoded: %s", req.Params.CtorMsg.Function, err)
+	}
+	var h = c.HTTP
+	if h == nil {
+		h = http.DefaultClient
+	}
+	httpResp, err := h.Post(c.URL+"/chaincode", "application/json", bytes.NewReader(reqBytes))
+	if err != nil {
+		return nil, fmt.Errorf("%s request failed: %s", req.Params.CtorMsg.Function, err)
+	}
+	defer httpResp.Body.Close()
+	respBytes, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s response cannot be read: %s", req.Params.CtorMsg.Function, err)
+	}
+	var resp Response
+	if err := json.Unmarshal(respBytes, &resp); err != nil {
+		return nil, fmt.Errorf("%s response is not JSON-RPC, status %s: %s", req.Params.CtorMsg.Function, httpResp.Status, err)
+	}
+	if resp.Error != nil {
+		return &resp, fmt.Errorf("%s failed: %s %s", req.Params.CtorMsg.Function, resp.Error.Message, resp.Error.Data)
+	}
+	return &resp, nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package iotcpclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequest(t *testing.T) {
+	c := NewClient("http://localhost:7050", "mycc", "user_type1_0")
+	req, err := c.Request("invoke", "createAsset", map[string]interface{}{"asset": map[string]interface{}{"assetID": "A1"}}, `{"raw":true}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqBytes, _ := json.Marshal(req)
+	const want = `{"jsonrpc":"2.0","method":"invoke","params":{"type":1,"chaincodeID":{"name":"mycc"},"ctorMsg":{"function":"createAsset","args":["{\"asset\":{\"assetID\":\"A1\"}}","{\"raw\":true}"]},"secureContext":"user_type1_0"},"id":1}`
+	if string(reqBytes) != want {
+		t.Fatalf("unexpected request\n%s\nwant\n%s", reqBytes, want)
+	}
+	req, _ = c.Request("query", "readAllRoutes")
+	if req.ID != 2 || req.Params.CtorMsg.Args == nil || len(req.Params.CtorMsg.Args) != 0 {
+		t.Fatalf("unexpected request %+v", req)
+	}
+	if _, err := c.Request("invoke", "createAsset", func() {}); err == nil {
+		t.Fatal("encoded a func arg")
+	}
+}
+
+func TestSend(t *testing.T) {
+	var got Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chaincode" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		if got.Params.CtorMsg.Function == "missing" {
+			w.Write([]byte(`{"jsonrpc":"2.0","error":{"code":-32003,"message":"Query failure","data":"Query did not find registered query function missing"},"id":2}`))
+			return
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0","result":{"status":"OK","message":"[]"},"id":1}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "mycc", "")
+	req, _ := c.Request("query", "readAllRoutes")
+	resp, err := c.Send(req)
+	if err != nil || resp.Result == nil || resp.Result.Message != "[]" || got.Params.CtorMsg.Function != "readAllRoutes" {
+		t.Fatalf("unexpected response %+v (%v)", resp, err)
+	}
+	req, _ = c.Request("query", "missing")
+	if _, err := c.Send(req); err == nil {
+		t.Fatal("JSON-RPC error was not returned")
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- the versioned envelope of the invoke result event and its typed notifications
+
+// Package iotcpevents describes and decodes the EVT.IOTCP.INVOKE.RESULT chaincode event that
+// contracts built on the iot contract platform emit at the end of every invoke. Fabric emits
+// one event per transaction, so the payload is an envelope that carries the status of the
+// invoke and any number of typed notifications, e.g.
+//     {"version": 1, "status": "OK", "alertsRaised": ["OUTOFAREA"], "notifications": [
+//         {"type": "alertRaised", "class": "SurgicalKit", "assetID": "K1", "data": {"alert": "OUTOFAREA"}},
+//         {"type": "geofenceExit", "class": "SurgicalKit", "assetID": "K1", "data": {"distance": 1113, "radius": 100}}]}
+// Consumers decode the payload with Decode, or register a handler per notification type
+// with a Dispatcher. The package depends only on the standard library.
+package iotcpevents
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// EventName is the name of the chaincode event emitted at the end of every invoke
+const EventName string = "EVT.IOTCP.INVOKE.RESULT"
+
+// Version is the envelope version written by this package, payloads from contracts that
+// predate the envelope have no version and no notifications
+const Version int = 1
+
+// Type names a kind of notification, contracts can add their own
+type Type string
+
+const (
+	// AlertRaised is emitted by the platform for every alert that an invoke raises
+	AlertRaised Type = "alertRaised"
+	// AlertCleared is emitted by the platform for every alert that an invoke clears
+	AlertCleared Type = "alertCleared"
+	// GeofenceExit is emitted by rules when an asset leaves its geofence
+	GeofenceExit Type = "geofenceExit"
+	// StateTransition is emitted by rules and routes when a property such as a status
+	// changes value
+	StateTransition Type = "stateTransition"
+)
+
+// Notification is one typed item in the envelope, about an asset when class and asset
+// are present
+type Notification struct {
+	Type     Type            `json:"type"`
+	Class    string          `json:"class,omitempty"`
+	AssetKey string          `json:"assetkey,omitempty"`
+	AssetID  string          `json:"assetID,omitempty"`
+	TXNTS    *time.Time      `json:"txnts,omitempty"`
+	Data     json.RawMessage `json:"data,omitempty"`
+}
+
+// AlertData is the data of AlertRaised and AlertCleared notifications
+type AlertData struct {
+	Alert string `json:"alert"`
+}
+
+// GeofenceData is the data of GeofenceExit notifications, with distances in meters
+type GeofenceData struct {
+	Distance  float64 `json:"distance"`
+	Radius    float64 `json:"radius"`
+	Latitude  float64 `json:"latitude,omitempty"`
+	Longitude float64 `json:"longitude,omitempty"`
+}
+
+// TransitionData is the data of StateTransition notifications
+type TransitionData struct {
+	QProp string      `json:"qprop"`
+	From  interface{} `json:"from,omitempty"`
+	To    interface{} `json:"to"`
+}
+
+// Envelope is the payload of the invoke result event. The alert arrays are those of the
+// asset written last by the invoke and are kept for consumers that predate notifications.
+type Envelope struct {
+	Version       int            `json:"version"`
+	Status        string         `json:"status"`
+	Message       string         `json:"message,omitempty"`
+	AlertsRaised  []string       `json:"alertsRaised,omitempty"`
+	AlertsCleared []string       `json:"alertsCleared,omitempty"`
+	ActiveAlerts  []string       `json:"activeAlerts,omitempty"`
+	Notifications []Notification `json:"notifications,omitempty"`
+}
+
+// NewNotification marshals the data of a notification
+func NewNotification(t Type, data interface{}) (Notification, error) {
+	var n = Notification{Type: t}
+	if t == "" {
+		return n, errors.New("notification type is blank")
+	}
+	if data == nil {
+		return n, nil
+	}
+	dbytes, err := json.Marshal(data)
+	if err != nil {
+		return n, fmt.Errorf("notification %s data does not marshal: %s", t, err)
+	}
+	n.Data = dbytes
+	return n, nil
+}
+
+// DecodeData unmarshals the data of a notification, e.g. into AlertData for AlertRaised
+func (n Notification) DecodeData(v interface{}) error {
+	if len(n.Data) == 0 {
+		return fmt.Errorf("notification %s has no data", n.Type)
+	}
+	if err := json.Unmarshal(n.Data, v); err != nil {
+		return fmt.Errorf("notification %s data does not unmarshal: %s", n.Type, err)
+	}
+	return nil
+}
+
+// Decode unmarshals the payload of an invoke result event
+func Decode(payload []byte) (Envelope, error) {
+	var env Envelope
+	if err := json.Unmarshal(payload, &env); err != nil {
+		return env, fmt.Errorf("invoke result payload does not unmarshal: %s", err)
+	}
+	if env.Version > Version {
+		return env, fmt.Errorf("invoke result envelope version %d is newer than version %d of this package", env.Version, Version)
+	}
+	for i, n := range env.Notifications {
+		if n.Type == "" {
+			return env, fmt.Errorf("invoke result notification %d has no type", i)
+		}
+	}
+	return env, nil
+}
+
+// Handler is called with the envelope and one of its notifications
+type Handler func(env Envelope, n Notification) error
+
+// Dispatcher routes the notifications in invoke result events to handlers by type
+type Dispatcher struct {
+	handlers map[Type][]Handler
+	fallback Handler
+}
+
+// NewDispatcher returns a dispatcher with no handlers
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{handlers: make(map[Type][]Handler)}
+}
+
+// Handle subscribes a handler to a notification type, handlers of a type are called in
+// the order in which they subscribed
+func (d *Dispatcher) Handle(t Type, h Handler) *Dispatcher {
+	d.handlers[t] = append(d.handlers[t], h)
+	return d
+}
+
+// HandleOther subscribes a handler to the notifications that have no handler of their own
+func (d *Dispatcher) HandleOther(h Handler) *Dispatcher {
+	d.fallback = h
+	return d
+}
+
+// Dispatch decodes a payload and calls the handlers of each notification in order. The
+// first error from a handler stops the dispatch and is returned.
+func (d *Dispatcher) Dispatch(payload []byte) (Envelope, error) {
+	env, err := Decode(payload)
+	if err != nil {
+		return env, err
+	}
+	for _, n := range env.Notifications {
+		handlers, found := d.handlers[n.Type]
+		if !found && d.fallback != nil {
+			handlers = []Handler{d.fallback}
+		}
+		for _, h := range handlers {
+			if err := h(env, n); err != nil {
+				return env, fmt.Errorf("handler of notification %s for %s failed: %s", n.Type, n.AssetKey, err)
+			}
+		}
+	}
+	return env, nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package iotcpevents
+
+import (
+	"errors"
+	"testing"
+)
+
+const payload = `{"version":1,"status":"OK","alertsRaised":["OUTOFAREA"],"notifications":[
+	{"type":"geofenceExit","class":"SurgicalKit","assetkey":"SKTK1","assetID":"K1","data":{"distance":1113,"radius":100}},
+	{"type":"alertRaised","class":"SurgicalKit","assetkey":"SKTK1","assetID":"K1","data":{"alert":"OUTOFAREA"}},
+	{"type":"kitOpened","class":"SurgicalKit","assetkey":"SKTK1","assetID":"K1"}]}`
+
+func TestDecode(t *testing.T) {
+	env, err := Decode([]byte(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env.Status != "OK" || len(env.AlertsRaised) != 1 || len(env.Notifications) != 3 {
+		t.Fatalf("unexpected envelope %+v", env)
+	}
+	var fence GeofenceData
+	if err := env.Notifications[0].DecodeData(&fence); err != nil || fence.Distance != 1113 || fence.Radius != 100 {
+		t.Fatalf("unexpected geofence data %+v (%v)", fence, err)
+	}
+	if err := env.Notifications[2].DecodeData(&fence); err == nil {
+		t.Fatal("decoded missing data")
+	}
+
+	// payloads from contracts that predate the envelope decode without notifications
+	env, err = Decode([]byte(`{"status":"ERROR","message":"failed"}`))
+	if err != nil || env.Version != 0 || env.Message != "failed" || len(env.Notifications) != 0 {
+		t.Fatalf("unexpected envelope %+v (%v)", env, err)
+	}
+	for _, bad := range []string{`{"version":2,"status":"OK"}`, `{"version":1,"notifications":[{"assetID":"K1"}]}`, `[]`} {
+		if _, err := Decode([]byte(bad)); err == nil {
+			t.Errorf("payload %s decoded", bad)
+		}
+	}
+}
+
+func TestDispatcher(t *testing.T) {
+	var got []Type
+	record := func(env Envelope, n Notification) error {
+		got = append(got, n.Type)
+		return nil
+	}
+	d := NewDispatcher().Handle(AlertRaised, record).Handle(GeofenceExit, record)
+	if _, err := d.Dispatch([]byte(payload)); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0] != GeofenceExit || got[1] != AlertRaised {
+		t.Fatalf("dispatched %v", got)
+	}
+
+	got = nil
+	d.HandleOther(record)
+	if _, err := d.Dispatch([]byte(payload)); err != nil || len(got) != 3 || got[2] != "kitOpened" {
+		t.Fatalf("dispatched %v (%v)", got, err)
+	}
+
+	d.Handle(AlertRaised, func(env Envelope, n Notification) error { return errors.New("unavailable") })
+	if _, err := d.Dispatch([]byte(payload)); err == nil {
+		t.Fatal("handler error was not returned")
+	}
+}
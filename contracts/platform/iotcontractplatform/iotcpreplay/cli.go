@@ -0,0 +1,113 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- replay subcommand for contract binaries
+
+package iotcpreplay
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
+)
+
+// Command is the first argument that selects replay instead of starting the shim
+const Command = "replay"
+
+// Requested returns true when the command line asks for a replay, e.g.
+//
+//     trackandtrace replay -in incident.jsonl -out incident.json
+func Requested(args []string) bool {
+	return len(args) > 1 && args[1] == Command
+}
+
+// Main runs the replay subcommand with the arguments that follow it and returns the
+// process exit code. A contract's main function calls it before shim.Start:
+//
+//     if iotcpreplay.Requested(os.Args) {
+//         os.Exit(iotcpreplay.Main(new(SimpleChaincode), CONTRACTVERSION, os.Args[2:]))
+//     }
+func Main(cc shim.Chaincode, contractVersion string, args []string) int {
+	flags := flag.NewFlagSet(Command, flag.ContinueOnError)
+	in := flags.String("in", "-", "replay log of JSON records {function, args, txTimestamp}, - for stdin")
+	out := flags.String("out", "-", "report file, - for stdout")
+	deploy := flags.Bool("deploy", true, "deploy the contract first unless the log starts with a deploy")
+	nickname := flags.String("nickname", "REPLAY", "nickname of the automatic deploy")
+	state := flags.Bool("state", true, "include the final world state in the report")
+	stop := flags.Bool("stop", false, "stop at the first failed transaction")
+	level := flags.String("loglevel", "WARNING", "contract logging level")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	logLevel, err := shim.LogLevel(*level)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "replay: unknown logging level %s\n", *level)
+		return 2
+	}
+	iot.SetContractLoggingLevel(logLevel)
+
+	var r io.Reader = os.Stdin
+	if *in != "-" {
+		f, err := os.Open(*in)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "replay: %s\n", err)
+			return 1
+		}
+		defer f.Close()
+		r = f
+	}
+
+	options := Options{Nickname: *nickname, WorldState: *state, StopOnError: *stop}
+	if *deploy {
+		options.ContractVersion = contractVersion
+	}
+	result, replayErr := Replay(cc, r, options)
+
+	report, err := json.MarshalIndent(result, "", "    ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "replay: failed to marshal report: %s\n", err)
+		return 1
+	}
+	report = append(report, '\n')
+	if *out == "-" {
+		_, err = os.Stdout.Write(report)
+	} else {
+		f, ferr := os.Create(*out)
+		if ferr != nil {
+			fmt.Fprintf(os.Stderr, "replay: %s\n", ferr)
+			return 1
+		}
+		_, err = f.Write(report)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "replay: failed to write report: %s\n", err)
+		return 1
+	}
+	if replayErr != nil {
+		fmt.Fprintf(os.Stderr, "replay: %s\n", replayErr)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,293 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- replays recorded transactions against a contract in memory
+
+// Package iotcpreplay replays a JSON lines log of recorded transactions against a
+// contract running in process on an in memory stub, and reports the transactions,
+// the alerts timeline, the emitted events and the resulting world state.
+package iotcpreplay
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpstub"
+)
+
+// Record is one line of a replay log. Args that are JSON strings are passed to the
+// contract as they are, any other JSON value is passed as its JSON text. The method
+// is looked up from the contract's routes when absent.
+type Record struct {
+	Method      string            `json:"method,omitempty"`
+	Function    string            `json:"function"`
+	Args        []json.RawMessage `json:"args"`
+	TxTimestamp *time.Time        `json:"txTimestamp,omitempty"`
+}
+
+// Transaction is the outcome of one replayed record
+type Transaction struct {
+	Line     int         `json:"line"`
+	TXID     string      `json:"txid,omitempty"`
+	TXNTS    time.Time   `json:"txnts"`
+	Method   string      `json:"method"`
+	Function string      `json:"function"`
+	Status   string      `json:"status"`
+	Error    string      `json:"error,omitempty"`
+	Result   interface{} `json:"result,omitempty"`
+}
+
+// AlertChange records alerts raised or cleared on an asset by a transaction
+type AlertChange struct {
+	TXID     string             `json:"txid"`
+	TXNTS    time.Time          `json:"txnts"`
+	Function string             `json:"function"`
+	AssetKey string             `json:"assetkey"`
+	Raised   iot.AlertNameArray `json:"alertsRaised,omitempty"`
+	Cleared  iot.AlertNameArray `json:"alertsCleared,omitempty"`
+	Active   iot.AlertNameArray `json:"activeAlerts"`
+}
+
+// Event is an event emitted by a replayed transaction
+type Event struct {
+	TXID    string      `json:"txid"`
+	Name    string      `json:"name"`
+	Payload interface{} `json:"payload"`
+}
+
+// Result is the report of a replay
+type Result struct {
+	Transactions []Transaction         `json:"transactions"`
+	Alerts       []AlertChange          `json:"alerts"`
+	Events       []Event                `json:"events"`
+	WorldState   map[string]interface{} `json:"worldstate,omitempty"`
+}
+
+// Options controls a replay
+type Options struct {
+	ContractVersion string // deployed with this version unless the log starts with a deploy
+	Nickname        string // nickname of the automatic deploy
+	WorldState      bool   // include the final world state in the result
+	StopOnError     bool   // stop at the first transaction that returns an error
+}
+
+// Player replays records against one contract on one stub
+type Player struct {
+	CC      shim.Chaincode
+	Stub    *iotcpstub.Stub
+	Options Options
+	Result  Result
+	methods map[string]string
+	alerts  map[string]iot.AlertNameArray
+}
+
+// NewPlayer returns a player for the contract on an empty stub
+func NewPlayer(cc shim.Chaincode, options Options) *Player {
+	return &Player{
+		CC:      cc,
+		Stub:    iotcpstub.NewStub("replay"),
+		Options: options,
+		Result:  Result{make([]Transaction, 0), make([]AlertChange, 0), make([]Event, 0), nil},
+		alerts:  make(map[string]iot.AlertNameArray),
+	}
+}
+
+// Replay reads a replay log and replays every record in order, returning an error only
+// when the log cannot be read or StopOnError is set and a transaction fails
+func Replay(cc shim.Chaincode, r io.Reader, options Options) (*Result, error) {
+	p := NewPlayer(cc, options)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		var rec Record
+		if err := json.Unmarshal([]byte(text), &rec); err != nil {
+			return &p.Result, fmt.Errorf("line %d is not a replay record: %s", line, err)
+		}
+		t := p.Play(line, rec)
+		if t.Status != "OK" && options.StopOnError {
+			return &p.Result, fmt.Errorf("line %d %s failed: %s", line, rec.Function, t.Error)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return &p.Result, err
+	}
+	if options.WorldState {
+		p.Result.WorldState = p.worldState()
+	}
+	return &p.Result, nil
+}
+
+// returns the JSON value for JSON bytes, or the bytes as a string
+func jsonValue(b []byte) interface{} {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return string(b)
+	}
+	return v
+}
+
+// the method of each function, read from the contract's routes
+func (p *Player) method(rec Record) string {
+	if rec.Method != "" {
+		return rec.Method
+	}
+	if p.methods == nil {
+		p.methods = make(map[string]string)
+		var routes []struct {
+			FunctionName string `json:"functionname"`
+			Method       string `json:"method"`
+		}
+		b, err := p.CC.Query(p.Stub, "readAllRoutes", []string{})
+		if err == nil && json.Unmarshal(b, &routes) == nil {
+			for _, r := range routes {
+				p.methods[r.FunctionName] = r.Method
+			}
+		}
+	}
+	if m, found := p.methods[rec.Function]; found {
+		return m
+	}
+	return "invoke"
+}
+
+func recordArgs(rec Record) []string {
+	var args = make([]string, 0, len(rec.Args))
+	for _, raw := range rec.Args {
+		var s string
+		if json.Unmarshal(raw, &s) == nil {
+			args = append(args, s)
+		} else {
+			args = append(args, string(raw))
+		}
+	}
+	return args
+}
+
+// Play replays one record, deploying the contract first if this is the first record
+// and it is not a deploy
+func (p *Player) Play(line int, rec Record) Transaction {
+	method := p.method(rec)
+	if len(p.Result.Transactions) == 0 && method != "deploy" && p.Options.ContractVersion != "" {
+		arg, _ := json.Marshal(map[string]string{"version": p.Options.ContractVersion, "nickname": p.Options.Nickname})
+		p.Play(0, Record{Method: "deploy", Function: "init", Args: []json.RawMessage{json.RawMessage(arg)}, TxTimestamp: rec.TxTimestamp})
+	}
+	if rec.TxTimestamp != nil {
+		p.Stub.Clock = *rec.TxTimestamp
+	}
+
+	invoke := method != "query"
+	p.Stub.Begin(invoke)
+	t := Transaction{Line: line, TXID: p.Stub.TxID, TXNTS: p.Stub.Clock, Method: method, Function: rec.Function, Status: "OK"}
+	args := recordArgs(rec)
+	var result []byte
+	var err error
+	switch method {
+	case "deploy":
+		result, err = p.CC.Init(p.Stub, rec.Function, args)
+	case "invoke":
+		result, err = p.CC.Invoke(p.Stub, rec.Function, args)
+	case "query":
+		result, err = p.CC.Query(p.Stub, rec.Function, args)
+	default:
+		err = errors.New("method must be deploy, invoke or query")
+	}
+	if err != nil {
+		t.Status = "ERROR"
+		t.Error = err.Error()
+		if invoke {
+			p.Stub.Rollback()
+		}
+	} else {
+		if len(result) > 0 {
+			t.Result = jsonValue(result)
+		}
+		if invoke {
+			p.trackAlerts(t)
+		}
+	}
+	eventCount := len(p.Stub.Events)
+	p.Stub.End(invoke)
+	if len(p.Stub.Events) > eventCount {
+		e := p.Stub.Events[len(p.Stub.Events)-1]
+		p.Result.Events = append(p.Result.Events, Event{e.TXID, e.Name, jsonValue(e.Payload)})
+	}
+	p.Result.Transactions = append(p.Result.Transactions, t)
+	return t
+}
+
+// compares the alerts of every asset written by the transaction with their alerts
+// after the previous write
+func (p *Player) trackAlerts(t Transaction) {
+	var keys = make([]string, 0, len(p.Stub.Writes))
+	var seen = make(map[string]bool)
+	for _, key := range p.Stub.Writes {
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		b, _ := p.Stub.GetState(key)
+		if len(b) == 0 {
+			delete(p.alerts, key)
+			continue
+		}
+		var a iot.Asset
+		if json.Unmarshal(b, &a) != nil || a.AssetKey != key {
+			// history, configuration and the like
+			continue
+		}
+		old := p.alerts[key]
+		deltas := iot.GetAlertsAndDeltas(old, a.AlertsActive)
+		p.alerts[key] = a.AlertsActive
+		if deltas == nil {
+			continue
+		}
+		raised, _ := deltas["alertsRaised"].(iot.AlertNameArray)
+		cleared, _ := deltas["alertsCleared"].(iot.AlertNameArray)
+		if len(raised) == 0 && len(cleared) == 0 {
+			continue
+		}
+		active := a.AlertsActive
+		if active == nil {
+			active = iot.AlertNameArray{}
+		}
+		p.Result.Alerts = append(p.Result.Alerts, AlertChange{t.TXID, t.TXNTS, t.Function, key, raised, cleared, active})
+	}
+}
+
+func (p *Player) worldState() map[string]interface{} {
+	var ws = make(map[string]interface{}, len(p.Stub.State))
+	for key, value := range p.Stub.State {
+		ws[key] = jsonValue(value)
+	}
+	return ws
+}
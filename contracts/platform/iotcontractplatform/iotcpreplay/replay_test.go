@@ -0,0 +1,82 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package iotcpreplay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
+)
+
+type defaultContract struct{}
+
+func (t *defaultContract) Init(stub shim.ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+	return iot.Init(stub, function, args, "1.0")
+}
+
+func (t *defaultContract) Invoke(stub shim.ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+	return iot.Invoke(stub, function, args)
+}
+
+func (t *defaultContract) Query(stub shim.ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+	return iot.Query(stub, function, args)
+}
+
+func init() {
+	iot.RegisterDefaultRoutes()
+}
+
+const replayLog = `
+# a device warms up and cools down
+{"function":"createAsset","args":[{"asset":{"assetID":"A1","temperature":-1}}],"txTimestamp":"2016-12-02T10:00:00Z"}
+{"function":"updateAsset","args":["{\"asset\":{\"assetID\":\"A1\",\"temperature\":3}}"],"txTimestamp":"2016-12-02T10:05:00Z"}
+{"function":"updateAsset","args":["not json"]}
+{"function":"readAsset","args":[{"asset":{"assetID":"A1"}}]}
+{"function":"updateAsset","args":[{"asset":{"assetID":"A1","temperature":-2}}],"txTimestamp":"2016-12-02T10:20:00Z"}
+`
+
+func TestReplay(t *testing.T) {
+	r, err := Replay(new(defaultContract), strings.NewReader(replayLog), Options{ContractVersion: "1.0", WorldState: true})
+	if err != nil {
+		t.Fatalf("replay failed: %s", err)
+	}
+	if len(r.Transactions) != 6 || r.Transactions[0].Method != "deploy" {
+		t.Fatalf("expected a deploy and 5 transactions, got %+v", r.Transactions)
+	}
+	if r.Transactions[3].Status != "ERROR" || r.Transactions[4].Method != "query" || r.Transactions[4].Result == nil {
+		t.Fatalf("unexpected transactions %+v", r.Transactions)
+	}
+	if len(r.Alerts) != 2 || len(r.Alerts[0].Raised) != 1 || len(r.Alerts[1].Cleared) != 1 {
+		t.Fatalf("unexpected alerts timeline %+v", r.Alerts)
+	}
+	if r.Alerts[1].TXNTS.Format("15:04") != "10:20" {
+		t.Fatalf("alert cleared at %s, expected 10:20", r.Alerts[1].TXNTS)
+	}
+	if len(r.Events) != 5 {
+		t.Fatalf("expected an event from each deploy and invoke, got %d", len(r.Events))
+	}
+	if _, found := r.WorldState["DEFA1"]; !found {
+		t.Fatal("world state does not contain the asset")
+	}
+
+	_, err = Replay(new(defaultContract), strings.NewReader(replayLog), Options{ContractVersion: "1.0", StopOnError: true})
+	if err == nil || !strings.Contains(err.Error(), "line 5") {
+		t.Fatalf("expected replay to stop at line 5, got %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- in memory stub for contract tests and replay, MockStub with timestamps, events
+//            and a range query that honours its keys
+
+// Package iotcpstub runs contracts built on the iot contract platform in memory, for
+// scenario tests and for replaying recorded transactions without a peer network.
+package iotcpstub
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// DefaultStart is the transaction timestamp of the first transaction on a new stub
+var DefaultStart = time.Date(2016, time.December, 1, 0, 0, 0, 0, time.UTC)
+
+// DefaultStep is how far the clock advances after each transaction
+const DefaultStep = time.Second
+
+// Event is a chaincode event emitted by a transaction
+type Event struct {
+	TXID    string `json:"txid"`
+	Name    string `json:"name"`
+	Payload []byte `json:"payload"`
+}
+
+// Stub is a MockStub with deterministic transaction IDs and timestamps, event capture
+// and a range query that returns keys from startKey to endKey inclusive in lexical
+// order, with a blank endKey meaning no upper bound
+type Stub struct {
+	*shim.MockStub
+	Clock  time.Time         // timestamp of the next transaction
+	Step   time.Duration     // clock advance after each transaction
+	Events []Event           // last event of each transaction, in order
+	Writes []string          // keys written or deleted by the current or last transaction
+	Attrs  map[string]string // attributes of the caller's certificate by name
+	seq    int
+	txts   time.Time
+	event  *Event
+	undo   map[string][]byte // values before the transaction's first write, nil if absent
+}
+
+// NewStub returns an empty stub with the clock at DefaultStart
+func NewStub(name string) *Stub {
+	return &Stub{
+		MockStub: shim.NewMockStub(name, nil),
+		Clock:    DefaultStart,
+		Step:     DefaultStep,
+		Events:   make([]Event, 0),
+		Writes:   make([]string, 0),
+		undo:     make(map[string][]byte),
+	}
+}
+
+// Begin starts a transaction at the clock, a query has no transaction ID so cannot write
+func (s *Stub) Begin(invoke bool) {
+	s.txts = s.Clock
+	s.event = nil
+	s.Writes = make([]string, 0)
+	s.undo = make(map[string][]byte)
+	if invoke {
+		s.seq++
+		s.MockTransactionStart(fmt.Sprintf("%s-%06d", s.Name, s.seq))
+	}
+}
+
+// End finishes a transaction, keeping its event and advancing the clock
+func (s *Stub) End(invoke bool) {
+	if invoke {
+		if s.event != nil {
+			s.Events = append(s.Events, *s.event)
+		}
+		s.MockTransactionEnd(s.TxID)
+	}
+	s.event = nil
+	s.Clock = s.Clock.Add(s.Step)
+}
+
+// GetTxTimestamp returns the clock as it was when the transaction began
+func (s *Stub) GetTxTimestamp() (*timestamp.Timestamp, error) {
+	return &timestamp.Timestamp{Seconds: s.txts.Unix(), Nanos: int32(s.txts.Nanosecond())}, nil
+}
+
+// ReadCertAttribute returns the caller's certificate attribute from Attrs, as a peer
+// with security enabled does, and an error when the certificate has no such attribute
+func (s *Stub) ReadCertAttribute(attributeName string) ([]byte, error) {
+	value, found := s.Attrs[attributeName]
+	if !found {
+		return nil, fmt.Errorf("the caller's certificate has no attribute %s", attributeName)
+	}
+	return []byte(value), nil
+}
+
+func (s *Stub) remember(key string) {
+	if _, found := s.undo[key]; !found {
+		s.undo[key] = s.State[key]
+	}
+}
+
+// Rollback undoes the writes of the current transaction, as a peer does not commit
+// a transaction whose chaincode returned an error
+func (s *Stub) Rollback() {
+	for key, value := range s.undo {
+		if value == nil {
+			s.MockStub.DelState(key)
+		} else {
+			s.MockStub.PutState(key, value)
+		}
+	}
+	s.undo = make(map[string][]byte)
+	s.Writes = make([]string, 0)
+}
+
+// PutState writes the key and remembers it as written by the transaction, a query has
+// no transaction so cannot write
+func (s *Stub) PutState(key string, value []byte) error {
+	if s.TxID == "" {
+		return errors.New("Cannot PutState without a transaction - call Begin(true)?")
+	}
+	s.remember(key)
+	err := s.MockStub.PutState(key, value)
+	if err == nil {
+		s.Writes = append(s.Writes, key)
+	}
+	return err
+}
+
+// DelState deletes the key and remembers it as written by the transaction
+func (s *Stub) DelState(key string) error {
+	if s.TxID == "" {
+		return errors.New("Cannot DelState without a transaction - call Begin(true)?")
+	}
+	s.remember(key)
+	err := s.MockStub.DelState(key)
+	if err == nil {
+		s.Writes = append(s.Writes, key)
+	}
+	return err
+}
+
+// SetEvent keeps the event, as on a peer only the last event of a transaction is emitted
+func (s *Stub) SetEvent(name string, payload []byte) error {
+	if name == "" {
+		return errors.New("event name can not be nil string")
+	}
+	s.event = &Event{s.TxID, name, payload}
+	return nil
+}
+
+// RangeQueryState returns an iterator over a copy of the keys in range
+func (s *Stub) RangeQueryState(startKey, endKey string) (shim.StateRangeQueryIteratorInterface, error) {
+	var keys = make([]string, 0)
+	for key := range s.State {
+		if key >= startKey && (endKey == "" || key <= endKey) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return &rangeIterator{s, keys, false}, nil
+}
+
+type rangeIterator struct {
+	stub   *Stub
+	keys   []string
+	closed bool
+}
+
+func (it *rangeIterator) HasNext() bool {
+	return !it.closed && len(it.keys) > 0
+}
+
+func (it *rangeIterator) Next() (string, []byte, error) {
+	if !it.HasNext() {
+		return "", nil, errors.New("range query iterator has no next key")
+	}
+	key := it.keys[0]
+	it.keys = it.keys[1:]
+	value, err := it.stub.GetState(key)
+	return key, value, err
+}
+
+func (it *rangeIterator) Close() error {
+	if it.closed {
+		return errors.New("range query iterator closed twice")
+	}
+	it.closed = true
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package iotcpstub
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRollbackMixedPutsAndDeletes(t *testing.T) {
+	s := NewStub("rollback")
+	s.Begin(true)
+	for _, kv := range [][]string{{"A", "1"}, {"B", "2"}, {"C", "3"}} {
+		if err := s.PutState(kv[0], []byte(kv[1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.End(true)
+	var before = map[string][]byte{"A": []byte("1"), "B": []byte("2"), "C": []byte("3")}
+
+	s.Begin(true)
+	for _, err := range []error{
+		s.PutState("A", []byte("10")),
+		s.DelState("B"),
+		s.PutState("D", []byte("4")),
+		s.DelState("A"),
+		s.PutState("B", []byte("20")),
+		s.DelState("D"),
+		s.DelState("C"),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.Rollback()
+	s.End(true)
+	if !reflect.DeepEqual(s.State, before) {
+		t.Fatalf("state after rollback is %v, expected %v", s.State, before)
+	}
+	iter, err := s.RangeQueryState("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for iter.HasNext() {
+		key, _, _ := iter.Next()
+		keys = append(keys, key)
+	}
+	if !reflect.DeepEqual(keys, []string{"A", "B", "C"}) {
+		t.Fatalf("keys after rollback are %v", keys)
+	}
+}
+
+func TestQueryCannotWrite(t *testing.T) {
+	s := NewStub("query")
+	s.Begin(true)
+	if err := s.PutState("A", []byte("1")); err != nil {
+		t.Fatal(err)
+	}
+	s.End(true)
+	s.Begin(false)
+	if err := s.PutState("B", []byte("2")); err == nil {
+		t.Fatal("a query wrote a key")
+	}
+	if err := s.DelState("A"); err == nil {
+		t.Fatal("a query deleted a key")
+	}
+	s.End(false)
+	if string(s.State["A"]) != "1" || len(s.State) != 1 {
+		t.Fatalf("a query changed the state to %v", s.State)
+	}
+}
+
+func TestReadCertAttribute(t *testing.T) {
+	s := NewStub("attrs")
+	if _, err := s.ReadCertAttribute("party"); err == nil {
+		t.Fatal("a certificate without attributes has a party")
+	}
+	s.Attrs = map[string]string{"party": "Central Depot"}
+	if party, err := s.ReadCertAttribute("party"); err != nil || string(party) != "Central Depot" {
+		t.Fatalf("unexpected party %s (%v)", party, err)
+	}
+}
@@ -0,0 +1,383 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- fluent scenario helpers over the in memory stub
+
+package iotcptest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpevents"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpstub"
+)
+
+// Harness drives a contract through its shim API. Every call records its result and
+// error, and the Expect helpers fail the test when the outcome does not match, e.g.
+//
+//     h := iotcptest.New(t, new(SimpleChaincode))
+//     h.Init("1.0").ExpectOK()
+//     h.CreateAsset(ContainerClass, `{"container":{"barcode":"C1","temperature":5}}`).ExpectOK()
+//     h.ExpectAlert(ContainerClass, "C1", "OVERTEMP")
+type Harness struct {
+	T      testing.TB
+	CC     shim.Chaincode
+	Stub   *iotcpstub.Stub
+	Last   string // description of the last call, for failure messages
+	Result []byte // result of the last call
+	Err    error  // error returned by the last call
+}
+
+// New returns a harness for the chaincode on an empty stub
+func New(t testing.TB, cc shim.Chaincode) *Harness {
+	return &Harness{T: t, CC: cc, Stub: iotcpstub.NewStub("iotcptest")}
+}
+
+// toArgs accepts strings as they are and marshals anything else to JSON
+func toArgs(args []interface{}) ([]string, error) {
+	var out = make([]string, 0, len(args))
+	for _, a := range args {
+		if s, ok := a.(string); ok {
+			out = append(out, s)
+			continue
+		}
+		b, err := json.Marshal(a)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, string(b))
+	}
+	return out, nil
+}
+
+func (h *Harness) call(method string, function string, args []interface{}) *Harness {
+	h.Last = fmt.Sprintf("%s %s %v", method, function, args)
+	h.Result, h.Err = nil, nil
+	sargs, err := toArgs(args)
+	if err != nil {
+		h.Err = fmt.Errorf("iotcptest could not marshal args: %s", err)
+		return h
+	}
+	invoke := method != "query"
+	h.Stub.Begin(invoke)
+	defer h.Stub.End(invoke)
+	switch method {
+	case "init":
+		h.Result, h.Err = h.CC.Init(h.Stub, function, sargs)
+	case "invoke":
+		h.Result, h.Err = h.CC.Invoke(h.Stub, function, sargs)
+	default:
+		h.Result, h.Err = h.CC.Query(h.Stub, function, sargs)
+	}
+	if invoke && h.Err != nil {
+		h.Stub.Rollback()
+	}
+	return h
+}
+
+// Init deploys the contract with the given version and a test nickname
+func (h *Harness) Init(version string) *Harness {
+	return h.call("init", "init", []interface{}{map[string]string{"version": version, "nickname": "iotcptest"}})
+}
+
+// Invoke runs an invoke transaction, args that are not strings are marshaled to JSON
+func (h *Harness) Invoke(function string, args ...interface{}) *Harness {
+	return h.call("invoke", function, args)
+}
+
+// Query runs a query, args that are not strings are marshaled to JSON
+func (h *Harness) Query(function string, args ...interface{}) *Harness {
+	return h.call("query", function, args)
+}
+
+// InvokeConfirmed runs a destructive route with a confirm token that it first reads for
+// exactly the argument, a JSON object, e.g.
+//     h.InvokeConfirmed("deleteWorldState", `{"reinit":true}`).ExpectOK()
+func (h *Harness) InvokeConfirmed(function string, arg interface{}) *Harness {
+	sargs, err := toArgs([]interface{}{arg})
+	var object map[string]interface{}
+	if err == nil {
+		err = json.Unmarshal([]byte(sargs[0]), &object)
+	}
+	if err != nil {
+		h.Last = fmt.Sprintf("invoke %s %v", function, arg)
+		h.Result, h.Err = nil, fmt.Errorf("iotcptest needs a JSON object to confirm: %s", err)
+		return h
+	}
+	h.Query("readConfirmationToken", map[string]interface{}{"function": function, "args": json.RawMessage(sargs[0])})
+	if h.Err != nil {
+		return h
+	}
+	var token iot.ConfirmationToken
+	if err := json.Unmarshal(h.Result, &token); err != nil {
+		h.Result, h.Err = nil, fmt.Errorf("iotcptest could not read the confirm token: %s", err)
+		return h
+	}
+	if object == nil {
+		object = make(map[string]interface{})
+	}
+	object["confirm"] = token.Token
+	return h.Invoke(function, object)
+}
+
+// Advance moves the clock forward before the next transaction
+func (h *Harness) Advance(d time.Duration) *Harness {
+	h.Stub.Clock = h.Stub.Clock.Add(d)
+	return h
+}
+
+// classFunction returns the registered function name for a class route, as the
+// route suffix is not always the class name
+func (h *Harness) classFunction(class iot.AssetClass, route iot.ClassRoute) (string, error) {
+	b, err := h.CC.Query(h.Stub, "readAllRoutes", []string{})
+	if err != nil {
+		return "", err
+	}
+	var routes []struct {
+		FunctionName string        `json:"functionname"`
+		Class        iot.AssetClass `json:"class"`
+	}
+	if err = json.Unmarshal(b, &routes); err != nil {
+		return "", err
+	}
+	var found = ""
+	for _, r := range routes {
+		if r.Class.Name == class.Name && strings.HasPrefix(r.FunctionName, string(route)) {
+			// the shortest match, so that readAsset does not find readAssetStateHistory
+			if found == "" || len(r.FunctionName) < len(found) {
+				found = r.FunctionName
+			}
+		}
+	}
+	if found == "" {
+		return "", fmt.Errorf("no %s route registered for class %s", route, class.Name)
+	}
+	return found, nil
+}
+
+func (h *Harness) classCall(method string, class iot.AssetClass, route iot.ClassRoute, args []interface{}) *Harness {
+	f, err := h.classFunction(class, route)
+	if err != nil {
+		h.Last = fmt.Sprintf("%s %s for class %s", method, route, class.Name)
+		h.Result, h.Err = nil, err
+		return h
+	}
+	return h.call(method, f, args)
+}
+
+// CreateAsset invokes the create route of the class with the event
+func (h *Harness) CreateAsset(class iot.AssetClass, event interface{}) *Harness {
+	return h.classCall("invoke", class, iot.CreateAssetRoute, []interface{}{event})
+}
+
+// ReplaceAsset invokes the replace route of the class with the event
+func (h *Harness) ReplaceAsset(class iot.AssetClass, event interface{}) *Harness {
+	return h.classCall("invoke", class, iot.ReplaceAssetRoute, []interface{}{event})
+}
+
+// UpdateAsset invokes the update route of the class with the event
+func (h *Harness) UpdateAsset(class iot.AssetClass, event interface{}) *Harness {
+	return h.classCall("invoke", class, iot.UpdateAssetRoute, []interface{}{event})
+}
+
+// DeleteAsset invokes the delete route of the class for the asset
+func (h *Harness) DeleteAsset(class iot.AssetClass, assetID string) *Harness {
+	return h.classCall("invoke", class, iot.DeleteAssetRoute, []interface{}{assetIDArg(class, assetID)})
+}
+
+// ReadAssetStateHistory queries the history route of the class for the asset
+func (h *Harness) ReadAssetStateHistory(class iot.AssetClass, assetID string) *Harness {
+	return h.classCall("query", class, iot.ReadAssetStateHistoryRoute, []interface{}{assetIDArg(class, assetID)})
+}
+
+// builds the smallest event that identifies an asset, e.g. {"container":{"barcode":"C1"}}
+func assetIDArg(class iot.AssetClass, assetID string) map[string]interface{} {
+	var arg = make(map[string]interface{})
+	iot.PutObject(&arg, class.AssetIDPath, assetID)
+	return arg
+}
+
+// Asset returns the asset straight from world state, failing the test if it is missing
+func (h *Harness) Asset(class iot.AssetClass, assetID string) iot.Asset {
+	var a iot.Asset
+	b, _ := h.Stub.GetState(class.Prefix + assetID)
+	if len(b) == 0 {
+		h.T.Fatalf("asset %s of class %s does not exist", assetID, class.Name)
+	}
+	if err := json.Unmarshal(b, &a); err != nil {
+		h.T.Fatalf("asset %s of class %s does not unmarshal: %s", assetID, class.Name, err)
+	}
+	return a
+}
+
+// ExpectOK fails the test if the last call returned an error
+func (h *Harness) ExpectOK() *Harness {
+	if h.Err != nil {
+		h.T.Fatalf("%s failed: %s", h.Last, h.Err)
+	}
+	return h
+}
+
+// ExpectError fails the test unless the last call returned an error containing the text
+func (h *Harness) ExpectError(contains string) *Harness {
+	if h.Err == nil {
+		h.T.Fatalf("%s succeeded, expected an error containing '%s'", h.Last, contains)
+	}
+	if !strings.Contains(h.Err.Error(), contains) {
+		h.T.Fatalf("%s failed with '%s', expected an error containing '%s'", h.Last, h.Err, contains)
+	}
+	return h
+}
+
+// ExpectResult unmarshals the result of the last call into v
+func (h *Harness) ExpectResult(v interface{}) *Harness {
+	h.ExpectOK()
+	if err := json.Unmarshal(h.Result, v); err != nil {
+		h.T.Fatalf("%s result does not unmarshal: %s\n%s", h.Last, err, h.Result)
+	}
+	return h
+}
+
+// ExpectState fails the test unless the asset's state holds the value at the qualified
+// property, values are compared as JSON so 5 matches 5.0
+func (h *Harness) ExpectState(class iot.AssetClass, assetID string, qprop string, value interface{}) *Harness {
+	a := h.Asset(class, assetID)
+	got, found := iot.GetObject(a.State, qprop)
+	if !found {
+		h.T.Fatalf("asset %s has no property %s", assetID, qprop)
+	}
+	if !jsonEqual(got, value) {
+		h.T.Fatalf("asset %s property %s is %v, expected %v", assetID, qprop, got, value)
+	}
+	return h
+}
+
+// ExpectNoState fails the test if the asset's state has the qualified property
+func (h *Harness) ExpectNoState(class iot.AssetClass, assetID string, qprop string) *Harness {
+	a := h.Asset(class, assetID)
+	if got, found := iot.GetObject(a.State, qprop); found {
+		h.T.Fatalf("asset %s property %s is %v, expected no property", assetID, qprop, got)
+	}
+	return h
+}
+
+// ExpectAlert fails the test unless the alert is active on the asset
+func (h *Harness) ExpectAlert(class iot.AssetClass, assetID string, alert iot.AlertName) *Harness {
+	a := h.Asset(class, assetID)
+	if !iot.Contains(a.AlertsActive, alert) {
+		h.T.Fatalf("asset %s alert %s is not active, active alerts are %v", assetID, alert, a.AlertsActive)
+	}
+	return h
+}
+
+// ExpectNoAlert fails the test if the alert is active on the asset
+func (h *Harness) ExpectNoAlert(class iot.AssetClass, assetID string, alert iot.AlertName) *Harness {
+	a := h.Asset(class, assetID)
+	if iot.Contains(a.AlertsActive, alert) {
+		h.T.Fatalf("asset %s alert %s is active", assetID, alert)
+	}
+	return h
+}
+
+// ExpectCompliant fails the test unless the asset's compliance matches
+func (h *Harness) ExpectCompliant(class iot.AssetClass, assetID string, compliant bool) *Harness {
+	a := h.Asset(class, assetID)
+	if a.Compliant != compliant {
+		h.T.Fatalf("asset %s compliant is %t, expected %t", assetID, a.Compliant, compliant)
+	}
+	return h
+}
+
+// LastEvent returns the event emitted by the most recent invoke, failing the test if there
+// has been none
+func (h *Harness) LastEvent() iotcpstub.Event {
+	if len(h.Stub.Events) == 0 {
+		h.T.Fatal("no event has been emitted")
+	}
+	return h.Stub.Events[len(h.Stub.Events)-1]
+}
+
+// ExpectEvent fails the test unless the most recent invoke emitted the named event with a
+// payload holding the value at the qualified property
+func (h *Harness) ExpectEvent(name string, qprop string, value interface{}) *Harness {
+	e := h.LastEvent()
+	if e.Name != name {
+		h.T.Fatalf("last event is %s, expected %s", e.Name, name)
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(e.Payload, &payload); err != nil {
+		h.T.Fatalf("event %s payload does not unmarshal: %s", e.Name, err)
+	}
+	got, found := iot.GetObject(&payload, qprop)
+	if !found || !jsonEqual(got, value) {
+		h.T.Fatalf("event %s property %s is %v, expected %v", e.Name, qprop, got, value)
+	}
+	return h
+}
+
+// Notifications returns the notifications in the result event of the most recent invoke
+func (h *Harness) Notifications() []iotcpevents.Notification {
+	e := h.LastEvent()
+	env, err := iotcpevents.Decode(e.Payload)
+	if err != nil {
+		h.T.Fatalf("event %s does not decode: %s", e.Name, err)
+	}
+	return env.Notifications
+}
+
+// ExpectNotification fails the test unless the most recent invoke notified the type about
+// the asset, and returns the notification for checks of its data
+func (h *Harness) ExpectNotification(t iotcpevents.Type, class iot.AssetClass, assetID string) iotcpevents.Notification {
+	notifications := h.Notifications()
+	for _, n := range notifications {
+		if n.Type == t && n.Class == class.Name && n.AssetID == assetID {
+			return n
+		}
+	}
+	h.T.Fatalf("no %s notification for %s %s in %+v", t, class.Name, assetID, notifications)
+	return iotcpevents.Notification{}
+}
+
+// ExpectNoNotification fails the test if the most recent invoke notified the type about
+// the asset
+func (h *Harness) ExpectNoNotification(t iotcpevents.Type, class iot.AssetClass, assetID string) *Harness {
+	for _, n := range h.Notifications() {
+		if n.Type == t && n.Class == class.Name && n.AssetID == assetID {
+			h.T.Fatalf("unexpected %s notification for %s %s", t, class.Name, assetID)
+		}
+	}
+	return h
+}
+
+func jsonEqual(a interface{}, b interface{}) bool {
+	var na, nb interface{}
+	ab, erra := json.Marshal(a)
+	bb, errb := json.Marshal(b)
+	if erra != nil || errb != nil {
+		return false
+	}
+	if json.Unmarshal(ab, &na) != nil || json.Unmarshal(bb, &nb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(na, nb)
+}
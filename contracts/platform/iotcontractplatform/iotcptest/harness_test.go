@@ -0,0 +1,318 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package iotcptest
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpevents"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpstub"
+)
+
+type defaultContract struct{}
+
+func (t *defaultContract) Init(stub shim.ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+	return iot.Init(stub, function, args, "1.0")
+}
+
+func (t *defaultContract) Invoke(stub shim.ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+	return iot.Invoke(stub, function, args)
+}
+
+func (t *defaultContract) Query(stub shim.ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+	return iot.Query(stub, function, args)
+}
+
+func init() {
+	iot.RegisterDefaultRoutes()
+}
+
+func TestDefaultClassScenario(t *testing.T) {
+	h := New(t, new(defaultContract))
+	h.Init("1.0").ExpectOK()
+	h.Init("2.0").ExpectError("MUST match")
+
+	h.CreateAsset(iot.DefaultClass, `{"asset":{"assetID":"A1","temperature":5}}`).ExpectOK()
+	h.ExpectEvent(iot.EVTCCINVRESULT, "status", "OK")
+	var alert iotcpevents.AlertData
+	if err := h.ExpectNotification(iotcpevents.AlertRaised, iot.DefaultClass, "A1").DecodeData(&alert); err != nil || alert.Alert != "OVERTEMP" {
+		t.Fatalf("unexpected alert notification %+v (%v)", alert, err)
+	}
+	h.ExpectState(iot.DefaultClass, "A1", "asset.temperature", 5).
+		ExpectAlert(iot.DefaultClass, "A1", "OVERTEMP").
+		ExpectCompliant(iot.DefaultClass, "A1", false)
+
+	h.Advance(time.Hour)
+	h.UpdateAsset(iot.DefaultClass, map[string]interface{}{"asset": map[string]interface{}{"assetID": "A1", "temperature": -2}}).ExpectOK()
+	h.ExpectState(iot.DefaultClass, "A1", "asset.temperature", -2).
+		ExpectNoAlert(iot.DefaultClass, "A1", "OVERTEMP").
+		ExpectCompliant(iot.DefaultClass, "A1", true)
+	h.ExpectNotification(iotcpevents.AlertCleared, iot.DefaultClass, "A1")
+	h.ExpectNoNotification(iotcpevents.AlertRaised, iot.DefaultClass, "A1")
+	ts := h.Asset(iot.DefaultClass, "A1").TXNTS
+	if ts == nil || !ts.Equal(iotcpstub.DefaultStart.Add(time.Hour+3*iotcpstub.DefaultStep)) {
+		t.Fatalf("unexpected transaction timestamp %v", ts)
+	}
+
+	var history []iot.Asset
+	h.ReadAssetStateHistory(iot.DefaultClass, "A1").ExpectResult(&history)
+	if len(history) != 2 {
+		t.Fatalf("expected 2 history states, got %d", len(history))
+	}
+
+	h.CreateAsset(iot.DefaultClass, `{"asset":{"assetID":"A2"}}`).ExpectOK()
+	var recent []iot.Asset
+	h.Query("readRecentStates", `{"class":"default"}`).ExpectResult(&recent)
+	if len(recent) != 2 || recent[0].AssetKey != "DEFA2" {
+		t.Fatalf("unexpected recent states %v", recent)
+	}
+
+	h.Invoke("setCreateOnFirstUpdate", `{"setCreateOnFirstUpdate":false}`).ExpectOK()
+	h.UpdateAsset(iot.DefaultClass, `{"asset":{"assetID":"A3"}}`).ExpectError("does not exist")
+	h.ExpectEvent(iot.EVTCCINVRESULT, "status", "ERROR")
+	h.Invoke("deleteAllAssets", `{"filter":{"match":"all","select":[]}}`).ExpectError("confirm")
+	h.DeleteAsset(iot.DefaultClass, "A2").ExpectOK()
+	var all []iot.Asset
+	h.Query("readAllAssets", `{}`).ExpectResult(&all)
+	if len(all) != 1 {
+		t.Fatalf("expected 1 asset after delete, got %d", len(all))
+	}
+}
+
+func TestContractMetricsAndHealth(t *testing.T) {
+	h := New(t, new(defaultContract))
+	h.Init("1.0").ExpectOK()
+	h.CreateAsset(iot.DefaultClass, `{"asset":{"assetID":"A1","temperature":5}}`).ExpectOK()
+	h.CreateAsset(iot.DefaultClass, `{"asset":{"assetID":"A2","temperature":5}}`).ExpectOK()
+	h.UpdateAsset(iot.DefaultClass, `{"asset":{"assetID":"A1","temperature":-2}}`).ExpectOK()
+	h.Invoke("setCreateOnFirstUpdate", `{"setCreateOnFirstUpdate":false}`).ExpectOK()
+	h.UpdateAsset(iot.DefaultClass, `{"asset":{"assetID":"A3"}}`).ExpectError("does not exist")
+
+	var metrics iot.ContractMetricsOut
+	h.Query("readContractMetrics").ExpectResult(&metrics)
+	if metrics.Assets["default"] != 2 || metrics.Alerts["OVERTEMP"] != 1 || metrics.History["default"] != 3 {
+		t.Fatalf("unexpected counters after updates %+v", metrics)
+	}
+	update, _ := h.classFunction(iot.DefaultClass, iot.UpdateAssetRoute)
+	if metrics.Invokes[update] != 1 || metrics.Invokes["init"] != 1 || metrics.Errors[update] < 1 {
+		t.Fatalf("unexpected invoke counts %+v errors %+v", metrics.Invokes, metrics.Errors)
+	}
+
+	// an update writes the counters of its route and class, errors stay with the peer
+	h.UpdateAsset(iot.DefaultClass, `{"asset":{"assetID":"A2","temperature":6}}`).ExpectOK()
+	var written []string
+	for _, key := range h.Stub.Writes {
+		if strings.HasPrefix(key, iot.CONTRACTMETRICSKEY) {
+			written = append(written, key)
+		}
+	}
+	sort.Strings(written)
+	if !reflect.DeepEqual(written, []string{iot.CONTRACTMETRICSKEY + ".history.default", iot.CONTRACTMETRICSKEY + ".invokes." + update}) {
+		t.Fatalf("the update wrote the counters %v", written)
+	}
+	for key := range h.Stub.State {
+		if strings.HasPrefix(key, iot.CONTRACTMETRICSKEY+".errors.") {
+			t.Fatalf("the error of the failed update is in world state as %s", key)
+		}
+	}
+
+	h.DeleteAsset(iot.DefaultClass, "A2").ExpectOK()
+	var deleted iot.ContractMetricsOut
+	h.Query("readContractMetrics").ExpectResult(&deleted)
+	if deleted.Assets["default"] != 1 || len(deleted.Alerts) != 0 || deleted.History["default"] != 4 {
+		t.Fatalf("unexpected counters after delete %+v", deleted)
+	}
+
+	var health iot.ContractHealthOut
+	h.Query("readContractHealth").ExpectResult(&health)
+	if !health.Healthy || len(health.Checks) != 2 {
+		t.Fatalf("expected a healthy contract, got %+v", health)
+	}
+	h.Stub.Begin(true)
+	h.Stub.DelState("DEFA1")
+	h.Stub.End(true)
+	h.Query("readContractHealth").ExpectResult(&health)
+	if health.Healthy || health.Checks[1].OK || len(health.Checks[1].Details) != 1 {
+		t.Fatalf("expected the recent states check to fail, got %+v", health)
+	}
+}
+
+func TestReplaceAssetInAlarm(t *testing.T) {
+	h := New(t, new(defaultContract))
+	h.Init("1.0").ExpectOK()
+	h.CreateAsset(iot.DefaultClass, `{"asset":{"assetID":"A1","temperature":5}}`).ExpectOK()
+
+	// the replacement is still in alarm, the alert is neither raised again nor counted twice
+	h.ReplaceAsset(iot.DefaultClass, `{"asset":{"assetID":"A1","temperature":6}}`).ExpectOK()
+	h.ExpectAlert(iot.DefaultClass, "A1", "OVERTEMP").
+		ExpectNoNotification(iotcpevents.AlertRaised, iot.DefaultClass, "A1")
+	var metrics iot.ContractMetricsOut
+	h.Query("readContractMetrics").ExpectResult(&metrics)
+	if metrics.Alerts["OVERTEMP"] != 1 {
+		t.Fatalf("unexpected alert counters after replace %+v", metrics.Alerts)
+	}
+
+	h.DeleteAsset(iot.DefaultClass, "A1").ExpectOK()
+	var deleted iot.ContractMetricsOut
+	h.Query("readContractMetrics").ExpectResult(&deleted)
+	if len(deleted.Alerts) != 0 {
+		t.Fatalf("unexpected alert counters after delete %+v", deleted.Alerts)
+	}
+
+	// a replacement out of alarm clears the alert
+	h.CreateAsset(iot.DefaultClass, `{"asset":{"assetID":"A2","temperature":5}}`).ExpectOK()
+	h.ReplaceAsset(iot.DefaultClass, `{"asset":{"assetID":"A2","temperature":-2}}`).ExpectOK()
+	h.ExpectNoAlert(iot.DefaultClass, "A2", "OVERTEMP")
+	h.ExpectNotification(iotcpevents.AlertCleared, iot.DefaultClass, "A2")
+	h.Query("readContractMetrics").ExpectResult(&deleted)
+	if len(deleted.Alerts) != 0 {
+		t.Fatalf("unexpected alert counters after the alert cleared %+v", deleted.Alerts)
+	}
+}
+
+func TestVerifyAndRepairWorldState(t *testing.T) {
+	h := New(t, new(defaultContract))
+	h.Init("1.0").ExpectOK()
+	for _, id := range []string{"A1", "A2", "A3"} {
+		h.CreateAsset(iot.DefaultClass, map[string]interface{}{"asset": map[string]interface{}{"assetID": id}}).ExpectOK()
+	}
+	h.DeleteAsset(iot.DefaultClass, "A2").ExpectOK()
+	var report iot.WorldStateReport
+	h.Query("verifyWorldState").ExpectResult(&report)
+	if len(report.Problems) != 1 || report.Problems[0].Kind != iot.OrphanHistory {
+		t.Fatalf("expected the history of the deleted asset, got %+v", report.Problems)
+	}
+
+	h.Stub.Begin(true)
+	h.Stub.DelState("DEFA3")
+	h.Stub.PutState(iot.STATEHISTORYKEY+"DEFA9.x", []byte("not json"))
+	h.Stub.PutState(iot.PROVENANCEKEY+"DEFA9", []byte("{}"))
+	h.Stub.PutState("DEFA4", []byte(`{"assetclass":{"name":"gone","prefix":"GON"},"assetkey":"DEFA4"}`))
+	h.Stub.PutState("XYZ1", []byte(`{"assetclass":{"name":"gone","prefix":"GON"},"assetkey":"XYZ1"}`))
+	h.Stub.PutState(iot.RECENTSTATESKEY, []byte(`{"recentstates":["DEFA1"]}`))
+	h.Stub.End(true)
+	var recent []iot.Asset
+	h.Query("readRecentStates", `{"class":"default"}`).ExpectResult(&recent)
+	if len(recent) != 1 || recent[0].AssetKey != "DEFA1" {
+		t.Fatalf("expected only the existing recent state, got %v", recent)
+	}
+
+	var kinds = make(map[iot.WorldStateProblemKind]int)
+	h.Query("verifyWorldState").ExpectResult(&report)
+	for _, p := range report.Problems {
+		kinds[p.Kind]++
+	}
+	if kinds[iot.OrphanHistory] != 2 || kinds[iot.OrphanProvenance] != 1 || kinds[iot.DanglingRecentState] != 1 ||
+		kinds[iot.ClassMismatch] != 2 || kinds[iot.Unparsable] != 1 || kinds[iot.LegacyRecentStates] != 1 ||
+		report.Remaining != 7 || report.Next != "" {
+		t.Fatalf("unexpected problems %+v", report.Problems)
+	}
+
+	h.Invoke("repairWorldState", `{"limit":2}`).ExpectError("confirm")
+	h.InvokeConfirmed("repairWorldState", `{"limit":2}`).ExpectOK()
+	h.ExpectEvent(iot.EVTCCINVRESULT, "repaired", 2)
+	var batch iot.WorldStateReport
+	if err := json.Unmarshal(h.LastEvent().Payload, &batch); err != nil {
+		t.Fatal(err)
+	}
+	// the batch stops at the key after its last problem, the history of A3
+	if !strings.HasPrefix(batch.Next, iot.STATEHISTORYKEY+"DEFA3.") {
+		t.Fatalf("expected the next batch to begin at the history of A3, got '%s'", batch.Next)
+	}
+	h.InvokeConfirmed("repairWorldState", `{"limit":0}`).ExpectError("between 1")
+	h.InvokeConfirmed("repairWorldState", map[string]interface{}{"begin": batch.Next}).ExpectOK()
+	h.ExpectEvent(iot.EVTCCINVRESULT, "repaired", 5)
+	if payload := string(h.LastEvent().Payload); strings.Contains(payload, `"next"`) {
+		t.Fatalf("expected the last batch to reach the end of world state, got %s", payload)
+	}
+	var repaired iot.WorldStateReport
+	h.Query("verifyWorldState").ExpectResult(&repaired)
+	if len(repaired.Problems) != 1 || repaired.Problems[0].Key != "XYZ1" || repaired.Problems[0].Repairable {
+		t.Fatalf("expected only the asset without a class, got %+v", repaired.Problems)
+	}
+	if a := h.Asset(iot.DefaultClass, "A4"); a.Class.Name != iot.DefaultClass.Name {
+		t.Fatalf("expected the asset's class to be repaired, got %+v", a.Class)
+	}
+	if _, found := h.Stub.State[iot.RECENTSTATESKEY]; found {
+		t.Fatal("the legacy recent states survived the repair")
+	}
+}
+
+func TestStubRangeQueryHonoursKeys(t *testing.T) {
+	s := iotcpstub.NewStub("range")
+	s.Begin(true)
+	for _, k := range []string{"A", "B1", "B2", "B3", "C"} {
+		s.PutState(k, []byte(k))
+	}
+	s.End(true)
+	iter, _ := s.RangeQueryState("B", "B}")
+	var keys []string
+	for iter.HasNext() {
+		k, _, _ := iter.Next()
+		keys = append(keys, k)
+	}
+	iter.Close()
+	if len(keys) != 3 || keys[0] != "B1" || keys[2] != "B3" {
+		t.Fatalf("unexpected range %v", keys)
+	}
+}
+
+func TestWorldStateRoutesAreGuarded(t *testing.T) {
+	h := New(t, new(defaultContract))
+	h.Init("1.0").ExpectOK()
+	h.CreateAsset(iot.DefaultClass, `{"asset":{"assetID":"A1"}}`).ExpectOK()
+
+	// the plain "reinit" argument of earlier releases is confirmed as {"reinit":true}
+	h.Invoke("deleteWorldState", "reinit").ExpectError("confirm")
+	var token iot.ConfirmationToken
+	h.Query("readConfirmationToken", `{"function":"deleteWorldState","args":{"reinit":true}}`).ExpectResult(&token)
+	h.Invoke("deleteWorldState", "reinit", `{"confirm":"`+token.Token+`"}`).ExpectOK()
+	h.Query("readContractState").ExpectOK()
+	var all []iot.Asset
+	h.Query("readAllAssets", `{}`).ExpectResult(&all)
+	if len(all) != 0 {
+		t.Fatalf("expected no assets after deleteWorldState, got %d", len(all))
+	}
+
+	var chunk iot.WorldStateSnapshot
+	h.Query("exportWorldState").ExpectResult(&chunk)
+	h.Invoke("importWorldState", chunk).ExpectOK()
+	h.Invoke("setProductionMode", `{"productionMode":true}`).ExpectOK()
+	h.Invoke("importWorldState", chunk).ExpectError("production mode")
+	h.InvokeConfirmed("repairWorldState", `{}`).ExpectError("production mode")
+	h.InvokeConfirmed("deleteWorldState", `{"reinit":true}`).ExpectError("production mode")
+}
+
+func TestDeleteWorldStateUnroutesRuntimeClasses(t *testing.T) {
+	h := New(t, new(defaultContract))
+	h.Init("1.0").ExpectOK()
+	h.Invoke("defineAssetClass", `{"class":{"name":"Valve","prefix":"VLV","assetIDpath":"valve.id"}}`).ExpectOK()
+	h.Invoke("createAssetValve", `{"valve":{"id":"V1"}}`).ExpectOK()
+	h.Query("readConfirmationToken", `{"function":"deleteAllAssetsValve","args":{}}`).ExpectOK()
+	h.InvokeConfirmed("deleteWorldState", `{"reinit":true}`).ExpectOK()
+	h.Invoke("createAssetValve", `{"valve":{"id":"V2"}}`).ExpectError("did not find")
+	h.Query("readConfirmationToken", `{"function":"deleteAllAssetsValve","args":{}}`).ExpectError("not a destructive route")
+	h.Invoke("defineAssetClass", `{"class":{"name":"Valve","prefix":"VLV","assetIDpath":"valve.id"}}`).ExpectOK()
+	h.Invoke("createAssetValve", `{"valve":{"id":"V2"}}`).ExpectOK()
+}
@@ -1,624 +1,2021 @@
-/*
-Copyright (c) 2016 IBM Corporation and other Contributors.
-
-Licensed under the Apache License, Version 2.0 (the "License");
-you may not use this file except in compliance with the License.
-You may obtain a copy of the License at
-
-    http://www.apache.org/licenses/LICENSE-2.0
-
-Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
-See the License for the specific language governing permissions and limitations under the License.
-
-Contributors:
-Kim Letkeman - Initial Contribution
-*/
-
-// KL 2016 Nov 20 rewrite, as funky behaviors had crept in with old algorithm, new algorithm uses lookup
-//                table for references, which will improve accuracy and performance
-
-package main
-
-import (
-	"bufio"
-	"encoding/json"
-	"errors"
-	"flag"
-	"fmt"
-	"io/ioutil"
-	"os"
-	"path/filepath"
-	"strings"
-	"time"
-)
-
-// Config defines contents of "generate.json" colocated in scripts folder with this script
-type Config struct {
-	Schemas struct {
-		SchemaFilename   string   `json:"schemaFilename"`
-		GoSchemaFilename string   `json:"goSchemaFilename"`
-		API              []string `json:"API"`
-		Model            []string `json:"Model"`
-	} `json:"schemas"`
-	Samples struct {
-		GoSampleFilename string   `json:"goSampleFilename"`
-		API              []string `json:"API"`
-		Model            []string `json:"Model"`
-	} `json:"samples"`
-}
-
-var configFile = flag.String("configFile", "generate.json", "json file that selects API to be exposed")
-var verbose = flag.Bool("debug", false, "prints information during processing to help debug schema issues")
-var config Config
-var finalschema map[string]interface{}
-var lookup = make(map[string]interface{}, 0)
-
-// PrettyPrint returns an indented JSON stringified object
-func PrettyPrint(m interface{}) string {
-	bytes, _ := json.MarshalIndent(m, "", "    ")
-	return string(bytes)
-}
-
-// PrettyPrintBytes returns an indented JSON stringified object as []bytes
-func PrettyPrintBytes(m interface{}) []byte {
-	bytes, _ := json.MarshalIndent(m, "", "    ")
-	return bytes
-}
-
-// DeepMergeMap all levels of a src map into a dst map and return dst
-func DeepMergeMap(srcIn map[string]interface{}, dstIn map[string]interface{}) map[string]interface{} {
-	for k, v := range srcIn {
-		switch v.(type) {
-		case map[string]interface{}:
-			dstv, found := dstIn[k]
-			if found {
-				// recursive DeepMerge into existing key
-				dstIn[k] = DeepMergeMap(v.(map[string]interface{}), dstv.(map[string]interface{}))
-			} else {
-				// copy src to dst at same key
-				dstIn[k] = v
-			}
-		default:
-			// copy discrete type
-			dstIn[k] = v
-		}
-	}
-	return dstIn
-}
-
-// can print very accurate syntax errors as found by the JSON marshaler
-// relies on the offset table created when reading the schema JSON file and expunging
-// comments and blank lines
-func printSyntaxErrorIncludes(js string, err interface{}) string {
-	syntax, ok := err.(*json.SyntaxError)
-	if !ok {
-		fmt.Println("*********** ERR trying to get syntax error location **************\n", err)
-		return "*********** ERR trying to get syntax error location **************"
-	}
-
-	start, end := strings.LastIndex(js[:syntax.Offset], "\n")+1, len(js)
-	if idx := strings.Index(js[start:], "\n"); idx >= 0 {
-		end = start + idx
-	}
-
-	line, pos := strings.Count(js[:start], "\n"), int(syntax.Offset)-start-1
-
-	e := fmt.Sprintf("Error in line %d: %s \n", line, err)
-	e += fmt.Sprintf("%s\n%s^\n\n", js[start:end], strings.Repeat(" ", pos))
-	fmt.Println(e)
-	return e
-}
-
-// can print very accurate syntax errors as found by the JSON marshaler
-// relies on the offset table created when reading the schema JSON file and expunging
-// comments and blank lines
-func printSyntaxErrorOffsets(js string, off *[5000]int, err interface{}) string {
-	syntax, ok := err.(*json.SyntaxError)
-	if !ok {
-		fmt.Println("*********** ERR trying to get syntax error location **************\n", err)
-		return "*********** ERR trying to get syntax error location **************"
-	}
-
-	start, end := strings.LastIndex(js[:syntax.Offset], "\n")+1, len(js)
-	if idx := strings.Index(js[start:], "\n"); idx >= 0 {
-		end = start + idx
-	}
-
-	line, pos := strings.Count(js[:start], "\n"), int(syntax.Offset)-start-1
-
-	e := fmt.Sprintf("Error in line %d: %s \n", off[line]+1, err)
-	e += fmt.Sprintf("%s\n%s^\n\n", js[start:end], strings.Repeat(" ", pos))
-	fmt.Println(e)
-	return e
-}
-
-// GetObject finds an object by its qualified name, which looks like "location.latitude"
-// as one example. Returns as interface{} to maintain generic handling
-func getObject(objIn interface{}, qname string, level string) interface{} {
-	if objIn == nil {
-		fmt.Printf("Error: GetObject received nil schema from which to search for %s in %s\n", level, qname)
-		os.Exit(1)
-	}
-	s := strings.SplitN(strings.TrimPrefix(level, "#/"), "/", 2)
-	var leaf = len(s) == 1
-	searchObj, found := objIn.(map[string]interface{})
-	if !found {
-		fmt.Printf("Error: object %s not map shaped at level %s\n", qname, level)
-	}
-	props, found := (searchObj["properties"]).(map[string]interface{})
-	if !found {
-		props, found = (searchObj["patternProperties"]).(map[string]interface{})
-	}
-	if found {
-		searchObj = props
-	}
-	if o, found := searchObj[s[0]]; found {
-		if leaf {
-			return o
-		}
-		return getObject(o, qname, s[1])
-	}
-	return nil
-}
-
-// replaces all references recursively in the passed-in object (subschema) using the passed-in schema
-func replaceReferences(schema map[string]interface{}, name string, obj interface{}) interface{} {
-	oMap, isMap := obj.(map[string]interface{})
-	switch {
-	default:
-		return obj
-	case isMap:
-		for k, v := range oMap {
-			if k == "$ref" {
-				r, found := lookup[v.(string)]
-				if !found {
-					fmt.Printf("** ERROR ** replaceReferences failed to lookup %s\n", v.(string))
-					os.Exit(1)
-				}
-				if mapr, found := r.(map[string]interface{}); found {
-					for kk, vv := range mapr {
-						oMap[kk] = replaceReferences(schema, kk, vv)
-					}
-				}
-				delete(oMap, "$ref")
-			} else {
-				oMap[k] = replaceReferences(schema, k, v)
-			}
-		}
-		return oMap
-	}
-}
-
-// Generates a file <munged elementName>.go to contain a string literal for the pretty version
-// of the schema with all references resolved. In the same file, creates a sample JSON that
-// can be used to show a complete structure of the object.
-func generateGoSchemaFile(schema map[string]interface{}, config Config, imports string, regSchemas string) {
-	var obj interface{}
-	var schemas = make(map[string]interface{})
-	var outString = "package main\n\n" + imports + "\n\n" + "var schemas = `\n\n"
-
-	var filename = config.Schemas.GoSchemaFilename
-	var apiFunctions = config.Schemas.API
-	var elementNames = config.Schemas.Model
-
-	schemas["API"] = make(map[string]interface{})
-	schemas["Model"] = make(map[string]interface{})
-
-	for i := range apiFunctions {
-		functionSchemaName := "API/" + apiFunctions[i]
-		obj = getObject(schema, functionSchemaName, functionSchemaName)
-		if obj == nil {
-			fmt.Printf("** WARN ** %s returned nil from getObject\n", functionSchemaName)
-			return
-		}
-		schemas["API"].(map[string]interface{})[apiFunctions[i]] = obj
-	}
-
-	for i := range elementNames {
-		elementName := "Model/" + elementNames[i]
-		obj = getObject(schema, elementName, elementName)
-		if obj == nil {
-			fmt.Printf("** ERR ** %s returned nil from getObject\n", elementName)
-			return
-		}
-		schemas["Model"].(map[string]interface{})[elementNames[i]] = obj
-	}
-
-	schemaOut, err := json.MarshalIndent(&schemas, "", "    ")
-	if err != nil {
-		fmt.Printf("** ERR ** cannot marshal schema file output for writing\n")
-		return
-	}
-	outString += string(schemaOut) + "`\n\n" + regSchemas
-	ioutil.WriteFile(filename, []byte(outString), 0644)
-}
-
-func sampleType(obj interface{}, elementName string) interface{} {
-	o, found := obj.(map[string]interface{})
-	if !found {
-		return "SCHEMA ELEMENT " + elementName + " IS NOT MAP"
-	}
-	t, found := o["type"].(string)
-	if !found {
-		if elementName == "oneOf" {
-			return o
-		}
-		return "NO TYPE PROPERTY"
-	}
-	switch t {
-	default:
-		fmt.Printf("** WARN ** Unknown type in sampleType %s\n", t)
-	case "number":
-		return 123.456
-	case "integer":
-		return 789
-	case "string":
-		if strings.ToLower(elementName) == "timestamp" {
-			return time.Now().Format(time.RFC3339Nano)
-		}
-		example, found := o["example"].(string)
-		if found && len(example) > 0 {
-			return example
-		}
-		def, found := o["default"].(string)
-		if found && len(def) > 0 {
-			return def
-		}
-		enum, found := o["enum"].([]interface{})
-		if found {
-			if len(enum) > 1 {
-				return enum[1]
-			}
-			if len(enum) > 0 {
-				return enum[0]
-			}
-		}
-		desc, found := o["description"].(string)
-		if found && len(desc) > 0 {
-			return desc
-		}
-		return "carpe noctem"
-	case "null":
-		return nil
-	case "boolean":
-		return true
-	case "array":
-		var items, found = o["items"].(map[string]interface{})
-		if !found {
-			// fmt.Printf("** WARN ** Element %s is array with no items property\n", elementName)
-			return "ARRAY WITH NO ITEMS PROPERTY"
-		}
-		return arrayFromSchema(items, elementName)
-	case "object":
-		{
-			var props map[string]interface{}
-			var found bool
-			props, found = o["properties"].(map[string]interface{})
-			if !found {
-				props, found = (o["patternProperties"]).(map[string]interface{})
-			}
-			if !found {
-				// fmt.Printf("** WARN ** %s is type object yet has no properties in SampleType\n", elementName)
-				return "INVALID OBJECT - MISSING PROPERTIES"
-			}
-			objOut := make(map[string]interface{})
-			for k, v := range props {
-				//// fmt.Printf("Visiting key %s with value %s\n", k, v)
-				if v == nil {
-					fmt.Printf("** WARN ** Key %s has NIL value in SampleType\n", k)
-					return "INVALID OBJECT - " + fmt.Sprintf("Key %s has NIL value in SampleType\n", k)
-				}
-				aArr, isArr := v.([]interface{})
-				aMap, isMap := v.(map[string]interface{})
-				switch {
-				case isArr:
-					if "oneOf" == k {
-						aOut := make([]interface{}, len(aArr))
-						// outer loop is anonymous objects
-						for k2, v2 := range aArr {
-							//// fmt.Printf("SAMTYP outer OneOf: %d [%v]\n", k2, v2)
-							vObj, found := v2.(map[string]interface{})
-							if found {
-								// inner loop should find one named object
-								for k3, v3 := range vObj {
-									tmp := make(map[string]interface{}, 1)
-									//// fmt.Printf("SAMTYP inner OneOf: %s [%v]\n", k3, v3)
-									//printObject(k3, v3)
-									tmp[k3] = sampleType(v3, k3)
-									aOut[k2] = tmp
-								}
-							}
-							objOut[k] = aOut
-						}
-					} else {
-						objOut[k] = "UNKNOWN ARRAY OBJECT"
-					}
-				case isMap:
-					objOut[k] = sampleType(aMap, k)
-				}
-			}
-			return objOut
-		}
-	}
-	fmt.Printf("** WARN ** UNKNOWN TYPE in SampleType: %s\n", t)
-	return fmt.Sprintf("UNKNOWN TYPE in SampleType: %s\n", t)
-}
-
-// Generate a sample array from a schema
-func arrayFromSchema(schema map[string]interface{}, elementName string) interface{} {
-	enum, found := schema["enum"]
-	if found {
-		// there is a set of enums, just use it
-		return enum
-	}
-	return []interface{}{sampleType(schema, elementName)}
-}
-
-// Generates a file <munged elementName>.go to contain a string literal for the pretty version
-// of the schema with all references resolved. In the same file, creates a sample JSON that
-// can be used to show a complete structure of the object.
-func generateGoSampleFile(schema map[string]interface{}, config Config, imports string, regSamples string) {
-	var obj interface{}
-	var samples = make(map[string]interface{})
-	var outString = "package main\n\n" + imports + "\n\n" + "var samples = `\n\n"
-
-	var filename = config.Samples.GoSampleFilename
-	var apiFunctions = config.Samples.API
-	var modelNames = config.Samples.Model
-
-	samples["API"] = interface{}(make(map[string]interface{}))
-	samples["Model"] = interface{}(make(map[string]interface{}))
-
-	for i := range apiFunctions {
-		functionSchemaName := "API/" + apiFunctions[i]
-		// use the schema subset
-		obj = getObject(schema, functionSchemaName, functionSchemaName)
-		if obj == nil {
-			fmt.Printf("** WARN ** %s returned nil from getObject\n", functionSchemaName)
-			return
-		}
-		samples["API"].(map[string]interface{})[apiFunctions[i]] = sampleType(obj, functionSchemaName)
-	}
-	for i := range modelNames {
-		modelName := "Model/" + modelNames[i]
-		// use the schema subset
-		obj = getObject(schema, modelName, modelName)
-		if obj == nil {
-			fmt.Printf("** WARN ** %s returned nil from getObject\n", modelName)
-			return
-		}
-		samples["Model"].(map[string]interface{})[modelNames[i]] = sampleType(obj, modelName)
-	}
-	samplesOut, err := json.MarshalIndent(&samples, "", "    ")
-	if err != nil {
-		fmt.Println("** ERR ** cannot marshal sample file output for writing")
-		return
-	}
-	outString += string(samplesOut) + "`\n\n" + regSamples
-	ioutil.WriteFile(filename, []byte(outString), 0644)
-}
-
-func loadModelTables(schema map[string]interface{}) {
-	model, modelfound := schema["definitions"].(map[string]interface{})["Model"].(map[string]interface{})
-	if !modelfound {
-		fmt.Println("Warning: no Model section found in schema")
-		os.Exit(1)
-	} else {
-		// all model entries as copies placed in lookup table
-		for k, v := range model {
-			lookup["#/definitions/Model/"+k] = DeepMergeMap(v.(map[string]interface{}), make(map[string]interface{}, 0))
-		}
-		// references in copies replaced
-		for k := range model {
-			lookup["#/definitions/Model/"+k] = replaceReferences(schema, k, lookup["#/definitions/Model/"+k])
-		}
-	}
-	if *verbose {
-		lookupfilename := "Model.lookup.table.json"
-		fmt.Println("Writing model lookup table to: " + lookupfilename)
-		_ = ioutil.WriteFile(lookupfilename, PrettyPrintBytes(lookup), 0744)
-	}
-}
-
-func buildResolvedSchema(schema map[string]interface{}) map[string]interface{} {
-	newSchema := make(map[string]interface{}, 0)
-	newSchema["Model"] = make(map[string]interface{}, 0)
-	var names []string
-	for name, modelObj := range lookup {
-		names = strings.SplitAfter(name, "#/definitions/Model/")
-		if len(names) != 2 {
-			fmt.Println("Error: cannot get last segment of name: " + name)
-			os.Exit(1)
-		}
-		newSchema["Model"].(map[string]interface{})[names[1]] = modelObj.(map[string]interface{})
-	}
-	// use table to resolve API references
-	newAPI := DeepMergeMap(schema["definitions"].(map[string]interface{})["API"].(map[string]interface{}), make(map[string]interface{}))
-	newAPI = replaceReferences(schema, "API", newAPI).(map[string]interface{})
-	newSchema["API"] = newAPI
-
-	return newSchema
-}
-
-func getIncludedFile(path string, localpath string) string {
-	var retstr = make([]byte, 0)
-	var err error
-	parts := strings.Split(path, "/#")
-	includeschema := parts[0]
-	level := parts[1]
-	paths := strings.Split(os.Getenv("GOPATH"), ":")
-	for _, s := range paths {
-		retstr, err = ioutil.ReadFile(s + "/src/" + includeschema)
-		if err != nil {
-			if os.IsNotExist(err) {
-				// fmt.Println("****** include file not found on path: " + s + "/src/" + includeschema)
-				continue
-			}
-			panic(errors.New("unknown error reading include file " + s + includeschema + err.Error()))
-		} else {
-			// fmt.Println("****** include file found on path: " + s + "/src/" + includeschema)
-			break
-		}
-	}
-	if retstr == nil || len(retstr) == 0 {
-		fmt.Println("\nIncluded schema file not found on GOPATH: " + os.Getenv("GOPATH") + ": " + includeschema)
-		fmt.Println("    -- please ensure that you have cloned or fetched the platform to your GOPATH")
-		fmt.Println("    -- also, please ensure that you add /local-dev to your GOPATH using the command")
-		fmt.Printf("       'export GOPATH=/opt/gopath:/local-dev' and then run 'go generate' again\n\n")
-		os.Exit(1)
-	}
-	var ischema interface{}
-	err = json.Unmarshal(retstr, &ischema)
-	if err != nil {
-		fmt.Println("*********** UNMARSHAL ERR **************\n", err)
-		synerr := printSyntaxErrorIncludes(string(retstr), err)
-		incfilename := localpath + "schema.with.failed.include.json"
-		fmt.Println("Writing filed preprocessed schema to: " + incfilename)
-		_ = ioutil.WriteFile(incfilename, retstr, 0744)
-		panic(errors.New("unmarshal of schema with includes failed with err: " + synerr))
-	}
-	m, found := ischema.(map[string]interface{})
-	if !found {
-		panic(errors.New("included schema is not map shaped"))
-	}
-	o := getObject(m, "#/"+level, "#/"+level)
-	if o == nil {
-		panic(errors.New("Level " + level + " not found in schema " + path))
-	}
-	retstr, err = json.MarshalIndent(o, "", "   ")
-	if err != nil {
-		panic(errors.New("Level " + level + " in schema " + path + " failed to marshal: " + err.Error()))
-	}
-	// fmt.Println("\n\n RETURNING FROM INCLUDE PROCESSING\n\n" + string(retstr) + "\n\n")
-	return string(retstr)
-}
-
-// Reads payloadschema.json api file
-// encodes as a string literal in payloadschema.go
-func main() {
-
-	flag.Parse()
-
-	if *verbose {
-		fmt.Printf("genschema runs with config file %s\n", *configFile)
-	}
-
-	var regReadSamples = `
-	var readAssetSamples iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-		return []byte(samples), nil
-	}
-
-	func init() {
-		iot.AddRoute("readAssetSamples", "query", iot.SystemClass, readAssetSamples)
-	}
-	`
-	var regReadSchemas = `
-	var readAssetSchemas iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
-		return []byte(schemas), nil
-	}
-	func init() {
-		iot.AddRoute("readAssetSchemas", "query", iot.SystemClass, readAssetSchemas)
-	}
-	`
-	var imports = `
-	import (
-		"github.com/hyperledger/fabric/core/chaincode/shim"
-		iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
-)`
-
-	var api string
-	var line = 1
-	var lineOut = 1
-	var offsets [5000]int
-
-	filename, _ := filepath.Abs("./" + *configFile)
-	jsonFile, err := ioutil.ReadFile(filename)
-	if err != nil {
-		panic(errors.New("error reading json file" + err.Error()))
-	}
-	err = json.Unmarshal(jsonFile, &config)
-	if err != nil {
-		panic(errors.New("error unmarshaling json config" + err.Error()))
-	}
-
-	// ************** Stage 1
-	// read the schema and preprocess for file includes at the top level
-	filepre, err := os.Open(config.Schemas.SchemaFilename)
-	if err != nil {
-		fmt.Printf("** ERR ** [%s] opening input schema file at %s\n", err, config.Schemas.SchemaFilename)
-		return
-	}
-	defer filepre.Close()
-	reader := bufio.NewReader(filepre)
-	scanner := bufio.NewScanner(reader)
-	scanner.Split(bufio.ScanLines)
-	for scanner.Scan() {
-		l := scanner.Text()
-		// fmt.Println("MAIN SCHEMA: " + l)
-		ts := strings.TrimSpace(l)
-		if strings.HasPrefix(ts, "#") {
-			fmt.Println("Line: ", line, " is a comment")
-		} else if ts == "" {
-			fmt.Println("Line: ", line, " is blank")
-		} else if strings.HasPrefix(ts, "\"$ref\"") && strings.Index(ts, "\"#/") == -1 {
-			ss := strings.Split(ts, "\"")
-			p := ss[len(ss)-2]
-			// fmt.Printf("line: %d includes: %s\n", line, p)
-			refArr := getIncludedFile(p, "./")
-			lines := strings.Split(refArr, "\n")
-			// remove open and close brace as we are replacing the reference in place with the contents of the names object
-			lines = lines[1 : len(lines)-1]
-			for _, l2 := range lines {
-				api += l2 + "\n"
-				lineOut++
-			}
-			if len(ss) > 0 && ss[len(ss)-1] == "," {
-				api += ","
-			}
-		} else {
-			api += l + "\n"
-			lineOut++
-		}
-		offsets[lineOut] = line
-		line++
-	}
-
-	// ************** Stage 2
-	// unmarshal the preprocessed schema
-	var schema map[string]interface{}
-	err = json.Unmarshal([]byte(api), &schema)
-	if err != nil {
-		fmt.Println("*********** UNMARSHAL ERR **************\n", err)
-		printSyntaxErrorOffsets(api, &offsets, err)
-		return
-	}
-
-	if *verbose {
-		prefilename := strings.Split(filename, "/")[0] + "schema.with.includes.json"
-		fmt.Println("Writing preprocessed schema to: " + prefilename)
-		_ = ioutil.WriteFile(prefilename, PrettyPrintBytes(schema), 0744)
-	}
-
-	// ************** Stage 3
-	// load the lookup tables with the data model, resolves all Model references
-	loadModelTables(schema)
-
-	// ************** Stage 4
-	// build final schema by inserting API, resolving all references using lookup table, and
-	// inserting the data model from the lookup table
-	finalschema = buildResolvedSchema(schema)
-
-	if *verbose {
-		finalfilename := strings.Split(filename, "/")[0] + "schema.with.no.refs.json"
-		fmt.Println("Writing final schema to: " + finalfilename)
-		_ = ioutil.WriteFile(finalfilename, []byte(PrettyPrint(finalschema)), 0744)
-	}
-
-	// ************** Stage 5
-	// generate the Go files that the contract needs -- for now, complete schema and
-	// event schema and sample object
-
-	generateGoSchemaFile(finalschema, config, imports, regReadSchemas)
-	generateGoSampleFile(finalschema, config, imports, regReadSamples)
-
-}
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// KL 2016 Nov 20 rewrite, as funky behaviors had crept in with old algorithm, new algorithm uses lookup
+//                table for references, which will improve accuracy and performance
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Config defines contents of "generate.json" colocated in scripts folder with this script
+type Config struct {
+	Schemas struct {
+		SchemaFilename   string   `json:"schemaFilename"`
+		GoSchemaFilename string   `json:"goSchemaFilename"`
+		API              []string `json:"API"`
+		Model            []string `json:"Model"`
+		GoRoutesFilename string   `json:"goRoutesFilename"`
+		GoSchemaElements []string `json:"goSchemaElements"` // legacy contracts only
+	} `json:"schemas"`
+	Samples struct {
+		GoSampleFilename string   `json:"goSampleFilename"`
+		API              []string `json:"API"`
+		Model            []string `json:"Model"`
+		GoSampleElements []string `json:"goSampleElements"` // legacy contracts only
+		Fixtures         struct {
+			FixturesFilename string   `json:"fixturesFilename"`
+			Count            int      `json:"count"`
+			Seed             int64    `json:"seed"`
+			API              []string `json:"API"`
+		} `json:"fixtures"`
+	} `json:"samples"`
+	Types struct {
+		GoTypesFilename string   `json:"goTypesFilename"`
+		Model           []string `json:"Model"`
+	} `json:"types"`
+	OpenAPI struct {
+		OpenAPIFilename string   `json:"openAPIFilename"`
+		Title           string   `json:"title"`
+		Version         string   `json:"version"`
+		API             []string `json:"API"`
+	} `json:"openapi"`
+	Client struct {
+		GoClientFilename string   `json:"goClientFilename"`
+		API              []string `json:"API"`
+	} `json:"client"`
+	Includes struct {
+		SearchPath []string `json:"searchPath"`
+		Cache      string   `json:"cache"`
+	} `json:"includes"`
+}
+
+var configFile = flag.String("configFile", "generate.json", "json file that selects API to be exposed")
+var verbose = flag.Bool("debug", false, "prints information during processing to help debug schema issues")
+var check = flag.Bool("check", false, "reports unresolved references and functions or Models missing from the schema, generates nothing")
+var routesFile = flag.String("routes", "", "with -check, a file with the output of readAllRoutes to compare with the schema's API")
+var compare = flag.String("compare", "", "reports backward incompatible changes from this older version of the schema, generates nothing")
+var config Config
+var finalschema map[string]interface{}
+var lookup = make(map[string]interface{}, 0)
+
+// PrettyPrint returns an indented JSON stringified object
+func PrettyPrint(m interface{}) string {
+	bytes, _ := json.MarshalIndent(m, "", "    ")
+	return string(bytes)
+}
+
+// PrettyPrintBytes returns an indented JSON stringified object as []bytes
+func PrettyPrintBytes(m interface{}) []byte {
+	bytes, _ := json.MarshalIndent(m, "", "    ")
+	return bytes
+}
+
+// DeepMergeMap all levels of a src map into a dst map and return dst
+func DeepMergeMap(srcIn map[string]interface{}, dstIn map[string]interface{}) map[string]interface{} {
+	for k, v := range srcIn {
+		switch v.(type) {
+		case map[string]interface{}:
+			dstv, found := dstIn[k]
+			if found {
+				// recursive DeepMerge into existing key
+				dstIn[k] = DeepMergeMap(v.(map[string]interface{}), dstv.(map[string]interface{}))
+			} else {
+				// copy src to dst at same key
+				dstIn[k] = v
+			}
+		default:
+			// copy discrete type
+			dstIn[k] = v
+		}
+	}
+	return dstIn
+}
+
+// can print very accurate syntax errors as found by the JSON marshaler
+// relies on the offset table created when reading the schema JSON file and expunging
+// comments and blank lines
+func printSyntaxErrorIncludes(js string, err interface{}) string {
+	syntax, ok := err.(*json.SyntaxError)
+	if !ok {
+		fmt.Println("*********** ERR trying to get syntax error location **************\n", err)
+		return "*********** ERR trying to get syntax error location **************"
+	}
+
+	start, end := strings.LastIndex(js[:syntax.Offset], "\n")+1, len(js)
+	if idx := strings.Index(js[start:], "\n"); idx >= 0 {
+		end = start + idx
+	}
+
+	line, pos := strings.Count(js[:start], "\n"), int(syntax.Offset)-start-1
+
+	e := fmt.Sprintf("Error in line %d: %s \n", line, err)
+	e += fmt.Sprintf("%s\n%s^\n\n", js[start:end], strings.Repeat(" ", pos))
+	fmt.Println(e)
+	return e
+}
+
+// can print very accurate syntax errors as found by the JSON marshaler
+// relies on the offset table created when reading the schema JSON file and expunging
+// comments and blank lines
+func printSyntaxErrorOffsets(js string, off *[5000]int, err interface{}) string {
+	syntax, ok := err.(*json.SyntaxError)
+	if !ok {
+		fmt.Println("*********** ERR trying to get syntax error location **************\n", err)
+		return "*********** ERR trying to get syntax error location **************"
+	}
+
+	start, end := strings.LastIndex(js[:syntax.Offset], "\n")+1, len(js)
+	if idx := strings.Index(js[start:], "\n"); idx >= 0 {
+		end = start + idx
+	}
+
+	line, pos := strings.Count(js[:start], "\n"), int(syntax.Offset)-start-1
+
+	e := fmt.Sprintf("Error in line %d: %s \n", off[line]+1, err)
+	e += fmt.Sprintf("%s\n%s^\n\n", js[start:end], strings.Repeat(" ", pos))
+	fmt.Println(e)
+	return e
+}
+
+// GetObject finds an object by its qualified name, which looks like "location.latitude"
+// as one example. Returns as interface{} to maintain generic handling
+func getObject(objIn interface{}, qname string, level string) interface{} {
+	if objIn == nil {
+		fmt.Printf("Error: GetObject received nil schema from which to search for %s in %s\n", level, qname)
+		os.Exit(1)
+	}
+	s := strings.SplitN(strings.TrimPrefix(level, "#/"), "/", 2)
+	var leaf = len(s) == 1
+	searchObj, found := objIn.(map[string]interface{})
+	if !found {
+		fmt.Printf("Error: object %s not map shaped at level %s\n", qname, level)
+	}
+	props, found := (searchObj["properties"]).(map[string]interface{})
+	if !found {
+		props, found = (searchObj["patternProperties"]).(map[string]interface{})
+	}
+	if found {
+		searchObj = props
+	}
+	if o, found := searchObj[s[0]]; found {
+		if leaf {
+			return o
+		}
+		return getObject(o, qname, s[1])
+	}
+	return nil
+}
+
+// replaces all references recursively in the passed-in object (subschema) using the passed-in schema
+func replaceReferences(schema map[string]interface{}, name string, obj interface{}) interface{} {
+	oMap, isMap := obj.(map[string]interface{})
+	switch {
+	default:
+		return obj
+	case isMap:
+		for k, v := range oMap {
+			if k == "$ref" {
+				r, found := lookup[v.(string)]
+				if !found {
+					fmt.Printf("** ERROR ** replaceReferences failed to lookup %s\n", v.(string))
+					os.Exit(1)
+				}
+				if mapr, found := r.(map[string]interface{}); found {
+					for kk, vv := range mapr {
+						oMap[kk] = replaceReferences(schema, kk, vv)
+					}
+				}
+				delete(oMap, "$ref")
+			} else {
+				oMap[k] = replaceReferences(schema, k, v)
+			}
+		}
+		return oMap
+	}
+}
+
+// Generates a file <munged elementName>.go to contain a string literal for the pretty version
+// of the schema with all references resolved. In the same file, creates a sample JSON that
+// can be used to show a complete structure of the object.
+func generateGoSchemaFile(schema map[string]interface{}, config Config, imports string, regSchemas string) {
+	var obj interface{}
+	var schemas = make(map[string]interface{})
+	var outString = "package main\n\n" + imports + "\n\n" + "var schemas = `\n\n"
+
+	var filename = config.Schemas.GoSchemaFilename
+	var apiFunctions = config.Schemas.API
+	var elementNames = config.Schemas.Model
+
+	schemas["API"] = make(map[string]interface{})
+	schemas["Model"] = make(map[string]interface{})
+
+	for i := range apiFunctions {
+		functionSchemaName := "API/" + apiFunctions[i]
+		obj = getObject(schema, functionSchemaName, functionSchemaName)
+		if obj == nil {
+			fmt.Printf("** WARN ** %s returned nil from getObject\n", functionSchemaName)
+			return
+		}
+		schemas["API"].(map[string]interface{})[apiFunctions[i]] = obj
+	}
+
+	for i := range elementNames {
+		elementName := "Model/" + elementNames[i]
+		obj = getObject(schema, elementName, elementName)
+		if obj == nil {
+			fmt.Printf("** ERR ** %s returned nil from getObject\n", elementName)
+			return
+		}
+		schemas["Model"].(map[string]interface{})[elementNames[i]] = obj
+	}
+
+	schemaOut, err := json.MarshalIndent(&schemas, "", "    ")
+	if err != nil {
+		fmt.Printf("** ERR ** cannot marshal schema file output for writing\n")
+		return
+	}
+	outString += string(schemaOut) + "`\n\n" + regSchemas
+	ioutil.WriteFile(filename, []byte(outString), 0644)
+}
+
+// sampler makes sample values from a schema element, the canonical sample when rnd is nil
+// and otherwise random values within the same constraints, which repeat for a given seed
+type sampler struct {
+	rnd  *rand.Rand
+	base time.Time // the canonical timestamp, random timestamps fall in the year after it
+}
+
+// the timestamp of canonical samples and the first of seeded ones, fixed so that samples
+// and fixtures do not change between runs
+var fixtureEpoch = time.Date(2016, time.November, 20, 0, 0, 0, 0, time.UTC)
+
+// a canonical sample, Ottawa when it is a location
+const sampleLatitude, sampleLongitude = 45.4215, -75.6972
+
+func (s *sampler) sampleType(obj interface{}, elementName string) interface{} {
+	o, found := obj.(map[string]interface{})
+	if !found {
+		return "SCHEMA ELEMENT " + elementName + " IS NOT MAP"
+	}
+	t, found := o["type"].(string)
+	if !found {
+		if elementName == "oneOf" {
+			return o
+		}
+		return "NO TYPE PROPERTY"
+	}
+	if example, found := o["example"]; found {
+		return example
+	}
+	if def, found := o["default"]; found {
+		return def
+	}
+	if enum, found := o["enum"].([]interface{}); found && len(enum) > 0 && t != "array" && t != "object" {
+		if s.rnd != nil {
+			return enum[s.rnd.Intn(len(enum))]
+		}
+		if len(enum) > 1 {
+			return enum[1]
+		}
+		return enum[0]
+	}
+	switch t {
+	default:
+		fmt.Printf("** WARN ** Unknown type in sampleType %s\n", t)
+	case "number":
+		return s.number(o, elementName, false)
+	case "integer":
+		return s.number(o, elementName, true)
+	case "string":
+		return s.str(o, elementName)
+	case "null":
+		return nil
+	case "boolean":
+		if s.rnd != nil {
+			return s.rnd.Intn(2) == 1
+		}
+		return true
+	case "array":
+		var items, found = o["items"].(map[string]interface{})
+		if !found {
+			// fmt.Printf("** WARN ** Element %s is array with no items property\n", elementName)
+			return "ARRAY WITH NO ITEMS PROPERTY"
+		}
+		return s.arrayFromSchema(o, items, elementName)
+	case "object":
+		{
+			var props map[string]interface{}
+			var found bool
+			props, found = o["properties"].(map[string]interface{})
+			if !found {
+				props, found = (o["patternProperties"]).(map[string]interface{})
+			}
+			if !found && o["additionalProperties"] != false {
+				// a map by name or a free form object, e.g. a notification's data, is empty in samples
+				return map[string]interface{}{}
+			}
+			if !found {
+				// fmt.Printf("** WARN ** %s is type object yet has no properties in SampleType\n", elementName)
+				return "INVALID OBJECT - MISSING PROPERTIES"
+			}
+			// visited in order so that random samples repeat for a seed
+			var keys = make([]string, 0, len(props))
+			for k := range props {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			objOut := make(map[string]interface{})
+			for _, k := range keys {
+				v := props[k]
+				//// fmt.Printf("Visiting key %s with value %s\n", k, v)
+				if v == nil {
+					fmt.Printf("** WARN ** Key %s has NIL value in SampleType\n", k)
+					return "INVALID OBJECT - " + fmt.Sprintf("Key %s has NIL value in SampleType\n", k)
+				}
+				aArr, isArr := v.([]interface{})
+				aMap, isMap := v.(map[string]interface{})
+				switch {
+				case isArr:
+					if "oneOf" == k {
+						aOut := make([]interface{}, len(aArr))
+						// outer loop is anonymous objects
+						for k2, v2 := range aArr {
+							//// fmt.Printf("SAMTYP outer OneOf: %d [%v]\n", k2, v2)
+							vObj, found := v2.(map[string]interface{})
+							if found {
+								// inner loop should find one named object
+								for k3, v3 := range vObj {
+									tmp := make(map[string]interface{}, 1)
+									//// fmt.Printf("SAMTYP inner OneOf: %s [%v]\n", k3, v3)
+									//printObject(k3, v3)
+									tmp[k3] = s.sampleType(v3, k3)
+									aOut[k2] = tmp
+								}
+							}
+							objOut[k] = aOut
+						}
+					} else {
+						objOut[k] = "UNKNOWN ARRAY OBJECT"
+					}
+				case isMap:
+					if readOnly, _ := aMap["readOnly"].(bool); readOnly && s.rnd != nil {
+						// set by the contract, so not part of an event
+						continue
+					}
+					objOut[k] = s.sampleType(aMap, k)
+				}
+			}
+			return objOut
+		}
+	}
+	fmt.Printf("** WARN ** UNKNOWN TYPE in SampleType: %s\n", t)
+	return fmt.Sprintf("UNKNOWN TYPE in SampleType: %s\n", t)
+}
+
+// a number within the element's minimum and maximum, or a valid latitude or longitude
+// when the element is named for one
+func (s *sampler) number(o map[string]interface{}, elementName string, integer bool) interface{} {
+	min, hasMin := o["minimum"].(float64)
+	max, hasMax := o["maximum"].(float64)
+	var places = 1000.0
+	if !hasMin && !hasMax {
+		name := strings.ToLower(elementName)
+		switch {
+		case name == "lat" || strings.HasSuffix(name, "latitude"):
+			if s.rnd == nil {
+				return sampleLatitude
+			}
+			min, max, hasMin, hasMax, places = -90, 90, true, true, 1000000
+		case name == "lon" || name == "lng" || name == "long" || strings.HasSuffix(name, "longitude"):
+			if s.rnd == nil {
+				return sampleLongitude
+			}
+			min, max, hasMin, hasMax, places = -180, 180, true, true, 1000000
+		}
+	}
+	if s.rnd == nil {
+		switch {
+		case hasMin && hasMax:
+			min = (min + max) / 2
+		case hasMax:
+			min = max
+		case !hasMin:
+			if integer {
+				return 789
+			}
+			return 123.456
+		}
+		if integer {
+			return int(math.Ceil(min))
+		}
+		return min
+	}
+	switch {
+	case !hasMin && !hasMax:
+		min, max = 0, 1000
+	case !hasMax:
+		max = min + 1000
+	case !hasMin:
+		min = max - 1000
+	}
+	if integer {
+		lo, hi := int64(math.Ceil(min)), int64(math.Floor(max))
+		if hi < lo {
+			return lo
+		}
+		return lo + s.rnd.Int63n(hi-lo+1)
+	}
+	return math.Min(max, math.Floor((min+s.rnd.Float64()*(max-min))*places+0.5)/places)
+}
+
+// a string in the element's format or matching its pattern, otherwise the description in
+// the canonical sample or a value made from the element's name in a random one
+func (s *sampler) str(o map[string]interface{}, elementName string) interface{} {
+	format, _ := o["format"].(string)
+	switch {
+	case format == "date-time" || strings.HasSuffix(strings.ToLower(elementName), "timestamp"):
+		return s.timestamp().Format(time.RFC3339Nano)
+	case format == "date":
+		return s.timestamp().Format("2006-01-02")
+	case format == "uri":
+		return "https://example.com/" + elementName + s.suffix()
+	case format == "email":
+		return strings.ToLower(elementName) + s.suffix() + "@example.com"
+	}
+	if pattern, found := o["pattern"].(string); found {
+		v, err := s.matching(pattern)
+		if err == nil {
+			return v
+		}
+		fmt.Printf("** WARN ** %s has a pattern that cannot be sampled: %s\n", elementName, err)
+	}
+	if s.rnd != nil {
+		return elementName + s.suffix()
+	}
+	desc, found := o["description"].(string)
+	if found && len(desc) > 0 {
+		return desc
+	}
+	return "carpe noctem"
+}
+
+func (s *sampler) timestamp() time.Time {
+	if s.rnd == nil {
+		return s.base
+	}
+	return s.base.Add(time.Duration(s.rnd.Int63n(int64(365 * 24 * time.Hour)))).Truncate(time.Second)
+}
+
+// distinguishes random values made from the same name
+func (s *sampler) suffix() string {
+	if s.rnd == nil {
+		return ""
+	}
+	return fmt.Sprintf("-%d", s.rnd.Intn(100000))
+}
+
+// a string that matches a regular expression, the shortest one in the canonical sample
+func (s *sampler) matching(pattern string) (string, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return "", err
+	}
+	var out []rune
+	s.generate(re.Simplify(), &out)
+	return string(out), nil
+}
+
+func (s *sampler) generate(re *syntax.Regexp, out *[]rune) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		*out = append(*out, re.Rune...)
+	case syntax.OpCharClass:
+		*out = append(*out, s.classRune(re.Rune))
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		*out = append(*out, s.classRune([]rune{'a', 'z'}))
+	case syntax.OpCapture:
+		s.generate(re.Sub[0], out)
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			s.generate(sub, out)
+		}
+	case syntax.OpAlternate:
+		var i int
+		if s.rnd != nil {
+			i = s.rnd.Intn(len(re.Sub))
+		}
+		s.generate(re.Sub[i], out)
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		var min, max = re.Min, re.Max
+		switch re.Op {
+		case syntax.OpStar:
+			min, max = 0, -1
+		case syntax.OpPlus:
+			min, max = 1, -1
+		case syntax.OpQuest:
+			min, max = 0, 1
+		}
+		var n = min
+		if s.rnd != nil {
+			if max < 0 {
+				max = min + 3
+			}
+			n += s.rnd.Intn(max - min + 1)
+		}
+		for i := 0; i < n; i++ {
+			s.generate(re.Sub[0], out)
+		}
+	}
+	// anchors, word boundaries and empty matches add nothing
+}
+
+// a printable rune from a character class, which is pairs of low and high runes
+func (s *sampler) classRune(ranges []rune) rune {
+	var printable []rune
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := ranges[i], ranges[i+1]
+		if lo < ' ' {
+			lo = ' '
+		}
+		if hi > '~' {
+			hi = '~'
+		}
+		if lo <= hi {
+			printable = append(printable, lo, hi)
+		}
+	}
+	if len(printable) == 0 {
+		if len(ranges) == 0 {
+			return 'x'
+		}
+		return ranges[0]
+	}
+	if s.rnd == nil {
+		return printable[0]
+	}
+	i := s.rnd.Intn(len(printable)/2) * 2
+	return printable[i] + rune(s.rnd.Intn(int(printable[i+1]-printable[i])+1))
+}
+
+// Generate a sample array from a schema, with the array's minimum number of items or one
+// in the canonical sample and up to three more in a random one, none when it allows none
+func (s *sampler) arrayFromSchema(array map[string]interface{}, schema map[string]interface{}, elementName string) interface{} {
+	enum, found := schema["enum"]
+	if found && s.rnd == nil {
+		// there is a set of enums, just use it
+		return enum
+	}
+	var n = 1
+	if min, found := array["minItems"].(float64); found {
+		n = int(min)
+	}
+	if max, found := array["maxItems"].(float64); found && max == 0 {
+		return []interface{}{}
+	}
+	if s.rnd != nil {
+		extra := 3
+		if max, found := array["maxItems"].(float64); found && int(max)-n < extra {
+			extra = int(max) - n
+		}
+		if extra > 0 {
+			n += s.rnd.Intn(extra + 1)
+		}
+	} else if n == 0 {
+		n = 1
+	}
+	var out = make([]interface{}, 0, n)
+	for i := 0; i < n; i++ {
+		out = append(out, s.sampleType(schema, elementName))
+	}
+	return out
+}
+
+// Generates a file <munged elementName>.go to contain a string literal for the pretty version
+// of the schema with all references resolved. In the same file, creates a sample JSON that
+// can be used to show a complete structure of the object.
+func generateGoSampleFile(schema map[string]interface{}, config Config, imports string, regSamples string) {
+	var obj interface{}
+	var samples = make(map[string]interface{})
+	var outString = "package main\n\n" + imports + "\n\n" + "var samples = `\n\n"
+
+	var filename = config.Samples.GoSampleFilename
+	var apiFunctions = config.Samples.API
+	var modelNames = config.Samples.Model
+	var s = sampler{base: fixtureEpoch}
+
+	samples["API"] = interface{}(make(map[string]interface{}))
+	samples["Model"] = interface{}(make(map[string]interface{}))
+
+	for i := range apiFunctions {
+		functionSchemaName := "API/" + apiFunctions[i]
+		// use the schema subset
+		obj = getObject(schema, functionSchemaName, functionSchemaName)
+		if obj == nil {
+			fmt.Printf("** WARN ** %s returned nil from getObject\n", functionSchemaName)
+			return
+		}
+		samples["API"].(map[string]interface{})[apiFunctions[i]] = s.sampleType(obj, functionSchemaName)
+	}
+	for i := range modelNames {
+		modelName := "Model/" + modelNames[i]
+		// use the schema subset
+		obj = getObject(schema, modelName, modelName)
+		if obj == nil {
+			fmt.Printf("** WARN ** %s returned nil from getObject\n", modelName)
+			return
+		}
+		samples["Model"].(map[string]interface{})[modelNames[i]] = s.sampleType(obj, modelName)
+	}
+	samplesOut, err := json.MarshalIndent(&samples, "", "    ")
+	if err != nil {
+		fmt.Println("** ERR ** cannot marshal sample file output for writing")
+		return
+	}
+	outString += string(samplesOut) + "`\n\n" + regSamples
+	ioutil.WriteFile(filename, []byte(outString), 0644)
+}
+
+// the args of one call to a function, one sample for each arg that it requires and at
+// least one unless it takes none
+func (s *sampler) args(args map[string]interface{}, function string) []interface{} {
+	var out = make([]interface{}, 0)
+	items, found := args["items"].(map[string]interface{})
+	if !found || len(items) == 0 {
+		return out
+	}
+	if max, found := args["maxItems"].(float64); found && max == 0 {
+		return out
+	}
+	var n = 1
+	if min, found := args["minItems"].(float64); found && int(min) > n {
+		n = int(min)
+	}
+	for i := 0; i < n; i++ {
+		out = append(out, s.sampleType(items, function))
+	}
+	return out
+}
+
+// Generates a file with random but seeded args for each function in the fixtures section of
+// the config, or in the schemas section when it lists none, so that tests can call the
+// contract with realistic events, e.g.
+//     {"createAssetSurgicalKit": [[{"surgicalkit": {...}}], [{"surgicalkit": {...}}]]}
+func generateFixturesFile(schema map[string]interface{}, config Config) {
+	var f = config.Samples.Fixtures
+	var s = sampler{rand.New(rand.NewSource(f.Seed)), fixtureEpoch}
+	var functions = f.API
+	if len(functions) == 0 {
+		functions = config.Schemas.API
+	}
+	var fixtures = make(map[string]interface{})
+	for _, function := range functions {
+		functionSchemaName := "API/" + function
+		obj, found := getObject(schema, functionSchemaName, functionSchemaName).(map[string]interface{})
+		if !found {
+			fmt.Printf("** WARN ** %s returned nil from getObject\n", functionSchemaName)
+			continue
+		}
+		props, _ := obj["properties"].(map[string]interface{})
+		args, _ := props["args"].(map[string]interface{})
+		var calls = make([]interface{}, 0, f.Count)
+		for i := 0; i < f.Count; i++ {
+			calls = append(calls, s.args(args, function))
+		}
+		fixtures[function] = calls
+	}
+	ioutil.WriteFile(f.FixturesFilename, append(PrettyPrintBytes(fixtures), '\n'), 0644)
+}
+
+// typeGenerator declares a Go type for every Model definition that the configured models
+// reach, working on the schema before references are resolved so that each reference
+// becomes its named type
+type typeGenerator struct {
+	models  map[string]interface{} // the Model definitions
+	named   map[string]string      // Model name -> Go type
+	decls   map[string]string      // Go type name -> declaration
+	structs map[string]bool        // Go type names that are structs
+	order   []string               // Go type names in the order declared
+}
+
+// goName turns a schema name like "distanceFromFenceCenter" or "skitID" into an
+// exported Go name
+func goName(name string) string {
+	var out string
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		out += strings.ToUpper(part[:1]) + part[1:]
+	}
+	if out == "" || unicode.IsDigit(rune(out[0])) {
+		out = "X" + out
+	}
+	return out
+}
+
+// a description as one comment line that follows "X is", so "The hospital" becomes "the
+// hospital" while acronyms are left alone
+func goComment(obj map[string]interface{}) string {
+	desc, _ := obj["description"].(string)
+	desc = strings.Join(strings.Fields(desc), " ")
+	if len(desc) > 1 && unicode.IsUpper(rune(desc[0])) && !unicode.IsUpper(rune(desc[1])) {
+		desc = strings.ToLower(desc[:1]) + desc[1:]
+	}
+	return desc
+}
+
+func (g *typeGenerator) reserve(tname string) {
+	if _, found := g.decls[tname]; found {
+		fmt.Printf("** ERR ** type generation declares %s twice, rename one of the schema elements\n", tname)
+		os.Exit(1)
+	}
+	g.decls[tname] = ""
+	g.order = append(g.order, tname)
+}
+
+// the Go type of a referenced Model definition
+func (g *typeGenerator) modelType(ref string) string {
+	name := strings.TrimPrefix(ref, "#/definitions/Model/")
+	if t, found := g.named[name]; found {
+		return t
+	}
+	def, found := g.models[name].(map[string]interface{})
+	if !found {
+		fmt.Printf("** ERR ** type generation cannot find %s\n", ref)
+		os.Exit(1)
+	}
+	// named before its properties are visited so that a model can refer to itself
+	g.named[name] = goName(name)
+	g.named[name] = g.schemaType(goName(name), def, true)
+	return g.named[name]
+}
+
+// the Go type of a schema element, objects with properties and enumerated string models
+// are declared as named types
+func (g *typeGenerator) schemaType(tname string, obj map[string]interface{}, model bool) string {
+	if ref, found := obj["$ref"].(string); found {
+		return g.modelType(ref)
+	}
+	t, _ := obj["type"].(string)
+	switch t {
+	case "string":
+		if enum, found := obj["enum"].([]interface{}); found && model {
+			g.declareEnum(tname, obj, enum)
+			return tname
+		}
+		return "string"
+	case "number":
+		return "float64"
+	case "integer":
+		return "int"
+	case "boolean":
+		return "bool"
+	case "array":
+		items, found := obj["items"].(map[string]interface{})
+		if !found {
+			return "[]interface{}"
+		}
+		return "[]" + g.schemaType(tname, items, false)
+	case "object":
+		props := g.properties(obj["properties"])
+		if len(props) == 0 {
+			return "map[string]interface{}"
+		}
+		g.declareStruct(tname, obj, props)
+		return tname
+	default:
+		return "interface{}"
+	}
+}
+
+// an object's properties with those of each referenced Model merged in place of the
+// reference, e.g. {"$ref": "#/definitions/Model/surgicalkitKey", "qprops": {...}}
+func (g *typeGenerator) properties(obj interface{}) map[string]interface{} {
+	var out = make(map[string]interface{})
+	props, _ := obj.(map[string]interface{})
+	for k, v := range props {
+		if k != "$ref" {
+			out[k] = v
+			continue
+		}
+		ref, _ := v.(string)
+		merged, _ := g.models[strings.TrimPrefix(ref, "#/definitions/Model/")].(map[string]interface{})
+		if t, _ := merged["type"].(string); t == "object" {
+			merged, _ = merged["properties"].(map[string]interface{})
+		}
+		for mk, mv := range g.properties(merged) {
+			out[mk] = mv
+		}
+	}
+	return out
+}
+
+func (g *typeGenerator) declareEnum(tname string, obj map[string]interface{}, enum []interface{}) {
+	g.reserve(tname)
+	var decl = fmt.Sprintf("// %s is %s\ntype %s string\n\n// values of %s\nconst (\n", tname, goComment(obj), tname, tname)
+	for _, e := range enum {
+		if s, ok := e.(string); ok && s != "" {
+			decl += fmt.Sprintf("%s%s %s = %q\n", tname, goName(s), tname, s)
+		}
+	}
+	g.decls[tname] = decl + ")\n"
+}
+
+// a struct with pointers for scalars and objects so that a partial state unmarshals
+// without inventing values, and nil safe accessors for every property
+func (g *typeGenerator) declareStruct(tname string, obj map[string]interface{}, props map[string]interface{}) {
+	g.reserve(tname)
+	g.structs[tname] = true
+	var names = make([]string, 0, len(props))
+	for p := range props {
+		names = append(names, p)
+	}
+	sort.Strings(names)
+	var fields, accessors string
+	for _, p := range names {
+		pobj, ok := props[p].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		fname := goName(p)
+		ftype := g.schemaType(tname+fname, pobj, false)
+		if c := goComment(pobj); c != "" {
+			fields += "// " + c + "\n"
+		}
+		switch {
+		case strings.HasPrefix(ftype, "[]") || strings.HasPrefix(ftype, "map[") || ftype == "interface{}":
+			fields += fmt.Sprintf("%s %s `json:\"%s,omitempty\"`\n", fname, ftype, p)
+			accessors += fmt.Sprintf("\n// Get%s returns %s\nfunc (m *%s) Get%s() %s {\nif m == nil {\nreturn nil\n}\nreturn m.%s\n}\n",
+				fname, p, tname, fname, ftype, fname)
+		case g.structs[ftype]:
+			fields += fmt.Sprintf("%s *%s `json:\"%s,omitempty\"`\n", fname, ftype, p)
+			accessors += fmt.Sprintf("\n// Get%s returns %s, nil when it is not present\nfunc (m *%s) Get%s() *%s {\nif m == nil {\nreturn nil\n}\nreturn m.%s\n}\n",
+				fname, p, tname, fname, ftype, fname)
+		default:
+			fields += fmt.Sprintf("%s *%s `json:\"%s,omitempty\"`\n", fname, ftype, p)
+			accessors += fmt.Sprintf("\n// Get%s returns %s and whether it is present\nfunc (m *%s) Get%s() (%s, bool) {\nif m == nil || m.%s == nil {\nvar zero %s\nreturn zero, false\n}\nreturn *m.%s, true\n}\n",
+				fname, p, tname, fname, ftype, fname, ftype, fname)
+			accessors += fmt.Sprintf("\n// Set%s sets %s\nfunc (m *%s) Set%s(v %s) {\nm.%s = &v\n}\n",
+				fname, p, tname, fname, ftype, fname)
+		}
+	}
+	var comment = goComment(obj)
+	if comment == "" {
+		comment = "generated from the schema"
+	}
+	g.decls[tname] = fmt.Sprintf("// %s is %s\ntype %s struct {\n%s}\n%s", tname, comment, tname, fields, accessors)
+}
+
+// Generates a file with a Go type for each model in the types section of the config and
+// for the models that they refer to. The models listed in the config are the top level
+// objects of an asset's state, so each of them also gets functions to read it from and
+// write it to the state, e.g.
+//     kit, err := SurgicalkitFromState(asset.State)
+//     force, found := kit.GetSensors().GetMaxgforce()
+func generateGoTypesFile(schema map[string]interface{}, config Config) {
+	models, found := schema["definitions"].(map[string]interface{})["Model"].(map[string]interface{})
+	if !found {
+		fmt.Println("** ERR ** no Model section found in schema for type generation")
+		return
+	}
+	var g = typeGenerator{models, make(map[string]string), make(map[string]string), make(map[string]bool), make([]string, 0)}
+	var state string
+	for _, name := range config.Types.Model {
+		tname := g.modelType("#/definitions/Model/" + name)
+		if !g.structs[tname] {
+			fmt.Printf("** WARN ** %s is not an object, it has no state functions\n", name)
+			continue
+		}
+		state += fmt.Sprintf("\n// %sFromState reads the %s object from an asset state, e.g. asset.State\nfunc %sFromState(state *map[string]interface{}) (*%s, error) {\nvar m %s\nif err := iot.StateToStruct(state, %q, &m); err != nil {\nreturn nil, err\n}\nreturn &m, nil\n}\n",
+			tname, name, tname, tname, tname, name)
+		state += fmt.Sprintf("\n// ToState merges the %s object into an asset state, properties that are nil are left alone\nfunc (m *%s) ToState(state *map[string]interface{}) error {\nreturn iot.StructToState(m, state, %q)\n}\n",
+			name, tname, name)
+	}
+
+	var outString = "// Code generated by processSchema.go from " + config.Schemas.SchemaFilename + ", DO NOT EDIT.\n\npackage main\n\n"
+	if state != "" {
+		outString += "import iot \"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform\"\n\n"
+	}
+	for _, tname := range g.order {
+		outString += g.decls[tname] + "\n"
+	}
+	outString += state
+	formatted, err := format.Source([]byte(outString))
+	if err != nil {
+		fmt.Printf("** ERR ** generated types do not format, writing them as is: %s\n", err)
+		formatted = []byte(outString)
+	}
+	ioutil.WriteFile(config.Types.GoTypesFilename, formatted, 0644)
+}
+
+// Generates a file with the expected method of each function in the schemas section of the
+// config, which the contract's main checks with iot.VerifyRoutes at startup
+func generateGoRoutesFile(schema map[string]interface{}, config Config) {
+	definitions, _ := schema["definitions"].(map[string]interface{})
+	api, found := definitions["API"].(map[string]interface{})
+	if !found {
+		fmt.Println("** ERR ** no API section found in schema for route generation")
+		return
+	}
+	var routes string
+	for _, function := range config.Schemas.API {
+		def, _ := api[function].(map[string]interface{})
+		props, _ := def["properties"].(map[string]interface{})
+		method, found := props["method"].(string)
+		if !found {
+			fmt.Printf("** WARN ** %s has no method in the schema, its route is not checked\n", function)
+			continue
+		}
+		routes += fmt.Sprintf("%q: %q,\n", function, method)
+	}
+	var outString = "// Code generated by processSchema.go from " + config.Schemas.SchemaFilename + ", DO NOT EDIT.\n\npackage main\n\n" +
+		"import iot \"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform\"\n\n" +
+		"// the functions that the schema publishes, main calls iot.VerifyRoutes to check that each is routed\n" +
+		"func init() {\niot.ExpectRoutes(map[string]string{\n" + routes + "})\n}\n"
+	formatted, err := format.Source([]byte(outString))
+	if err != nil {
+		fmt.Printf("** ERR ** generated routes do not format, writing them as is: %s\n", err)
+		formatted = []byte(outString)
+	}
+	ioutil.WriteFile(config.Schemas.GoRoutesFilename, formatted, 0644)
+}
+
+// the Go type of a function's args and how many it takes: none, one, an optional one
+// (a pointer) or any number (variadic)
+func (g *typeGenerator) argType(function string, args map[string]interface{}) (string, string) {
+	items, _ := args["items"].(map[string]interface{})
+	if maxItems, limited := args["maxItems"].(float64); len(items) == 0 || (limited && maxItems == 0) {
+		return "", "none"
+	}
+	var tname = g.schemaType(goName(function)+"Arg", items, false)
+	minItems, _ := args["minItems"].(float64)
+	maxItems, limited := args["maxItems"].(float64)
+	switch {
+	case limited && maxItems == 1 && minItems >= 1:
+		return tname, "one"
+	case limited && maxItems == 1:
+		return tname, "optional"
+	default:
+		return tname, "variadic"
+	}
+}
+
+// Generates a client package with one method per function of the API, each of which builds
+// the JSON-RPC request that calls the function with typed args. The types of the args are
+// declared in the package, e.g.
+//     c := client.New("http://localhost:7050", "mycc", "user_type1_0")
+//     req, err := c.CreateAssetSurgicalKit(client.CreateAssetSurgicalKitArg{Surgicalkit: &kit})
+//     resp, err := c.Send(req)
+func generateGoClientFile(schema map[string]interface{}, config Config) {
+	definitions, _ := schema["definitions"].(map[string]interface{})
+	api, found := definitions["API"].(map[string]interface{})
+	if !found {
+		fmt.Println("** ERR ** no API section found in schema for client generation")
+		return
+	}
+	models, _ := definitions["Model"].(map[string]interface{})
+	var g = typeGenerator{models, make(map[string]string), make(map[string]string), make(map[string]bool), make([]string, 0)}
+	var pkg = filepath.Base(filepath.Dir(config.Client.GoClientFilename))
+	if pkg == "." || pkg == string(filepath.Separator) {
+		fmt.Println("** ERR ** the client must be generated in its own folder, e.g. client/client.go")
+		return
+	}
+
+	var functions = config.Client.API
+	if len(functions) == 0 {
+		functions = config.Schemas.API
+	}
+	var methods string
+	for _, function := range functions {
+		def, found := api[function].(map[string]interface{})
+		if !found {
+			fmt.Printf("** WARN ** %s is not in the API section of the schema, it has no client method\n", function)
+			continue
+		}
+		props, _ := def["properties"].(map[string]interface{})
+		method, _ := props["method"].(string)
+		args, _ := props["args"].(map[string]interface{})
+		mname := goName(function)
+		tname, count := g.argType(function, args)
+		methods += fmt.Sprintf("\n// %s builds the %s request of %s", mname, method, function)
+		if c := goComment(def); c != "" {
+			methods += ", " + strings.ToLower(c[:1]) + c[1:]
+		}
+		switch count {
+		case "none":
+			methods += fmt.Sprintf("\nfunc (c *Client) %s() (iotcpclient.Request, error) {\nreturn c.Request(%q, %q)\n}\n", mname, method, function)
+		case "one":
+			methods += fmt.Sprintf("\nfunc (c *Client) %s(arg %s) (iotcpclient.Request, error) {\nreturn c.Request(%q, %q, arg)\n}\n", mname, tname, method, function)
+		case "optional":
+			methods += fmt.Sprintf("\nfunc (c *Client) %s(arg *%s) (iotcpclient.Request, error) {\nif arg == nil {\nreturn c.Request(%q, %q)\n}\nreturn c.Request(%q, %q, arg)\n}\n",
+				mname, tname, method, function, method, function)
+		default:
+			methods += fmt.Sprintf("\nfunc (c *Client) %s(args ...%s) (iotcpclient.Request, error) {\nvar a = make([]interface{}, len(args))\nfor i := range args {\na[i] = args[i]\n}\nreturn c.Request(%q, %q, a...)\n}\n",
+				mname, tname, method, function)
+		}
+	}
+
+	var outString = "// Code generated by processSchema.go from " + config.Schemas.SchemaFilename + ", DO NOT EDIT.\n\n" +
+		"// Package " + pkg + " builds the requests that call the functions of the contract described by " + config.Schemas.SchemaFilename + "\n" +
+		"package " + pkg + "\n\n" +
+		"import \"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpclient\"\n\n" +
+		"// Client builds the requests, Send sends them\ntype Client struct {\n*iotcpclient.Client\n}\n\n" +
+		"// New returns a client for the contract deployed with the given name\nfunc New(url string, name string, secureContext string) *Client {\nreturn &Client{iotcpclient.NewClient(url, name, secureContext)}\n}\n" +
+		methods
+	for _, tname := range g.order {
+		outString += "\n" + g.decls[tname]
+	}
+	formatted, err := format.Source([]byte(outString))
+	if err != nil {
+		fmt.Printf("** ERR ** generated client does not format, writing it as is: %s\n", err)
+		formatted = []byte(outString)
+	}
+	_ = os.MkdirAll(filepath.Dir(config.Client.GoClientFilename), 0755)
+	ioutil.WriteFile(config.Client.GoClientFilename, formatted, 0644)
+}
+
+// openAPIGenerator converts the API section of the schema into OpenAPI operations, a Model
+// that is referenced where a schema is expected becomes a component
+type openAPIGenerator struct {
+	models     map[string]interface{} // the Model definitions
+	components map[string]interface{} // Model name -> converted schema
+}
+
+// the referenced Model definition
+func (g *openAPIGenerator) model(ref string) interface{} {
+	def, found := g.models[strings.TrimPrefix(ref, "#/definitions/Model/")]
+	if !found {
+		fmt.Printf("** ERR ** OpenAPI generation cannot find %s\n", ref)
+		os.Exit(1)
+	}
+	return def
+}
+
+// the component reference for a Model, converted the first time it is seen
+func (g *openAPIGenerator) component(ref string) string {
+	name := strings.TrimPrefix(ref, "#/definitions/Model/")
+	if _, found := g.components[name]; !found {
+		// reserved before conversion so that a model can refer to itself
+		g.components[name] = nil
+		g.components[name] = g.convert(g.model(ref))
+	}
+	return "#/components/schemas/" + name
+}
+
+// the OpenAPI form of a JSON schema element, a reference with siblings such as a
+// description becomes an allOf, keywords that OpenAPI 3.0 lacks are mapped or dropped
+func (g *openAPIGenerator) convert(obj interface{}) interface{} {
+	o, found := obj.(map[string]interface{})
+	if !found {
+		return obj
+	}
+	var out = make(map[string]interface{})
+	var ref string
+	for k, v := range o {
+		switch k {
+		case "$ref":
+			ref, _ = v.(string)
+		case "$schema", "id":
+		case "properties":
+			out[k] = g.properties(v)
+		case "patternProperties":
+			// any property name is allowed, the first pattern's schema describes the values
+			if pp, found := v.(map[string]interface{}); found {
+				for _, pv := range pp {
+					out["additionalProperties"] = g.convert(pv)
+					break
+				}
+			}
+		case "items", "additionalProperties":
+			out[k] = g.convert(v)
+		case "sample":
+			out["example"] = v
+		case "unit":
+			out["x-unit"] = v
+		default:
+			out[k] = v
+		}
+	}
+	if ref == "" {
+		return out
+	}
+	var refOut = map[string]interface{}{"$ref": g.component(ref)}
+	if len(out) == 0 {
+		return refOut
+	}
+	return map[string]interface{}{"allOf": []interface{}{refOut, out}}
+}
+
+// converts a properties map, a reference in place of a property name merges in the
+// properties of the referenced Model, which is either a map of properties like an asset's
+// key or an object schema
+func (g *openAPIGenerator) properties(obj interface{}) map[string]interface{} {
+	var out = make(map[string]interface{})
+	props, _ := obj.(map[string]interface{})
+	for k, v := range props {
+		if k == "$ref" {
+			ref, _ := v.(string)
+			merged, _ := g.model(ref).(map[string]interface{})
+			if t, _ := merged["type"].(string); t == "object" {
+				merged, _ = merged["properties"].(map[string]interface{})
+			}
+			for mk, mv := range g.properties(merged) {
+				out[mk] = mv
+			}
+			continue
+		}
+		if desc, found := v.(string); found {
+			// a bare description in place of a schema allows any value
+			out[k] = map[string]interface{}{"description": desc}
+			continue
+		}
+		out[k] = g.convert(v)
+	}
+	return out
+}
+
+func jsonContent(schema interface{}) map[string]interface{} {
+	return map[string]interface{}{"application/json": map[string]interface{}{"schema": schema}}
+}
+
+// the path and operation for one API function, the request body is the function's one
+// argument and the response is its result
+func (g *openAPIGenerator) operation(function string, def map[string]interface{}) (string, map[string]interface{}) {
+	props, _ := def["properties"].(map[string]interface{})
+	method, _ := props["method"].(string)
+	var op = map[string]interface{}{
+		"operationId":    function,
+		"summary":        def["description"],
+		"tags":           []string{method},
+		"x-iotcp-method": method,
+	}
+	if args, found := props["args"].(map[string]interface{}); found {
+		items, _ := args["items"].(map[string]interface{})
+		maxItems, limited := args["maxItems"].(float64)
+		if len(items) > 0 && (!limited || maxItems > 0) {
+			minItems, _ := args["minItems"].(float64)
+			op["requestBody"] = map[string]interface{}{
+				"required": minItems > 0,
+				"content":  jsonContent(g.convert(items)),
+			}
+		}
+	}
+	var ok = map[string]interface{}{"description": "the transaction was submitted"}
+	if result, found := props["result"]; found {
+		if method == "query" {
+			ok = map[string]interface{}{"description": "the result of the query", "content": jsonContent(g.convert(result))}
+		} else {
+			// an invoke's result is not returned to the caller, it is sent in the invoke result event
+			op["x-iotcp-result-event"] = map[string]interface{}{"event": "EVT.IOTCP.INVOKE.RESULT", "schema": g.convert(result)}
+		}
+	}
+	op["responses"] = map[string]interface{}{
+		"200":     ok,
+		"default": map[string]interface{}{"description": "the contract rejected the request, the message says why"},
+	}
+	return "/" + method + "/" + function, op
+}
+
+// Generates an OpenAPI 3 document with an operation for each function in the openapi section
+// of the config, or in the schemas section when it lists none. Each operation is a POST to
+// /<method>/<function>, which a REST gateway sends to the contract as that function with
+// the request body as its one argument.
+func generateOpenAPIFile(schema map[string]interface{}, config Config) {
+	definitions, _ := schema["definitions"].(map[string]interface{})
+	api, found := definitions["API"].(map[string]interface{})
+	if !found {
+		fmt.Println("** ERR ** no API section found in schema for OpenAPI generation")
+		return
+	}
+	models, _ := definitions["Model"].(map[string]interface{})
+	var g = openAPIGenerator{models, make(map[string]interface{})}
+
+	var functions = config.OpenAPI.API
+	if len(functions) == 0 {
+		functions = config.Schemas.API
+	}
+	var paths = make(map[string]interface{})
+	for _, function := range functions {
+		def, found := api[function].(map[string]interface{})
+		if !found {
+			fmt.Printf("** WARN ** %s is not in the API section of the schema, it has no operation\n", function)
+			continue
+		}
+		path, op := g.operation(function, def)
+		paths[path] = map[string]interface{}{"post": op}
+	}
+
+	var title = config.OpenAPI.Title
+	if title == "" {
+		title = strings.TrimSuffix(filepath.Base(config.Schemas.SchemaFilename), ".json")
+	}
+	var version = config.OpenAPI.Version
+	if version == "" {
+		version = "1.0.0"
+	}
+	var doc = map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       title,
+			"version":     version,
+			"description": "Generated by processSchema.go from " + config.Schemas.SchemaFilename + ". Each operation is one contract function, its request body is the function's argument.",
+		},
+		"paths":      paths,
+		"components": map[string]interface{}{"schemas": g.components},
+	}
+	ioutil.WriteFile(config.OpenAPI.OpenAPIFilename, append(PrettyPrintBytes(doc), '\n'), 0644)
+}
+
+func loadModelTables(schema map[string]interface{}) {
+	model, modelfound := schema["definitions"].(map[string]interface{})["Model"].(map[string]interface{})
+	if !modelfound {
+		fmt.Println("Warning: no Model section found in schema")
+		os.Exit(1)
+	} else {
+		// all model entries as copies placed in lookup table
+		for k, v := range model {
+			lookup["#/definitions/Model/"+k] = DeepMergeMap(v.(map[string]interface{}), make(map[string]interface{}, 0))
+		}
+		// references in copies replaced
+		for k := range model {
+			lookup["#/definitions/Model/"+k] = replaceReferences(schema, k, lookup["#/definitions/Model/"+k])
+		}
+	}
+	if *verbose {
+		lookupfilename := "Model.lookup.table.json"
+		fmt.Println("Writing model lookup table to: " + lookupfilename)
+		_ = ioutil.WriteFile(lookupfilename, PrettyPrintBytes(lookup), 0744)
+	}
+}
+
+func buildResolvedSchema(schema map[string]interface{}) map[string]interface{} {
+	newSchema := make(map[string]interface{}, 0)
+	newSchema["Model"] = make(map[string]interface{}, 0)
+	var names []string
+	for name, modelObj := range lookup {
+		names = strings.SplitAfter(name, "#/definitions/Model/")
+		if len(names) != 2 {
+			fmt.Println("Error: cannot get last segment of name: " + name)
+			os.Exit(1)
+		}
+		newSchema["Model"].(map[string]interface{})[names[1]] = modelObj.(map[string]interface{})
+	}
+	// use table to resolve API references
+	newAPI := DeepMergeMap(schema["definitions"].(map[string]interface{})["API"].(map[string]interface{}), make(map[string]interface{}))
+	newAPI = replaceReferences(schema, "API", newAPI).(map[string]interface{})
+	newSchema["API"] = newAPI
+
+	return newSchema
+}
+
+// the includes being processed, outermost first, so that a cycle can be reported
+var includeChain []string
+
+// Finds an included schema file. A path that starts with ./, ../ or / is relative to the
+// directory of the file that includes it. Any other path, such as the platform's
+// github.com/ibm-watson-iot/.../IOTCPschema.json, is looked for under each directory in the
+// includes search path of the config, under the vendor folders of the current directory
+// and its parents, under the src folder of each GOPATH entry and finally in the includes
+// cache. A search path entry like "github.com/ibm-watson-iot/blockchain-samples=../../.."
+// maps the paths under a prefix to a directory, e.g. a clone of the repository. Returns
+// the location of the file and the places searched.
+func findIncludedFile(path string, dir string) (string, []string) {
+	var candidates []string
+	if strings.HasPrefix(path, "./") || strings.HasPrefix(path, "../") || filepath.IsAbs(path) {
+		candidates = append(candidates, filepath.Join(dir, path))
+	} else {
+		for _, s := range config.Includes.SearchPath {
+			if mapping := strings.SplitN(s, "=", 2); len(mapping) == 2 {
+				if strings.HasPrefix(path, mapping[0]+"/") {
+					candidates = append(candidates, filepath.Join(mapping[1], strings.TrimPrefix(path, mapping[0])))
+				}
+				continue
+			}
+			candidates = append(candidates, filepath.Join(s, path))
+		}
+		if wd, err := os.Getwd(); err == nil {
+			for d := wd; ; d = filepath.Dir(d) {
+				candidates = append(candidates, filepath.Join(d, "vendor", path))
+				if filepath.Dir(d) == d {
+					break
+				}
+			}
+		}
+		for _, s := range filepath.SplitList(os.Getenv("GOPATH")) {
+			candidates = append(candidates, filepath.Join(s, "src", path))
+		}
+		if config.Includes.Cache != "" {
+			candidates = append(candidates, filepath.Join(config.Includes.Cache, path))
+		}
+	}
+	for _, c := range candidates {
+		if info, err := os.Stat(c); err == nil && !info.IsDir() {
+			return c, candidates
+		}
+	}
+	return "", candidates
+}
+
+// keeps a copy of an included file found outside the cache so that later runs can find it
+// without the platform on the search path
+func cacheIncludedFile(path string, location string, contents []byte) {
+	if config.Includes.Cache == "" || strings.HasPrefix(path, "./") || strings.HasPrefix(path, "../") || filepath.IsAbs(path) {
+		return
+	}
+	cached := filepath.Join(config.Includes.Cache, path)
+	if absPath(location) == absPath(cached) {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(cached), 0755); err != nil {
+		fmt.Printf("** WARN ** cannot create includes cache folder for %s: %s\n", cached, err)
+		return
+	}
+	if err := ioutil.WriteFile(cached, contents, 0644); err != nil {
+		fmt.Printf("** WARN ** cannot write %s to the includes cache: %s\n", cached, err)
+	}
+}
+
+func absPath(path string) string {
+	abs, _ := filepath.Abs(path)
+	return abs
+}
+
+// stops generation with the chain of includes that led to a failure
+func includeError(format string, a ...interface{}) {
+	fmt.Printf("\n** ERR ** "+format+"\n", a...)
+	if len(includeChain) > 0 {
+		fmt.Printf("    -- included from %s\n", strings.Join(includeChain, " -> "))
+	}
+	os.Exit(1)
+}
+
+// Returns the contents of the object that an include names, e.g.
+//     "$ref": "github.com/ibm-watson-iot/.../schema/IOTCPschema.json/#definitions/API"
+// after the includes in the included file are replaced in turn, relative to that file.
+func getIncludedFile(path string, dir string) string {
+	parts := strings.Split(path, "/#")
+	if len(parts) != 2 {
+		includeError("include %s does not name an object in the form <file>/#<level>", path)
+	}
+	includeschema := parts[0]
+	level := parts[1]
+	location, searched := findIncludedFile(includeschema, dir)
+	if location == "" {
+		fmt.Printf("\nIncluded schema file %s not found, searched:\n", includeschema)
+		for _, s := range searched {
+			fmt.Println("    " + s)
+		}
+		fmt.Println("    -- add the folder that contains it to includes.searchPath in " + *configFile)
+		fmt.Println("       or vendor the platform, or add the platform's workspace to your GOPATH")
+		includeError("include %s not found", path)
+	}
+	var key = absPath(location)
+	for _, k := range includeChain {
+		if k == key {
+			includeError("include cycle, %s includes itself", key)
+		}
+	}
+	includeChain = append(includeChain, key)
+	defer func() { includeChain = includeChain[:len(includeChain)-1] }()
+
+	contents, err := ioutil.ReadFile(location)
+	if err != nil {
+		includeError("cannot read include file %s: %s", location, err)
+	}
+	cacheIncludedFile(includeschema, location, contents)
+	retstr := expandIncludes(string(contents), filepath.Dir(location))
+	var ischema interface{}
+	err = json.Unmarshal([]byte(retstr), &ischema)
+	if err != nil {
+		fmt.Println("*********** UNMARSHAL ERR **************\n", err)
+		synerr := printSyntaxErrorIncludes(retstr, err)
+		incfilename := "schema.with.failed.include.json"
+		fmt.Println("Writing failed preprocessed schema to: " + incfilename)
+		_ = ioutil.WriteFile(incfilename, []byte(retstr), 0744)
+		includeError("unmarshal of included schema %s failed with err: %s", location, synerr)
+	}
+	m, found := ischema.(map[string]interface{})
+	if !found {
+		includeError("included schema %s is not map shaped", location)
+	}
+	o := getObject(m, "#/"+level, "#/"+level)
+	if o == nil {
+		includeError("level %s not found in included schema %s", level, location)
+	}
+	out, err := json.MarshalIndent(o, "", "   ")
+	if err != nil {
+		includeError("level %s in included schema %s failed to marshal: %s", level, location, err)
+	}
+	return string(out)
+}
+
+// replaces each include line in an included file with the object that it names, and
+// drops comments and blank lines as for the main schema
+func expandIncludes(js string, dir string) string {
+	var out string
+	for _, l := range strings.Split(js, "\n") {
+		ts := strings.TrimSpace(l)
+		switch {
+		case strings.HasPrefix(ts, "#") || ts == "":
+		case strings.HasPrefix(ts, "\"$ref\"") && strings.Index(ts, "\"#/") == -1:
+			ss := strings.Split(ts, "\"")
+			lines := strings.Split(getIncludedFile(ss[len(ss)-2], dir), "\n")
+			if len(lines) > 1 {
+				// remove open and close brace as the contents replace the reference in place
+				out += strings.Join(lines[1:len(lines)-1], "\n") + "\n"
+			}
+			if ss[len(ss)-1] == "," {
+				out += ","
+			}
+		default:
+			out += l + "\n"
+		}
+	}
+	return out
+}
+
+// reports each reference to a Model that does not exist, with the path at which it appears
+func unresolvedReferences(models map[string]interface{}, path string, obj interface{}, problems *[]string) {
+	switch o := obj.(type) {
+	case map[string]interface{}:
+		for k, v := range o {
+			if ref, found := v.(string); found && k == "$ref" {
+				name := strings.TrimPrefix(ref, "#/definitions/Model/")
+				if _, found := models[name]; !found || name == ref {
+					*problems = append(*problems, fmt.Sprintf("%s refers to %s, which does not exist", path, ref))
+				}
+				continue
+			}
+			unresolvedReferences(models, path+"/"+k, v, problems)
+		}
+	case []interface{}:
+		for i, v := range o {
+			unresolvedReferences(models, fmt.Sprintf("%s/%d", path, i), v, problems)
+		}
+	}
+}
+
+// reports each name that a section of the config lists but the schema does not define
+func missingFromSchema(section string, names []string, defs map[string]interface{}, problems *[]string) {
+	for _, name := range names {
+		if _, found := defs[name]; !found {
+			*problems = append(*problems, fmt.Sprintf("%s %s lists %s, which is not in the schema", *configFile, section, name))
+		}
+	}
+}
+
+// compares the routes that a contract registers, as returned by its readAllRoutes query,
+// with the schema's API and with the functions that the config publishes
+func checkRoutes(api map[string]interface{}, published []string, filename string) []string {
+	var problems []string
+	var routes []struct {
+		FunctionName string `json:"functionname"`
+		Method       string `json:"method"`
+	}
+	routesJSON, err := ioutil.ReadFile(filename)
+	if err == nil {
+		err = json.Unmarshal(routesJSON, &routes)
+	}
+	if err != nil {
+		return []string{fmt.Sprintf("cannot read routes from %s: %s", filename, err)}
+	}
+	var registered = make(map[string]bool)
+	for _, r := range routes {
+		registered[r.FunctionName] = true
+		def, found := api[r.FunctionName].(map[string]interface{})
+		if !found {
+			problems = append(problems, fmt.Sprintf("route %s is registered but is not in the schema's API", r.FunctionName))
+			continue
+		}
+		props, _ := def["properties"].(map[string]interface{})
+		if method, _ := props["method"].(string); method != r.Method {
+			problems = append(problems, fmt.Sprintf("route %s is registered as %s but the schema says %s", r.FunctionName, r.Method, method))
+		}
+	}
+	for _, function := range published {
+		if !registered[function] {
+			problems = append(problems, fmt.Sprintf("%s schemas.API lists %s, which is not registered as a route", *configFile, function))
+		}
+	}
+	return problems
+}
+
+// checkSchema returns the problems that generation would stop on or only warn about:
+// references to Models that do not exist and names in the config that are not in the
+// schema, and when a routes file is given, routes that are not in the schema and
+// published functions that are not routes
+func checkSchema(schema map[string]interface{}, config Config) []string {
+	var problems []string
+	definitions, _ := schema["definitions"].(map[string]interface{})
+	api, found := definitions["API"].(map[string]interface{})
+	if !found {
+		problems = append(problems, "the schema has no API section")
+	}
+	models, found := definitions["Model"].(map[string]interface{})
+	if !found {
+		problems = append(problems, "the schema has no Model section")
+	}
+	unresolvedReferences(models, "API", api, &problems)
+	unresolvedReferences(models, "Model", models, &problems)
+	missingFromSchema("schemas.API", config.Schemas.API, api, &problems)
+	missingFromSchema("schemas.Model", config.Schemas.Model, models, &problems)
+	missingFromSchema("samples.API", config.Samples.API, api, &problems)
+	missingFromSchema("samples.Model", config.Samples.Model, models, &problems)
+	missingFromSchema("samples.fixtures.API", config.Samples.Fixtures.API, api, &problems)
+	missingFromSchema("types.Model", config.Types.Model, models, &problems)
+	missingFromSchema("openapi.API", config.OpenAPI.API, api, &problems)
+	missingFromSchema("client.API", config.Client.API, api, &problems)
+	if *routesFile != "" {
+		problems = append(problems, checkRoutes(api, config.Schemas.API, *routesFile)...)
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+// reports the changes from an old to a new version of one schema element that break
+// callers or stored states that were written against the old one
+func compareElements(path string, oldIn interface{}, newIn interface{}, problems *[]string) {
+	o, oldIsMap := oldIn.(map[string]interface{})
+	n, newIsMap := newIn.(map[string]interface{})
+	if !oldIsMap || !newIsMap {
+		// a plain value such as an API function's method
+		if !reflect.DeepEqual(oldIn, newIn) {
+			*problems = append(*problems, fmt.Sprintf("%s changed from %v to %v", path, oldIn, newIn))
+		}
+		return
+	}
+	if oldRef, found := o["$ref"].(string); found && o["$ref"] != n["$ref"] {
+		*problems = append(*problems, fmt.Sprintf("%s referred to %s, now %v", path, oldRef, n["$ref"]))
+	}
+	if oldType, found := o["type"].(string); found && o["type"] != n["type"] {
+		*problems = append(*problems, fmt.Sprintf("%s changed type from %s to %v", path, oldType, n["type"]))
+	}
+	if newEnum, found := n["enum"].([]interface{}); found {
+		oldEnum, _ := o["enum"].([]interface{})
+		for _, v := range oldEnum {
+			if !containsValue(newEnum, v) {
+				*problems = append(*problems, fmt.Sprintf("%s no longer allows %v", path, v))
+			}
+		}
+	}
+	oldRequired, _ := o["required"].([]interface{})
+	newRequired, _ := n["required"].([]interface{})
+	for _, v := range newRequired {
+		if !containsValue(oldRequired, v) {
+			*problems = append(*problems, fmt.Sprintf("%s now requires %v", path, v))
+		}
+	}
+	for _, k := range []string{"properties", "patternProperties"} {
+		oldProps, _ := o[k].(map[string]interface{})
+		newProps, _ := n[k].(map[string]interface{})
+		for name, op := range oldProps {
+			np, found := newProps[name]
+			if !found {
+				*problems = append(*problems, fmt.Sprintf("%s.%s was removed", path, name))
+				continue
+			}
+			compareElements(path+"."+name, op, np, problems)
+		}
+	}
+	if oldItems, found := o["items"]; found {
+		compareElements(path+"[]", oldItems, n["items"], problems)
+	}
+}
+
+func containsValue(values []interface{}, v interface{}) bool {
+	for _, value := range values {
+		if reflect.DeepEqual(value, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareSchemas returns the backward incompatible changes from an old version of a
+// schema: removed API functions, Models and properties, changed types, methods and
+// references, enum values that are no longer allowed and newly required properties
+func compareSchemas(oldSchema map[string]interface{}, newSchema map[string]interface{}) []string {
+	var problems []string
+	oldDefinitions, _ := oldSchema["definitions"].(map[string]interface{})
+	newDefinitions, _ := newSchema["definitions"].(map[string]interface{})
+	for _, section := range []string{"API", "Model"} {
+		oldDefs, _ := oldDefinitions[section].(map[string]interface{})
+		newDefs, _ := newDefinitions[section].(map[string]interface{})
+		for name, o := range oldDefs {
+			n, found := newDefs[name]
+			if !found {
+				problems = append(problems, fmt.Sprintf("%s/%s was removed", section, name))
+				continue
+			}
+			compareElements(section+"/"+name, o, n, &problems)
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+// ************** Legacy contracts
+// The contracts written before the platform keep their schema in payloadschema.json and
+// their config in scripts/generate.json, which lists goSchemaElements and
+// goSampleElements in place of Models. Their references can name any path under
+// definitions and their generated files hold only the string literal, as the contracts
+// register readAssetSchemas and readAssetSamples themselves.
+
+// legacyConfig is true when the config is in the layout of the legacy contracts
+func legacyConfig(config Config) bool {
+	return len(config.Schemas.GoSchemaElements) > 0 || len(config.Samples.GoSampleElements) > 0
+}
+
+// getLegacyObject finds an object by a legacy reference, which is a full path like
+// "#/definitions/API/init", a path after definitions like "event", or "definitions"
+// itself. A level with properties is searched there first, so "API/init" and
+// "API/properties/init" both find the init function.
+func getLegacyObject(schema map[string]interface{}, ref string) map[string]interface{} {
+	var path = ref
+	switch {
+	case path == "definitions":
+		path = "#/definitions"
+	case !strings.HasPrefix(path, "#/"):
+		path = "#/definitions/" + path
+	}
+	var obj = schema
+	for _, level := range strings.Split(strings.TrimPrefix(path, "#/"), "/") {
+		if props, found := obj["properties"].(map[string]interface{}); found {
+			if o, found := props[level].(map[string]interface{}); found {
+				obj = o
+				continue
+			}
+		}
+		o, found := obj[level].(map[string]interface{})
+		if !found {
+			return nil
+		}
+		obj = o
+	}
+	return obj
+}
+
+// resolveLegacyReferences returns a copy of obj in which each object that is a reference
+// is replaced by the object it refers to, with that object's references resolved in turn
+func resolveLegacyReferences(schema map[string]interface{}, obj interface{}, refs []string) interface{} {
+	switch o := obj.(type) {
+	case map[string]interface{}:
+		if ref, found := o["$ref"].(string); found {
+			for _, r := range refs {
+				if r == ref {
+					fmt.Printf("** ERR ** %s refers to itself through %s\n", ref, strings.Join(refs, " -> "))
+					os.Exit(1)
+				}
+			}
+			target := getLegacyObject(schema, ref)
+			if target == nil {
+				fmt.Printf("** ERR ** cannot find %s in %s\n", ref, config.Schemas.SchemaFilename)
+				os.Exit(1)
+			}
+			return resolveLegacyReferences(schema, target, append(refs, ref))
+		}
+		out := make(map[string]interface{}, len(o))
+		for k, v := range o {
+			out[k] = resolveLegacyReferences(schema, v, refs)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(o))
+		for i, v := range o {
+			out[i] = resolveLegacyReferences(schema, v, refs)
+		}
+		return out
+	}
+	return obj
+}
+
+// Generates the legacy schemas file, the API functions and the selected elements with all
+// references resolved
+func generateLegacyGoSchemaFile(schema map[string]interface{}, config Config) {
+	var api = make(map[string]interface{})
+	var elements = make(map[string]interface{})
+	for _, function := range config.Schemas.API {
+		obj := getLegacyObject(schema, "API/"+function)
+		if obj == nil {
+			fmt.Printf("** ERR ** API/%s not found in schema\n", function)
+			return
+		}
+		api[function] = obj
+	}
+	for _, element := range config.Schemas.GoSchemaElements {
+		obj := getLegacyObject(schema, element)
+		if obj == nil {
+			fmt.Printf("** ERR ** %s not found in schema\n", element)
+			return
+		}
+		elements[element] = obj
+	}
+	schemaOut, err := json.MarshalIndent(map[string]interface{}{"API": api, "objectModelSchemas": elements}, "", "    ")
+	if err != nil {
+		fmt.Printf("** ERR ** cannot marshal schema file output for writing\n")
+		return
+	}
+	ioutil.WriteFile(config.Schemas.GoSchemaFilename, []byte("package main\n\nvar schemas = `\n"+string(schemaOut)+"`"), 0644)
+}
+
+// Generates the legacy samples file, a canonical sample of each selected element, where
+// the element "schema" is the whole schema
+func generateLegacyGoSampleFile(schema map[string]interface{}, config Config) {
+	var samples = make(map[string]interface{})
+	var s = sampler{base: fixtureEpoch}
+	for _, element := range config.Samples.GoSampleElements {
+		obj := schema
+		if element != "schema" {
+			obj = getLegacyObject(schema, element)
+			if obj == nil {
+				fmt.Printf("** ERR ** %s not found in schema\n", element)
+				return
+			}
+		}
+		samples[element] = s.sampleType(obj, element)
+	}
+	samplesOut, err := json.MarshalIndent(samples, "", "    ")
+	if err != nil {
+		fmt.Println("** ERR ** cannot marshal sample file output for writing")
+		return
+	}
+	ioutil.WriteFile(config.Samples.GoSampleFilename, []byte("package main\n\nvar samples = `\n"+string(samplesOut)+"`"), 0644)
+}
+
+// checkLegacySchema returns the problems that legacy generation would stop on, references
+// that do not resolve and names in the config that are not in the schema
+func checkLegacySchema(schema map[string]interface{}, config Config) []string {
+	var problems []string
+	unresolvedLegacyReferences(schema, "", schema, &problems)
+	missing := func(section string, names []string, prefix string) {
+		for _, name := range names {
+			if name != "schema" && getLegacyObject(schema, prefix+name) == nil {
+				problems = append(problems, fmt.Sprintf("%s %s lists %s, which is not in the schema", *configFile, section, name))
+			}
+		}
+	}
+	missing("schemas.API", config.Schemas.API, "API/")
+	missing("schemas.goSchemaElements", config.Schemas.GoSchemaElements, "")
+	missing("samples.goSampleElements", config.Samples.GoSampleElements, "")
+	sort.Strings(problems)
+	return problems
+}
+
+// reports each legacy reference that does not resolve, with the path at which it appears
+func unresolvedLegacyReferences(schema map[string]interface{}, path string, obj interface{}, problems *[]string) {
+	switch o := obj.(type) {
+	case map[string]interface{}:
+		for k, v := range o {
+			if ref, found := v.(string); found && k == "$ref" {
+				if getLegacyObject(schema, ref) == nil {
+					*problems = append(*problems, fmt.Sprintf("%s refers to %s, which does not exist", path, ref))
+				}
+				continue
+			}
+			unresolvedLegacyReferences(schema, path+"/"+k, v, problems)
+		}
+	case []interface{}:
+		for i, v := range o {
+			unresolvedLegacyReferences(schema, fmt.Sprintf("%s/%d", path, i), v, problems)
+		}
+	}
+}
+
+// Reads a schema, replacing each line that includes a file with the included object's
+// contents, and unmarshals it. The preprocessed text is returned with
+// the schema so that fresh copies can be made from it.
+func readSchema(filename string) (string, map[string]interface{}, error) {
+	var api string
+	var line = 1
+	var lineOut = 1
+	var offsets [5000]int
+
+	// ************** Stage 1
+	// read the schema and preprocess for file includes at the top level
+	filepre, err := os.Open(filename)
+	if err != nil {
+		fmt.Printf("** ERR ** [%s] opening input schema file at %s\n", err, filename)
+		return "", nil, err
+	}
+	defer filepre.Close()
+	includeChain = []string{filename}
+	defer func() { includeChain = nil }()
+	reader := bufio.NewReader(filepre)
+	scanner := bufio.NewScanner(reader)
+	scanner.Split(bufio.ScanLines)
+	for scanner.Scan() {
+		l := scanner.Text()
+		// fmt.Println("MAIN SCHEMA: " + l)
+		ts := strings.TrimSpace(l)
+		if strings.HasPrefix(ts, "#") {
+			fmt.Println("Line: ", line, " is a comment")
+		} else if ts == "" {
+			fmt.Println("Line: ", line, " is blank")
+		} else if strings.HasPrefix(ts, "\"$ref\"") && strings.Index(ts, "\"#/") == -1 {
+			ss := strings.Split(ts, "\"")
+			p := ss[len(ss)-2]
+			// fmt.Printf("line: %d includes: %s\n", line, p)
+			refArr := getIncludedFile(p, filepath.Dir(filename))
+			lines := strings.Split(refArr, "\n")
+			// remove open and close brace as we are replacing the reference in place with the contents of the names object
+			lines = lines[1 : len(lines)-1]
+			for _, l2 := range lines {
+				api += l2 + "\n"
+				lineOut++
+			}
+			if len(ss) > 0 && ss[len(ss)-1] == "," {
+				api += ","
+			}
+		} else {
+			api += l + "\n"
+			lineOut++
+		}
+		offsets[lineOut] = line
+		line++
+	}
+
+	// ************** Stage 2
+	// unmarshal the preprocessed schema
+	var schema map[string]interface{}
+	err = json.Unmarshal([]byte(api), &schema)
+	if err != nil {
+		fmt.Println("*********** UNMARSHAL ERR **************\n", err)
+		printSyntaxErrorOffsets(api, &offsets, err)
+		return "", nil, err
+	}
+	return api, schema, nil
+}
+
+// Reads payloadschema.json api file
+// encodes as a string literal in payloadschema.go
+func main() {
+
+	flag.Parse()
+
+	if *verbose {
+		fmt.Printf("genschema runs with config file %s\n", *configFile)
+	}
+
+	var regReadSamples = `
+	var readAssetSamples iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+		return []byte(samples), nil
+	}
+
+	func init() {
+		iot.AddRoute("readAssetSamples", "query", iot.SystemClass, readAssetSamples)
+	}
+	`
+	var regReadSchemas = `
+	var readAssetSchemas iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+		return []byte(schemas), nil
+	}
+	func init() {
+		iot.AddRoute("readAssetSchemas", "query", iot.SystemClass, readAssetSchemas)
+	}
+	`
+	var imports = `
+	import (
+		"github.com/hyperledger/fabric/core/chaincode/shim"
+		iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
+)`
+
+	filename, _ := filepath.Abs("./" + *configFile)
+	jsonFile, err := ioutil.ReadFile(filename)
+	if err != nil {
+		panic(errors.New("error reading json file" + err.Error()))
+	}
+	err = json.Unmarshal(jsonFile, &config)
+	if err != nil {
+		panic(errors.New("error unmarshaling json config" + err.Error()))
+	}
+
+	// ************** Stages 1 and 2
+	// preprocess the schema for file includes and unmarshal it
+	api, schema, err := readSchema(config.Schemas.SchemaFilename)
+	if err != nil {
+		return
+	}
+
+	if *verbose {
+		prefilename := strings.Split(filename, "/")[0] + "schema.with.includes.json"
+		fmt.Println("Writing preprocessed schema to: " + prefilename)
+		_ = ioutil.WriteFile(prefilename, PrettyPrintBytes(schema), 0744)
+	}
+
+	// ************** Check and compare modes
+	// report problems with the schema and config, or incompatible changes from an older
+	// version of the schema, and exit non-zero if there are any
+	if *check || *compare != "" {
+		var problems []string
+		switch {
+		case *check && legacyConfig(config):
+			problems = append(problems, checkLegacySchema(schema, config)...)
+		case *check:
+			problems = append(problems, checkSchema(schema, config)...)
+		}
+		if *compare != "" {
+			_, oldschema, err := readSchema(*compare)
+			if err != nil {
+				os.Exit(1)
+			}
+			problems = append(problems, compareSchemas(oldschema, schema)...)
+		}
+		for _, p := range problems {
+			fmt.Println("** ERR ** " + p)
+		}
+		if len(problems) > 0 {
+			fmt.Printf("%d problems found in %s\n", len(problems), config.Schemas.SchemaFilename)
+			os.Exit(1)
+		}
+		fmt.Printf("no problems found in %s\n", config.Schemas.SchemaFilename)
+		return
+	}
+
+	// ************** Legacy contracts
+	// resolve every reference in the whole schema and pick the selected elements from it
+	if legacyConfig(config) {
+		schema = resolveLegacyReferences(schema, schema, nil).(map[string]interface{})
+		generateLegacyGoSchemaFile(schema, config)
+		generateLegacyGoSampleFile(schema, config)
+		return
+	}
+
+	// ************** Stage 3
+	// load the lookup tables with the data model, resolves all Model references
+	loadModelTables(schema)
+
+	// ************** Stage 4
+	// build final schema by inserting API, resolving all references using lookup table, and
+	// inserting the data model from the lookup table
+	finalschema = buildResolvedSchema(schema)
+
+	if *verbose {
+		finalfilename := strings.Split(filename, "/")[0] + "schema.with.no.refs.json"
+		fmt.Println("Writing final schema to: " + finalfilename)
+		_ = ioutil.WriteFile(finalfilename, []byte(PrettyPrint(finalschema)), 0744)
+	}
+
+	// ************** Stage 5
+	// generate the Go files that the contract needs -- for now, complete schema and
+	// event schema and sample object
+
+	generateGoSchemaFile(finalschema, config, imports, regReadSchemas)
+	generateGoSampleFile(finalschema, config, imports, regReadSamples)
+	if config.Samples.Fixtures.FixturesFilename != "" {
+		generateFixturesFile(finalschema, config)
+	}
+
+	// the lookup tables share objects with the schema and resolve its references in
+	// place, so types and the OpenAPI document are generated from fresh copies of the
+	// preprocessed schema, where each reference still names its Model
+	if config.Types.GoTypesFilename != "" {
+		var typeschema map[string]interface{}
+		_ = json.Unmarshal([]byte(api), &typeschema)
+		generateGoTypesFile(typeschema, config)
+	}
+	if config.OpenAPI.OpenAPIFilename != "" {
+		var apischema map[string]interface{}
+		_ = json.Unmarshal([]byte(api), &apischema)
+		generateOpenAPIFile(apischema, config)
+	}
+	if config.Schemas.GoRoutesFilename != "" {
+		var routeschema map[string]interface{}
+		_ = json.Unmarshal([]byte(api), &routeschema)
+		generateGoRoutesFile(routeschema, config)
+	}
+	if config.Client.GoClientFilename != "" {
+		var clientschema map[string]interface{}
+		_ = json.Unmarshal([]byte(api), &clientschema)
+		generateGoClientFile(clientschema, config)
+	}
+
+}
@@ -0,0 +1,2254 @@
+// Code generated by processSchema.go from trackandtrace.json, DO NOT EDIT.
+
+// Package client builds the requests that call the functions of the contract described by trackandtrace.json
+package client
+
+import "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpclient"
+
+// Client builds the requests, Send sends them
+type Client struct {
+	*iotcpclient.Client
+}
+
+// New returns a client for the contract deployed with the given name
+func New(url string, name string, secureContext string) *Client {
+	return &Client{iotcpclient.NewClient(url, name, secureContext)}
+}
+
+// InitContract builds the deploy request of initContract, sets contract version and nickname
+func (c *Client) InitContract(arg InitContractArg) (iotcpclient.Request, error) {
+	return c.Request("deploy", "initContract", arg)
+}
+
+// CreateAssetSurgicalKit builds the invoke request of createAssetSurgicalKit, creates a new surgicalkit (e.g. put new), a new surgicalkit is with its oem and has no transit, custody, sterilization or damage records
+func (c *Client) CreateAssetSurgicalKit(arg CreateAssetSurgicalKitArg) (iotcpclient.Request, error) {
+	return c.Request("invoke", "createAssetSurgicalKit", arg)
+}
+
+// ReplaceAssetSurgicalKit builds the invoke request of replaceAssetSurgicalKit, replaces a surgicalkit's state (e.g. put existing)
+func (c *Client) ReplaceAssetSurgicalKit(arg ReplaceAssetSurgicalKitArg) (iotcpclient.Request, error) {
+	return c.Request("invoke", "replaceAssetSurgicalKit", arg)
+}
+
+// UpdateAssetSurgicalKit builds the invoke request of updateAssetSurgicalKit, update a contaner's state with one or more property changes (e.g. patch existing)
+func (c *Client) UpdateAssetSurgicalKit(arg UpdateAssetSurgicalKitArg) (iotcpclient.Request, error) {
+	return c.Request("invoke", "updateAssetSurgicalKit", arg)
+}
+
+// DeleteAssetSurgicalKit builds the invoke request of deleteAssetSurgicalKit, delete a surgicalkit from world state, transactions remain on the blockchain
+func (c *Client) DeleteAssetSurgicalKit(arg DeleteAssetSurgicalKitArg) (iotcpclient.Request, error) {
+	return c.Request("invoke", "deleteAssetSurgicalKit", arg)
+}
+
+// DeleteAssetStateHistorySurgicalKit builds the invoke request of deleteAssetStateHistorySurgicalKit, delete a surgicalkit's history from world state, transactions remain on the blockchain
+func (c *Client) DeleteAssetStateHistorySurgicalKit(arg DeleteAssetStateHistorySurgicalKitArg) (iotcpclient.Request, error) {
+	return c.Request("invoke", "deleteAssetStateHistorySurgicalKit", arg)
+}
+
+// DeletePropertiesFromAssetSurgicalKit builds the invoke request of deletePropertiesFromAssetSurgicalKit, delete one or more properties from a surgicalkit's state, an example being temperature, which is only relevant for sensitive (as in frozen) shipments, the instruments and the properties that change only through the contract's own routes cannot be deleted
+func (c *Client) DeletePropertiesFromAssetSurgicalKit(arg DeletePropertiesFromAssetSurgicalKitArg) (iotcpclient.Request, error) {
+	return c.Request("invoke", "deletePropertiesFromAssetSurgicalKit", arg)
+}
+
+// DeleteAllAssetsSurgicalKit builds the invoke request of deleteAllAssetsSurgicalKit, delete all surgicalkits from world state, supports filters
+func (c *Client) DeleteAllAssetsSurgicalKit(arg *DeleteAllAssetsSurgicalKitArg) (iotcpclient.Request, error) {
+	if arg == nil {
+		return c.Request("invoke", "deleteAllAssetsSurgicalKit")
+	}
+	return c.Request("invoke", "deleteAllAssetsSurgicalKit", arg)
+}
+
+// ReadAssetSurgicalKit builds the query request of readAssetSurgicalKit, returns the state a surgicalkit
+func (c *Client) ReadAssetSurgicalKit(arg ReadAssetSurgicalKitArg) (iotcpclient.Request, error) {
+	return c.Request("query", "readAssetSurgicalKit", arg)
+}
+
+// ReadAllAssetsSurgicalKit builds the query request of readAllAssetsSurgicalKit, returns the state of all surgicalkits, supports filters
+func (c *Client) ReadAllAssetsSurgicalKit(arg *ReadAllAssetsSurgicalKitArg) (iotcpclient.Request, error) {
+	if arg == nil {
+		return c.Request("query", "readAllAssetsSurgicalKit")
+	}
+	return c.Request("query", "readAllAssetsSurgicalKit", arg)
+}
+
+// ReadAllRoutes builds the query request of readAllRoutes, returns an array of registered API calls by function (debugging)
+func (c *Client) ReadAllRoutes() (iotcpclient.Request, error) {
+	return c.Request("query", "readAllRoutes")
+}
+
+// ReadAllRules builds the query request of readAllRules, returns an array of registered rules by class (debugging)
+func (c *Client) ReadAllRules() (iotcpclient.Request, error) {
+	return c.Request("query", "readAllRules")
+}
+
+// ReadWorldState builds the query request of readWorldState, returns the entire contents of world state
+func (c *Client) ReadWorldState() (iotcpclient.Request, error) {
+	return c.Request("query", "readWorldState")
+}
+
+// DeleteWorldState builds the invoke request of deleteWorldState, **** WARNING *** Clears the entire contents of world state except destructive guard and audit records, requires a confirm token from readConfirmationToken and is disabled in production mode. The plain "reinit" argument of earlier releases is confirmed as {"reinit": true}
+func (c *Client) DeleteWorldState(arg DeleteWorldStateArg) (iotcpclient.Request, error) {
+	return c.Request("invoke", "deleteWorldState", arg)
+}
+
+// ReadAssetStateHistorySurgicalKit builds the query request of readAssetStateHistorySurgicalKit, returns history states for a surgicalkit
+func (c *Client) ReadAssetStateHistorySurgicalKit(arg ReadAssetStateHistorySurgicalKitArg) (iotcpclient.Request, error) {
+	return c.Request("query", "readAssetStateHistorySurgicalKit", arg)
+}
+
+// ShipSurgicalKit builds the invoke request of shipSurgicalKit, starts the handoff of a surgicalkit to the next custodian, the kit is in transit until the receiving party confirms it
+func (c *Client) ShipSurgicalKit(arg ShipSurgicalKitArg) (iotcpclient.Request, error) {
+	return c.Request("invoke", "shipSurgicalKit", arg)
+}
+
+// ReceiveSurgicalKit builds the invoke request of receiveSurgicalKit, completes the handoff of a surgicalkit, the receiving party becomes its holder and the custody change is recorded
+func (c *Client) ReceiveSurgicalKit(arg ReceiveSurgicalKitArg) (iotcpclient.Request, error) {
+	return c.Request("invoke", "receiveSurgicalKit", arg)
+}
+
+// CheckOverdueSurgicalKits builds the invoke request of checkOverdueSurgicalKits, evaluates the overdue shipment alert of every surgicalkit in transit, for shipments that have had no other update since their deadline
+func (c *Client) CheckOverdueSurgicalKits() (iotcpclient.Request, error) {
+	return c.Request("invoke", "checkOverdueSurgicalKits")
+}
+
+// SetShipmentWindow builds the invoke request of setShipmentWindow, sets how long a receiving party has to confirm a shipment before the overdue shipment alert is raised, applies to later shipments
+func (c *Client) SetShipmentWindow(arg SetShipmentWindowArg) (iotcpclient.Request, error) {
+	return c.Request("invoke", "setShipmentWindow", arg)
+}
+
+// SterilizeSurgicalKit builds the invoke request of sterilizeSurgicalKit, records a sterilization cycle of a surgicalkit and counts it for each instrument in the cycle
+func (c *Client) SterilizeSurgicalKit(arg SterilizeSurgicalKitArg) (iotcpclient.Request, error) {
+	return c.Request("invoke", "sterilizeSurgicalKit", arg)
+}
+
+// RecallSurgicalKits builds the invoke request of recallSurgicalKits, recalls an instrument lot, every surgicalkit with an instrument of the lot raises the RECALLED alert and is no longer compliant, the kits with their custodian and location are reported in the invoke's result event as recall
+func (c *Client) RecallSurgicalKits(arg RecallSurgicalKitsArg) (iotcpclient.Request, error) {
+	return c.Request("invoke", "recallSurgicalKits", arg)
+}
+
+// InspectSurgicalKit builds the invoke request of inspectSurgicalKit, records an inspection of a surgicalkit in its damage timeline, a passed inspection clears the EXCESSFORCE and EXCESSTILT alerts
+func (c *Client) InspectSurgicalKit(arg InspectSurgicalKitArg) (iotcpclient.Request, error) {
+	return c.Request("invoke", "inspectSurgicalKit", arg)
+}
+
+// ReadDamageTimelineSurgicalKit builds the query request of readDamageTimelineSurgicalKit, returns the shocks, tilts and inspections of a surgicalkit with the alerts that wait for an inspection
+func (c *Client) ReadDamageTimelineSurgicalKit(arg ReadDamageTimelineSurgicalKitArg) (iotcpclient.Request, error) {
+	return c.Request("query", "readDamageTimelineSurgicalKit", arg)
+}
+
+// ReadRecentStates builds the query request of readRecentStates, returns the state of recently updated assets for one class, or for all classes merged newest first
+func (c *Client) ReadRecentStates(arg *ReadRecentStatesArg) (iotcpclient.Request, error) {
+	if arg == nil {
+		return c.Request("query", "readRecentStates")
+	}
+	return c.Request("query", "readRecentStates", arg)
+}
+
+// SetLoggingLevel builds the invoke request of setLoggingLevel, sets the logging level for the contract, or for one module of the platform
+func (c *Client) SetLoggingLevel(arg SetLoggingLevelArg) (iotcpclient.Request, error) {
+	return c.Request("invoke", "setLoggingLevel", arg)
+}
+
+// ReadAssetSamples builds the query request of readAssetSamples, returns samples of selected contract objects
+func (c *Client) ReadAssetSamples() (iotcpclient.Request, error) {
+	return c.Request("query", "readAssetSamples")
+}
+
+// ReadAssetSchemas builds the query request of readAssetSchemas, returns the API for this contract for the use of self-configuring applications; is MANDATORY for integration with the Watson IoT Platform
+func (c *Client) ReadAssetSchemas() (iotcpclient.Request, error) {
+	return c.Request("query", "readAssetSchemas")
+}
+
+// SetCreateOnFirstUpdate builds the invoke request of setCreateOnFirstUpdate, allow updateAsset to create an asset upon receipt of its first event
+func (c *Client) SetCreateOnFirstUpdate(arg SetCreateOnFirstUpdateArg) (iotcpclient.Request, error) {
+	return c.Request("invoke", "setCreateOnFirstUpdate", arg)
+}
+
+// InitContractArg is generated from the schema
+type InitContractArg struct {
+	Nickname *string `json:"nickname,omitempty"`
+	Version  *string `json:"version,omitempty"`
+}
+
+// GetNickname returns nickname and whether it is present
+func (m *InitContractArg) GetNickname() (string, bool) {
+	if m == nil || m.Nickname == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Nickname, true
+}
+
+// SetNickname sets nickname
+func (m *InitContractArg) SetNickname(v string) {
+	m.Nickname = &v
+}
+
+// GetVersion returns version and whether it is present
+func (m *InitContractArg) GetVersion() (string, bool) {
+	if m == nil || m.Version == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Version, true
+}
+
+// SetVersion sets version
+func (m *InitContractArg) SetVersion(v string) {
+	m.Version = &v
+}
+
+// CreateAssetSurgicalKitArg is generated from the schema
+type CreateAssetSurgicalKitArg struct {
+	Surgicalkit *Surgicalkit `json:"surgicalkit,omitempty"`
+}
+
+// GetSurgicalkit returns surgicalkit, nil when it is not present
+func (m *CreateAssetSurgicalKitArg) GetSurgicalkit() *Surgicalkit {
+	if m == nil {
+		return nil
+	}
+	return m.Surgicalkit
+}
+
+// Surgicalkit is the changeable properties for a surgicalkit, also considered its 'event' as a partial state, the status, transit, holder, custody, sterilizations and damage properties are changed only by the contract's own routes
+type Surgicalkit struct {
+	Burst  *Burst          `json:"burst,omitempty"`
+	Common *Ioteventcommon `json:"common,omitempty"`
+	// the kit's changes of custody, oldest first, appended by receiveSurgicalKit
+	Custody []CustodyChange `json:"custody,omitempty"`
+	// the kit's most recent shocks, tilts and inspections, oldest first, shocks and tilts are recorded from sensor readings beyond the limits and inspections by inspectSurgicalKit, the latest shock and tilt that wait for a passed inspection are kept however old
+	Damage []DamageEvent `json:"damage,omitempty"`
+	// calculated distance in meters from the hospital fence center to the end location, can be compared to the fence radius
+	DistanceFromFenceCenter *float64 `json:"distanceFromFenceCenter,omitempty"`
+	// the party that holds the kit, set by receiveSurgicalKit
+	Holder   *string   `json:"holder,omitempty"`
+	Hospital *Hospital `json:"hospital,omitempty"`
+	// the instruments in the kit, an update replaces those with the same serial and adds the others
+	Instruments []Instrument `json:"instruments,omitempty"`
+	Sensors     *Sensors     `json:"sensors,omitempty"`
+	SkitID      *string      `json:"skitID,omitempty"`
+	Status      *Status      `json:"status,omitempty"`
+	// the kit's most recent sterilization cycles, oldest first, appended by sterilizeSurgicalKit
+	Sterilizations []SterilizationCycle `json:"sterilizations,omitempty"`
+	Transit        *Transit             `json:"transit,omitempty"`
+}
+
+// GetBurst returns burst, nil when it is not present
+func (m *Surgicalkit) GetBurst() *Burst {
+	if m == nil {
+		return nil
+	}
+	return m.Burst
+}
+
+// GetCommon returns common, nil when it is not present
+func (m *Surgicalkit) GetCommon() *Ioteventcommon {
+	if m == nil {
+		return nil
+	}
+	return m.Common
+}
+
+// GetCustody returns custody
+func (m *Surgicalkit) GetCustody() []CustodyChange {
+	if m == nil {
+		return nil
+	}
+	return m.Custody
+}
+
+// GetDamage returns damage
+func (m *Surgicalkit) GetDamage() []DamageEvent {
+	if m == nil {
+		return nil
+	}
+	return m.Damage
+}
+
+// GetDistanceFromFenceCenter returns distanceFromFenceCenter and whether it is present
+func (m *Surgicalkit) GetDistanceFromFenceCenter() (float64, bool) {
+	if m == nil || m.DistanceFromFenceCenter == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.DistanceFromFenceCenter, true
+}
+
+// SetDistanceFromFenceCenter sets distanceFromFenceCenter
+func (m *Surgicalkit) SetDistanceFromFenceCenter(v float64) {
+	m.DistanceFromFenceCenter = &v
+}
+
+// GetHolder returns holder and whether it is present
+func (m *Surgicalkit) GetHolder() (string, bool) {
+	if m == nil || m.Holder == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Holder, true
+}
+
+// SetHolder sets holder
+func (m *Surgicalkit) SetHolder(v string) {
+	m.Holder = &v
+}
+
+// GetHospital returns hospital, nil when it is not present
+func (m *Surgicalkit) GetHospital() *Hospital {
+	if m == nil {
+		return nil
+	}
+	return m.Hospital
+}
+
+// GetInstruments returns instruments
+func (m *Surgicalkit) GetInstruments() []Instrument {
+	if m == nil {
+		return nil
+	}
+	return m.Instruments
+}
+
+// GetSensors returns sensors, nil when it is not present
+func (m *Surgicalkit) GetSensors() *Sensors {
+	if m == nil {
+		return nil
+	}
+	return m.Sensors
+}
+
+// GetSkitID returns skitID and whether it is present
+func (m *Surgicalkit) GetSkitID() (string, bool) {
+	if m == nil || m.SkitID == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.SkitID, true
+}
+
+// SetSkitID sets skitID
+func (m *Surgicalkit) SetSkitID(v string) {
+	m.SkitID = &v
+}
+
+// GetStatus returns status and whether it is present
+func (m *Surgicalkit) GetStatus() (Status, bool) {
+	if m == nil || m.Status == nil {
+		var zero Status
+		return zero, false
+	}
+	return *m.Status, true
+}
+
+// SetStatus sets status
+func (m *Surgicalkit) SetStatus(v Status) {
+	m.Status = &v
+}
+
+// GetSterilizations returns sterilizations
+func (m *Surgicalkit) GetSterilizations() []SterilizationCycle {
+	if m == nil {
+		return nil
+	}
+	return m.Sterilizations
+}
+
+// GetTransit returns transit, nil when it is not present
+func (m *Surgicalkit) GetTransit() *Transit {
+	if m == nil {
+		return nil
+	}
+	return m.Transit
+}
+
+// Burst is one individual message in a sequenced burst of messages stored in history for testing purposes
+type Burst struct {
+	// length of this burst
+	Burstlength *float64 `json:"burstlength,omitempty"`
+	Burstnum    *float64 `json:"burstnum,omitempty"`
+	Sequence    *float64 `json:"sequence,omitempty"`
+}
+
+// GetBurstlength returns burstlength and whether it is present
+func (m *Burst) GetBurstlength() (float64, bool) {
+	if m == nil || m.Burstlength == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Burstlength, true
+}
+
+// SetBurstlength sets burstlength
+func (m *Burst) SetBurstlength(v float64) {
+	m.Burstlength = &v
+}
+
+// GetBurstnum returns burstnum and whether it is present
+func (m *Burst) GetBurstnum() (float64, bool) {
+	if m == nil || m.Burstnum == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Burstnum, true
+}
+
+// SetBurstnum sets burstnum
+func (m *Burst) SetBurstnum(v float64) {
+	m.Burstnum = &v
+}
+
+// GetSequence returns sequence and whether it is present
+func (m *Burst) GetSequence() (float64, bool) {
+	if m == nil || m.Sequence == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Sequence, true
+}
+
+// SetSequence sets sequence
+func (m *Burst) SetSequence(v float64) {
+	m.Sequence = &v
+}
+
+// Ioteventcommon is common properties for all assets
+type Ioteventcommon struct {
+	// application managed information as an array of key:value pairs
+	Appdata []IoteventcommonAppdata `json:"appdata,omitempty"`
+	// a unique identifier for the device that sent the current event
+	DeviceID *string `json:"deviceID,omitempty"`
+	// a timestamp recoded by the device that sent the current event
+	Devicetimestamp *string `json:"devicetimestamp,omitempty"`
+	Location        *Geo    `json:"location,omitempty"`
+}
+
+// GetAppdata returns appdata
+func (m *Ioteventcommon) GetAppdata() []IoteventcommonAppdata {
+	if m == nil {
+		return nil
+	}
+	return m.Appdata
+}
+
+// GetDeviceID returns deviceID and whether it is present
+func (m *Ioteventcommon) GetDeviceID() (string, bool) {
+	if m == nil || m.DeviceID == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.DeviceID, true
+}
+
+// SetDeviceID sets deviceID
+func (m *Ioteventcommon) SetDeviceID(v string) {
+	m.DeviceID = &v
+}
+
+// GetDevicetimestamp returns devicetimestamp and whether it is present
+func (m *Ioteventcommon) GetDevicetimestamp() (string, bool) {
+	if m == nil || m.Devicetimestamp == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Devicetimestamp, true
+}
+
+// SetDevicetimestamp sets devicetimestamp
+func (m *Ioteventcommon) SetDevicetimestamp(v string) {
+	m.Devicetimestamp = &v
+}
+
+// GetLocation returns location, nil when it is not present
+func (m *Ioteventcommon) GetLocation() *Geo {
+	if m == nil {
+		return nil
+	}
+	return m.Location
+}
+
+// IoteventcommonAppdata is generated from the schema
+type IoteventcommonAppdata struct {
+	K *string `json:"K,omitempty"`
+	V *string `json:"V,omitempty"`
+}
+
+// GetK returns K and whether it is present
+func (m *IoteventcommonAppdata) GetK() (string, bool) {
+	if m == nil || m.K == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.K, true
+}
+
+// SetK sets K
+func (m *IoteventcommonAppdata) SetK(v string) {
+	m.K = &v
+}
+
+// GetV returns V and whether it is present
+func (m *IoteventcommonAppdata) GetV() (string, bool) {
+	if m == nil || m.V == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.V, true
+}
+
+// SetV sets V
+func (m *IoteventcommonAppdata) SetV(v string) {
+	m.V = &v
+}
+
+// Geo is a geographical coordinate
+type Geo struct {
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+}
+
+// GetLatitude returns latitude and whether it is present
+func (m *Geo) GetLatitude() (float64, bool) {
+	if m == nil || m.Latitude == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Latitude, true
+}
+
+// SetLatitude sets latitude
+func (m *Geo) SetLatitude(v float64) {
+	m.Latitude = &v
+}
+
+// GetLongitude returns longitude and whether it is present
+func (m *Geo) GetLongitude() (float64, bool) {
+	if m == nil || m.Longitude == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Longitude, true
+}
+
+// SetLongitude sets longitude
+func (m *Geo) SetLongitude(v float64) {
+	m.Longitude = &v
+}
+
+// CustodyChange is one change of custody, confirmed by the receiving party
+type CustodyChange struct {
+	Carrier   *string `json:"carrier,omitempty"`
+	From      *Status `json:"from,omitempty"`
+	Fromparty *string `json:"fromparty,omitempty"`
+	// true when the receipt came after the shipment's deadline
+	Late *bool `json:"late,omitempty"`
+	// timestamp of the receipt
+	Received *string `json:"received,omitempty"`
+	// timestamp of the shipment
+	Shipped *string `json:"shipped,omitempty"`
+	To      *Status `json:"to,omitempty"`
+	Toparty *string `json:"toparty,omitempty"`
+}
+
+// GetCarrier returns carrier and whether it is present
+func (m *CustodyChange) GetCarrier() (string, bool) {
+	if m == nil || m.Carrier == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Carrier, true
+}
+
+// SetCarrier sets carrier
+func (m *CustodyChange) SetCarrier(v string) {
+	m.Carrier = &v
+}
+
+// GetFrom returns from and whether it is present
+func (m *CustodyChange) GetFrom() (Status, bool) {
+	if m == nil || m.From == nil {
+		var zero Status
+		return zero, false
+	}
+	return *m.From, true
+}
+
+// SetFrom sets from
+func (m *CustodyChange) SetFrom(v Status) {
+	m.From = &v
+}
+
+// GetFromparty returns fromparty and whether it is present
+func (m *CustodyChange) GetFromparty() (string, bool) {
+	if m == nil || m.Fromparty == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Fromparty, true
+}
+
+// SetFromparty sets fromparty
+func (m *CustodyChange) SetFromparty(v string) {
+	m.Fromparty = &v
+}
+
+// GetLate returns late and whether it is present
+func (m *CustodyChange) GetLate() (bool, bool) {
+	if m == nil || m.Late == nil {
+		var zero bool
+		return zero, false
+	}
+	return *m.Late, true
+}
+
+// SetLate sets late
+func (m *CustodyChange) SetLate(v bool) {
+	m.Late = &v
+}
+
+// GetReceived returns received and whether it is present
+func (m *CustodyChange) GetReceived() (string, bool) {
+	if m == nil || m.Received == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Received, true
+}
+
+// SetReceived sets received
+func (m *CustodyChange) SetReceived(v string) {
+	m.Received = &v
+}
+
+// GetShipped returns shipped and whether it is present
+func (m *CustodyChange) GetShipped() (string, bool) {
+	if m == nil || m.Shipped == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Shipped, true
+}
+
+// SetShipped sets shipped
+func (m *CustodyChange) SetShipped(v string) {
+	m.Shipped = &v
+}
+
+// GetTo returns to and whether it is present
+func (m *CustodyChange) GetTo() (Status, bool) {
+	if m == nil || m.To == nil {
+		var zero Status
+		return zero, false
+	}
+	return *m.To, true
+}
+
+// SetTo sets to
+func (m *CustodyChange) SetTo(v Status) {
+	m.To = &v
+}
+
+// GetToparty returns toparty and whether it is present
+func (m *CustodyChange) GetToparty() (string, bool) {
+	if m == nil || m.Toparty == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Toparty, true
+}
+
+// SetToparty sets toparty
+func (m *CustodyChange) SetToparty(v string) {
+	m.Toparty = &v
+}
+
+// Status is current kit status as a named entity in possession of the kit
+type Status string
+
+// values of Status
+const (
+	StatusOem       Status = "oem"
+	StatusWarehouse Status = "warehouse"
+	StatusDealer    Status = "dealer"
+	StatusRetailer  Status = "retailer"
+	StatusHospital  Status = "hospital"
+	StatusScrapped  Status = "scrapped"
+)
+
+// DamageEvent is a shock or tilt beyond the kit's limits, or an inspection of the kit
+type DamageEvent struct {
+	// the force in Gs of a shock
+	Gforce    *float64 `json:"gforce,omitempty"`
+	Inspector *string  `json:"inspector,omitempty"`
+	Kind      *string  `json:"kind,omitempty"`
+	Location  *Geo     `json:"location,omitempty"`
+	Notes     *string  `json:"notes,omitempty"`
+	// whether the kit passed an inspection
+	Passed *bool `json:"passed,omitempty"`
+	// the tilt in degrees from horizontal of a tilt
+	Tilt *float64 `json:"tilt,omitempty"`
+	// timestamp of the transaction that reported the event
+	Timestamp *string `json:"timestamp,omitempty"`
+}
+
+// GetGforce returns gforce and whether it is present
+func (m *DamageEvent) GetGforce() (float64, bool) {
+	if m == nil || m.Gforce == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Gforce, true
+}
+
+// SetGforce sets gforce
+func (m *DamageEvent) SetGforce(v float64) {
+	m.Gforce = &v
+}
+
+// GetInspector returns inspector and whether it is present
+func (m *DamageEvent) GetInspector() (string, bool) {
+	if m == nil || m.Inspector == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Inspector, true
+}
+
+// SetInspector sets inspector
+func (m *DamageEvent) SetInspector(v string) {
+	m.Inspector = &v
+}
+
+// GetKind returns kind and whether it is present
+func (m *DamageEvent) GetKind() (string, bool) {
+	if m == nil || m.Kind == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Kind, true
+}
+
+// SetKind sets kind
+func (m *DamageEvent) SetKind(v string) {
+	m.Kind = &v
+}
+
+// GetLocation returns location, nil when it is not present
+func (m *DamageEvent) GetLocation() *Geo {
+	if m == nil {
+		return nil
+	}
+	return m.Location
+}
+
+// GetNotes returns notes and whether it is present
+func (m *DamageEvent) GetNotes() (string, bool) {
+	if m == nil || m.Notes == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Notes, true
+}
+
+// SetNotes sets notes
+func (m *DamageEvent) SetNotes(v string) {
+	m.Notes = &v
+}
+
+// GetPassed returns passed and whether it is present
+func (m *DamageEvent) GetPassed() (bool, bool) {
+	if m == nil || m.Passed == nil {
+		var zero bool
+		return zero, false
+	}
+	return *m.Passed, true
+}
+
+// SetPassed sets passed
+func (m *DamageEvent) SetPassed(v bool) {
+	m.Passed = &v
+}
+
+// GetTilt returns tilt and whether it is present
+func (m *DamageEvent) GetTilt() (float64, bool) {
+	if m == nil || m.Tilt == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Tilt, true
+}
+
+// SetTilt sets tilt
+func (m *DamageEvent) SetTilt(v float64) {
+	m.Tilt = &v
+}
+
+// GetTimestamp returns timestamp and whether it is present
+func (m *DamageEvent) GetTimestamp() (string, bool) {
+	if m == nil || m.Timestamp == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Timestamp, true
+}
+
+// SetTimestamp sets timestamp
+func (m *DamageEvent) SetTimestamp(v string) {
+	m.Timestamp = &v
+}
+
+// Hospital is the hospital within which the surgical kit is used, and within which it is geofenced
+type Hospital struct {
+	Address *HospitalAddress `json:"address,omitempty"`
+	Fence   *HospitalFence   `json:"fence,omitempty"`
+	Name    *string          `json:"name,omitempty"`
+}
+
+// GetAddress returns address, nil when it is not present
+func (m *Hospital) GetAddress() *HospitalAddress {
+	if m == nil {
+		return nil
+	}
+	return m.Address
+}
+
+// GetFence returns fence, nil when it is not present
+func (m *Hospital) GetFence() *HospitalFence {
+	if m == nil {
+		return nil
+	}
+	return m.Fence
+}
+
+// GetName returns name and whether it is present
+func (m *Hospital) GetName() (string, bool) {
+	if m == nil || m.Name == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Name, true
+}
+
+// SetName sets name
+func (m *Hospital) SetName(v string) {
+	m.Name = &v
+}
+
+// HospitalAddress is generated from the schema
+type HospitalAddress struct {
+	City            *string `json:"city,omitempty"`
+	Country         *string `json:"country,omitempty"`
+	Postcode        *string `json:"postcode,omitempty"`
+	Streetandnumber *string `json:"streetandnumber,omitempty"`
+}
+
+// GetCity returns city and whether it is present
+func (m *HospitalAddress) GetCity() (string, bool) {
+	if m == nil || m.City == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.City, true
+}
+
+// SetCity sets city
+func (m *HospitalAddress) SetCity(v string) {
+	m.City = &v
+}
+
+// GetCountry returns country and whether it is present
+func (m *HospitalAddress) GetCountry() (string, bool) {
+	if m == nil || m.Country == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Country, true
+}
+
+// SetCountry sets country
+func (m *HospitalAddress) SetCountry(v string) {
+	m.Country = &v
+}
+
+// GetPostcode returns postcode and whether it is present
+func (m *HospitalAddress) GetPostcode() (string, bool) {
+	if m == nil || m.Postcode == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Postcode, true
+}
+
+// SetPostcode sets postcode
+func (m *HospitalAddress) SetPostcode(v string) {
+	m.Postcode = &v
+}
+
+// GetStreetandnumber returns streetandnumber and whether it is present
+func (m *HospitalAddress) GetStreetandnumber() (string, bool) {
+	if m == nil || m.Streetandnumber == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Streetandnumber, true
+}
+
+// SetStreetandnumber sets streetandnumber
+func (m *HospitalAddress) SetStreetandnumber(v string) {
+	m.Streetandnumber = &v
+}
+
+// HospitalFence is generated from the schema
+type HospitalFence struct {
+	Center *Geo `json:"center,omitempty"`
+	// radius of the fence in meters, readings in other units are sent as {"value": 0.5, "unit": "km"}
+	Radius *float64 `json:"radius,omitempty"`
+}
+
+// GetCenter returns center, nil when it is not present
+func (m *HospitalFence) GetCenter() *Geo {
+	if m == nil {
+		return nil
+	}
+	return m.Center
+}
+
+// GetRadius returns radius and whether it is present
+func (m *HospitalFence) GetRadius() (float64, bool) {
+	if m == nil || m.Radius == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Radius, true
+}
+
+// SetRadius sets radius
+func (m *HospitalFence) SetRadius(v float64) {
+	m.Radius = &v
+}
+
+// Instrument is a surgical instrument in the kit, identified by its serial number
+type Instrument struct {
+	// the sterilization cycles that the instrument has been through, counted by sterilizeSurgicalKit
+	Cycles      *int    `json:"cycles,omitempty"`
+	Description *string `json:"description,omitempty"`
+	// the manufacturing lot of the instrument, which recalls name
+	Lot *string `json:"lot,omitempty"`
+	// the sterilization cycles that the instrument is rated for, no limit when absent
+	Maxcycles *int `json:"maxcycles,omitempty"`
+	// the instrument's serial number
+	Serial *string `json:"serial,omitempty"`
+}
+
+// GetCycles returns cycles and whether it is present
+func (m *Instrument) GetCycles() (int, bool) {
+	if m == nil || m.Cycles == nil {
+		var zero int
+		return zero, false
+	}
+	return *m.Cycles, true
+}
+
+// SetCycles sets cycles
+func (m *Instrument) SetCycles(v int) {
+	m.Cycles = &v
+}
+
+// GetDescription returns description and whether it is present
+func (m *Instrument) GetDescription() (string, bool) {
+	if m == nil || m.Description == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Description, true
+}
+
+// SetDescription sets description
+func (m *Instrument) SetDescription(v string) {
+	m.Description = &v
+}
+
+// GetLot returns lot and whether it is present
+func (m *Instrument) GetLot() (string, bool) {
+	if m == nil || m.Lot == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Lot, true
+}
+
+// SetLot sets lot
+func (m *Instrument) SetLot(v string) {
+	m.Lot = &v
+}
+
+// GetMaxcycles returns maxcycles and whether it is present
+func (m *Instrument) GetMaxcycles() (int, bool) {
+	if m == nil || m.Maxcycles == nil {
+		var zero int
+		return zero, false
+	}
+	return *m.Maxcycles, true
+}
+
+// SetMaxcycles sets maxcycles
+func (m *Instrument) SetMaxcycles(v int) {
+	m.Maxcycles = &v
+}
+
+// GetSerial returns serial and whether it is present
+func (m *Instrument) GetSerial() (string, bool) {
+	if m == nil || m.Serial == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Serial, true
+}
+
+// SetSerial sets serial
+func (m *Instrument) SetSerial(v string) {
+	m.Serial = &v
+}
+
+// Sensors is sensor readings for the surgical kit
+type Sensors struct {
+	// timestamp formatted yyyy-mm-dd hh:mm:ss
+	Begin *string `json:"begin,omitempty"`
+	// the current tilt that the kit is experiencing
+	Currtilt *float64 `json:"currtilt,omitempty"`
+	// timestamp formatted yyyy-mm-dd hh:mm:ss
+	End         *string `json:"end,omitempty"`
+	Endlocation *Geo    `json:"endlocation,omitempty"`
+	// the highest (in Gs) force that the kit experienced during the sample, readings in m/s2 are sent as {"value": 19.6, "unit": "m/s2"}
+	Maxgforce *float64 `json:"maxgforce,omitempty"`
+	// the highest (in degrees from horizontal) tilt that the kit experienced during the sample
+	Maxtilt       *float64 `json:"maxtilt,omitempty"`
+	Startlocation *Geo     `json:"startlocation,omitempty"`
+}
+
+// GetBegin returns begin and whether it is present
+func (m *Sensors) GetBegin() (string, bool) {
+	if m == nil || m.Begin == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Begin, true
+}
+
+// SetBegin sets begin
+func (m *Sensors) SetBegin(v string) {
+	m.Begin = &v
+}
+
+// GetCurrtilt returns currtilt and whether it is present
+func (m *Sensors) GetCurrtilt() (float64, bool) {
+	if m == nil || m.Currtilt == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Currtilt, true
+}
+
+// SetCurrtilt sets currtilt
+func (m *Sensors) SetCurrtilt(v float64) {
+	m.Currtilt = &v
+}
+
+// GetEnd returns end and whether it is present
+func (m *Sensors) GetEnd() (string, bool) {
+	if m == nil || m.End == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.End, true
+}
+
+// SetEnd sets end
+func (m *Sensors) SetEnd(v string) {
+	m.End = &v
+}
+
+// GetEndlocation returns endlocation, nil when it is not present
+func (m *Sensors) GetEndlocation() *Geo {
+	if m == nil {
+		return nil
+	}
+	return m.Endlocation
+}
+
+// GetMaxgforce returns maxgforce and whether it is present
+func (m *Sensors) GetMaxgforce() (float64, bool) {
+	if m == nil || m.Maxgforce == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Maxgforce, true
+}
+
+// SetMaxgforce sets maxgforce
+func (m *Sensors) SetMaxgforce(v float64) {
+	m.Maxgforce = &v
+}
+
+// GetMaxtilt returns maxtilt and whether it is present
+func (m *Sensors) GetMaxtilt() (float64, bool) {
+	if m == nil || m.Maxtilt == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Maxtilt, true
+}
+
+// SetMaxtilt sets maxtilt
+func (m *Sensors) SetMaxtilt(v float64) {
+	m.Maxtilt = &v
+}
+
+// GetStartlocation returns startlocation, nil when it is not present
+func (m *Sensors) GetStartlocation() *Geo {
+	if m == nil {
+		return nil
+	}
+	return m.Startlocation
+}
+
+// SterilizationCycle is one sterilization cycle and its parameters
+type SterilizationCycle struct {
+	// the sterilizer's identifier for the cycle
+	CycleID *string `json:"cycleID,omitempty"`
+	// the exposure time in minutes
+	Duration *float64 `json:"duration,omitempty"`
+	Method   *string  `json:"method,omitempty"`
+	// whether the cycle's indicators passed
+	Passed *bool `json:"passed,omitempty"`
+	// timestamp of the cycle, the transaction's when absent
+	Performed *string `json:"performed,omitempty"`
+	// the chamber pressure in kPa
+	Pressure *float64 `json:"pressure,omitempty"`
+	// the serial numbers of the instruments in the cycle, all of the kit's instruments when absent
+	Serials []string `json:"serials,omitempty"`
+	// the sterilizer that ran the cycle
+	Sterilizer *string `json:"sterilizer,omitempty"`
+	// the exposure temperature in degrees Celsius
+	Temperature *float64 `json:"temperature,omitempty"`
+}
+
+// GetCycleID returns cycleID and whether it is present
+func (m *SterilizationCycle) GetCycleID() (string, bool) {
+	if m == nil || m.CycleID == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.CycleID, true
+}
+
+// SetCycleID sets cycleID
+func (m *SterilizationCycle) SetCycleID(v string) {
+	m.CycleID = &v
+}
+
+// GetDuration returns duration and whether it is present
+func (m *SterilizationCycle) GetDuration() (float64, bool) {
+	if m == nil || m.Duration == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Duration, true
+}
+
+// SetDuration sets duration
+func (m *SterilizationCycle) SetDuration(v float64) {
+	m.Duration = &v
+}
+
+// GetMethod returns method and whether it is present
+func (m *SterilizationCycle) GetMethod() (string, bool) {
+	if m == nil || m.Method == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Method, true
+}
+
+// SetMethod sets method
+func (m *SterilizationCycle) SetMethod(v string) {
+	m.Method = &v
+}
+
+// GetPassed returns passed and whether it is present
+func (m *SterilizationCycle) GetPassed() (bool, bool) {
+	if m == nil || m.Passed == nil {
+		var zero bool
+		return zero, false
+	}
+	return *m.Passed, true
+}
+
+// SetPassed sets passed
+func (m *SterilizationCycle) SetPassed(v bool) {
+	m.Passed = &v
+}
+
+// GetPerformed returns performed and whether it is present
+func (m *SterilizationCycle) GetPerformed() (string, bool) {
+	if m == nil || m.Performed == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Performed, true
+}
+
+// SetPerformed sets performed
+func (m *SterilizationCycle) SetPerformed(v string) {
+	m.Performed = &v
+}
+
+// GetPressure returns pressure and whether it is present
+func (m *SterilizationCycle) GetPressure() (float64, bool) {
+	if m == nil || m.Pressure == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Pressure, true
+}
+
+// SetPressure sets pressure
+func (m *SterilizationCycle) SetPressure(v float64) {
+	m.Pressure = &v
+}
+
+// GetSerials returns serials
+func (m *SterilizationCycle) GetSerials() []string {
+	if m == nil {
+		return nil
+	}
+	return m.Serials
+}
+
+// GetSterilizer returns sterilizer and whether it is present
+func (m *SterilizationCycle) GetSterilizer() (string, bool) {
+	if m == nil || m.Sterilizer == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Sterilizer, true
+}
+
+// SetSterilizer sets sterilizer
+func (m *SterilizationCycle) SetSterilizer(v string) {
+	m.Sterilizer = &v
+}
+
+// GetTemperature returns temperature and whether it is present
+func (m *SterilizationCycle) GetTemperature() (float64, bool) {
+	if m == nil || m.Temperature == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Temperature, true
+}
+
+// SetTemperature sets temperature
+func (m *SterilizationCycle) SetTemperature(v float64) {
+	m.Temperature = &v
+}
+
+// Transit is shipping data during transit periods
+type Transit struct {
+	// timestamp formatted yyyy-mm-dd hh:mm:ss
+	Begintransit *string `json:"begintransit,omitempty"`
+	Carrier      *string `json:"carrier,omitempty"`
+	// timestamp by which the receiver must confirm the shipment, begintransit plus the shipment window
+	Deadline *string `json:"deadline,omitempty"`
+	// timestamp formatted yyyy-mm-dd hh:mm:ss
+	Endtransit *string `json:"endtransit,omitempty"`
+	// true from the shipment until the receiver confirms it
+	Intransit     *bool   `json:"intransit,omitempty"`
+	Receiver      *Status `json:"receiver,omitempty"`
+	Receiverparty *string `json:"receiverparty,omitempty"`
+	Shipper       *Status `json:"shipper,omitempty"`
+	Shipperparty  *string `json:"shipperparty,omitempty"`
+}
+
+// GetBegintransit returns begintransit and whether it is present
+func (m *Transit) GetBegintransit() (string, bool) {
+	if m == nil || m.Begintransit == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Begintransit, true
+}
+
+// SetBegintransit sets begintransit
+func (m *Transit) SetBegintransit(v string) {
+	m.Begintransit = &v
+}
+
+// GetCarrier returns carrier and whether it is present
+func (m *Transit) GetCarrier() (string, bool) {
+	if m == nil || m.Carrier == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Carrier, true
+}
+
+// SetCarrier sets carrier
+func (m *Transit) SetCarrier(v string) {
+	m.Carrier = &v
+}
+
+// GetDeadline returns deadline and whether it is present
+func (m *Transit) GetDeadline() (string, bool) {
+	if m == nil || m.Deadline == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Deadline, true
+}
+
+// SetDeadline sets deadline
+func (m *Transit) SetDeadline(v string) {
+	m.Deadline = &v
+}
+
+// GetEndtransit returns endtransit and whether it is present
+func (m *Transit) GetEndtransit() (string, bool) {
+	if m == nil || m.Endtransit == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Endtransit, true
+}
+
+// SetEndtransit sets endtransit
+func (m *Transit) SetEndtransit(v string) {
+	m.Endtransit = &v
+}
+
+// GetIntransit returns intransit and whether it is present
+func (m *Transit) GetIntransit() (bool, bool) {
+	if m == nil || m.Intransit == nil {
+		var zero bool
+		return zero, false
+	}
+	return *m.Intransit, true
+}
+
+// SetIntransit sets intransit
+func (m *Transit) SetIntransit(v bool) {
+	m.Intransit = &v
+}
+
+// GetReceiver returns receiver and whether it is present
+func (m *Transit) GetReceiver() (Status, bool) {
+	if m == nil || m.Receiver == nil {
+		var zero Status
+		return zero, false
+	}
+	return *m.Receiver, true
+}
+
+// SetReceiver sets receiver
+func (m *Transit) SetReceiver(v Status) {
+	m.Receiver = &v
+}
+
+// GetReceiverparty returns receiverparty and whether it is present
+func (m *Transit) GetReceiverparty() (string, bool) {
+	if m == nil || m.Receiverparty == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Receiverparty, true
+}
+
+// SetReceiverparty sets receiverparty
+func (m *Transit) SetReceiverparty(v string) {
+	m.Receiverparty = &v
+}
+
+// GetShipper returns shipper and whether it is present
+func (m *Transit) GetShipper() (Status, bool) {
+	if m == nil || m.Shipper == nil {
+		var zero Status
+		return zero, false
+	}
+	return *m.Shipper, true
+}
+
+// SetShipper sets shipper
+func (m *Transit) SetShipper(v Status) {
+	m.Shipper = &v
+}
+
+// GetShipperparty returns shipperparty and whether it is present
+func (m *Transit) GetShipperparty() (string, bool) {
+	if m == nil || m.Shipperparty == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Shipperparty, true
+}
+
+// SetShipperparty sets shipperparty
+func (m *Transit) SetShipperparty(v string) {
+	m.Shipperparty = &v
+}
+
+// ReplaceAssetSurgicalKitArg is generated from the schema
+type ReplaceAssetSurgicalKitArg struct {
+	Surgicalkit *Surgicalkit `json:"surgicalkit,omitempty"`
+}
+
+// GetSurgicalkit returns surgicalkit, nil when it is not present
+func (m *ReplaceAssetSurgicalKitArg) GetSurgicalkit() *Surgicalkit {
+	if m == nil {
+		return nil
+	}
+	return m.Surgicalkit
+}
+
+// UpdateAssetSurgicalKitArg is generated from the schema
+type UpdateAssetSurgicalKitArg struct {
+	Surgicalkit *Surgicalkit `json:"surgicalkit,omitempty"`
+}
+
+// GetSurgicalkit returns surgicalkit, nil when it is not present
+func (m *UpdateAssetSurgicalKitArg) GetSurgicalkit() *Surgicalkit {
+	if m == nil {
+		return nil
+	}
+	return m.Surgicalkit
+}
+
+// DeleteAssetSurgicalKitArg is generated from the schema
+type DeleteAssetSurgicalKitArg struct {
+	Surgicalkit *DeleteAssetSurgicalKitArgSurgicalkit `json:"surgicalkit,omitempty"`
+}
+
+// GetSurgicalkit returns surgicalkit, nil when it is not present
+func (m *DeleteAssetSurgicalKitArg) GetSurgicalkit() *DeleteAssetSurgicalKitArgSurgicalkit {
+	if m == nil {
+		return nil
+	}
+	return m.Surgicalkit
+}
+
+// DeleteAssetSurgicalKitArgSurgicalkit is generated from the schema
+type DeleteAssetSurgicalKitArgSurgicalkit struct {
+	SkitID *string `json:"skitID,omitempty"`
+}
+
+// GetSkitID returns skitID and whether it is present
+func (m *DeleteAssetSurgicalKitArgSurgicalkit) GetSkitID() (string, bool) {
+	if m == nil || m.SkitID == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.SkitID, true
+}
+
+// SetSkitID sets skitID
+func (m *DeleteAssetSurgicalKitArgSurgicalkit) SetSkitID(v string) {
+	m.SkitID = &v
+}
+
+// DeleteAssetStateHistorySurgicalKitArg is generated from the schema
+type DeleteAssetStateHistorySurgicalKitArg struct {
+	Surgicalkit *DeleteAssetStateHistorySurgicalKitArgSurgicalkit `json:"surgicalkit,omitempty"`
+}
+
+// GetSurgicalkit returns surgicalkit, nil when it is not present
+func (m *DeleteAssetStateHistorySurgicalKitArg) GetSurgicalkit() *DeleteAssetStateHistorySurgicalKitArgSurgicalkit {
+	if m == nil {
+		return nil
+	}
+	return m.Surgicalkit
+}
+
+// DeleteAssetStateHistorySurgicalKitArgSurgicalkit is generated from the schema
+type DeleteAssetStateHistorySurgicalKitArgSurgicalkit struct {
+	SkitID *string `json:"skitID,omitempty"`
+}
+
+// GetSkitID returns skitID and whether it is present
+func (m *DeleteAssetStateHistorySurgicalKitArgSurgicalkit) GetSkitID() (string, bool) {
+	if m == nil || m.SkitID == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.SkitID, true
+}
+
+// SetSkitID sets skitID
+func (m *DeleteAssetStateHistorySurgicalKitArgSurgicalkit) SetSkitID(v string) {
+	m.SkitID = &v
+}
+
+// DeletePropertiesFromAssetSurgicalKitArg is generated from the schema
+type DeletePropertiesFromAssetSurgicalKitArg struct {
+	// qualified property names, e.g. surgicalkit.skitID
+	Qprops      []string                                            `json:"qprops,omitempty"`
+	Surgicalkit *DeletePropertiesFromAssetSurgicalKitArgSurgicalkit `json:"surgicalkit,omitempty"`
+}
+
+// GetQprops returns qprops
+func (m *DeletePropertiesFromAssetSurgicalKitArg) GetQprops() []string {
+	if m == nil {
+		return nil
+	}
+	return m.Qprops
+}
+
+// GetSurgicalkit returns surgicalkit, nil when it is not present
+func (m *DeletePropertiesFromAssetSurgicalKitArg) GetSurgicalkit() *DeletePropertiesFromAssetSurgicalKitArgSurgicalkit {
+	if m == nil {
+		return nil
+	}
+	return m.Surgicalkit
+}
+
+// DeletePropertiesFromAssetSurgicalKitArgSurgicalkit is generated from the schema
+type DeletePropertiesFromAssetSurgicalKitArgSurgicalkit struct {
+	SkitID *string `json:"skitID,omitempty"`
+}
+
+// GetSkitID returns skitID and whether it is present
+func (m *DeletePropertiesFromAssetSurgicalKitArgSurgicalkit) GetSkitID() (string, bool) {
+	if m == nil || m.SkitID == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.SkitID, true
+}
+
+// SetSkitID sets skitID
+func (m *DeletePropertiesFromAssetSurgicalKitArgSurgicalkit) SetSkitID(v string) {
+	m.SkitID = &v
+}
+
+// DeleteAllAssetsSurgicalKitArg is generated from the schema
+type DeleteAllAssetsSurgicalKitArg struct {
+	Filter *StateFilter `json:"filter,omitempty"`
+}
+
+// GetFilter returns filter, nil when it is not present
+func (m *DeleteAllAssetsSurgicalKitArg) GetFilter() *StateFilter {
+	if m == nil {
+		return nil
+	}
+	return m.Filter
+}
+
+// StateFilter is filter asset states
+type StateFilter struct {
+	// defines how to match properties, missing property always fails match
+	Match *string `json:"match,omitempty"`
+	// qualified property names and values match
+	Select []StateFilterSelect `json:"select,omitempty"`
+}
+
+// GetMatch returns match and whether it is present
+func (m *StateFilter) GetMatch() (string, bool) {
+	if m == nil || m.Match == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Match, true
+}
+
+// SetMatch sets match
+func (m *StateFilter) SetMatch(v string) {
+	m.Match = &v
+}
+
+// GetSelect returns select
+func (m *StateFilter) GetSelect() []StateFilterSelect {
+	if m == nil {
+		return nil
+	}
+	return m.Select
+}
+
+// StateFilterSelect is generated from the schema
+type StateFilterSelect struct {
+	// qualified property to compare, for example 'asset.assetID'
+	Qprop *string `json:"qprop,omitempty"`
+	// value to be compared
+	Value *string `json:"value,omitempty"`
+}
+
+// GetQprop returns qprop and whether it is present
+func (m *StateFilterSelect) GetQprop() (string, bool) {
+	if m == nil || m.Qprop == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Qprop, true
+}
+
+// SetQprop sets qprop
+func (m *StateFilterSelect) SetQprop(v string) {
+	m.Qprop = &v
+}
+
+// GetValue returns value and whether it is present
+func (m *StateFilterSelect) GetValue() (string, bool) {
+	if m == nil || m.Value == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Value, true
+}
+
+// SetValue sets value
+func (m *StateFilterSelect) SetValue(v string) {
+	m.Value = &v
+}
+
+// ReadAssetSurgicalKitArg is generated from the schema
+type ReadAssetSurgicalKitArg struct {
+	Surgicalkit *ReadAssetSurgicalKitArgSurgicalkit `json:"surgicalkit,omitempty"`
+}
+
+// GetSurgicalkit returns surgicalkit, nil when it is not present
+func (m *ReadAssetSurgicalKitArg) GetSurgicalkit() *ReadAssetSurgicalKitArgSurgicalkit {
+	if m == nil {
+		return nil
+	}
+	return m.Surgicalkit
+}
+
+// ReadAssetSurgicalKitArgSurgicalkit is generated from the schema
+type ReadAssetSurgicalKitArgSurgicalkit struct {
+	SkitID *string `json:"skitID,omitempty"`
+}
+
+// GetSkitID returns skitID and whether it is present
+func (m *ReadAssetSurgicalKitArgSurgicalkit) GetSkitID() (string, bool) {
+	if m == nil || m.SkitID == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.SkitID, true
+}
+
+// SetSkitID sets skitID
+func (m *ReadAssetSurgicalKitArgSurgicalkit) SetSkitID(v string) {
+	m.SkitID = &v
+}
+
+// ReadAllAssetsSurgicalKitArg is generated from the schema
+type ReadAllAssetsSurgicalKitArg struct {
+	Filter *StateFilter `json:"filter,omitempty"`
+}
+
+// GetFilter returns filter, nil when it is not present
+func (m *ReadAllAssetsSurgicalKitArg) GetFilter() *StateFilter {
+	if m == nil {
+		return nil
+	}
+	return m.Filter
+}
+
+// DeleteWorldStateArg is generated from the schema
+type DeleteWorldStateArg struct {
+	Confirm *string `json:"confirm,omitempty"`
+	// reinitialize the contract state with the current version and nickname
+	Reinit *bool `json:"reinit,omitempty"`
+}
+
+// GetConfirm returns confirm and whether it is present
+func (m *DeleteWorldStateArg) GetConfirm() (string, bool) {
+	if m == nil || m.Confirm == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Confirm, true
+}
+
+// SetConfirm sets confirm
+func (m *DeleteWorldStateArg) SetConfirm(v string) {
+	m.Confirm = &v
+}
+
+// GetReinit returns reinit and whether it is present
+func (m *DeleteWorldStateArg) GetReinit() (bool, bool) {
+	if m == nil || m.Reinit == nil {
+		var zero bool
+		return zero, false
+	}
+	return *m.Reinit, true
+}
+
+// SetReinit sets reinit
+func (m *DeleteWorldStateArg) SetReinit(v bool) {
+	m.Reinit = &v
+}
+
+// ReadAssetStateHistorySurgicalKitArg is generated from the schema
+type ReadAssetStateHistorySurgicalKitArg struct {
+	Daterange   *DateRange                                      `json:"daterange,omitempty"`
+	Filter      *StateFilter                                    `json:"filter,omitempty"`
+	Surgicalkit *ReadAssetStateHistorySurgicalKitArgSurgicalkit `json:"surgicalkit,omitempty"`
+}
+
+// GetDaterange returns daterange, nil when it is not present
+func (m *ReadAssetStateHistorySurgicalKitArg) GetDaterange() *DateRange {
+	if m == nil {
+		return nil
+	}
+	return m.Daterange
+}
+
+// GetFilter returns filter, nil when it is not present
+func (m *ReadAssetStateHistorySurgicalKitArg) GetFilter() *StateFilter {
+	if m == nil {
+		return nil
+	}
+	return m.Filter
+}
+
+// GetSurgicalkit returns surgicalkit, nil when it is not present
+func (m *ReadAssetStateHistorySurgicalKitArg) GetSurgicalkit() *ReadAssetStateHistorySurgicalKitArgSurgicalkit {
+	if m == nil {
+		return nil
+	}
+	return m.Surgicalkit
+}
+
+// DateRange is if specified, dates must fall in between these values, inclusive
+type DateRange struct {
+	// timestamp formatted yyyy-mm-dd hh:mm:ss
+	Begin *string `json:"begin,omitempty"`
+	// timestamp formatted yyyy-mm-dd hh:mm:ss
+	End *string `json:"end,omitempty"`
+}
+
+// GetBegin returns begin and whether it is present
+func (m *DateRange) GetBegin() (string, bool) {
+	if m == nil || m.Begin == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Begin, true
+}
+
+// SetBegin sets begin
+func (m *DateRange) SetBegin(v string) {
+	m.Begin = &v
+}
+
+// GetEnd returns end and whether it is present
+func (m *DateRange) GetEnd() (string, bool) {
+	if m == nil || m.End == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.End, true
+}
+
+// SetEnd sets end
+func (m *DateRange) SetEnd(v string) {
+	m.End = &v
+}
+
+// ReadAssetStateHistorySurgicalKitArgSurgicalkit is generated from the schema
+type ReadAssetStateHistorySurgicalKitArgSurgicalkit struct {
+	SkitID *string `json:"skitID,omitempty"`
+}
+
+// GetSkitID returns skitID and whether it is present
+func (m *ReadAssetStateHistorySurgicalKitArgSurgicalkit) GetSkitID() (string, bool) {
+	if m == nil || m.SkitID == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.SkitID, true
+}
+
+// SetSkitID sets skitID
+func (m *ReadAssetStateHistorySurgicalKitArgSurgicalkit) SetSkitID(v string) {
+	m.SkitID = &v
+}
+
+// ShipSurgicalKitArg is generated from the schema
+type ShipSurgicalKitArg struct {
+	Shipment    *Shipment                      `json:"shipment,omitempty"`
+	Surgicalkit *ShipSurgicalKitArgSurgicalkit `json:"surgicalkit,omitempty"`
+}
+
+// GetShipment returns shipment, nil when it is not present
+func (m *ShipSurgicalKitArg) GetShipment() *Shipment {
+	if m == nil {
+		return nil
+	}
+	return m.Shipment
+}
+
+// GetSurgicalkit returns surgicalkit, nil when it is not present
+func (m *ShipSurgicalKitArg) GetSurgicalkit() *ShipSurgicalKitArgSurgicalkit {
+	if m == nil {
+		return nil
+	}
+	return m.Surgicalkit
+}
+
+// Shipment is a sender's request to hand a kit to the next custodian
+type Shipment struct {
+	Carrier *string `json:"carrier,omitempty"`
+	// the sending party, which must be the kit's holder when it has one and the party attribute of the caller's certificate when it has one, defaults to either
+	Fromparty *string `json:"fromparty,omitempty"`
+	// the next custodian in the progression oem, warehouse, dealer, retailer, hospital, which is the default
+	To *Status `json:"to,omitempty"`
+	// the party that must confirm the receipt
+	Toparty *string `json:"toparty,omitempty"`
+}
+
+// GetCarrier returns carrier and whether it is present
+func (m *Shipment) GetCarrier() (string, bool) {
+	if m == nil || m.Carrier == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Carrier, true
+}
+
+// SetCarrier sets carrier
+func (m *Shipment) SetCarrier(v string) {
+	m.Carrier = &v
+}
+
+// GetFromparty returns fromparty and whether it is present
+func (m *Shipment) GetFromparty() (string, bool) {
+	if m == nil || m.Fromparty == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Fromparty, true
+}
+
+// SetFromparty sets fromparty
+func (m *Shipment) SetFromparty(v string) {
+	m.Fromparty = &v
+}
+
+// GetTo returns to and whether it is present
+func (m *Shipment) GetTo() (Status, bool) {
+	if m == nil || m.To == nil {
+		var zero Status
+		return zero, false
+	}
+	return *m.To, true
+}
+
+// SetTo sets to
+func (m *Shipment) SetTo(v Status) {
+	m.To = &v
+}
+
+// GetToparty returns toparty and whether it is present
+func (m *Shipment) GetToparty() (string, bool) {
+	if m == nil || m.Toparty == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Toparty, true
+}
+
+// SetToparty sets toparty
+func (m *Shipment) SetToparty(v string) {
+	m.Toparty = &v
+}
+
+// ShipSurgicalKitArgSurgicalkit is generated from the schema
+type ShipSurgicalKitArgSurgicalkit struct {
+	SkitID *string `json:"skitID,omitempty"`
+}
+
+// GetSkitID returns skitID and whether it is present
+func (m *ShipSurgicalKitArgSurgicalkit) GetSkitID() (string, bool) {
+	if m == nil || m.SkitID == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.SkitID, true
+}
+
+// SetSkitID sets skitID
+func (m *ShipSurgicalKitArgSurgicalkit) SetSkitID(v string) {
+	m.SkitID = &v
+}
+
+// ReceiveSurgicalKitArg is generated from the schema
+type ReceiveSurgicalKitArg struct {
+	Receipt     *Receipt                          `json:"receipt,omitempty"`
+	Surgicalkit *ReceiveSurgicalKitArgSurgicalkit `json:"surgicalkit,omitempty"`
+}
+
+// GetReceipt returns receipt, nil when it is not present
+func (m *ReceiveSurgicalKitArg) GetReceipt() *Receipt {
+	if m == nil {
+		return nil
+	}
+	return m.Receipt
+}
+
+// GetSurgicalkit returns surgicalkit, nil when it is not present
+func (m *ReceiveSurgicalKitArg) GetSurgicalkit() *ReceiveSurgicalKitArgSurgicalkit {
+	if m == nil {
+		return nil
+	}
+	return m.Surgicalkit
+}
+
+// Receipt is a receiver's confirmation that a shipped kit has arrived
+type Receipt struct {
+	// the receiving party, which must be the shipment's toparty and the party attribute of the caller's certificate when it has one, defaults to the latter
+	Party *string `json:"party,omitempty"`
+}
+
+// GetParty returns party and whether it is present
+func (m *Receipt) GetParty() (string, bool) {
+	if m == nil || m.Party == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Party, true
+}
+
+// SetParty sets party
+func (m *Receipt) SetParty(v string) {
+	m.Party = &v
+}
+
+// ReceiveSurgicalKitArgSurgicalkit is generated from the schema
+type ReceiveSurgicalKitArgSurgicalkit struct {
+	SkitID *string `json:"skitID,omitempty"`
+}
+
+// GetSkitID returns skitID and whether it is present
+func (m *ReceiveSurgicalKitArgSurgicalkit) GetSkitID() (string, bool) {
+	if m == nil || m.SkitID == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.SkitID, true
+}
+
+// SetSkitID sets skitID
+func (m *ReceiveSurgicalKitArgSurgicalkit) SetSkitID(v string) {
+	m.SkitID = &v
+}
+
+// SetShipmentWindowArg is generated from the schema
+type SetShipmentWindowArg struct {
+	// hours from the shipment to its deadline, 72 when never set
+	Hours *float64 `json:"hours,omitempty"`
+}
+
+// GetHours returns hours and whether it is present
+func (m *SetShipmentWindowArg) GetHours() (float64, bool) {
+	if m == nil || m.Hours == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Hours, true
+}
+
+// SetHours sets hours
+func (m *SetShipmentWindowArg) SetHours(v float64) {
+	m.Hours = &v
+}
+
+// SterilizeSurgicalKitArg is generated from the schema
+type SterilizeSurgicalKitArg struct {
+	Sterilization *SterilizationCycle                 `json:"sterilization,omitempty"`
+	Surgicalkit   *SterilizeSurgicalKitArgSurgicalkit `json:"surgicalkit,omitempty"`
+}
+
+// GetSterilization returns sterilization, nil when it is not present
+func (m *SterilizeSurgicalKitArg) GetSterilization() *SterilizationCycle {
+	if m == nil {
+		return nil
+	}
+	return m.Sterilization
+}
+
+// GetSurgicalkit returns surgicalkit, nil when it is not present
+func (m *SterilizeSurgicalKitArg) GetSurgicalkit() *SterilizeSurgicalKitArgSurgicalkit {
+	if m == nil {
+		return nil
+	}
+	return m.Surgicalkit
+}
+
+// SterilizeSurgicalKitArgSurgicalkit is generated from the schema
+type SterilizeSurgicalKitArgSurgicalkit struct {
+	SkitID *string `json:"skitID,omitempty"`
+}
+
+// GetSkitID returns skitID and whether it is present
+func (m *SterilizeSurgicalKitArgSurgicalkit) GetSkitID() (string, bool) {
+	if m == nil || m.SkitID == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.SkitID, true
+}
+
+// SetSkitID sets skitID
+func (m *SterilizeSurgicalKitArgSurgicalkit) SetSkitID(v string) {
+	m.SkitID = &v
+}
+
+// RecallSurgicalKitsArg is generated from the schema
+type RecallSurgicalKitsArg struct {
+	// the manufacturing lot of the recalled instruments
+	Lot *string `json:"lot,omitempty"`
+}
+
+// GetLot returns lot and whether it is present
+func (m *RecallSurgicalKitsArg) GetLot() (string, bool) {
+	if m == nil || m.Lot == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Lot, true
+}
+
+// SetLot sets lot
+func (m *RecallSurgicalKitsArg) SetLot(v string) {
+	m.Lot = &v
+}
+
+// InspectSurgicalKitArg is generated from the schema
+type InspectSurgicalKitArg struct {
+	Inspection  *Inspection                       `json:"inspection,omitempty"`
+	Surgicalkit *InspectSurgicalKitArgSurgicalkit `json:"surgicalkit,omitempty"`
+}
+
+// GetInspection returns inspection, nil when it is not present
+func (m *InspectSurgicalKitArg) GetInspection() *Inspection {
+	if m == nil {
+		return nil
+	}
+	return m.Inspection
+}
+
+// GetSurgicalkit returns surgicalkit, nil when it is not present
+func (m *InspectSurgicalKitArg) GetSurgicalkit() *InspectSurgicalKitArgSurgicalkit {
+	if m == nil {
+		return nil
+	}
+	return m.Surgicalkit
+}
+
+// Inspection is an inspection of a kit for damage from shocks and tilts
+type Inspection struct {
+	Inspector *string `json:"inspector,omitempty"`
+	Notes     *string `json:"notes,omitempty"`
+	// whether the kit passed, a passed inspection clears the excess force and tilt alerts
+	Passed *bool `json:"passed,omitempty"`
+}
+
+// GetInspector returns inspector and whether it is present
+func (m *Inspection) GetInspector() (string, bool) {
+	if m == nil || m.Inspector == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Inspector, true
+}
+
+// SetInspector sets inspector
+func (m *Inspection) SetInspector(v string) {
+	m.Inspector = &v
+}
+
+// GetNotes returns notes and whether it is present
+func (m *Inspection) GetNotes() (string, bool) {
+	if m == nil || m.Notes == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Notes, true
+}
+
+// SetNotes sets notes
+func (m *Inspection) SetNotes(v string) {
+	m.Notes = &v
+}
+
+// GetPassed returns passed and whether it is present
+func (m *Inspection) GetPassed() (bool, bool) {
+	if m == nil || m.Passed == nil {
+		var zero bool
+		return zero, false
+	}
+	return *m.Passed, true
+}
+
+// SetPassed sets passed
+func (m *Inspection) SetPassed(v bool) {
+	m.Passed = &v
+}
+
+// InspectSurgicalKitArgSurgicalkit is generated from the schema
+type InspectSurgicalKitArgSurgicalkit struct {
+	SkitID *string `json:"skitID,omitempty"`
+}
+
+// GetSkitID returns skitID and whether it is present
+func (m *InspectSurgicalKitArgSurgicalkit) GetSkitID() (string, bool) {
+	if m == nil || m.SkitID == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.SkitID, true
+}
+
+// SetSkitID sets skitID
+func (m *InspectSurgicalKitArgSurgicalkit) SetSkitID(v string) {
+	m.SkitID = &v
+}
+
+// ReadDamageTimelineSurgicalKitArg is generated from the schema
+type ReadDamageTimelineSurgicalKitArg struct {
+	Surgicalkit *ReadDamageTimelineSurgicalKitArgSurgicalkit `json:"surgicalkit,omitempty"`
+}
+
+// GetSurgicalkit returns surgicalkit, nil when it is not present
+func (m *ReadDamageTimelineSurgicalKitArg) GetSurgicalkit() *ReadDamageTimelineSurgicalKitArgSurgicalkit {
+	if m == nil {
+		return nil
+	}
+	return m.Surgicalkit
+}
+
+// ReadDamageTimelineSurgicalKitArgSurgicalkit is generated from the schema
+type ReadDamageTimelineSurgicalKitArgSurgicalkit struct {
+	SkitID *string `json:"skitID,omitempty"`
+}
+
+// GetSkitID returns skitID and whether it is present
+func (m *ReadDamageTimelineSurgicalKitArgSurgicalkit) GetSkitID() (string, bool) {
+	if m == nil || m.SkitID == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.SkitID, true
+}
+
+// SetSkitID sets skitID
+func (m *ReadDamageTimelineSurgicalKitArgSurgicalkit) SetSkitID(v string) {
+	m.SkitID = &v
+}
+
+// ReadRecentStatesArg is generated from the schema
+type ReadRecentStatesArg struct {
+	// zero based beginning of range
+	Begin *int `json:"begin,omitempty"`
+	// asset class name, absence means all classes
+	Class *string `json:"class,omitempty"`
+	// zero based end of range, absence means to end
+	End *int `json:"end,omitempty"`
+}
+
+// GetBegin returns begin and whether it is present
+func (m *ReadRecentStatesArg) GetBegin() (int, bool) {
+	if m == nil || m.Begin == nil {
+		var zero int
+		return zero, false
+	}
+	return *m.Begin, true
+}
+
+// SetBegin sets begin
+func (m *ReadRecentStatesArg) SetBegin(v int) {
+	m.Begin = &v
+}
+
+// GetClass returns class and whether it is present
+func (m *ReadRecentStatesArg) GetClass() (string, bool) {
+	if m == nil || m.Class == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Class, true
+}
+
+// SetClass sets class
+func (m *ReadRecentStatesArg) SetClass(v string) {
+	m.Class = &v
+}
+
+// GetEnd returns end and whether it is present
+func (m *ReadRecentStatesArg) GetEnd() (int, bool) {
+	if m == nil || m.End == nil {
+		var zero int
+		return zero, false
+	}
+	return *m.End, true
+}
+
+// SetEnd sets end
+func (m *ReadRecentStatesArg) SetEnd(v int) {
+	m.End = &v
+}
+
+// SetLoggingLevelArg is generated from the schema
+type SetLoggingLevelArg struct {
+	LogLevel *string `json:"logLevel,omitempty"`
+	// optional module, the platform file that logs without the ct prefix
+	Module *string `json:"module,omitempty"`
+}
+
+// GetLogLevel returns logLevel and whether it is present
+func (m *SetLoggingLevelArg) GetLogLevel() (string, bool) {
+	if m == nil || m.LogLevel == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.LogLevel, true
+}
+
+// SetLogLevel sets logLevel
+func (m *SetLoggingLevelArg) SetLogLevel(v string) {
+	m.LogLevel = &v
+}
+
+// GetModule returns module and whether it is present
+func (m *SetLoggingLevelArg) GetModule() (string, bool) {
+	if m == nil || m.Module == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Module, true
+}
+
+// SetModule sets module
+func (m *SetLoggingLevelArg) SetModule(v string) {
+	m.Module = &v
+}
+
+// SetCreateOnFirstUpdateArg is generated from the schema
+type SetCreateOnFirstUpdateArg struct {
+	// allows updates to create missing assets on first event
+	SetCreateOnFirstUpdate *bool `json:"setCreateOnFirstUpdate,omitempty"`
+}
+
+// GetSetCreateOnFirstUpdate returns setCreateOnFirstUpdate and whether it is present
+func (m *SetCreateOnFirstUpdateArg) GetSetCreateOnFirstUpdate() (bool, bool) {
+	if m == nil || m.SetCreateOnFirstUpdate == nil {
+		var zero bool
+		return zero, false
+	}
+	return *m.SetCreateOnFirstUpdate, true
+}
+
+// SetSetCreateOnFirstUpdate sets setCreateOnFirstUpdate
+func (m *SetCreateOnFirstUpdateArg) SetSetCreateOnFirstUpdate(v bool) {
+	m.SetCreateOnFirstUpdate = &v
+}
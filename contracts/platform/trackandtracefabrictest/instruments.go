@@ -0,0 +1,368 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+// v0.1 KL -- kit contents, sterilization cycles and instrument recalls
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
+)
+
+// MaxSterilizationRecords is how many of its most recent sterilization cycles a kit keeps,
+// the cycle counts of its instruments include all of them
+const MaxSterilizationRecords int = 20
+
+// recalledLotsSetting names the contract setting that holds the recalled lots
+const recalledLotsSetting = "SurgicalKit.RecalledLots"
+
+// RecalledLots is the stored list of recalled instrument lots
+type RecalledLots struct {
+	Lots []string `json:"lots"`
+}
+
+// SterilizationArg is the argument to sterilizeSurgicalKit
+type SterilizationArg struct {
+	Surgicalkit   Surgicalkit        `json:"surgicalkit"`
+	Sterilization SterilizationCycle `json:"sterilization"`
+}
+
+// RecallArg is the argument to recallSurgicalKits
+type RecallArg struct {
+	Lot string `json:"lot"`
+}
+
+// RecalledKit is a kit that holds a recalled instrument, with its custodian and location
+type RecalledKit struct {
+	SkitID        string `json:"skitID"`
+	Status        Status `json:"status,omitempty"`
+	Holder        string `json:"holder,omitempty"`
+	Intransit     bool   `json:"intransit,omitempty"`
+	Receiverparty string `json:"receiverparty,omitempty"`
+	Location      *Geo   `json:"location,omitempty"`
+}
+
+// RecallOut is reported in the result event of recallSurgicalKits as recall
+type RecallOut struct {
+	Lot  string        `json:"lot"`
+	Kits []RecalledKit `json:"kits"`
+}
+
+// the instruments of a create, update or replace event, which cannot write cycle counts
+func checkInstruments(route string, event map[string]interface{}) error {
+	instruments, _ := iot.GetObject(&event, "surgicalkit.instruments")
+	list, _ := instruments.([]interface{})
+	for _, i := range list {
+		instrument, _ := i.(map[string]interface{})
+		if _, found := instrument["cycles"]; found {
+			err := fmt.Errorf("%s cannot write the cycles of instrument %v, which change only through sterilizeSurgicalKit", route, instrument["serial"])
+			log.Errorf(err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+// the instruments of an update or replace event cannot move an instrument that the kit
+// holds to another lot, which would clear its recall
+func checkInstrumentLots(route string, event map[string]interface{}, state *map[string]interface{}) error {
+	kit, err := SurgicalkitFromState(state)
+	if err != nil {
+		return nil
+	}
+	var lots = make(map[string]string, 0)
+	for _, instrument := range kit.GetInstruments() {
+		serial, _ := instrument.GetSerial()
+		if lot, found := instrument.GetLot(); found {
+			lots[serial] = lot
+		}
+	}
+	instruments, _ := iot.GetObject(&event, "surgicalkit.instruments")
+	list, _ := instruments.([]interface{})
+	for _, i := range list {
+		instrument, _ := i.(map[string]interface{})
+		serial, _ := instrument["serial"].(string)
+		held, found := lots[serial]
+		if lot, ok := instrument["lot"]; found && ok && lot != held {
+			err := fmt.Errorf("%s cannot move instrument %s from lot %s to lot %v", route, serial, held, lot)
+			log.Errorf(err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+// the qualified property names of a deleteProperties event cannot remove the kit's
+// instruments, whose cycle counts change only through sterilizeSurgicalKit and whose lots
+// can be recalled
+func checkDeleteInstruments(route string, qprops []string) error {
+	for _, qprop := range qprops {
+		if deletesProperty(qprop, "surgicalkit.instruments") {
+			err := fmt.Errorf("%s cannot delete surgicalkit.instruments, whose cycles change only through sterilizeSurgicalKit, replace the kit to remove an instrument", route)
+			log.Errorf(err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+// copies the cycle counts of the kit's instruments into the instruments of an update or
+// replace event that have the same serial, returns whether any was copied
+func carryInstrumentCycles(event map[string]interface{}, state *map[string]interface{}) bool {
+	kit, err := SurgicalkitFromState(state)
+	if err != nil {
+		return false
+	}
+	var cycles = make(map[string]int, 0)
+	for _, instrument := range kit.GetInstruments() {
+		serial, _ := instrument.GetSerial()
+		if c, found := instrument.GetCycles(); found {
+			cycles[serial] = c
+		}
+	}
+	var carried bool
+	instruments, _ := iot.GetObject(&event, "surgicalkit.instruments")
+	list, _ := instruments.([]interface{})
+	for _, i := range list {
+		instrument, _ := i.(map[string]interface{})
+		serial, _ := instrument["serial"].(string)
+		if c, found := cycles[serial]; found && instrument != nil {
+			instrument["cycles"] = c
+			carried = true
+		}
+	}
+	if carried {
+		iot.PutObject(&event, "surgicalkit.instruments", list)
+	}
+	return carried
+}
+
+func getRecalledLots(stub shim.ChaincodeStubInterface) (RecalledLots, error) {
+	var recalled RecalledLots
+	_, err := iot.GETContractSetting(stub, recalledLotsSetting, &recalled)
+	return recalled, err
+}
+
+// whether a kit holds an instrument of the lot
+func holdsLot(kit *Surgicalkit, lot string) bool {
+	for _, instrument := range kit.GetInstruments() {
+		if l, found := instrument.GetLot(); found && l == lot {
+			return true
+		}
+	}
+	return false
+}
+
+// sterilizeSurgicalKit records a sterilization cycle of a kit and adds one to the cycle
+// count of each instrument in the cycle
+var sterilizeSurgicalKit iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	var arg SterilizationArg
+	if len(args) != 1 {
+		err := errors.New("sterilizeSurgicalKit expects a JSON object with surgicalkit and sterilization")
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(args[0]), &arg); err != nil {
+		err = fmt.Errorf("sterilizeSurgicalKit failed to unmarshal arg: %s", err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	_, kit, err := getSurgicalKit(stub, "sterilizeSurgicalKit", arg.Surgicalkit)
+	if err != nil {
+		return nil, err
+	}
+	skitID, _ := kit.GetSkitID()
+	var inKit = make(map[string]Instrument, 0)
+	var serials = make([]string, 0)
+	for _, instrument := range kit.GetInstruments() {
+		serial, _ := instrument.GetSerial()
+		inKit[serial] = instrument
+		serials = append(serials, serial)
+	}
+	if len(inKit) == 0 {
+		err = fmt.Errorf("sterilizeSurgicalKit kit %s has no instruments", skitID)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	cycle := arg.Sterilization
+	if len(cycle.Serials) > 0 {
+		serials = cycle.Serials
+	}
+	cycle.Serials = serials
+	if _, found := cycle.GetPerformed(); !found {
+		now, err := iot.GetTxnTimestamp(stub)
+		if err != nil {
+			err = fmt.Errorf("sterilizeSurgicalKit kit %s: %s", skitID, err)
+			log.Errorf(err.Error())
+			return nil, err
+		}
+		cycle.SetPerformed(now.Format(time.RFC3339))
+	}
+
+	var event = Surgicalkit{SkitID: &skitID, Sterilizations: []SterilizationCycle{cycle}}
+	var counted = make(map[string]bool, 0)
+	for _, serial := range serials {
+		if counted[serial] {
+			continue
+		}
+		counted[serial] = true
+		instrument, found := inKit[serial]
+		if !found {
+			err = fmt.Errorf("sterilizeSurgicalKit kit %s has no instrument %s", skitID, serial)
+			log.Errorf(err.Error())
+			return nil, err
+		}
+		cycles, _ := instrument.GetCycles()
+		instrument.SetCycles(cycles + 1)
+		event.Instruments = append(event.Instruments, instrument)
+	}
+	return updateSurgicalKit(stub, "sterilizeSurgicalKit", &event)
+}
+
+// recallSurgicalKits records a recalled lot, so that every kit that holds an instrument of
+// the lot, now or later, raises the recalled alert and is not compliant. The kits that hold
+// one now are reported in the result event with their custodian and location.
+var recallSurgicalKits iot.ChaincodeFunc = func(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	var arg RecallArg
+	if len(args) != 1 {
+		err := errors.New("recallSurgicalKits expects a JSON object with lot")
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(args[0]), &arg); err != nil {
+		err = fmt.Errorf("recallSurgicalKits failed to unmarshal arg: %s", err)
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	if arg.Lot == "" {
+		err := errors.New("recallSurgicalKits needs a lot")
+		log.Errorf(err.Error())
+		return nil, err
+	}
+	recalled, err := getRecalledLots(stub)
+	if err != nil {
+		return nil, err
+	}
+	if !iot.Contains(recalled.Lots, arg.Lot) {
+		recalled.Lots = append(recalled.Lots, arg.Lot)
+		sort.Strings(recalled.Lots)
+		if err := iot.PUTContractSetting(stub, recalledLotsSetting, recalled); err != nil {
+			return nil, err
+		}
+	}
+
+	kits, err := SurgicalKitClass.ReadAllAssetsUnmarshalled(stub, []string{})
+	if err != nil {
+		return nil, err
+	}
+	var out = RecallOut{arg.Lot, make([]RecalledKit, 0)}
+	for _, a := range kits {
+		kit, err := SurgicalkitFromState(a.State)
+		if err != nil {
+			return nil, err
+		}
+		if !holdsLot(kit, arg.Lot) {
+			continue
+		}
+		skitID, _ := kit.GetSkitID()
+		if !iot.Contains(a.AlertsActive, recalledAlert) {
+			if _, err := updateSurgicalKit(stub, "recallSurgicalKits", &Surgicalkit{SkitID: &skitID}); err != nil {
+				return nil, err
+			}
+		}
+		var rk = RecalledKit{SkitID: skitID}
+		rk.Status, _ = kit.GetStatus()
+		rk.Holder, _ = kit.GetHolder()
+		rk.Intransit, _ = kit.GetTransit().GetIntransit()
+		if rk.Intransit {
+			rk.Receiverparty, _ = kit.GetTransit().GetReceiverparty()
+		}
+		rk.Location = kitLocation(kit)
+		out.Kits = append(out.Kits, rk)
+	}
+	return json.Marshal(map[string]interface{}{"recall": out})
+}
+
+var maxCyclesAlert iot.AlertName = "MAXCYCLES"
+var maxCyclesRule iot.RuleFunc = func(stub shim.ChaincodeStubInterface, SurgicalKit *iot.Asset) error {
+	kit, err := SurgicalkitFromState(SurgicalKit.State)
+	if err != nil {
+		return err
+	}
+	for _, instrument := range kit.GetInstruments() {
+		maxcycles, found := instrument.GetMaxcycles()
+		cycles, _ := instrument.GetCycles()
+		if found && maxcycles > 0 && cycles >= maxcycles {
+			iot.RaiseAlert(SurgicalKit, maxCyclesAlert)
+			return nil
+		}
+	}
+	iot.ClearAlert(SurgicalKit, maxCyclesAlert)
+	return nil
+}
+
+var recalledAlert iot.AlertName = "RECALLED"
+var recalledRule iot.RuleFunc = func(stub shim.ChaincodeStubInterface, SurgicalKit *iot.Asset) error {
+	kit, err := SurgicalkitFromState(SurgicalKit.State)
+	if err != nil {
+		return err
+	}
+	if len(kit.GetInstruments()) == 0 {
+		iot.ClearAlert(SurgicalKit, recalledAlert)
+		return nil
+	}
+	recalled, err := getRecalledLots(stub)
+	if err != nil {
+		return err
+	}
+	for _, lot := range recalled.Lots {
+		if holdsLot(kit, lot) {
+			iot.RaiseAlert(SurgicalKit, recalledAlert)
+			return nil
+		}
+	}
+	iot.ClearAlert(SurgicalKit, recalledAlert)
+	return nil
+}
+
+func init() {
+	if err := iot.AddMergeStrategy(SurgicalKitClass, "surgicalkit.instruments", iot.MergeStrategy{Kind: iot.MergeUnionByKey, Key: "serial"}); err != nil {
+		panic(err)
+	}
+	if err := iot.AddMergeStrategy(SurgicalKitClass, "surgicalkit.sterilizations", iot.MergeStrategy{Kind: iot.MergeBoundedAppend, Limit: MaxSterilizationRecords}); err != nil {
+		panic(err)
+	}
+	if err := iot.AddRule("Max Sterilization Cycles Alert", SurgicalKitClass, []iot.AlertName{maxCyclesAlert}, maxCyclesRule); err != nil {
+		panic(err)
+	}
+	if err := iot.AddRule("Recalled Instrument Alert", SurgicalKitClass, []iot.AlertName{recalledAlert}, recalledRule); err != nil {
+		panic(err)
+	}
+
+	if err := iot.AddRoute("sterilizeSurgicalKit", "invoke", SurgicalKitClass, sterilizeSurgicalKit); err != nil {
+		panic(err)
+	}
+	if err := iot.AddRoute("recallSurgicalKits", "invoke", SurgicalKitClass, recallSurgicalKits); err != nil {
+		panic(err)
+	}
+}
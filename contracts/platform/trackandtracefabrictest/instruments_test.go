@@ -0,0 +1,156 @@
+/*
+Copyright (c) 2016 IBM Corporation and other Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and limitations under the License.
+
+Contributors:
+Kim Letkeman - Initial Contribution
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcpevents"
+	"github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform/iotcptest"
+)
+
+func instrumentCycles(t *testing.T, h *iotcptest.Harness, skitID string) map[string]int {
+	kit, err := SurgicalkitFromState(h.Asset(SurgicalKitClass, skitID).State)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cycles = make(map[string]int, 0)
+	for _, instrument := range kit.GetInstruments() {
+		serial, _ := instrument.GetSerial()
+		cycles[serial], _ = instrument.GetCycles()
+	}
+	return cycles
+}
+
+func TestSurgicalKitSterilizationCycles(t *testing.T) {
+	h := newSurgicalKitHarness(t)
+	h.CreateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K7"}}`).ExpectOK()
+	h.Invoke("sterilizeSurgicalKit", `{"surgicalkit":{"skitID":"K7"},"sterilization":{"method":"steam"}}`).ExpectError("has no instruments")
+
+	h.UpdateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K7","instruments":[
+		{"serial":"S1","lot":"L1","description":"scalpel handle","maxcycles":2},
+		{"serial":"S2","lot":"L2","description":"forceps"}]}}`).ExpectOK()
+	h.Invoke("sterilizeSurgicalKit", `{"surgicalkit":{"skitID":"K7"},"sterilization":{"cycleID":"C1","method":"steam","temperature":134,"pressure":304,"duration":4,"passed":true}}`).ExpectOK()
+	h.ExpectNoAlert(SurgicalKitClass, "K7", maxCyclesAlert)
+	if c := instrumentCycles(t, h, "K7"); c["S1"] != 1 || c["S2"] != 1 {
+		t.Fatalf("unexpected cycles %v", c)
+	}
+
+	// cycle counts are the contract's, an update that replaces an instrument keeps its count
+	h.UpdateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K7","instruments":[{"serial":"S1","lot":"L1","cycles":0}]}}`).ExpectError("sterilizeSurgicalKit")
+	h.UpdateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K7","instruments":[{"serial":"S1","lot":"L1","description":"scalpel handle #3","maxcycles":2}]}}`).ExpectOK()
+	h.UpdateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K7","sterilizations":[]}}`).ExpectError("sterilizeSurgicalKit")
+	h.CreateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K7a","instruments":[{"serial":"S1","lot":"L1","cycles":1}]}}`).ExpectError("sterilizeSurgicalKit")
+	h.CreateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K7a","sterilizations":[{"cycleID":"C0"}]}}`).ExpectError("sterilizeSurgicalKit")
+
+	h.Invoke("sterilizeSurgicalKit", `{"surgicalkit":{"skitID":"K7"},"sterilization":{"cycleID":"C2","serials":["S9"]}}`).ExpectError("has no instrument S9")
+	h.Invoke("sterilizeSurgicalKit", `{"surgicalkit":{"skitID":"K7"},"sterilization":{"cycleID":"C2","method":"hydrogenperoxide","serials":["S1"]}}`).ExpectOK()
+	h.ExpectNotification(iotcpevents.AlertRaised, SurgicalKitClass, "K7")
+	h.ExpectAlert(SurgicalKitClass, "K7", maxCyclesAlert).
+		ExpectCompliant(SurgicalKitClass, "K7", false)
+	if c := instrumentCycles(t, h, "K7"); c["S1"] != 2 || c["S2"] != 1 {
+		t.Fatalf("unexpected cycles %v", c)
+	}
+
+	// nor can deleting the instruments or the sterilizations reset the counts
+	for _, qprop := range []string{"surgicalkit.instruments", "surgicalkit.instruments.serial", "surgicalkit.sterilizations"} {
+		h.Invoke("deletePropertiesFromAssetSurgicalKit", `{"surgicalkit":{"skitID":"K7"},"qprops":["`+qprop+`"]}`).ExpectError("sterilizeSurgicalKit")
+	}
+	h.ExpectAlert(SurgicalKitClass, "K7", maxCyclesAlert)
+	if c := instrumentCycles(t, h, "K7"); c["S1"] != 2 || c["S2"] != 1 {
+		t.Fatalf("unexpected cycles %v", c)
+	}
+
+	kit, _ := SurgicalkitFromState(h.Asset(SurgicalKitClass, "K7").State)
+	cycles := kit.GetSterilizations()
+	if len(cycles) != 2 || len(cycles[0].Serials) != 2 || len(cycles[1].Serials) != 1 {
+		t.Fatalf("unexpected sterilizations %+v", cycles)
+	}
+	if performed, found := cycles[1].GetPerformed(); !found || performed == "" {
+		t.Fatal("the cycle has no timestamp")
+	}
+
+	// replacing the worn instrument clears the alert
+	h.Invoke("replaceAssetSurgicalKit", `{"surgicalkit":{"skitID":"K7","instruments":[{"serial":"S2","lot":"L2"},{"serial":"S3","lot":"L1","maxcycles":2}]}}`).ExpectOK()
+	h.ExpectNoAlert(SurgicalKitClass, "K7", maxCyclesAlert)
+	if c := instrumentCycles(t, h, "K7"); len(c) != 2 || c["S2"] != 1 || c["S3"] != 0 {
+		t.Fatalf("unexpected cycles %v", c)
+	}
+	if kit, _ := SurgicalkitFromState(h.Asset(SurgicalKitClass, "K7").State); len(kit.GetSterilizations()) != 2 {
+		t.Fatal("replace dropped the sterilization records")
+	}
+}
+
+func TestSurgicalKitRecall(t *testing.T) {
+	h := newSurgicalKitHarness(t)
+	h.CreateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K8","instruments":[{"serial":"S1","lot":"L1"},{"serial":"S2","lot":"L2"}],
+		"sensors":{"endlocation":{"latitude":45.4215,"longitude":-75.6972}}}}`).ExpectOK()
+	h.CreateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K9","instruments":[{"serial":"S3","lot":"L2"}]}}`).ExpectOK()
+	h.CreateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K10","instruments":[{"serial":"S4","lot":"L3"}]}}`).ExpectOK()
+	ship(h, "K9", `{"fromparty":"Acme Medical","toparty":"Central Depot"}`).ExpectOK()
+
+	h.Invoke("recallSurgicalKits", `{}`).ExpectError("needs a lot")
+	h.Invoke("recallSurgicalKits", `{"lot":"L2"}`).ExpectOK()
+	h.ExpectNotification(iotcpevents.AlertRaised, SurgicalKitClass, "K8")
+	h.ExpectEvent(iot.EVTCCINVRESULT, "recall.lot", "L2")
+	var payload struct {
+		Recall RecallOut `json:"recall"`
+	}
+	if err := json.Unmarshal(h.LastEvent().Payload, &payload); err != nil {
+		t.Fatal(err)
+	}
+	kits := payload.Recall.Kits
+	if len(kits) != 2 {
+		t.Fatalf("unexpected recalled kits %+v", kits)
+	}
+	for _, k := range kits {
+		switch k.SkitID {
+		case "K8":
+			if lat, _ := k.Location.GetLatitude(); lat != 45.4215 || k.Status != "" {
+				t.Fatalf("unexpected recalled kit %+v", k)
+			}
+		case "K9":
+			if k.Status != StatusOem || k.Holder != "" || !k.Intransit || k.Receiverparty != "Central Depot" {
+				t.Fatalf("unexpected recalled kit %+v", k)
+			}
+		default:
+			t.Fatalf("kit %s does not hold lot L2", k.SkitID)
+		}
+	}
+	h.ExpectAlert(SurgicalKitClass, "K8", recalledAlert).
+		ExpectCompliant(SurgicalKitClass, "K8", false).
+		ExpectAlert(SurgicalKitClass, "K9", recalledAlert).
+		ExpectNoAlert(SurgicalKitClass, "K10", recalledAlert).
+		ExpectCompliant(SurgicalKitClass, "K10", true)
+
+	// the lot stays recalled for kits that are given one of its instruments later
+	h.UpdateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K10","instruments":[{"serial":"S5","lot":"L2"}]}}`).ExpectOK()
+	h.ExpectAlert(SurgicalKitClass, "K10", recalledAlert)
+
+	// a recall is not cleared by moving the instrument to another lot or deleting it
+	h.UpdateAsset(SurgicalKitClass, `{"surgicalkit":{"skitID":"K8","instruments":[{"serial":"S2","lot":"L9"}]}}`).ExpectError("cannot move instrument S2")
+	h.Invoke("replaceAssetSurgicalKit", `{"surgicalkit":{"skitID":"K8","instruments":[{"serial":"S1","lot":"L1"},{"serial":"S2","lot":"L9"}]}}`).ExpectError("cannot move instrument S2")
+	h.Invoke("deletePropertiesFromAssetSurgicalKit", `{"surgicalkit":{"skitID":"K8"},"qprops":["surgicalkit.instruments"]}`).ExpectError("surgicalkit.instruments")
+	h.ExpectAlert(SurgicalKitClass, "K8", recalledAlert)
+
+	// only removing the instrument from the kit does
+	h.Invoke("replaceAssetSurgicalKit", `{"surgicalkit":{"skitID":"K8","instruments":[{"serial":"S1","lot":"L1"}]}}`).ExpectOK()
+	h.ExpectNoAlert(SurgicalKitClass, "K8", recalledAlert).
+		ExpectCompliant(SurgicalKitClass, "K8", true)
+}
@@ -0,0 +1,39 @@
+// Code generated by processSchema.go from trackandtrace.json, DO NOT EDIT.
+
+package main
+
+import iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
+
+// the functions that the schema publishes, main calls iot.VerifyRoutes to check that each is routed
+func init() {
+	iot.ExpectRoutes(map[string]string{
+		"initContract":                         "deploy",
+		"createAssetSurgicalKit":               "invoke",
+		"replaceAssetSurgicalKit":              "invoke",
+		"updateAssetSurgicalKit":               "invoke",
+		"deleteAssetSurgicalKit":               "invoke",
+		"deleteAssetStateHistorySurgicalKit":   "invoke",
+		"deletePropertiesFromAssetSurgicalKit": "invoke",
+		"deleteAllAssetsSurgicalKit":           "invoke",
+		"readAssetSurgicalKit":                 "query",
+		"readAllAssetsSurgicalKit":             "query",
+		"readAllRoutes":                        "query",
+		"readAllRules":                         "query",
+		"readWorldState":                       "query",
+		"deleteWorldState":                     "invoke",
+		"readAssetStateHistorySurgicalKit":     "query",
+		"shipSurgicalKit":                      "invoke",
+		"receiveSurgicalKit":                   "invoke",
+		"checkOverdueSurgicalKits":             "invoke",
+		"setShipmentWindow":                    "invoke",
+		"sterilizeSurgicalKit":                 "invoke",
+		"recallSurgicalKits":                   "invoke",
+		"inspectSurgicalKit":                   "invoke",
+		"readDamageTimelineSurgicalKit":        "query",
+		"readRecentStates":                     "query",
+		"setLoggingLevel":                      "invoke",
+		"readAssetSamples":                     "query",
+		"readAssetSchemas":                     "query",
+		"setCreateOnFirstUpdate":               "invoke",
+	})
+}
@@ -14,8 +14,8 @@ var samples = `
             "args": [
                 {
                     "daterange": {
-                        "begin": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                        "end": "timestamp formatted yyyy-mm-dd hh:mm:ss"
+                        "begin": "2016-11-20T00:00:00Z",
+                        "end": "2016-11-20T00:00:00Z"
                     },
                     "filter": {
                         "match": "all",
@@ -25,13 +25,273 @@ var samples = `
                                 "value": "Value to be compared"
                             }
                         ]
+                    },
+                    "surgicalkit": {
+                        "skitID": "A surgicalkit's ID"
                     }
                 }
             ],
             "function": "readAssetStateHistorySurgicalKit",
             "result": [
                 {
-                    "^CON": "INVALID OBJECT - MISSING PROPERTIES"
+                    "^CON": {
+                        "AssetKey": "This surgicalkit's world state surgicalkit ID",
+                        "alerts": [
+                            "An alert name"
+                        ],
+                        "class": {},
+                        "compliant": true,
+                        "eventin": {
+                            "surgicalkit": {
+                                "burst": {
+                                    "burstlength": 123.456,
+                                    "burstnum": 123.456,
+                                    "sequence": 123.456
+                                },
+                                "common": {
+                                    "appdata": [
+                                        {
+                                            "K": "carpe noctem",
+                                            "V": "carpe noctem"
+                                        }
+                                    ],
+                                    "deviceID": "A unique identifier for the device that sent the current event",
+                                    "devicetimestamp": "2016-11-20T00:00:00Z",
+                                    "location": {
+                                        "latitude": 45.4215,
+                                        "longitude": -75.6972
+                                    }
+                                },
+                                "custody": [
+                                    {
+                                        "carrier": "carpe noctem",
+                                        "from": "oem",
+                                        "fromparty": "the organization that holds or handles a kit, e.g. a company name",
+                                        "late": true,
+                                        "received": "2016-11-20T00:00:00Z",
+                                        "shipped": "2016-11-20T00:00:00Z",
+                                        "to": "oem",
+                                        "toparty": "the organization that holds or handles a kit, e.g. a company name"
+                                    }
+                                ],
+                                "damage": [
+                                    {
+                                        "gforce": 123.456,
+                                        "inspector": "the organization that holds or handles a kit, e.g. a company name",
+                                        "kind": "tilt",
+                                        "location": {
+                                            "latitude": 45.4215,
+                                            "longitude": -75.6972
+                                        },
+                                        "notes": "carpe noctem",
+                                        "passed": true,
+                                        "tilt": 123.456,
+                                        "timestamp": "2016-11-20T00:00:00Z"
+                                    }
+                                ],
+                                "distanceFromFenceCenter": 123.456,
+                                "holder": "the organization that holds or handles a kit, e.g. a company name",
+                                "hospital": {
+                                    "address": {
+                                        "city": "carpe noctem",
+                                        "country": "carpe noctem",
+                                        "postcode": "carpe noctem",
+                                        "streetandnumber": "carpe noctem"
+                                    },
+                                    "fence": {
+                                        "center": {
+                                            "latitude": 45.4215,
+                                            "longitude": -75.6972
+                                        },
+                                        "radius": 123.456
+                                    },
+                                    "name": "carpe noctem"
+                                },
+                                "instruments": [
+                                    {
+                                        "cycles": 789,
+                                        "description": "carpe noctem",
+                                        "lot": "the manufacturing lot of the instrument, which recalls name",
+                                        "maxcycles": 789,
+                                        "serial": "the instrument's serial number"
+                                    }
+                                ],
+                                "sensors": {
+                                    "begin": "2016-11-20T00:00:00Z",
+                                    "currtilt": 123.456,
+                                    "end": "2016-11-20T00:00:00Z",
+                                    "endlocation": {
+                                        "latitude": 45.4215,
+                                        "longitude": -75.6972
+                                    },
+                                    "maxgforce": 123.456,
+                                    "maxtilt": 123.456,
+                                    "startlocation": {
+                                        "latitude": 45.4215,
+                                        "longitude": -75.6972
+                                    }
+                                },
+                                "skitID": "A surgicalkit's ID",
+                                "status": "oem",
+                                "sterilizations": [
+                                    {
+                                        "cycleID": "the sterilizer's identifier for the cycle",
+                                        "duration": 123.456,
+                                        "method": "dryheat",
+                                        "passed": true,
+                                        "performed": "2016-11-20T00:00:00Z",
+                                        "pressure": 123.456,
+                                        "serials": [
+                                            "carpe noctem"
+                                        ],
+                                        "sterilizer": "the sterilizer that ran the cycle",
+                                        "temperature": 123.456
+                                    }
+                                ],
+                                "transit": {
+                                    "begintransit": "2016-11-20T00:00:00Z",
+                                    "carrier": "carpe noctem",
+                                    "deadline": "2016-11-20T00:00:00Z",
+                                    "endtransit": "2016-11-20T00:00:00Z",
+                                    "intransit": true,
+                                    "receiver": "oem",
+                                    "receiverparty": "the organization that holds or handles a kit, e.g. a company name",
+                                    "shipper": "oem",
+                                    "shipperparty": "the organization that holds or handles a kit, e.g. a company name"
+                                }
+                            }
+                        },
+                        "eventout": {
+                            "surgicalkit": {
+                                "name": "EVT.IOTCP.INVOKE.RESULT",
+                                "payload": {
+                                    "properties": "NO TYPE PROPERTY"
+                                }
+                            }
+                        },
+                        "state": {
+                            "surgicalkit": {
+                                "burst": {
+                                    "burstlength": 123.456,
+                                    "burstnum": 123.456,
+                                    "sequence": 123.456
+                                },
+                                "common": {
+                                    "appdata": [
+                                        {
+                                            "K": "carpe noctem",
+                                            "V": "carpe noctem"
+                                        }
+                                    ],
+                                    "deviceID": "A unique identifier for the device that sent the current event",
+                                    "devicetimestamp": "2016-11-20T00:00:00Z",
+                                    "location": {
+                                        "latitude": 45.4215,
+                                        "longitude": -75.6972
+                                    }
+                                },
+                                "custody": [
+                                    {
+                                        "carrier": "carpe noctem",
+                                        "from": "oem",
+                                        "fromparty": "the organization that holds or handles a kit, e.g. a company name",
+                                        "late": true,
+                                        "received": "2016-11-20T00:00:00Z",
+                                        "shipped": "2016-11-20T00:00:00Z",
+                                        "to": "oem",
+                                        "toparty": "the organization that holds or handles a kit, e.g. a company name"
+                                    }
+                                ],
+                                "damage": [
+                                    {
+                                        "gforce": 123.456,
+                                        "inspector": "the organization that holds or handles a kit, e.g. a company name",
+                                        "kind": "tilt",
+                                        "location": {
+                                            "latitude": 45.4215,
+                                            "longitude": -75.6972
+                                        },
+                                        "notes": "carpe noctem",
+                                        "passed": true,
+                                        "tilt": 123.456,
+                                        "timestamp": "2016-11-20T00:00:00Z"
+                                    }
+                                ],
+                                "distanceFromFenceCenter": 123.456,
+                                "holder": "the organization that holds or handles a kit, e.g. a company name",
+                                "hospital": {
+                                    "address": {
+                                        "city": "carpe noctem",
+                                        "country": "carpe noctem",
+                                        "postcode": "carpe noctem",
+                                        "streetandnumber": "carpe noctem"
+                                    },
+                                    "fence": {
+                                        "center": {
+                                            "latitude": 45.4215,
+                                            "longitude": -75.6972
+                                        },
+                                        "radius": 123.456
+                                    },
+                                    "name": "carpe noctem"
+                                },
+                                "instruments": [
+                                    {
+                                        "cycles": 789,
+                                        "description": "carpe noctem",
+                                        "lot": "the manufacturing lot of the instrument, which recalls name",
+                                        "maxcycles": 789,
+                                        "serial": "the instrument's serial number"
+                                    }
+                                ],
+                                "sensors": {
+                                    "begin": "2016-11-20T00:00:00Z",
+                                    "currtilt": 123.456,
+                                    "end": "2016-11-20T00:00:00Z",
+                                    "endlocation": {
+                                        "latitude": 45.4215,
+                                        "longitude": -75.6972
+                                    },
+                                    "maxgforce": 123.456,
+                                    "maxtilt": 123.456,
+                                    "startlocation": {
+                                        "latitude": 45.4215,
+                                        "longitude": -75.6972
+                                    }
+                                },
+                                "skitID": "A surgicalkit's ID",
+                                "status": "oem",
+                                "sterilizations": [
+                                    {
+                                        "cycleID": "the sterilizer's identifier for the cycle",
+                                        "duration": 123.456,
+                                        "method": "dryheat",
+                                        "passed": true,
+                                        "performed": "2016-11-20T00:00:00Z",
+                                        "pressure": 123.456,
+                                        "serials": [
+                                            "carpe noctem"
+                                        ],
+                                        "sterilizer": "the sterilizer that ran the cycle",
+                                        "temperature": 123.456
+                                    }
+                                ],
+                                "transit": {
+                                    "begintransit": "2016-11-20T00:00:00Z",
+                                    "carrier": "carpe noctem",
+                                    "deadline": "2016-11-20T00:00:00Z",
+                                    "endtransit": "2016-11-20T00:00:00Z",
+                                    "intransit": true,
+                                    "receiver": "oem",
+                                    "receiverparty": "the organization that holds or handles a kit, e.g. a company name",
+                                    "shipper": "oem",
+                                    "shipperparty": "the organization that holds or handles a kit, e.g. a company name"
+                                }
+                            }
+                        },
+                        "txnid": "Transaction UUID matching the blockchain",
+                        "txnts": "Transaction timestamp matching the blockchain"
+                    }
                 }
             ]
         }
@@ -51,12 +311,41 @@ var samples = `
                     }
                 ],
                 "deviceID": "A unique identifier for the device that sent the current event",
-                "devicetimestamp": "A timestamp recoded by the device that sent the current event",
+                "devicetimestamp": "2016-11-20T00:00:00Z",
                 "location": {
-                    "latitude": 123.456,
-                    "longitude": 123.456
+                    "latitude": 45.4215,
+                    "longitude": -75.6972
                 }
             },
+            "custody": [
+                {
+                    "carrier": "carpe noctem",
+                    "from": "oem",
+                    "fromparty": "the organization that holds or handles a kit, e.g. a company name",
+                    "late": true,
+                    "received": "2016-11-20T00:00:00Z",
+                    "shipped": "2016-11-20T00:00:00Z",
+                    "to": "oem",
+                    "toparty": "the organization that holds or handles a kit, e.g. a company name"
+                }
+            ],
+            "damage": [
+                {
+                    "gforce": 123.456,
+                    "inspector": "the organization that holds or handles a kit, e.g. a company name",
+                    "kind": "tilt",
+                    "location": {
+                        "latitude": 45.4215,
+                        "longitude": -75.6972
+                    },
+                    "notes": "carpe noctem",
+                    "passed": true,
+                    "tilt": 123.456,
+                    "timestamp": "2016-11-20T00:00:00Z"
+                }
+            ],
+            "distanceFromFenceCenter": 123.456,
+            "holder": "the organization that holds or handles a kit, e.g. a company name",
             "hospital": {
                 "address": {
                     "city": "carpe noctem",
@@ -66,36 +355,64 @@ var samples = `
                 },
                 "fence": {
                     "center": {
-                        "latitude": 123.456,
-                        "longitude": 123.456
+                        "latitude": 45.4215,
+                        "longitude": -75.6972
                     },
                     "radius": 123.456
                 },
                 "name": "carpe noctem"
             },
+            "instruments": [
+                {
+                    "cycles": 789,
+                    "description": "carpe noctem",
+                    "lot": "the manufacturing lot of the instrument, which recalls name",
+                    "maxcycles": 789,
+                    "serial": "the instrument's serial number"
+                }
+            ],
             "sensors": {
-                "begin": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                "begin": "2016-11-20T00:00:00Z",
                 "currtilt": 123.456,
-                "end": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                "end": "2016-11-20T00:00:00Z",
                 "endlocation": {
-                    "latitude": 123.456,
-                    "longitude": 123.456
+                    "latitude": 45.4215,
+                    "longitude": -75.6972
                 },
                 "maxgforce": 123.456,
                 "maxtilt": 123.456,
                 "startlocation": {
-                    "latitude": 123.456,
-                    "longitude": 123.456
+                    "latitude": 45.4215,
+                    "longitude": -75.6972
                 }
             },
             "skitID": "A surgicalkit's ID",
             "status": "oem",
+            "sterilizations": [
+                {
+                    "cycleID": "the sterilizer's identifier for the cycle",
+                    "duration": 123.456,
+                    "method": "dryheat",
+                    "passed": true,
+                    "performed": "2016-11-20T00:00:00Z",
+                    "pressure": 123.456,
+                    "serials": [
+                        "carpe noctem"
+                    ],
+                    "sterilizer": "the sterilizer that ran the cycle",
+                    "temperature": 123.456
+                }
+            ],
             "transit": {
-                "begintransit": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                "begintransit": "2016-11-20T00:00:00Z",
                 "carrier": "carpe noctem",
-                "endtransit": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                "deadline": "2016-11-20T00:00:00Z",
+                "endtransit": "2016-11-20T00:00:00Z",
+                "intransit": true,
                 "receiver": "oem",
-                "shipper": "oem"
+                "receiverparty": "the organization that holds or handles a kit, e.g. a company name",
+                "shipper": "oem",
+                "shipperparty": "the organization that holds or handles a kit, e.g. a company name"
             }
         }
     }
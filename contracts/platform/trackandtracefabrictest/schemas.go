@@ -10,14 +10,36 @@ var schemas = `
 
 {
     "API": {
+        "checkOverdueSurgicalKits": {
+            "description": "Evaluates the overdue shipment alert of every surgicalkit in transit, for shipments that have had no other update since their deadline",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {},
+                        "type": "object"
+                    },
+                    "maxItems": 0,
+                    "minItems": 0,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "checkOverdueSurgicalKits"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
         "createAssetSurgicalKit": {
-            "description": "Creates a new surgicalkit (e.g. put new)",
+            "description": "Creates a new surgicalkit (e.g. put new), a new surgicalkit is with its oem and has no transit, custody, sterilization or damage records",
             "properties": {
                 "args": {
                     "items": {
                         "properties": {
                             "surgicalkit": {
-                                "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
+                                "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state, the status, transit, holder, custody, sterilizations and damage properties are changed only by the contract's own routes",
                                 "properties": {
                                     "burst": {
                                         "description": "one individual message in a sequenced burst of messages stored in history for testing purposes",
@@ -77,6 +99,136 @@ var schemas = `
                                         },
                                         "type": "object"
                                     },
+                                    "custody": {
+                                        "description": "the kit's changes of custody, oldest first, appended by receiveSurgicalKit",
+                                        "items": {
+                                            "description": "one change of custody, confirmed by the receiving party",
+                                            "properties": {
+                                                "carrier": {
+                                                    "type": "string"
+                                                },
+                                                "from": {
+                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                    "enum": [
+                                                        "",
+                                                        "oem",
+                                                        "warehouse",
+                                                        "dealer",
+                                                        "retailer",
+                                                        "hospital",
+                                                        "scrapped"
+                                                    ],
+                                                    "type": "string"
+                                                },
+                                                "fromparty": {
+                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                    "type": "string"
+                                                },
+                                                "late": {
+                                                    "description": "true when the receipt came after the shipment's deadline",
+                                                    "type": "boolean"
+                                                },
+                                                "received": {
+                                                    "description": "timestamp of the receipt",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "shipped": {
+                                                    "description": "timestamp of the shipment",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "to": {
+                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                    "enum": [
+                                                        "",
+                                                        "oem",
+                                                        "warehouse",
+                                                        "dealer",
+                                                        "retailer",
+                                                        "hospital",
+                                                        "scrapped"
+                                                    ],
+                                                    "type": "string"
+                                                },
+                                                "toparty": {
+                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "readOnly": true,
+                                        "type": "array"
+                                    },
+                                    "damage": {
+                                        "description": "the kit's most recent shocks, tilts and inspections, oldest first, shocks and tilts are recorded from sensor readings beyond the limits and inspections by inspectSurgicalKit, the latest shock and tilt that wait for a passed inspection are kept however old",
+                                        "items": {
+                                            "description": "a shock or tilt beyond the kit's limits, or an inspection of the kit",
+                                            "properties": {
+                                                "gforce": {
+                                                    "description": "the force in Gs of a shock",
+                                                    "type": "number"
+                                                },
+                                                "inspector": {
+                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                    "type": "string"
+                                                },
+                                                "kind": {
+                                                    "enum": [
+                                                        "shock",
+                                                        "tilt",
+                                                        "inspection"
+                                                    ],
+                                                    "type": "string"
+                                                },
+                                                "location": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "notes": {
+                                                    "type": "string"
+                                                },
+                                                "passed": {
+                                                    "description": "whether the kit passed an inspection",
+                                                    "type": "boolean"
+                                                },
+                                                "tilt": {
+                                                    "description": "the tilt in degrees from horizontal of a tilt",
+                                                    "type": "number"
+                                                },
+                                                "timestamp": {
+                                                    "description": "timestamp of the transaction that reported the event",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "readOnly": true,
+                                        "type": "array"
+                                    },
+                                    "distanceFromFenceCenter": {
+                                        "description": "calculated distance in meters from the hospital fence center to the end location, can be compared to the fence radius",
+                                        "readOnly": true,
+                                        "type": "number"
+                                    },
+                                    "holder": {
+                                        "description": "the organization that holds or handles a kit, e.g. a company name",
+                                        "readOnly": true,
+                                        "type": "string"
+                                    },
                                     "hospital": {
                                         "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
                                         "properties": {
@@ -112,7 +264,9 @@ var schemas = `
                                                         "type": "object"
                                                     },
                                                     "radius": {
-                                                        "type": "number"
+                                                        "description": "radius of the fence in meters, readings in other units are sent as {\"value\": 0.5, \"unit\": \"km\"}",
+                                                        "type": "number",
+                                                        "unit": "m"
                                                     }
                                                 },
                                                 "type": "object"
@@ -123,6 +277,39 @@ var schemas = `
                                         },
                                         "type": "object"
                                     },
+                                    "instruments": {
+                                        "description": "the instruments in the kit, an update replaces those with the same serial and adds the others",
+                                        "items": {
+                                            "description": "a surgical instrument in the kit, identified by its serial number",
+                                            "properties": {
+                                                "cycles": {
+                                                    "description": "the sterilization cycles that the instrument has been through, counted by sterilizeSurgicalKit",
+                                                    "readOnly": true,
+                                                    "type": "integer"
+                                                },
+                                                "description": {
+                                                    "type": "string"
+                                                },
+                                                "lot": {
+                                                    "description": "the manufacturing lot of the instrument, which recalls name",
+                                                    "type": "string"
+                                                },
+                                                "maxcycles": {
+                                                    "description": "the sterilization cycles that the instrument is rated for, no limit when absent",
+                                                    "type": "integer"
+                                                },
+                                                "serial": {
+                                                    "description": "the instrument's serial number",
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "required": [
+                                                "serial"
+                                            ],
+                                            "type": "object"
+                                        },
+                                        "type": "array"
+                                    },
                                     "sensors": {
                                         "description": "sensor readings for the surgical kit",
                                         "properties": {
@@ -155,8 +342,9 @@ var schemas = `
                                                 "type": "object"
                                             },
                                             "maxgforce": {
-                                                "description": "The highest (in Gs) force that the kit experienced during the sample",
-                                                "type": "number"
+                                                "description": "The highest (in Gs) force that the kit experienced during the sample, readings in m/s2 are sent as {\"value\": 19.6, \"unit\": \"m/s2\"}",
+                                                "type": "number",
+                                                "unit": "g"
                                             },
                                             "maxtilt": {
                                                 "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
@@ -192,8 +380,66 @@ var schemas = `
                                             "hospital",
                                             "scrapped"
                                         ],
+                                        "readOnly": true,
                                         "type": "string"
                                     },
+                                    "sterilizations": {
+                                        "description": "the kit's most recent sterilization cycles, oldest first, appended by sterilizeSurgicalKit",
+                                        "items": {
+                                            "description": "one sterilization cycle and its parameters",
+                                            "properties": {
+                                                "cycleID": {
+                                                    "description": "the sterilizer's identifier for the cycle",
+                                                    "type": "string"
+                                                },
+                                                "duration": {
+                                                    "description": "the exposure time in minutes",
+                                                    "type": "number"
+                                                },
+                                                "method": {
+                                                    "enum": [
+                                                        "steam",
+                                                        "dryheat",
+                                                        "ethyleneoxide",
+                                                        "hydrogenperoxide"
+                                                    ],
+                                                    "type": "string"
+                                                },
+                                                "passed": {
+                                                    "description": "whether the cycle's indicators passed",
+                                                    "type": "boolean"
+                                                },
+                                                "performed": {
+                                                    "description": "timestamp of the cycle, the transaction's when absent",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "pressure": {
+                                                    "description": "the chamber pressure in kPa",
+                                                    "type": "number"
+                                                },
+                                                "serials": {
+                                                    "description": "the serial numbers of the instruments in the cycle, all of the kit's instruments when absent",
+                                                    "items": {
+                                                        "type": "string"
+                                                    },
+                                                    "type": "array"
+                                                },
+                                                "sterilizer": {
+                                                    "description": "the sterilizer that ran the cycle",
+                                                    "type": "string"
+                                                },
+                                                "temperature": {
+                                                    "description": "the exposure temperature in degrees Celsius",
+                                                    "type": "number"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "readOnly": true,
+                                        "type": "array"
+                                    },
                                     "transit": {
                                         "description": "shipping data during transit periods",
                                         "properties": {
@@ -206,12 +452,22 @@ var schemas = `
                                             "carrier": {
                                                 "type": "string"
                                             },
+                                            "deadline": {
+                                                "description": "timestamp by which the receiver must confirm the shipment, begintransit plus the shipment window",
+                                                "format": "date-time",
+                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                "type": "string"
+                                            },
                                             "endtransit": {
                                                 "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
                                                 "format": "date-time",
                                                 "sample": "yyyy-mm-dd hh:mm:ss",
                                                 "type": "string"
                                             },
+                                            "intransit": {
+                                                "description": "true from the shipment until the receiver confirms it",
+                                                "type": "boolean"
+                                            },
                                             "receiver": {
                                                 "description": "current kit status as a named entity in possession of the kit",
                                                 "enum": [
@@ -225,6 +481,10 @@ var schemas = `
                                                 ],
                                                 "type": "string"
                                             },
+                                            "receiverparty": {
+                                                "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                "type": "string"
+                                            },
                                             "shipper": {
                                                 "description": "current kit status as a named entity in possession of the kit",
                                                 "enum": [
@@ -237,8 +497,13 @@ var schemas = `
                                                     "scrapped"
                                                 ],
                                                 "type": "string"
+                                            },
+                                            "shipperparty": {
+                                                "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                "type": "string"
                                             }
                                         },
+                                        "readOnly": true,
                                         "type": "object"
                                     }
                                 },
@@ -385,7 +650,7 @@ var schemas = `
             "type": "object"
         },
         "deletePropertiesFromAssetSurgicalKit": {
-            "description": "Delete one or more properties from a surgicalkit's state, an example being temperature, which is only relevant for sensitive (as in frozen) shipments",
+            "description": "Delete one or more properties from a surgicalkit's state, an example being temperature, which is only relevant for sensitive (as in frozen) shipments, the instruments and the properties that change only through the contract's own routes cannot be deleted",
             "properties": {
                 "args": {
                     "items": {
@@ -424,12 +689,24 @@ var schemas = `
             "type": "object"
         },
         "deleteWorldState": {
-            "description": "**** WARNING *** Clears the entire contents of world state, redeploy the contract after using this, in debugging mode, will require a restart",
+            "description": "**** WARNING *** Clears the entire contents of world state except destructive guard and audit records, requires a confirm token from readConfirmationToken and is disabled in production mode. The plain \"reinit\" argument of earlier releases is confirmed as {\"reinit\": true}",
             "properties": {
                 "args": {
-                    "items": {},
-                    "maxItems": 0,
-                    "minItems": 0,
+                    "items": {
+                        "properties": {
+                            "confirm": {
+                                "description": "token returned by readConfirmationToken, valid for one call within two minutes",
+                                "type": "string"
+                            },
+                            "reinit": {
+                                "description": "reinitialize the contract state with the current version and nickname",
+                                "type": "boolean"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
                     "type": "array"
                 },
                 "function": {
@@ -474,6 +751,62 @@ var schemas = `
             },
             "type": "object"
         },
+        "inspectSurgicalKit": {
+            "description": "Records an inspection of a surgicalkit in its damage timeline, a passed inspection clears the EXCESSFORCE and EXCESSTILT alerts",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "inspection": {
+                                "description": "an inspection of a kit for damage from shocks and tilts",
+                                "properties": {
+                                    "inspector": {
+                                        "description": "the organization that holds or handles a kit, e.g. a company name",
+                                        "type": "string"
+                                    },
+                                    "notes": {
+                                        "type": "string"
+                                    },
+                                    "passed": {
+                                        "description": "whether the kit passed, a passed inspection clears the excess force and tilt alerts",
+                                        "type": "boolean"
+                                    }
+                                },
+                                "required": [
+                                    "inspector",
+                                    "passed"
+                                ],
+                                "type": "object"
+                            },
+                            "surgicalkit": {
+                                "properties": {
+                                    "skitID": {
+                                        "description": "A surgicalkit's ID",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            }
+                        },
+                        "required": [
+                            "inspection"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "inspectSurgicalKit"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
         "readAllAssetsSurgicalKit": {
             "description": "Returns the state of all surgicalkits, supports filters",
             "properties": {
@@ -532,242 +865,2459 @@ var schemas = `
                     "items": {
                         "patternProperties": {
                             "^CON": {
-                                "description": "The external state of one surgicalkit asset, named by its world state ID",
-                                "type": "object"
-                            }
-                        },
-                        "type": "object"
-                    },
-                    "minItems": 0,
-                    "type": "array"
-                }
-            },
-            "type": "object"
-        },
-        "readAllRoutes": {
-            "description": "Returns an array of registered API calls by function (debugging)",
-            "properties": {
-                "args": {
-                    "items": {},
-                    "maxItems": 0,
-                    "minItems": 0,
-                    "type": "array"
-                },
-                "function": {
-                    "enum": [
-                        "readAllRoutes"
-                    ],
-                    "type": "string"
-                },
-                "method": "query",
-                "result": {
-                    "description": "An array of routes",
-                    "items": {
-                        "description": "A route defines a contract API that can be called to perform a service",
-                        "properties": {
-                            "class": {
-                                "description": "An asset's classifier definition",
+                                "description": "A surgicalkit's complete state",
                                 "properties": {
-                                    "assetidpath": "An asset's primary key, expressed as a qualified property path (see example contracts)",
-                                    "name": "An asset's class name",
-                                    "prefix": "An asset's world state prefix, used to allow iteration over all assets of a class"
-                                },
-                                "type": "object"
-                            },
-                            "functionname": {
-                                "type": "string"
-                            },
-                            "method": {
-                                "type": "string"
-                            }
-                        },
-                        "type": "object"
-                    },
-                    "minItems": 0,
-                    "type": "object"
-                }
-            },
-            "type": "object"
-        },
-        "readAllRules": {
-            "description": "Returns an array of registered rules by class (debugging)",
-            "properties": {
-                "args": {
-                    "items": {},
-                    "maxItems": 0,
-                    "minItems": 0,
-                    "type": "array"
-                },
-                "function": {
-                    "enum": [
-                        "readAllRules"
-                    ],
-                    "type": "string"
-                },
-                "method": "query",
-                "result": {
-                    "description": "An array of rules",
-                    "items": {
-                        "description": "A rule defines a behavior that is applied to every new asset state just before writing to world state, often raises or clears alerts",
-                        "properties": {
-                            "alerts": {
-                                "description": "An array of alert names",
-                                "items": {
-                                    "description": "An alert name",
-                                    "type": "string"
-                                },
-                                "type": "array"
-                            },
-                            "class": {
-                                "description": "An asset's classifier definition",
-                                "properties": {
-                                    "assetidpath": "An asset's primary key, expressed as a qualified property path (see example contracts)",
-                                    "name": "An asset's class name",
-                                    "prefix": "An asset's world state prefix, used to allow iteration over all assets of a class"
-                                },
-                                "type": "object"
-                            },
-                            "rulename": {
-                                "type": "string"
-                            }
-                        },
-                        "type": "object"
-                    },
-                    "minItems": 0,
-                    "type": "object"
-                }
-            },
-            "type": "object"
-        },
-        "readAssetSamples": {
-            "description": "Returns samples of selected contract objects",
-            "properties": {
-                "args": {
-                    "items": {},
-                    "maxItems": 0,
-                    "minItems": 0,
-                    "type": "array"
-                },
-                "function": {
-                    "enum": [
-                        "readAssetSamples"
-                    ],
-                    "type": "string"
-                },
-                "method": "query",
-                "result": {
-                    "properties": {},
-                    "type": "object"
-                }
-            },
-            "type": "object"
-        },
-        "readAssetSchemas": {
-            "description": "Returns the API for this contract for the use of self-configuring applications; is MANDATORY for integration with the Watson IoT Platform",
-            "properties": {
-                "args": {
-                    "items": {},
-                    "maxItems": 0,
-                    "minItems": 0,
-                    "type": "array"
-                },
-                "function": {
-                    "enum": [
-                        "readAssetSchemas"
-                    ],
-                    "type": "string"
-                },
-                "method": "query",
-                "result": {
-                    "properties": {},
-                    "type": "object"
-                }
-            },
-            "type": "object"
-        },
-        "readAssetStateHistorySurgicalKit": {
-            "description": "Returns history states for a surgicalkit",
-            "properties": {
-                "args": {
-                    "items": {
-                        "properties": {
-                            "daterange": {
-                                "description": "if specified, dates must fall in between these values, inclusive",
-                                "properties": {
-                                    "begin": {
-                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                        "format": "date-time",
-                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                    "AssetKey": {
+                                        "description": "This surgicalkit's world state surgicalkit ID",
                                         "type": "string"
                                     },
-                                    "end": {
-                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
-                                        "format": "date-time",
-                                        "sample": "yyyy-mm-dd hh:mm:ss",
-                                        "type": "string"
-                                    }
-                                },
-                                "type": "object"
-                            },
-                            "filter": {
-                                "description": "Filter asset states",
-                                "properties": {
-                                    "match": {
-                                        "description": "Defines how to match properties, missing property always fails match",
-                                        "enum": [
-                                            "n/a",
-                                            "all",
-                                            "any",
-                                            "none"
-                                        ],
+                                    "alerts": {
+                                        "description": "An array of alert names",
+                                        "items": {
+                                            "description": "An alert name",
+                                            "type": "string"
+                                        },
+                                        "type": "array"
+                                    },
+                                    "class": {
+                                        "description": "An asset's classifier definition",
+                                        "properties": {
+                                            "assetidpath": "An asset's primary key, expressed as a qualified property path (see example contracts)",
+                                            "name": "An asset's class name",
+                                            "prefix": "An asset's world state prefix, used to allow iteration over all assets of a class"
+                                        },
+                                        "type": "object"
+                                    },
+                                    "compliant": {
+                                        "description": "This surgicalkit has no active alerts",
+                                        "type": "boolean"
+                                    },
+                                    "eventin": {
+                                        "description": "The contract event that created this state, for example updateAssetSurgicalKit",
+                                        "properties": {
+                                            "surgicalkit": {
+                                                "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state, the status, transit, holder, custody, sterilizations and damage properties are changed only by the contract's own routes",
+                                                "properties": {
+                                                    "burst": {
+                                                        "description": "one individual message in a sequenced burst of messages stored in history for testing purposes",
+                                                        "properties": {
+                                                            "burstlength": {
+                                                                "description": "length of this burst",
+                                                                "type": "number"
+                                                            },
+                                                            "burstnum": {
+                                                                "type": "number"
+                                                            },
+                                                            "sequence": {
+                                                                "type": "number"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "common": {
+                                                        "description": "Common properties for all assets",
+                                                        "properties": {
+                                                            "appdata": {
+                                                                "description": "Application managed information as an array of key:value pairs",
+                                                                "items": {
+                                                                    "properties": {
+                                                                        "K": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "V": {
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "minItems": 0,
+                                                                "type": "array"
+                                                            },
+                                                            "deviceID": {
+                                                                "description": "A unique identifier for the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "devicetimestamp": {
+                                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "location": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "custody": {
+                                                        "description": "the kit's changes of custody, oldest first, appended by receiveSurgicalKit",
+                                                        "items": {
+                                                            "description": "one change of custody, confirmed by the receiving party",
+                                                            "properties": {
+                                                                "carrier": {
+                                                                    "type": "string"
+                                                                },
+                                                                "from": {
+                                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                                    "enum": [
+                                                                        "",
+                                                                        "oem",
+                                                                        "warehouse",
+                                                                        "dealer",
+                                                                        "retailer",
+                                                                        "hospital",
+                                                                        "scrapped"
+                                                                    ],
+                                                                    "type": "string"
+                                                                },
+                                                                "fromparty": {
+                                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                                    "type": "string"
+                                                                },
+                                                                "late": {
+                                                                    "description": "true when the receipt came after the shipment's deadline",
+                                                                    "type": "boolean"
+                                                                },
+                                                                "received": {
+                                                                    "description": "timestamp of the receipt",
+                                                                    "format": "date-time",
+                                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                    "type": "string"
+                                                                },
+                                                                "shipped": {
+                                                                    "description": "timestamp of the shipment",
+                                                                    "format": "date-time",
+                                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                    "type": "string"
+                                                                },
+                                                                "to": {
+                                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                                    "enum": [
+                                                                        "",
+                                                                        "oem",
+                                                                        "warehouse",
+                                                                        "dealer",
+                                                                        "retailer",
+                                                                        "hospital",
+                                                                        "scrapped"
+                                                                    ],
+                                                                    "type": "string"
+                                                                },
+                                                                "toparty": {
+                                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "readOnly": true,
+                                                        "type": "array"
+                                                    },
+                                                    "damage": {
+                                                        "description": "the kit's most recent shocks, tilts and inspections, oldest first, shocks and tilts are recorded from sensor readings beyond the limits and inspections by inspectSurgicalKit, the latest shock and tilt that wait for a passed inspection are kept however old",
+                                                        "items": {
+                                                            "description": "a shock or tilt beyond the kit's limits, or an inspection of the kit",
+                                                            "properties": {
+                                                                "gforce": {
+                                                                    "description": "the force in Gs of a shock",
+                                                                    "type": "number"
+                                                                },
+                                                                "inspector": {
+                                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                                    "type": "string"
+                                                                },
+                                                                "kind": {
+                                                                    "enum": [
+                                                                        "shock",
+                                                                        "tilt",
+                                                                        "inspection"
+                                                                    ],
+                                                                    "type": "string"
+                                                                },
+                                                                "location": {
+                                                                    "description": "A geographical coordinate",
+                                                                    "properties": {
+                                                                        "latitude": {
+                                                                            "type": "number"
+                                                                        },
+                                                                        "longitude": {
+                                                                            "type": "number"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "notes": {
+                                                                    "type": "string"
+                                                                },
+                                                                "passed": {
+                                                                    "description": "whether the kit passed an inspection",
+                                                                    "type": "boolean"
+                                                                },
+                                                                "tilt": {
+                                                                    "description": "the tilt in degrees from horizontal of a tilt",
+                                                                    "type": "number"
+                                                                },
+                                                                "timestamp": {
+                                                                    "description": "timestamp of the transaction that reported the event",
+                                                                    "format": "date-time",
+                                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "readOnly": true,
+                                                        "type": "array"
+                                                    },
+                                                    "distanceFromFenceCenter": {
+                                                        "description": "calculated distance in meters from the hospital fence center to the end location, can be compared to the fence radius",
+                                                        "readOnly": true,
+                                                        "type": "number"
+                                                    },
+                                                    "holder": {
+                                                        "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                        "readOnly": true,
+                                                        "type": "string"
+                                                    },
+                                                    "hospital": {
+                                                        "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
+                                                        "properties": {
+                                                            "address": {
+                                                                "properties": {
+                                                                    "city": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "country": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "postcode": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "streetandnumber": {
+                                                                        "type": "string"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "fence": {
+                                                                "properties": {
+                                                                    "center": {
+                                                                        "description": "A geographical coordinate",
+                                                                        "properties": {
+                                                                            "latitude": {
+                                                                                "type": "number"
+                                                                            },
+                                                                            "longitude": {
+                                                                                "type": "number"
+                                                                            }
+                                                                        },
+                                                                        "type": "object"
+                                                                    },
+                                                                    "radius": {
+                                                                        "description": "radius of the fence in meters, readings in other units are sent as {\"value\": 0.5, \"unit\": \"km\"}",
+                                                                        "type": "number",
+                                                                        "unit": "m"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "name": {
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "instruments": {
+                                                        "description": "the instruments in the kit, an update replaces those with the same serial and adds the others",
+                                                        "items": {
+                                                            "description": "a surgical instrument in the kit, identified by its serial number",
+                                                            "properties": {
+                                                                "cycles": {
+                                                                    "description": "the sterilization cycles that the instrument has been through, counted by sterilizeSurgicalKit",
+                                                                    "readOnly": true,
+                                                                    "type": "integer"
+                                                                },
+                                                                "description": {
+                                                                    "type": "string"
+                                                                },
+                                                                "lot": {
+                                                                    "description": "the manufacturing lot of the instrument, which recalls name",
+                                                                    "type": "string"
+                                                                },
+                                                                "maxcycles": {
+                                                                    "description": "the sterilization cycles that the instrument is rated for, no limit when absent",
+                                                                    "type": "integer"
+                                                                },
+                                                                "serial": {
+                                                                    "description": "the instrument's serial number",
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "required": [
+                                                                "serial"
+                                                            ],
+                                                            "type": "object"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "sensors": {
+                                                        "description": "sensor readings for the surgical kit",
+                                                        "properties": {
+                                                            "begin": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "currtilt": {
+                                                                "description": "The current tilt that the kit is experiencing",
+                                                                "type": "number"
+                                                            },
+                                                            "end": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "endlocation": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "maxgforce": {
+                                                                "description": "The highest (in Gs) force that the kit experienced during the sample, readings in m/s2 are sent as {\"value\": 19.6, \"unit\": \"m/s2\"}",
+                                                                "type": "number",
+                                                                "unit": "g"
+                                                            },
+                                                            "maxtilt": {
+                                                                "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
+                                                                "type": "number"
+                                                            },
+                                                            "startlocation": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "skitID": {
+                                                        "description": "A surgicalkit's ID",
+                                                        "type": "string"
+                                                    },
+                                                    "status": {
+                                                        "description": "current kit status as a named entity in possession of the kit",
+                                                        "enum": [
+                                                            "",
+                                                            "oem",
+                                                            "warehouse",
+                                                            "dealer",
+                                                            "retailer",
+                                                            "hospital",
+                                                            "scrapped"
+                                                        ],
+                                                        "readOnly": true,
+                                                        "type": "string"
+                                                    },
+                                                    "sterilizations": {
+                                                        "description": "the kit's most recent sterilization cycles, oldest first, appended by sterilizeSurgicalKit",
+                                                        "items": {
+                                                            "description": "one sterilization cycle and its parameters",
+                                                            "properties": {
+                                                                "cycleID": {
+                                                                    "description": "the sterilizer's identifier for the cycle",
+                                                                    "type": "string"
+                                                                },
+                                                                "duration": {
+                                                                    "description": "the exposure time in minutes",
+                                                                    "type": "number"
+                                                                },
+                                                                "method": {
+                                                                    "enum": [
+                                                                        "steam",
+                                                                        "dryheat",
+                                                                        "ethyleneoxide",
+                                                                        "hydrogenperoxide"
+                                                                    ],
+                                                                    "type": "string"
+                                                                },
+                                                                "passed": {
+                                                                    "description": "whether the cycle's indicators passed",
+                                                                    "type": "boolean"
+                                                                },
+                                                                "performed": {
+                                                                    "description": "timestamp of the cycle, the transaction's when absent",
+                                                                    "format": "date-time",
+                                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                    "type": "string"
+                                                                },
+                                                                "pressure": {
+                                                                    "description": "the chamber pressure in kPa",
+                                                                    "type": "number"
+                                                                },
+                                                                "serials": {
+                                                                    "description": "the serial numbers of the instruments in the cycle, all of the kit's instruments when absent",
+                                                                    "items": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "sterilizer": {
+                                                                    "description": "the sterilizer that ran the cycle",
+                                                                    "type": "string"
+                                                                },
+                                                                "temperature": {
+                                                                    "description": "the exposure temperature in degrees Celsius",
+                                                                    "type": "number"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "readOnly": true,
+                                                        "type": "array"
+                                                    },
+                                                    "transit": {
+                                                        "description": "shipping data during transit periods",
+                                                        "properties": {
+                                                            "begintransit": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "carrier": {
+                                                                "type": "string"
+                                                            },
+                                                            "deadline": {
+                                                                "description": "timestamp by which the receiver must confirm the shipment, begintransit plus the shipment window",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "endtransit": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "intransit": {
+                                                                "description": "true from the shipment until the receiver confirms it",
+                                                                "type": "boolean"
+                                                            },
+                                                            "receiver": {
+                                                                "description": "current kit status as a named entity in possession of the kit",
+                                                                "enum": [
+                                                                    "",
+                                                                    "oem",
+                                                                    "warehouse",
+                                                                    "dealer",
+                                                                    "retailer",
+                                                                    "hospital",
+                                                                    "scrapped"
+                                                                ],
+                                                                "type": "string"
+                                                            },
+                                                            "receiverparty": {
+                                                                "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                                "type": "string"
+                                                            },
+                                                            "shipper": {
+                                                                "description": "current kit status as a named entity in possession of the kit",
+                                                                "enum": [
+                                                                    "",
+                                                                    "oem",
+                                                                    "warehouse",
+                                                                    "dealer",
+                                                                    "retailer",
+                                                                    "hospital",
+                                                                    "scrapped"
+                                                                ],
+                                                                "type": "string"
+                                                            },
+                                                            "shipperparty": {
+                                                                "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "readOnly": true,
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "required": [
+                                                    "skitID"
+                                                ],
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "eventout": {
+                                        "description": "The chaincode event emitted on invoke exit, if any",
+                                        "properties": {
+                                            "surgicalkit": {
+                                                "description": "A chaincode event defining the standard platform-generated result event for a contract invoke, contains an array of contributed results",
+                                                "properties": {
+                                                    "name": {
+                                                        "default": "EVT.IOTCP.INVOKE.RESULT",
+                                                        "enum": [
+                                                            "EVT.IOTCP.INVOKE.RESULT"
+                                                        ],
+                                                        "type": "string"
+                                                    },
+                                                    "payload": {
+                                                        "description": "A map of contributed results",
+                                                        "properties": {
+                                                            "description": "the overall status of the invoke result, defined by err",
+                                                            "properties": {
+                                                                "activeAlerts": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "alertsCleared": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "alertsRaised": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "invokeresult": {
+                                                                    "description": "status: OK==txn succeeded, ERROR==txn failed",
+                                                                    "properties": {
+                                                                        "message": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "status": {
+                                                                            "enum": [
+                                                                                "OK",
+                                                                                "ERROR"
+                                                                            ],
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "notifications": {
+                                                                    "description": "typed notifications queued by rules and routes during the invoke, dropped when the invoke fails",
+                                                                    "items": {
+                                                                        "description": "A typed notification in the invoke result event",
+                                                                        "properties": {
+                                                                            "assetID": {
+                                                                                "type": "string"
+                                                                            },
+                                                                            "assetkey": {
+                                                                                "type": "string"
+                                                                            },
+                                                                            "class": {
+                                                                                "type": "string"
+                                                                            },
+                                                                            "data": {
+                                                                                "description": "data that depends on the type, e.g. {\"alert\": \"OVERTEMP\"} for alertRaised",
+                                                                                "type": "object"
+                                                                            },
+                                                                            "txnts": {
+                                                                                "format": "date-time",
+                                                                                "type": "string"
+                                                                            },
+                                                                            "type": {
+                                                                                "description": "alertRaised, alertCleared, geofenceExit, stateTransition or a contract defined type",
+                                                                                "type": "string"
+                                                                            }
+                                                                        },
+                                                                        "required": [
+                                                                            "type"
+                                                                        ],
+                                                                        "type": "object"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "version": {
+                                                                    "description": "version of the result envelope, decoded by the iotcpevents package",
+                                                                    "type": "integer"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "state": {
+                                        "description": "Properties that have been received or calculated for this surgicalkit",
+                                        "properties": {
+                                            "surgicalkit": {
+                                                "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state, the status, transit, holder, custody, sterilizations and damage properties are changed only by the contract's own routes",
+                                                "properties": {
+                                                    "burst": {
+                                                        "description": "one individual message in a sequenced burst of messages stored in history for testing purposes",
+                                                        "properties": {
+                                                            "burstlength": {
+                                                                "description": "length of this burst",
+                                                                "type": "number"
+                                                            },
+                                                            "burstnum": {
+                                                                "type": "number"
+                                                            },
+                                                            "sequence": {
+                                                                "type": "number"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "common": {
+                                                        "description": "Common properties for all assets",
+                                                        "properties": {
+                                                            "appdata": {
+                                                                "description": "Application managed information as an array of key:value pairs",
+                                                                "items": {
+                                                                    "properties": {
+                                                                        "K": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "V": {
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "minItems": 0,
+                                                                "type": "array"
+                                                            },
+                                                            "deviceID": {
+                                                                "description": "A unique identifier for the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "devicetimestamp": {
+                                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "location": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "custody": {
+                                                        "description": "the kit's changes of custody, oldest first, appended by receiveSurgicalKit",
+                                                        "items": {
+                                                            "description": "one change of custody, confirmed by the receiving party",
+                                                            "properties": {
+                                                                "carrier": {
+                                                                    "type": "string"
+                                                                },
+                                                                "from": {
+                                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                                    "enum": [
+                                                                        "",
+                                                                        "oem",
+                                                                        "warehouse",
+                                                                        "dealer",
+                                                                        "retailer",
+                                                                        "hospital",
+                                                                        "scrapped"
+                                                                    ],
+                                                                    "type": "string"
+                                                                },
+                                                                "fromparty": {
+                                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                                    "type": "string"
+                                                                },
+                                                                "late": {
+                                                                    "description": "true when the receipt came after the shipment's deadline",
+                                                                    "type": "boolean"
+                                                                },
+                                                                "received": {
+                                                                    "description": "timestamp of the receipt",
+                                                                    "format": "date-time",
+                                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                    "type": "string"
+                                                                },
+                                                                "shipped": {
+                                                                    "description": "timestamp of the shipment",
+                                                                    "format": "date-time",
+                                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                    "type": "string"
+                                                                },
+                                                                "to": {
+                                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                                    "enum": [
+                                                                        "",
+                                                                        "oem",
+                                                                        "warehouse",
+                                                                        "dealer",
+                                                                        "retailer",
+                                                                        "hospital",
+                                                                        "scrapped"
+                                                                    ],
+                                                                    "type": "string"
+                                                                },
+                                                                "toparty": {
+                                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "readOnly": true,
+                                                        "type": "array"
+                                                    },
+                                                    "damage": {
+                                                        "description": "the kit's most recent shocks, tilts and inspections, oldest first, shocks and tilts are recorded from sensor readings beyond the limits and inspections by inspectSurgicalKit, the latest shock and tilt that wait for a passed inspection are kept however old",
+                                                        "items": {
+                                                            "description": "a shock or tilt beyond the kit's limits, or an inspection of the kit",
+                                                            "properties": {
+                                                                "gforce": {
+                                                                    "description": "the force in Gs of a shock",
+                                                                    "type": "number"
+                                                                },
+                                                                "inspector": {
+                                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                                    "type": "string"
+                                                                },
+                                                                "kind": {
+                                                                    "enum": [
+                                                                        "shock",
+                                                                        "tilt",
+                                                                        "inspection"
+                                                                    ],
+                                                                    "type": "string"
+                                                                },
+                                                                "location": {
+                                                                    "description": "A geographical coordinate",
+                                                                    "properties": {
+                                                                        "latitude": {
+                                                                            "type": "number"
+                                                                        },
+                                                                        "longitude": {
+                                                                            "type": "number"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "notes": {
+                                                                    "type": "string"
+                                                                },
+                                                                "passed": {
+                                                                    "description": "whether the kit passed an inspection",
+                                                                    "type": "boolean"
+                                                                },
+                                                                "tilt": {
+                                                                    "description": "the tilt in degrees from horizontal of a tilt",
+                                                                    "type": "number"
+                                                                },
+                                                                "timestamp": {
+                                                                    "description": "timestamp of the transaction that reported the event",
+                                                                    "format": "date-time",
+                                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "readOnly": true,
+                                                        "type": "array"
+                                                    },
+                                                    "distanceFromFenceCenter": {
+                                                        "description": "calculated distance in meters from the hospital fence center to the end location, can be compared to the fence radius",
+                                                        "readOnly": true,
+                                                        "type": "number"
+                                                    },
+                                                    "holder": {
+                                                        "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                        "readOnly": true,
+                                                        "type": "string"
+                                                    },
+                                                    "hospital": {
+                                                        "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
+                                                        "properties": {
+                                                            "address": {
+                                                                "properties": {
+                                                                    "city": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "country": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "postcode": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "streetandnumber": {
+                                                                        "type": "string"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "fence": {
+                                                                "properties": {
+                                                                    "center": {
+                                                                        "description": "A geographical coordinate",
+                                                                        "properties": {
+                                                                            "latitude": {
+                                                                                "type": "number"
+                                                                            },
+                                                                            "longitude": {
+                                                                                "type": "number"
+                                                                            }
+                                                                        },
+                                                                        "type": "object"
+                                                                    },
+                                                                    "radius": {
+                                                                        "description": "radius of the fence in meters, readings in other units are sent as {\"value\": 0.5, \"unit\": \"km\"}",
+                                                                        "type": "number",
+                                                                        "unit": "m"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "name": {
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "instruments": {
+                                                        "description": "the instruments in the kit, an update replaces those with the same serial and adds the others",
+                                                        "items": {
+                                                            "description": "a surgical instrument in the kit, identified by its serial number",
+                                                            "properties": {
+                                                                "cycles": {
+                                                                    "description": "the sterilization cycles that the instrument has been through, counted by sterilizeSurgicalKit",
+                                                                    "readOnly": true,
+                                                                    "type": "integer"
+                                                                },
+                                                                "description": {
+                                                                    "type": "string"
+                                                                },
+                                                                "lot": {
+                                                                    "description": "the manufacturing lot of the instrument, which recalls name",
+                                                                    "type": "string"
+                                                                },
+                                                                "maxcycles": {
+                                                                    "description": "the sterilization cycles that the instrument is rated for, no limit when absent",
+                                                                    "type": "integer"
+                                                                },
+                                                                "serial": {
+                                                                    "description": "the instrument's serial number",
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "required": [
+                                                                "serial"
+                                                            ],
+                                                            "type": "object"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "sensors": {
+                                                        "description": "sensor readings for the surgical kit",
+                                                        "properties": {
+                                                            "begin": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "currtilt": {
+                                                                "description": "The current tilt that the kit is experiencing",
+                                                                "type": "number"
+                                                            },
+                                                            "end": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "endlocation": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "maxgforce": {
+                                                                "description": "The highest (in Gs) force that the kit experienced during the sample, readings in m/s2 are sent as {\"value\": 19.6, \"unit\": \"m/s2\"}",
+                                                                "type": "number",
+                                                                "unit": "g"
+                                                            },
+                                                            "maxtilt": {
+                                                                "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
+                                                                "type": "number"
+                                                            },
+                                                            "startlocation": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "skitID": {
+                                                        "description": "A surgicalkit's ID",
+                                                        "type": "string"
+                                                    },
+                                                    "status": {
+                                                        "description": "current kit status as a named entity in possession of the kit",
+                                                        "enum": [
+                                                            "",
+                                                            "oem",
+                                                            "warehouse",
+                                                            "dealer",
+                                                            "retailer",
+                                                            "hospital",
+                                                            "scrapped"
+                                                        ],
+                                                        "readOnly": true,
+                                                        "type": "string"
+                                                    },
+                                                    "sterilizations": {
+                                                        "description": "the kit's most recent sterilization cycles, oldest first, appended by sterilizeSurgicalKit",
+                                                        "items": {
+                                                            "description": "one sterilization cycle and its parameters",
+                                                            "properties": {
+                                                                "cycleID": {
+                                                                    "description": "the sterilizer's identifier for the cycle",
+                                                                    "type": "string"
+                                                                },
+                                                                "duration": {
+                                                                    "description": "the exposure time in minutes",
+                                                                    "type": "number"
+                                                                },
+                                                                "method": {
+                                                                    "enum": [
+                                                                        "steam",
+                                                                        "dryheat",
+                                                                        "ethyleneoxide",
+                                                                        "hydrogenperoxide"
+                                                                    ],
+                                                                    "type": "string"
+                                                                },
+                                                                "passed": {
+                                                                    "description": "whether the cycle's indicators passed",
+                                                                    "type": "boolean"
+                                                                },
+                                                                "performed": {
+                                                                    "description": "timestamp of the cycle, the transaction's when absent",
+                                                                    "format": "date-time",
+                                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                    "type": "string"
+                                                                },
+                                                                "pressure": {
+                                                                    "description": "the chamber pressure in kPa",
+                                                                    "type": "number"
+                                                                },
+                                                                "serials": {
+                                                                    "description": "the serial numbers of the instruments in the cycle, all of the kit's instruments when absent",
+                                                                    "items": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "sterilizer": {
+                                                                    "description": "the sterilizer that ran the cycle",
+                                                                    "type": "string"
+                                                                },
+                                                                "temperature": {
+                                                                    "description": "the exposure temperature in degrees Celsius",
+                                                                    "type": "number"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "readOnly": true,
+                                                        "type": "array"
+                                                    },
+                                                    "transit": {
+                                                        "description": "shipping data during transit periods",
+                                                        "properties": {
+                                                            "begintransit": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "carrier": {
+                                                                "type": "string"
+                                                            },
+                                                            "deadline": {
+                                                                "description": "timestamp by which the receiver must confirm the shipment, begintransit plus the shipment window",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "endtransit": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "intransit": {
+                                                                "description": "true from the shipment until the receiver confirms it",
+                                                                "type": "boolean"
+                                                            },
+                                                            "receiver": {
+                                                                "description": "current kit status as a named entity in possession of the kit",
+                                                                "enum": [
+                                                                    "",
+                                                                    "oem",
+                                                                    "warehouse",
+                                                                    "dealer",
+                                                                    "retailer",
+                                                                    "hospital",
+                                                                    "scrapped"
+                                                                ],
+                                                                "type": "string"
+                                                            },
+                                                            "receiverparty": {
+                                                                "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                                "type": "string"
+                                                            },
+                                                            "shipper": {
+                                                                "description": "current kit status as a named entity in possession of the kit",
+                                                                "enum": [
+                                                                    "",
+                                                                    "oem",
+                                                                    "warehouse",
+                                                                    "dealer",
+                                                                    "retailer",
+                                                                    "hospital",
+                                                                    "scrapped"
+                                                                ],
+                                                                "type": "string"
+                                                            },
+                                                            "shipperparty": {
+                                                                "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "readOnly": true,
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "required": [
+                                                    "skitID"
+                                                ],
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "txnid": {
+                                        "description": "Transaction UUID matching the blockchain",
+                                        "type": "string"
+                                    },
+                                    "txnts": {
+                                        "description": "Transaction timestamp matching the blockchain",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "minItems": 0,
+                    "type": "array"
+                }
+            },
+            "type": "object"
+        },
+        "readAllRoutes": {
+            "description": "Returns an array of registered API calls by function (debugging)",
+            "properties": {
+                "args": {
+                    "items": {},
+                    "maxItems": 0,
+                    "minItems": 0,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "readAllRoutes"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "An array of routes",
+                    "items": {
+                        "description": "A route defines a contract API that can be called to perform a service",
+                        "properties": {
+                            "class": {
+                                "description": "An asset's classifier definition",
+                                "properties": {
+                                    "assetidpath": "An asset's primary key, expressed as a qualified property path (see example contracts)",
+                                    "name": "An asset's class name",
+                                    "prefix": "An asset's world state prefix, used to allow iteration over all assets of a class"
+                                },
+                                "type": "object"
+                            },
+                            "destructive": {
+                                "description": "true when the route requires a confirm token",
+                                "type": "boolean"
+                            },
+                            "functionname": {
+                                "type": "string"
+                            },
+                            "method": {
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "minItems": 0,
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "readAllRules": {
+            "description": "Returns an array of registered rules by class (debugging)",
+            "properties": {
+                "args": {
+                    "items": {},
+                    "maxItems": 0,
+                    "minItems": 0,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "readAllRules"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "An array of rules",
+                    "items": {
+                        "description": "A rule defines a behavior that is applied to every new asset state just before writing to world state, often raises or clears alerts",
+                        "properties": {
+                            "alerts": {
+                                "description": "An array of alert names",
+                                "items": {
+                                    "description": "An alert name",
+                                    "type": "string"
+                                },
+                                "type": "array"
+                            },
+                            "class": {
+                                "description": "An asset's classifier definition",
+                                "properties": {
+                                    "assetidpath": "An asset's primary key, expressed as a qualified property path (see example contracts)",
+                                    "name": "An asset's class name",
+                                    "prefix": "An asset's world state prefix, used to allow iteration over all assets of a class"
+                                },
+                                "type": "object"
+                            },
+                            "rulename": {
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "minItems": 0,
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "readAssetSamples": {
+            "description": "Returns samples of selected contract objects",
+            "properties": {
+                "args": {
+                    "items": {},
+                    "maxItems": 0,
+                    "minItems": 0,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "readAssetSamples"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "properties": {},
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "readAssetSchemas": {
+            "description": "Returns the API for this contract for the use of self-configuring applications; is MANDATORY for integration with the Watson IoT Platform",
+            "properties": {
+                "args": {
+                    "items": {},
+                    "maxItems": 0,
+                    "minItems": 0,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "readAssetSchemas"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "properties": {},
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "readAssetStateHistorySurgicalKit": {
+            "description": "Returns history states for a surgicalkit",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "daterange": {
+                                "description": "if specified, dates must fall in between these values, inclusive",
+                                "properties": {
+                                    "begin": {
+                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                        "format": "date-time",
+                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                        "type": "string"
+                                    },
+                                    "end": {
+                                        "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                        "format": "date-time",
+                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "filter": {
+                                "description": "Filter asset states",
+                                "properties": {
+                                    "match": {
+                                        "description": "Defines how to match properties, missing property always fails match",
+                                        "enum": [
+                                            "n/a",
+                                            "all",
+                                            "any",
+                                            "none"
+                                        ],
+                                        "type": "string"
+                                    },
+                                    "select": {
+                                        "description": "Qualified property names and values match",
+                                        "items": {
+                                            "properties": {
+                                                "qprop": {
+                                                    "description": "Qualified property to compare, for example 'asset.assetID'",
+                                                    "type": "string"
+                                                },
+                                                "value": {
+                                                    "description": "Value to be compared",
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "type": "array"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "surgicalkit": {
+                                "properties": {
+                                    "skitID": {
+                                        "description": "A surgicalkit's ID",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            }
+                        },
+                        "required": [
+                            "surgicalkit"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "readAssetStateHistorySurgicalKit"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "Array of surgicalkit states, can mix asset classes",
+                    "items": {
+                        "patternProperties": {
+                            "^CON": {
+                                "description": "A surgicalkit's complete state",
+                                "properties": {
+                                    "AssetKey": {
+                                        "description": "This surgicalkit's world state surgicalkit ID",
+                                        "type": "string"
+                                    },
+                                    "alerts": {
+                                        "description": "An array of alert names",
+                                        "items": {
+                                            "description": "An alert name",
+                                            "type": "string"
+                                        },
+                                        "type": "array"
+                                    },
+                                    "class": {
+                                        "description": "An asset's classifier definition",
+                                        "properties": {
+                                            "assetidpath": "An asset's primary key, expressed as a qualified property path (see example contracts)",
+                                            "name": "An asset's class name",
+                                            "prefix": "An asset's world state prefix, used to allow iteration over all assets of a class"
+                                        },
+                                        "type": "object"
+                                    },
+                                    "compliant": {
+                                        "description": "This surgicalkit has no active alerts",
+                                        "type": "boolean"
+                                    },
+                                    "eventin": {
+                                        "description": "The contract event that created this state, for example updateAssetSurgicalKit",
+                                        "properties": {
+                                            "surgicalkit": {
+                                                "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state, the status, transit, holder, custody, sterilizations and damage properties are changed only by the contract's own routes",
+                                                "properties": {
+                                                    "burst": {
+                                                        "description": "one individual message in a sequenced burst of messages stored in history for testing purposes",
+                                                        "properties": {
+                                                            "burstlength": {
+                                                                "description": "length of this burst",
+                                                                "type": "number"
+                                                            },
+                                                            "burstnum": {
+                                                                "type": "number"
+                                                            },
+                                                            "sequence": {
+                                                                "type": "number"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "common": {
+                                                        "description": "Common properties for all assets",
+                                                        "properties": {
+                                                            "appdata": {
+                                                                "description": "Application managed information as an array of key:value pairs",
+                                                                "items": {
+                                                                    "properties": {
+                                                                        "K": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "V": {
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "minItems": 0,
+                                                                "type": "array"
+                                                            },
+                                                            "deviceID": {
+                                                                "description": "A unique identifier for the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "devicetimestamp": {
+                                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "location": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "custody": {
+                                                        "description": "the kit's changes of custody, oldest first, appended by receiveSurgicalKit",
+                                                        "items": {
+                                                            "description": "one change of custody, confirmed by the receiving party",
+                                                            "properties": {
+                                                                "carrier": {
+                                                                    "type": "string"
+                                                                },
+                                                                "from": {
+                                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                                    "enum": [
+                                                                        "",
+                                                                        "oem",
+                                                                        "warehouse",
+                                                                        "dealer",
+                                                                        "retailer",
+                                                                        "hospital",
+                                                                        "scrapped"
+                                                                    ],
+                                                                    "type": "string"
+                                                                },
+                                                                "fromparty": {
+                                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                                    "type": "string"
+                                                                },
+                                                                "late": {
+                                                                    "description": "true when the receipt came after the shipment's deadline",
+                                                                    "type": "boolean"
+                                                                },
+                                                                "received": {
+                                                                    "description": "timestamp of the receipt",
+                                                                    "format": "date-time",
+                                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                    "type": "string"
+                                                                },
+                                                                "shipped": {
+                                                                    "description": "timestamp of the shipment",
+                                                                    "format": "date-time",
+                                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                    "type": "string"
+                                                                },
+                                                                "to": {
+                                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                                    "enum": [
+                                                                        "",
+                                                                        "oem",
+                                                                        "warehouse",
+                                                                        "dealer",
+                                                                        "retailer",
+                                                                        "hospital",
+                                                                        "scrapped"
+                                                                    ],
+                                                                    "type": "string"
+                                                                },
+                                                                "toparty": {
+                                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "readOnly": true,
+                                                        "type": "array"
+                                                    },
+                                                    "damage": {
+                                                        "description": "the kit's most recent shocks, tilts and inspections, oldest first, shocks and tilts are recorded from sensor readings beyond the limits and inspections by inspectSurgicalKit, the latest shock and tilt that wait for a passed inspection are kept however old",
+                                                        "items": {
+                                                            "description": "a shock or tilt beyond the kit's limits, or an inspection of the kit",
+                                                            "properties": {
+                                                                "gforce": {
+                                                                    "description": "the force in Gs of a shock",
+                                                                    "type": "number"
+                                                                },
+                                                                "inspector": {
+                                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                                    "type": "string"
+                                                                },
+                                                                "kind": {
+                                                                    "enum": [
+                                                                        "shock",
+                                                                        "tilt",
+                                                                        "inspection"
+                                                                    ],
+                                                                    "type": "string"
+                                                                },
+                                                                "location": {
+                                                                    "description": "A geographical coordinate",
+                                                                    "properties": {
+                                                                        "latitude": {
+                                                                            "type": "number"
+                                                                        },
+                                                                        "longitude": {
+                                                                            "type": "number"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "notes": {
+                                                                    "type": "string"
+                                                                },
+                                                                "passed": {
+                                                                    "description": "whether the kit passed an inspection",
+                                                                    "type": "boolean"
+                                                                },
+                                                                "tilt": {
+                                                                    "description": "the tilt in degrees from horizontal of a tilt",
+                                                                    "type": "number"
+                                                                },
+                                                                "timestamp": {
+                                                                    "description": "timestamp of the transaction that reported the event",
+                                                                    "format": "date-time",
+                                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "readOnly": true,
+                                                        "type": "array"
+                                                    },
+                                                    "distanceFromFenceCenter": {
+                                                        "description": "calculated distance in meters from the hospital fence center to the end location, can be compared to the fence radius",
+                                                        "readOnly": true,
+                                                        "type": "number"
+                                                    },
+                                                    "holder": {
+                                                        "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                        "readOnly": true,
+                                                        "type": "string"
+                                                    },
+                                                    "hospital": {
+                                                        "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
+                                                        "properties": {
+                                                            "address": {
+                                                                "properties": {
+                                                                    "city": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "country": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "postcode": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "streetandnumber": {
+                                                                        "type": "string"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "fence": {
+                                                                "properties": {
+                                                                    "center": {
+                                                                        "description": "A geographical coordinate",
+                                                                        "properties": {
+                                                                            "latitude": {
+                                                                                "type": "number"
+                                                                            },
+                                                                            "longitude": {
+                                                                                "type": "number"
+                                                                            }
+                                                                        },
+                                                                        "type": "object"
+                                                                    },
+                                                                    "radius": {
+                                                                        "description": "radius of the fence in meters, readings in other units are sent as {\"value\": 0.5, \"unit\": \"km\"}",
+                                                                        "type": "number",
+                                                                        "unit": "m"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "name": {
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "instruments": {
+                                                        "description": "the instruments in the kit, an update replaces those with the same serial and adds the others",
+                                                        "items": {
+                                                            "description": "a surgical instrument in the kit, identified by its serial number",
+                                                            "properties": {
+                                                                "cycles": {
+                                                                    "description": "the sterilization cycles that the instrument has been through, counted by sterilizeSurgicalKit",
+                                                                    "readOnly": true,
+                                                                    "type": "integer"
+                                                                },
+                                                                "description": {
+                                                                    "type": "string"
+                                                                },
+                                                                "lot": {
+                                                                    "description": "the manufacturing lot of the instrument, which recalls name",
+                                                                    "type": "string"
+                                                                },
+                                                                "maxcycles": {
+                                                                    "description": "the sterilization cycles that the instrument is rated for, no limit when absent",
+                                                                    "type": "integer"
+                                                                },
+                                                                "serial": {
+                                                                    "description": "the instrument's serial number",
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "required": [
+                                                                "serial"
+                                                            ],
+                                                            "type": "object"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "sensors": {
+                                                        "description": "sensor readings for the surgical kit",
+                                                        "properties": {
+                                                            "begin": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "currtilt": {
+                                                                "description": "The current tilt that the kit is experiencing",
+                                                                "type": "number"
+                                                            },
+                                                            "end": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "endlocation": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "maxgforce": {
+                                                                "description": "The highest (in Gs) force that the kit experienced during the sample, readings in m/s2 are sent as {\"value\": 19.6, \"unit\": \"m/s2\"}",
+                                                                "type": "number",
+                                                                "unit": "g"
+                                                            },
+                                                            "maxtilt": {
+                                                                "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
+                                                                "type": "number"
+                                                            },
+                                                            "startlocation": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "skitID": {
+                                                        "description": "A surgicalkit's ID",
+                                                        "type": "string"
+                                                    },
+                                                    "status": {
+                                                        "description": "current kit status as a named entity in possession of the kit",
+                                                        "enum": [
+                                                            "",
+                                                            "oem",
+                                                            "warehouse",
+                                                            "dealer",
+                                                            "retailer",
+                                                            "hospital",
+                                                            "scrapped"
+                                                        ],
+                                                        "readOnly": true,
+                                                        "type": "string"
+                                                    },
+                                                    "sterilizations": {
+                                                        "description": "the kit's most recent sterilization cycles, oldest first, appended by sterilizeSurgicalKit",
+                                                        "items": {
+                                                            "description": "one sterilization cycle and its parameters",
+                                                            "properties": {
+                                                                "cycleID": {
+                                                                    "description": "the sterilizer's identifier for the cycle",
+                                                                    "type": "string"
+                                                                },
+                                                                "duration": {
+                                                                    "description": "the exposure time in minutes",
+                                                                    "type": "number"
+                                                                },
+                                                                "method": {
+                                                                    "enum": [
+                                                                        "steam",
+                                                                        "dryheat",
+                                                                        "ethyleneoxide",
+                                                                        "hydrogenperoxide"
+                                                                    ],
+                                                                    "type": "string"
+                                                                },
+                                                                "passed": {
+                                                                    "description": "whether the cycle's indicators passed",
+                                                                    "type": "boolean"
+                                                                },
+                                                                "performed": {
+                                                                    "description": "timestamp of the cycle, the transaction's when absent",
+                                                                    "format": "date-time",
+                                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                    "type": "string"
+                                                                },
+                                                                "pressure": {
+                                                                    "description": "the chamber pressure in kPa",
+                                                                    "type": "number"
+                                                                },
+                                                                "serials": {
+                                                                    "description": "the serial numbers of the instruments in the cycle, all of the kit's instruments when absent",
+                                                                    "items": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "sterilizer": {
+                                                                    "description": "the sterilizer that ran the cycle",
+                                                                    "type": "string"
+                                                                },
+                                                                "temperature": {
+                                                                    "description": "the exposure temperature in degrees Celsius",
+                                                                    "type": "number"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "readOnly": true,
+                                                        "type": "array"
+                                                    },
+                                                    "transit": {
+                                                        "description": "shipping data during transit periods",
+                                                        "properties": {
+                                                            "begintransit": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "carrier": {
+                                                                "type": "string"
+                                                            },
+                                                            "deadline": {
+                                                                "description": "timestamp by which the receiver must confirm the shipment, begintransit plus the shipment window",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "endtransit": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "intransit": {
+                                                                "description": "true from the shipment until the receiver confirms it",
+                                                                "type": "boolean"
+                                                            },
+                                                            "receiver": {
+                                                                "description": "current kit status as a named entity in possession of the kit",
+                                                                "enum": [
+                                                                    "",
+                                                                    "oem",
+                                                                    "warehouse",
+                                                                    "dealer",
+                                                                    "retailer",
+                                                                    "hospital",
+                                                                    "scrapped"
+                                                                ],
+                                                                "type": "string"
+                                                            },
+                                                            "receiverparty": {
+                                                                "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                                "type": "string"
+                                                            },
+                                                            "shipper": {
+                                                                "description": "current kit status as a named entity in possession of the kit",
+                                                                "enum": [
+                                                                    "",
+                                                                    "oem",
+                                                                    "warehouse",
+                                                                    "dealer",
+                                                                    "retailer",
+                                                                    "hospital",
+                                                                    "scrapped"
+                                                                ],
+                                                                "type": "string"
+                                                            },
+                                                            "shipperparty": {
+                                                                "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "readOnly": true,
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "required": [
+                                                    "skitID"
+                                                ],
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "eventout": {
+                                        "description": "The chaincode event emitted on invoke exit, if any",
+                                        "properties": {
+                                            "surgicalkit": {
+                                                "description": "A chaincode event defining the standard platform-generated result event for a contract invoke, contains an array of contributed results",
+                                                "properties": {
+                                                    "name": {
+                                                        "default": "EVT.IOTCP.INVOKE.RESULT",
+                                                        "enum": [
+                                                            "EVT.IOTCP.INVOKE.RESULT"
+                                                        ],
+                                                        "type": "string"
+                                                    },
+                                                    "payload": {
+                                                        "description": "A map of contributed results",
+                                                        "properties": {
+                                                            "description": "the overall status of the invoke result, defined by err",
+                                                            "properties": {
+                                                                "activeAlerts": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "alertsCleared": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "alertsRaised": {
+                                                                    "description": "An array of alert names",
+                                                                    "items": {
+                                                                        "description": "An alert name",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "invokeresult": {
+                                                                    "description": "status: OK==txn succeeded, ERROR==txn failed",
+                                                                    "properties": {
+                                                                        "message": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "status": {
+                                                                            "enum": [
+                                                                                "OK",
+                                                                                "ERROR"
+                                                                            ],
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "notifications": {
+                                                                    "description": "typed notifications queued by rules and routes during the invoke, dropped when the invoke fails",
+                                                                    "items": {
+                                                                        "description": "A typed notification in the invoke result event",
+                                                                        "properties": {
+                                                                            "assetID": {
+                                                                                "type": "string"
+                                                                            },
+                                                                            "assetkey": {
+                                                                                "type": "string"
+                                                                            },
+                                                                            "class": {
+                                                                                "type": "string"
+                                                                            },
+                                                                            "data": {
+                                                                                "description": "data that depends on the type, e.g. {\"alert\": \"OVERTEMP\"} for alertRaised",
+                                                                                "type": "object"
+                                                                            },
+                                                                            "txnts": {
+                                                                                "format": "date-time",
+                                                                                "type": "string"
+                                                                            },
+                                                                            "type": {
+                                                                                "description": "alertRaised, alertCleared, geofenceExit, stateTransition or a contract defined type",
+                                                                                "type": "string"
+                                                                            }
+                                                                        },
+                                                                        "required": [
+                                                                            "type"
+                                                                        ],
+                                                                        "type": "object"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "version": {
+                                                                    "description": "version of the result envelope, decoded by the iotcpevents package",
+                                                                    "type": "integer"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "state": {
+                                        "description": "Properties that have been received or calculated for this surgicalkit",
+                                        "properties": {
+                                            "surgicalkit": {
+                                                "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state, the status, transit, holder, custody, sterilizations and damage properties are changed only by the contract's own routes",
+                                                "properties": {
+                                                    "burst": {
+                                                        "description": "one individual message in a sequenced burst of messages stored in history for testing purposes",
+                                                        "properties": {
+                                                            "burstlength": {
+                                                                "description": "length of this burst",
+                                                                "type": "number"
+                                                            },
+                                                            "burstnum": {
+                                                                "type": "number"
+                                                            },
+                                                            "sequence": {
+                                                                "type": "number"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "common": {
+                                                        "description": "Common properties for all assets",
+                                                        "properties": {
+                                                            "appdata": {
+                                                                "description": "Application managed information as an array of key:value pairs",
+                                                                "items": {
+                                                                    "properties": {
+                                                                        "K": {
+                                                                            "type": "string"
+                                                                        },
+                                                                        "V": {
+                                                                            "type": "string"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "minItems": 0,
+                                                                "type": "array"
+                                                            },
+                                                            "deviceID": {
+                                                                "description": "A unique identifier for the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "devicetimestamp": {
+                                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                                "type": "string"
+                                                            },
+                                                            "location": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "custody": {
+                                                        "description": "the kit's changes of custody, oldest first, appended by receiveSurgicalKit",
+                                                        "items": {
+                                                            "description": "one change of custody, confirmed by the receiving party",
+                                                            "properties": {
+                                                                "carrier": {
+                                                                    "type": "string"
+                                                                },
+                                                                "from": {
+                                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                                    "enum": [
+                                                                        "",
+                                                                        "oem",
+                                                                        "warehouse",
+                                                                        "dealer",
+                                                                        "retailer",
+                                                                        "hospital",
+                                                                        "scrapped"
+                                                                    ],
+                                                                    "type": "string"
+                                                                },
+                                                                "fromparty": {
+                                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                                    "type": "string"
+                                                                },
+                                                                "late": {
+                                                                    "description": "true when the receipt came after the shipment's deadline",
+                                                                    "type": "boolean"
+                                                                },
+                                                                "received": {
+                                                                    "description": "timestamp of the receipt",
+                                                                    "format": "date-time",
+                                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                    "type": "string"
+                                                                },
+                                                                "shipped": {
+                                                                    "description": "timestamp of the shipment",
+                                                                    "format": "date-time",
+                                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                    "type": "string"
+                                                                },
+                                                                "to": {
+                                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                                    "enum": [
+                                                                        "",
+                                                                        "oem",
+                                                                        "warehouse",
+                                                                        "dealer",
+                                                                        "retailer",
+                                                                        "hospital",
+                                                                        "scrapped"
+                                                                    ],
+                                                                    "type": "string"
+                                                                },
+                                                                "toparty": {
+                                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "readOnly": true,
+                                                        "type": "array"
+                                                    },
+                                                    "damage": {
+                                                        "description": "the kit's most recent shocks, tilts and inspections, oldest first, shocks and tilts are recorded from sensor readings beyond the limits and inspections by inspectSurgicalKit, the latest shock and tilt that wait for a passed inspection are kept however old",
+                                                        "items": {
+                                                            "description": "a shock or tilt beyond the kit's limits, or an inspection of the kit",
+                                                            "properties": {
+                                                                "gforce": {
+                                                                    "description": "the force in Gs of a shock",
+                                                                    "type": "number"
+                                                                },
+                                                                "inspector": {
+                                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                                    "type": "string"
+                                                                },
+                                                                "kind": {
+                                                                    "enum": [
+                                                                        "shock",
+                                                                        "tilt",
+                                                                        "inspection"
+                                                                    ],
+                                                                    "type": "string"
+                                                                },
+                                                                "location": {
+                                                                    "description": "A geographical coordinate",
+                                                                    "properties": {
+                                                                        "latitude": {
+                                                                            "type": "number"
+                                                                        },
+                                                                        "longitude": {
+                                                                            "type": "number"
+                                                                        }
+                                                                    },
+                                                                    "type": "object"
+                                                                },
+                                                                "notes": {
+                                                                    "type": "string"
+                                                                },
+                                                                "passed": {
+                                                                    "description": "whether the kit passed an inspection",
+                                                                    "type": "boolean"
+                                                                },
+                                                                "tilt": {
+                                                                    "description": "the tilt in degrees from horizontal of a tilt",
+                                                                    "type": "number"
+                                                                },
+                                                                "timestamp": {
+                                                                    "description": "timestamp of the transaction that reported the event",
+                                                                    "format": "date-time",
+                                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "readOnly": true,
+                                                        "type": "array"
+                                                    },
+                                                    "distanceFromFenceCenter": {
+                                                        "description": "calculated distance in meters from the hospital fence center to the end location, can be compared to the fence radius",
+                                                        "readOnly": true,
+                                                        "type": "number"
+                                                    },
+                                                    "holder": {
+                                                        "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                        "readOnly": true,
+                                                        "type": "string"
+                                                    },
+                                                    "hospital": {
+                                                        "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
+                                                        "properties": {
+                                                            "address": {
+                                                                "properties": {
+                                                                    "city": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "country": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "postcode": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "streetandnumber": {
+                                                                        "type": "string"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "fence": {
+                                                                "properties": {
+                                                                    "center": {
+                                                                        "description": "A geographical coordinate",
+                                                                        "properties": {
+                                                                            "latitude": {
+                                                                                "type": "number"
+                                                                            },
+                                                                            "longitude": {
+                                                                                "type": "number"
+                                                                            }
+                                                                        },
+                                                                        "type": "object"
+                                                                    },
+                                                                    "radius": {
+                                                                        "description": "radius of the fence in meters, readings in other units are sent as {\"value\": 0.5, \"unit\": \"km\"}",
+                                                                        "type": "number",
+                                                                        "unit": "m"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "name": {
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "instruments": {
+                                                        "description": "the instruments in the kit, an update replaces those with the same serial and adds the others",
+                                                        "items": {
+                                                            "description": "a surgical instrument in the kit, identified by its serial number",
+                                                            "properties": {
+                                                                "cycles": {
+                                                                    "description": "the sterilization cycles that the instrument has been through, counted by sterilizeSurgicalKit",
+                                                                    "readOnly": true,
+                                                                    "type": "integer"
+                                                                },
+                                                                "description": {
+                                                                    "type": "string"
+                                                                },
+                                                                "lot": {
+                                                                    "description": "the manufacturing lot of the instrument, which recalls name",
+                                                                    "type": "string"
+                                                                },
+                                                                "maxcycles": {
+                                                                    "description": "the sterilization cycles that the instrument is rated for, no limit when absent",
+                                                                    "type": "integer"
+                                                                },
+                                                                "serial": {
+                                                                    "description": "the instrument's serial number",
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "required": [
+                                                                "serial"
+                                                            ],
+                                                            "type": "object"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "sensors": {
+                                                        "description": "sensor readings for the surgical kit",
+                                                        "properties": {
+                                                            "begin": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "currtilt": {
+                                                                "description": "The current tilt that the kit is experiencing",
+                                                                "type": "number"
+                                                            },
+                                                            "end": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "endlocation": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            },
+                                                            "maxgforce": {
+                                                                "description": "The highest (in Gs) force that the kit experienced during the sample, readings in m/s2 are sent as {\"value\": 19.6, \"unit\": \"m/s2\"}",
+                                                                "type": "number",
+                                                                "unit": "g"
+                                                            },
+                                                            "maxtilt": {
+                                                                "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
+                                                                "type": "number"
+                                                            },
+                                                            "startlocation": {
+                                                                "description": "A geographical coordinate",
+                                                                "properties": {
+                                                                    "latitude": {
+                                                                        "type": "number"
+                                                                    },
+                                                                    "longitude": {
+                                                                        "type": "number"
+                                                                    }
+                                                                },
+                                                                "type": "object"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "skitID": {
+                                                        "description": "A surgicalkit's ID",
+                                                        "type": "string"
+                                                    },
+                                                    "status": {
+                                                        "description": "current kit status as a named entity in possession of the kit",
+                                                        "enum": [
+                                                            "",
+                                                            "oem",
+                                                            "warehouse",
+                                                            "dealer",
+                                                            "retailer",
+                                                            "hospital",
+                                                            "scrapped"
+                                                        ],
+                                                        "readOnly": true,
+                                                        "type": "string"
+                                                    },
+                                                    "sterilizations": {
+                                                        "description": "the kit's most recent sterilization cycles, oldest first, appended by sterilizeSurgicalKit",
+                                                        "items": {
+                                                            "description": "one sterilization cycle and its parameters",
+                                                            "properties": {
+                                                                "cycleID": {
+                                                                    "description": "the sterilizer's identifier for the cycle",
+                                                                    "type": "string"
+                                                                },
+                                                                "duration": {
+                                                                    "description": "the exposure time in minutes",
+                                                                    "type": "number"
+                                                                },
+                                                                "method": {
+                                                                    "enum": [
+                                                                        "steam",
+                                                                        "dryheat",
+                                                                        "ethyleneoxide",
+                                                                        "hydrogenperoxide"
+                                                                    ],
+                                                                    "type": "string"
+                                                                },
+                                                                "passed": {
+                                                                    "description": "whether the cycle's indicators passed",
+                                                                    "type": "boolean"
+                                                                },
+                                                                "performed": {
+                                                                    "description": "timestamp of the cycle, the transaction's when absent",
+                                                                    "format": "date-time",
+                                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                    "type": "string"
+                                                                },
+                                                                "pressure": {
+                                                                    "description": "the chamber pressure in kPa",
+                                                                    "type": "number"
+                                                                },
+                                                                "serials": {
+                                                                    "description": "the serial numbers of the instruments in the cycle, all of the kit's instruments when absent",
+                                                                    "items": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": "array"
+                                                                },
+                                                                "sterilizer": {
+                                                                    "description": "the sterilizer that ran the cycle",
+                                                                    "type": "string"
+                                                                },
+                                                                "temperature": {
+                                                                    "description": "the exposure temperature in degrees Celsius",
+                                                                    "type": "number"
+                                                                }
+                                                            },
+                                                            "type": "object"
+                                                        },
+                                                        "readOnly": true,
+                                                        "type": "array"
+                                                    },
+                                                    "transit": {
+                                                        "description": "shipping data during transit periods",
+                                                        "properties": {
+                                                            "begintransit": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "carrier": {
+                                                                "type": "string"
+                                                            },
+                                                            "deadline": {
+                                                                "description": "timestamp by which the receiver must confirm the shipment, begintransit plus the shipment window",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "endtransit": {
+                                                                "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
+                                                                "format": "date-time",
+                                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                                "type": "string"
+                                                            },
+                                                            "intransit": {
+                                                                "description": "true from the shipment until the receiver confirms it",
+                                                                "type": "boolean"
+                                                            },
+                                                            "receiver": {
+                                                                "description": "current kit status as a named entity in possession of the kit",
+                                                                "enum": [
+                                                                    "",
+                                                                    "oem",
+                                                                    "warehouse",
+                                                                    "dealer",
+                                                                    "retailer",
+                                                                    "hospital",
+                                                                    "scrapped"
+                                                                ],
+                                                                "type": "string"
+                                                            },
+                                                            "receiverparty": {
+                                                                "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                                "type": "string"
+                                                            },
+                                                            "shipper": {
+                                                                "description": "current kit status as a named entity in possession of the kit",
+                                                                "enum": [
+                                                                    "",
+                                                                    "oem",
+                                                                    "warehouse",
+                                                                    "dealer",
+                                                                    "retailer",
+                                                                    "hospital",
+                                                                    "scrapped"
+                                                                ],
+                                                                "type": "string"
+                                                            },
+                                                            "shipperparty": {
+                                                                "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "readOnly": true,
+                                                        "type": "object"
+                                                    }
+                                                },
+                                                "required": [
+                                                    "skitID"
+                                                ],
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "txnid": {
+                                        "description": "Transaction UUID matching the blockchain",
                                         "type": "string"
                                     },
-                                    "select": {
-                                        "description": "Qualified property names and values match",
-                                        "items": {
-                                            "properties": {
-                                                "qprop": {
-                                                    "description": "Qualified property to compare, for example 'asset.assetID'",
-                                                    "type": "string"
-                                                },
-                                                "value": {
-                                                    "description": "Value to be compared",
-                                                    "type": "string"
-                                                }
-                                            },
-                                            "type": "object"
-                                        },
-                                        "type": "array"
+                                    "txnts": {
+                                        "description": "Transaction timestamp matching the blockchain",
+                                        "type": "string"
                                     }
                                 },
                                 "type": "object"
                             }
                         },
-                        "required": [
-                            "surgicalkit"
-                        ],
-                        "type": "object"
-                    },
-                    "maxItems": 1,
-                    "minItems": 1,
-                    "type": "array"
-                },
-                "function": {
-                    "enum": [
-                        "readAssetStateHistorySurgicalKit"
-                    ],
-                    "type": "string"
-                },
-                "method": "query",
-                "result": {
-                    "description": "Array of surgicalkit states, can mix asset classes",
-                    "items": {
-                        "patternProperties": {
-                            "^CON": {
-                                "description": "The external state of one surgicalkit asset, named by its world state ID",
-                                "type": "object"
-                            }
-                        },
                         "type": "object"
                     },
                     "minItems": 0,
@@ -837,7 +3387,7 @@ var schemas = `
                             "description": "The contract event that created this state, for example updateAssetSurgicalKit",
                             "properties": {
                                 "surgicalkit": {
-                                    "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
+                                    "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state, the status, transit, holder, custody, sterilizations and damage properties are changed only by the contract's own routes",
                                     "properties": {
                                         "burst": {
                                             "description": "one individual message in a sequenced burst of messages stored in history for testing purposes",
@@ -862,40 +3412,170 @@ var schemas = `
                                                     "description": "Application managed information as an array of key:value pairs",
                                                     "items": {
                                                         "properties": {
-                                                            "K": {
-                                                                "type": "string"
+                                                            "K": {
+                                                                "type": "string"
+                                                            },
+                                                            "V": {
+                                                                "type": "string"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "minItems": 0,
+                                                    "type": "array"
+                                                },
+                                                "deviceID": {
+                                                    "description": "A unique identifier for the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "devicetimestamp": {
+                                                    "description": "A timestamp recoded by the device that sent the current event",
+                                                    "type": "string"
+                                                },
+                                                "location": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "custody": {
+                                            "description": "the kit's changes of custody, oldest first, appended by receiveSurgicalKit",
+                                            "items": {
+                                                "description": "one change of custody, confirmed by the receiving party",
+                                                "properties": {
+                                                    "carrier": {
+                                                        "type": "string"
+                                                    },
+                                                    "from": {
+                                                        "description": "current kit status as a named entity in possession of the kit",
+                                                        "enum": [
+                                                            "",
+                                                            "oem",
+                                                            "warehouse",
+                                                            "dealer",
+                                                            "retailer",
+                                                            "hospital",
+                                                            "scrapped"
+                                                        ],
+                                                        "type": "string"
+                                                    },
+                                                    "fromparty": {
+                                                        "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                        "type": "string"
+                                                    },
+                                                    "late": {
+                                                        "description": "true when the receipt came after the shipment's deadline",
+                                                        "type": "boolean"
+                                                    },
+                                                    "received": {
+                                                        "description": "timestamp of the receipt",
+                                                        "format": "date-time",
+                                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                                        "type": "string"
+                                                    },
+                                                    "shipped": {
+                                                        "description": "timestamp of the shipment",
+                                                        "format": "date-time",
+                                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                                        "type": "string"
+                                                    },
+                                                    "to": {
+                                                        "description": "current kit status as a named entity in possession of the kit",
+                                                        "enum": [
+                                                            "",
+                                                            "oem",
+                                                            "warehouse",
+                                                            "dealer",
+                                                            "retailer",
+                                                            "hospital",
+                                                            "scrapped"
+                                                        ],
+                                                        "type": "string"
+                                                    },
+                                                    "toparty": {
+                                                        "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                        "type": "string"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "readOnly": true,
+                                            "type": "array"
+                                        },
+                                        "damage": {
+                                            "description": "the kit's most recent shocks, tilts and inspections, oldest first, shocks and tilts are recorded from sensor readings beyond the limits and inspections by inspectSurgicalKit, the latest shock and tilt that wait for a passed inspection are kept however old",
+                                            "items": {
+                                                "description": "a shock or tilt beyond the kit's limits, or an inspection of the kit",
+                                                "properties": {
+                                                    "gforce": {
+                                                        "description": "the force in Gs of a shock",
+                                                        "type": "number"
+                                                    },
+                                                    "inspector": {
+                                                        "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                        "type": "string"
+                                                    },
+                                                    "kind": {
+                                                        "enum": [
+                                                            "shock",
+                                                            "tilt",
+                                                            "inspection"
+                                                        ],
+                                                        "type": "string"
+                                                    },
+                                                    "location": {
+                                                        "description": "A geographical coordinate",
+                                                        "properties": {
+                                                            "latitude": {
+                                                                "type": "number"
                                                             },
-                                                            "V": {
-                                                                "type": "string"
+                                                            "longitude": {
+                                                                "type": "number"
                                                             }
                                                         },
                                                         "type": "object"
                                                     },
-                                                    "minItems": 0,
-                                                    "type": "array"
-                                                },
-                                                "deviceID": {
-                                                    "description": "A unique identifier for the device that sent the current event",
-                                                    "type": "string"
-                                                },
-                                                "devicetimestamp": {
-                                                    "description": "A timestamp recoded by the device that sent the current event",
-                                                    "type": "string"
-                                                },
-                                                "location": {
-                                                    "description": "A geographical coordinate",
-                                                    "properties": {
-                                                        "latitude": {
-                                                            "type": "number"
-                                                        },
-                                                        "longitude": {
-                                                            "type": "number"
-                                                        }
+                                                    "notes": {
+                                                        "type": "string"
                                                     },
-                                                    "type": "object"
-                                                }
+                                                    "passed": {
+                                                        "description": "whether the kit passed an inspection",
+                                                        "type": "boolean"
+                                                    },
+                                                    "tilt": {
+                                                        "description": "the tilt in degrees from horizontal of a tilt",
+                                                        "type": "number"
+                                                    },
+                                                    "timestamp": {
+                                                        "description": "timestamp of the transaction that reported the event",
+                                                        "format": "date-time",
+                                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                                        "type": "string"
+                                                    }
+                                                },
+                                                "type": "object"
                                             },
-                                            "type": "object"
+                                            "readOnly": true,
+                                            "type": "array"
+                                        },
+                                        "distanceFromFenceCenter": {
+                                            "description": "calculated distance in meters from the hospital fence center to the end location, can be compared to the fence radius",
+                                            "readOnly": true,
+                                            "type": "number"
+                                        },
+                                        "holder": {
+                                            "description": "the organization that holds or handles a kit, e.g. a company name",
+                                            "readOnly": true,
+                                            "type": "string"
                                         },
                                         "hospital": {
                                             "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
@@ -932,7 +3612,9 @@ var schemas = `
                                                             "type": "object"
                                                         },
                                                         "radius": {
-                                                            "type": "number"
+                                                            "description": "radius of the fence in meters, readings in other units are sent as {\"value\": 0.5, \"unit\": \"km\"}",
+                                                            "type": "number",
+                                                            "unit": "m"
                                                         }
                                                     },
                                                     "type": "object"
@@ -943,6 +3625,39 @@ var schemas = `
                                             },
                                             "type": "object"
                                         },
+                                        "instruments": {
+                                            "description": "the instruments in the kit, an update replaces those with the same serial and adds the others",
+                                            "items": {
+                                                "description": "a surgical instrument in the kit, identified by its serial number",
+                                                "properties": {
+                                                    "cycles": {
+                                                        "description": "the sterilization cycles that the instrument has been through, counted by sterilizeSurgicalKit",
+                                                        "readOnly": true,
+                                                        "type": "integer"
+                                                    },
+                                                    "description": {
+                                                        "type": "string"
+                                                    },
+                                                    "lot": {
+                                                        "description": "the manufacturing lot of the instrument, which recalls name",
+                                                        "type": "string"
+                                                    },
+                                                    "maxcycles": {
+                                                        "description": "the sterilization cycles that the instrument is rated for, no limit when absent",
+                                                        "type": "integer"
+                                                    },
+                                                    "serial": {
+                                                        "description": "the instrument's serial number",
+                                                        "type": "string"
+                                                    }
+                                                },
+                                                "required": [
+                                                    "serial"
+                                                ],
+                                                "type": "object"
+                                            },
+                                            "type": "array"
+                                        },
                                         "sensors": {
                                             "description": "sensor readings for the surgical kit",
                                             "properties": {
@@ -975,8 +3690,9 @@ var schemas = `
                                                     "type": "object"
                                                 },
                                                 "maxgforce": {
-                                                    "description": "The highest (in Gs) force that the kit experienced during the sample",
-                                                    "type": "number"
+                                                    "description": "The highest (in Gs) force that the kit experienced during the sample, readings in m/s2 are sent as {\"value\": 19.6, \"unit\": \"m/s2\"}",
+                                                    "type": "number",
+                                                    "unit": "g"
                                                 },
                                                 "maxtilt": {
                                                     "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
@@ -1012,8 +3728,66 @@ var schemas = `
                                                 "hospital",
                                                 "scrapped"
                                             ],
+                                            "readOnly": true,
                                             "type": "string"
                                         },
+                                        "sterilizations": {
+                                            "description": "the kit's most recent sterilization cycles, oldest first, appended by sterilizeSurgicalKit",
+                                            "items": {
+                                                "description": "one sterilization cycle and its parameters",
+                                                "properties": {
+                                                    "cycleID": {
+                                                        "description": "the sterilizer's identifier for the cycle",
+                                                        "type": "string"
+                                                    },
+                                                    "duration": {
+                                                        "description": "the exposure time in minutes",
+                                                        "type": "number"
+                                                    },
+                                                    "method": {
+                                                        "enum": [
+                                                            "steam",
+                                                            "dryheat",
+                                                            "ethyleneoxide",
+                                                            "hydrogenperoxide"
+                                                        ],
+                                                        "type": "string"
+                                                    },
+                                                    "passed": {
+                                                        "description": "whether the cycle's indicators passed",
+                                                        "type": "boolean"
+                                                    },
+                                                    "performed": {
+                                                        "description": "timestamp of the cycle, the transaction's when absent",
+                                                        "format": "date-time",
+                                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                                        "type": "string"
+                                                    },
+                                                    "pressure": {
+                                                        "description": "the chamber pressure in kPa",
+                                                        "type": "number"
+                                                    },
+                                                    "serials": {
+                                                        "description": "the serial numbers of the instruments in the cycle, all of the kit's instruments when absent",
+                                                        "items": {
+                                                            "type": "string"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "sterilizer": {
+                                                        "description": "the sterilizer that ran the cycle",
+                                                        "type": "string"
+                                                    },
+                                                    "temperature": {
+                                                        "description": "the exposure temperature in degrees Celsius",
+                                                        "type": "number"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "readOnly": true,
+                                            "type": "array"
+                                        },
                                         "transit": {
                                             "description": "shipping data during transit periods",
                                             "properties": {
@@ -1026,12 +3800,22 @@ var schemas = `
                                                 "carrier": {
                                                     "type": "string"
                                                 },
+                                                "deadline": {
+                                                    "description": "timestamp by which the receiver must confirm the shipment, begintransit plus the shipment window",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
                                                 "endtransit": {
                                                     "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
                                                     "format": "date-time",
                                                     "sample": "yyyy-mm-dd hh:mm:ss",
                                                     "type": "string"
                                                 },
+                                                "intransit": {
+                                                    "description": "true from the shipment until the receiver confirms it",
+                                                    "type": "boolean"
+                                                },
                                                 "receiver": {
                                                     "description": "current kit status as a named entity in possession of the kit",
                                                     "enum": [
@@ -1045,6 +3829,10 @@ var schemas = `
                                                     ],
                                                     "type": "string"
                                                 },
+                                                "receiverparty": {
+                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                    "type": "string"
+                                                },
                                                 "shipper": {
                                                     "description": "current kit status as a named entity in possession of the kit",
                                                     "enum": [
@@ -1057,8 +3845,13 @@ var schemas = `
                                                         "scrapped"
                                                     ],
                                                     "type": "string"
+                                                },
+                                                "shipperparty": {
+                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                    "type": "string"
                                                 }
                                             },
+                                            "readOnly": true,
                                             "type": "object"
                                         }
                                     },
@@ -1127,6 +3920,44 @@ var schemas = `
                                                             }
                                                         },
                                                         "type": "object"
+                                                    },
+                                                    "notifications": {
+                                                        "description": "typed notifications queued by rules and routes during the invoke, dropped when the invoke fails",
+                                                        "items": {
+                                                            "description": "A typed notification in the invoke result event",
+                                                            "properties": {
+                                                                "assetID": {
+                                                                    "type": "string"
+                                                                },
+                                                                "assetkey": {
+                                                                    "type": "string"
+                                                                },
+                                                                "class": {
+                                                                    "type": "string"
+                                                                },
+                                                                "data": {
+                                                                    "description": "data that depends on the type, e.g. {\"alert\": \"OVERTEMP\"} for alertRaised",
+                                                                    "type": "object"
+                                                                },
+                                                                "txnts": {
+                                                                    "format": "date-time",
+                                                                    "type": "string"
+                                                                },
+                                                                "type": {
+                                                                    "description": "alertRaised, alertCleared, geofenceExit, stateTransition or a contract defined type",
+                                                                    "type": "string"
+                                                                }
+                                                            },
+                                                            "required": [
+                                                                "type"
+                                                            ],
+                                                            "type": "object"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "version": {
+                                                        "description": "version of the result envelope, decoded by the iotcpevents package",
+                                                        "type": "integer"
                                                     }
                                                 },
                                                 "type": "object"
@@ -1142,12 +3973,8 @@ var schemas = `
                         "state": {
                             "description": "Properties that have been received or calculated for this surgicalkit",
                             "properties": {
-                                "distanceFromCenter": {
-                                    "description": "calculated distance from the fence center, can be compared to fence radius",
-                                    "type": "number"
-                                },
                                 "surgicalkit": {
-                                    "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
+                                    "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state, the status, transit, holder, custody, sterilizations and damage properties are changed only by the contract's own routes",
                                     "properties": {
                                         "burst": {
                                             "description": "one individual message in a sequenced burst of messages stored in history for testing purposes",
@@ -1207,6 +4034,136 @@ var schemas = `
                                             },
                                             "type": "object"
                                         },
+                                        "custody": {
+                                            "description": "the kit's changes of custody, oldest first, appended by receiveSurgicalKit",
+                                            "items": {
+                                                "description": "one change of custody, confirmed by the receiving party",
+                                                "properties": {
+                                                    "carrier": {
+                                                        "type": "string"
+                                                    },
+                                                    "from": {
+                                                        "description": "current kit status as a named entity in possession of the kit",
+                                                        "enum": [
+                                                            "",
+                                                            "oem",
+                                                            "warehouse",
+                                                            "dealer",
+                                                            "retailer",
+                                                            "hospital",
+                                                            "scrapped"
+                                                        ],
+                                                        "type": "string"
+                                                    },
+                                                    "fromparty": {
+                                                        "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                        "type": "string"
+                                                    },
+                                                    "late": {
+                                                        "description": "true when the receipt came after the shipment's deadline",
+                                                        "type": "boolean"
+                                                    },
+                                                    "received": {
+                                                        "description": "timestamp of the receipt",
+                                                        "format": "date-time",
+                                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                                        "type": "string"
+                                                    },
+                                                    "shipped": {
+                                                        "description": "timestamp of the shipment",
+                                                        "format": "date-time",
+                                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                                        "type": "string"
+                                                    },
+                                                    "to": {
+                                                        "description": "current kit status as a named entity in possession of the kit",
+                                                        "enum": [
+                                                            "",
+                                                            "oem",
+                                                            "warehouse",
+                                                            "dealer",
+                                                            "retailer",
+                                                            "hospital",
+                                                            "scrapped"
+                                                        ],
+                                                        "type": "string"
+                                                    },
+                                                    "toparty": {
+                                                        "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                        "type": "string"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "readOnly": true,
+                                            "type": "array"
+                                        },
+                                        "damage": {
+                                            "description": "the kit's most recent shocks, tilts and inspections, oldest first, shocks and tilts are recorded from sensor readings beyond the limits and inspections by inspectSurgicalKit, the latest shock and tilt that wait for a passed inspection are kept however old",
+                                            "items": {
+                                                "description": "a shock or tilt beyond the kit's limits, or an inspection of the kit",
+                                                "properties": {
+                                                    "gforce": {
+                                                        "description": "the force in Gs of a shock",
+                                                        "type": "number"
+                                                    },
+                                                    "inspector": {
+                                                        "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                        "type": "string"
+                                                    },
+                                                    "kind": {
+                                                        "enum": [
+                                                            "shock",
+                                                            "tilt",
+                                                            "inspection"
+                                                        ],
+                                                        "type": "string"
+                                                    },
+                                                    "location": {
+                                                        "description": "A geographical coordinate",
+                                                        "properties": {
+                                                            "latitude": {
+                                                                "type": "number"
+                                                            },
+                                                            "longitude": {
+                                                                "type": "number"
+                                                            }
+                                                        },
+                                                        "type": "object"
+                                                    },
+                                                    "notes": {
+                                                        "type": "string"
+                                                    },
+                                                    "passed": {
+                                                        "description": "whether the kit passed an inspection",
+                                                        "type": "boolean"
+                                                    },
+                                                    "tilt": {
+                                                        "description": "the tilt in degrees from horizontal of a tilt",
+                                                        "type": "number"
+                                                    },
+                                                    "timestamp": {
+                                                        "description": "timestamp of the transaction that reported the event",
+                                                        "format": "date-time",
+                                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                                        "type": "string"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "readOnly": true,
+                                            "type": "array"
+                                        },
+                                        "distanceFromFenceCenter": {
+                                            "description": "calculated distance in meters from the hospital fence center to the end location, can be compared to the fence radius",
+                                            "readOnly": true,
+                                            "type": "number"
+                                        },
+                                        "holder": {
+                                            "description": "the organization that holds or handles a kit, e.g. a company name",
+                                            "readOnly": true,
+                                            "type": "string"
+                                        },
                                         "hospital": {
                                             "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
                                             "properties": {
@@ -1242,7 +4199,9 @@ var schemas = `
                                                             "type": "object"
                                                         },
                                                         "radius": {
-                                                            "type": "number"
+                                                            "description": "radius of the fence in meters, readings in other units are sent as {\"value\": 0.5, \"unit\": \"km\"}",
+                                                            "type": "number",
+                                                            "unit": "m"
                                                         }
                                                     },
                                                     "type": "object"
@@ -1253,6 +4212,39 @@ var schemas = `
                                             },
                                             "type": "object"
                                         },
+                                        "instruments": {
+                                            "description": "the instruments in the kit, an update replaces those with the same serial and adds the others",
+                                            "items": {
+                                                "description": "a surgical instrument in the kit, identified by its serial number",
+                                                "properties": {
+                                                    "cycles": {
+                                                        "description": "the sterilization cycles that the instrument has been through, counted by sterilizeSurgicalKit",
+                                                        "readOnly": true,
+                                                        "type": "integer"
+                                                    },
+                                                    "description": {
+                                                        "type": "string"
+                                                    },
+                                                    "lot": {
+                                                        "description": "the manufacturing lot of the instrument, which recalls name",
+                                                        "type": "string"
+                                                    },
+                                                    "maxcycles": {
+                                                        "description": "the sterilization cycles that the instrument is rated for, no limit when absent",
+                                                        "type": "integer"
+                                                    },
+                                                    "serial": {
+                                                        "description": "the instrument's serial number",
+                                                        "type": "string"
+                                                    }
+                                                },
+                                                "required": [
+                                                    "serial"
+                                                ],
+                                                "type": "object"
+                                            },
+                                            "type": "array"
+                                        },
                                         "sensors": {
                                             "description": "sensor readings for the surgical kit",
                                             "properties": {
@@ -1285,8 +4277,9 @@ var schemas = `
                                                     "type": "object"
                                                 },
                                                 "maxgforce": {
-                                                    "description": "The highest (in Gs) force that the kit experienced during the sample",
-                                                    "type": "number"
+                                                    "description": "The highest (in Gs) force that the kit experienced during the sample, readings in m/s2 are sent as {\"value\": 19.6, \"unit\": \"m/s2\"}",
+                                                    "type": "number",
+                                                    "unit": "g"
                                                 },
                                                 "maxtilt": {
                                                     "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
@@ -1322,8 +4315,66 @@ var schemas = `
                                                 "hospital",
                                                 "scrapped"
                                             ],
+                                            "readOnly": true,
                                             "type": "string"
                                         },
+                                        "sterilizations": {
+                                            "description": "the kit's most recent sterilization cycles, oldest first, appended by sterilizeSurgicalKit",
+                                            "items": {
+                                                "description": "one sterilization cycle and its parameters",
+                                                "properties": {
+                                                    "cycleID": {
+                                                        "description": "the sterilizer's identifier for the cycle",
+                                                        "type": "string"
+                                                    },
+                                                    "duration": {
+                                                        "description": "the exposure time in minutes",
+                                                        "type": "number"
+                                                    },
+                                                    "method": {
+                                                        "enum": [
+                                                            "steam",
+                                                            "dryheat",
+                                                            "ethyleneoxide",
+                                                            "hydrogenperoxide"
+                                                        ],
+                                                        "type": "string"
+                                                    },
+                                                    "passed": {
+                                                        "description": "whether the cycle's indicators passed",
+                                                        "type": "boolean"
+                                                    },
+                                                    "performed": {
+                                                        "description": "timestamp of the cycle, the transaction's when absent",
+                                                        "format": "date-time",
+                                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                                        "type": "string"
+                                                    },
+                                                    "pressure": {
+                                                        "description": "the chamber pressure in kPa",
+                                                        "type": "number"
+                                                    },
+                                                    "serials": {
+                                                        "description": "the serial numbers of the instruments in the cycle, all of the kit's instruments when absent",
+                                                        "items": {
+                                                            "type": "string"
+                                                        },
+                                                        "type": "array"
+                                                    },
+                                                    "sterilizer": {
+                                                        "description": "the sterilizer that ran the cycle",
+                                                        "type": "string"
+                                                    },
+                                                    "temperature": {
+                                                        "description": "the exposure temperature in degrees Celsius",
+                                                        "type": "number"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            },
+                                            "readOnly": true,
+                                            "type": "array"
+                                        },
                                         "transit": {
                                             "description": "shipping data during transit periods",
                                             "properties": {
@@ -1336,12 +4387,22 @@ var schemas = `
                                                 "carrier": {
                                                     "type": "string"
                                                 },
+                                                "deadline": {
+                                                    "description": "timestamp by which the receiver must confirm the shipment, begintransit plus the shipment window",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
                                                 "endtransit": {
                                                     "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
                                                     "format": "date-time",
                                                     "sample": "yyyy-mm-dd hh:mm:ss",
                                                     "type": "string"
                                                 },
+                                                "intransit": {
+                                                    "description": "true from the shipment until the receiver confirms it",
+                                                    "type": "boolean"
+                                                },
                                                 "receiver": {
                                                     "description": "current kit status as a named entity in possession of the kit",
                                                     "enum": [
@@ -1355,6 +4416,10 @@ var schemas = `
                                                     ],
                                                     "type": "string"
                                                 },
+                                                "receiverparty": {
+                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                    "type": "string"
+                                                },
                                                 "shipper": {
                                                     "description": "current kit status as a named entity in possession of the kit",
                                                     "enum": [
@@ -1367,25 +4432,136 @@ var schemas = `
                                                         "scrapped"
                                                     ],
                                                     "type": "string"
+                                                },
+                                                "shipperparty": {
+                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                    "type": "string"
                                                 }
                                             },
-                                            "type": "object"
-                                        }
+                                            "readOnly": true,
+                                            "type": "object"
+                                        }
+                                    },
+                                    "required": [
+                                        "skitID"
+                                    ],
+                                    "type": "object"
+                                }
+                            },
+                            "type": "object"
+                        },
+                        "txnid": {
+                            "description": "Transaction UUID matching the blockchain",
+                            "type": "string"
+                        },
+                        "txnts": {
+                            "description": "Transaction timestamp matching the blockchain",
+                            "type": "string"
+                        }
+                    },
+                    "type": "object"
+                }
+            },
+            "type": "object"
+        },
+        "readDamageTimelineSurgicalKit": {
+            "description": "Returns the shocks, tilts and inspections of a surgicalkit with the alerts that wait for an inspection",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "surgicalkit": {
+                                "properties": {
+                                    "skitID": {
+                                        "description": "A surgicalkit's ID",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            }
+                        },
+                        "required": [
+                            "surgicalkit"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "readDamageTimelineSurgicalKit"
+                    ],
+                    "type": "string"
+                },
+                "method": "query",
+                "result": {
+                    "description": "the damage events of a kit, oldest first, and the alerts that they hold active",
+                    "properties": {
+                        "damage": {
+                            "items": {
+                                "description": "a shock or tilt beyond the kit's limits, or an inspection of the kit",
+                                "properties": {
+                                    "gforce": {
+                                        "description": "the force in Gs of a shock",
+                                        "type": "number"
+                                    },
+                                    "inspector": {
+                                        "description": "the organization that holds or handles a kit, e.g. a company name",
+                                        "type": "string"
+                                    },
+                                    "kind": {
+                                        "enum": [
+                                            "shock",
+                                            "tilt",
+                                            "inspection"
+                                        ],
+                                        "type": "string"
+                                    },
+                                    "location": {
+                                        "description": "A geographical coordinate",
+                                        "properties": {
+                                            "latitude": {
+                                                "type": "number"
+                                            },
+                                            "longitude": {
+                                                "type": "number"
+                                            }
+                                        },
+                                        "type": "object"
                                     },
-                                    "required": [
-                                        "skitID"
-                                    ],
-                                    "type": "object"
-                                }
+                                    "notes": {
+                                        "type": "string"
+                                    },
+                                    "passed": {
+                                        "description": "whether the kit passed an inspection",
+                                        "type": "boolean"
+                                    },
+                                    "tilt": {
+                                        "description": "the tilt in degrees from horizontal of a tilt",
+                                        "type": "number"
+                                    },
+                                    "timestamp": {
+                                        "description": "timestamp of the transaction that reported the event",
+                                        "format": "date-time",
+                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
                             },
-                            "type": "object"
+                            "type": "array"
                         },
-                        "txnid": {
-                            "description": "Transaction UUID matching the blockchain",
-                            "type": "string"
+                        "latched": {
+                            "description": "the EXCESSFORCE and EXCESSTILT alerts that wait for a passed inspection",
+                            "items": {
+                                "type": "string"
+                            },
+                            "type": "array"
                         },
-                        "txnts": {
-                            "description": "Transaction timestamp matching the blockchain",
+                        "skitID": {
+                            "description": "A surgicalkit's ID",
                             "type": "string"
                         }
                     },
@@ -1395,7 +4571,7 @@ var schemas = `
             "type": "object"
         },
         "readRecentStates": {
-            "description": "Returns the state of recently updated assets",
+            "description": "Returns the state of recently updated assets for one class, or for all classes merged newest first",
             "properties": {
                 "args": {
                     "items": {
@@ -1404,6 +4580,10 @@ var schemas = `
                                 "description": "zero based beginning of range",
                                 "type": "integer"
                             },
+                            "class": {
+                                "description": "asset class name, absence means all classes",
+                                "type": "string"
+                            },
                             "end": {
                                 "description": "zero based end of range, absence means to end",
                                 "type": "integer"
@@ -1411,7 +4591,7 @@ var schemas = `
                         },
                         "type": "object"
                     },
-                    "maxItems": 0,
+                    "maxItems": 1,
                     "minItems": 0,
                     "type": "array"
                 },
@@ -1578,6 +4758,44 @@ var schemas = `
                                                                 }
                                                             },
                                                             "type": "object"
+                                                        },
+                                                        "notifications": {
+                                                            "description": "typed notifications queued by rules and routes during the invoke, dropped when the invoke fails",
+                                                            "items": {
+                                                                "description": "A typed notification in the invoke result event",
+                                                                "properties": {
+                                                                    "assetID": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "assetkey": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "class": {
+                                                                        "type": "string"
+                                                                    },
+                                                                    "data": {
+                                                                        "description": "data that depends on the type, e.g. {\"alert\": \"OVERTEMP\"} for alertRaised",
+                                                                        "type": "object"
+                                                                    },
+                                                                    "txnts": {
+                                                                        "format": "date-time",
+                                                                        "type": "string"
+                                                                    },
+                                                                    "type": {
+                                                                        "description": "alertRaised, alertCleared, geofenceExit, stateTransition or a contract defined type",
+                                                                        "type": "string"
+                                                                    }
+                                                                },
+                                                                "required": [
+                                                                    "type"
+                                                                ],
+                                                                "type": "object"
+                                                            },
+                                                            "type": "array"
+                                                        },
+                                                        "version": {
+                                                            "description": "version of the result envelope, decoded by the iotcpevents package",
+                                                            "type": "integer"
                                                         }
                                                     },
                                                     "type": "object"
@@ -1590,6 +4808,39 @@ var schemas = `
                                 },
                                 "type": "object"
                             },
+                            "eventreadings": {
+                                "additionalProperties": {
+                                    "description": "A reading with its unit, which can be sent in an event in place of a number for any property that has a unit",
+                                    "properties": {
+                                        "unit": {
+                                            "description": "A unit of measure for a reading",
+                                            "enum": [
+                                                "C",
+                                                "F",
+                                                "K",
+                                                "m",
+                                                "km",
+                                                "mi",
+                                                "ft",
+                                                "g",
+                                                "m/s2",
+                                                "m/s²"
+                                            ],
+                                            "type": "string"
+                                        },
+                                        "value": {
+                                            "type": "number"
+                                        }
+                                    },
+                                    "required": [
+                                        "value",
+                                        "unit"
+                                    ],
+                                    "type": "object"
+                                },
+                                "description": "The original value and unit of each reading in the event that was converted to the class's unit, by qualified property name",
+                                "type": "object"
+                            },
                             "state": {
                                 "description": "Properties that have been received or calculated for this asset",
                                 "properties": {
@@ -1699,6 +4950,84 @@ var schemas = `
             },
             "type": "object"
         },
+        "recallSurgicalKits": {
+            "description": "Recalls an instrument lot, every surgicalkit with an instrument of the lot raises the RECALLED alert and is no longer compliant, the kits with their custodian and location are reported in the invoke's result event as recall",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "lot": {
+                                "description": "the manufacturing lot of the recalled instruments",
+                                "type": "string"
+                            }
+                        },
+                        "required": [
+                            "lot"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "recallSurgicalKits"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
+        "receiveSurgicalKit": {
+            "description": "Completes the handoff of a surgicalkit, the receiving party becomes its holder and the custody change is recorded",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "receipt": {
+                                "description": "a receiver's confirmation that a shipped kit has arrived",
+                                "properties": {
+                                    "party": {
+                                        "description": "the organization that holds or handles a kit, e.g. a company name",
+                                        "type": "string"
+                                    }
+                                },
+                                "required": [
+                                    "party"
+                                ],
+                                "type": "object"
+                            },
+                            "surgicalkit": {
+                                "properties": {
+                                    "skitID": {
+                                        "description": "A surgicalkit's ID",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            }
+                        },
+                        "required": [
+                            "receipt"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "receiveSurgicalKit"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
         "replaceAssetSurgicalKit": {
             "description": "Replaces a surgicalkit's state (e.g. put existing)",
             "properties": {
@@ -1706,7 +5035,7 @@ var schemas = `
                     "items": {
                         "properties": {
                             "surgicalkit": {
-                                "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
+                                "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state, the status, transit, holder, custody, sterilizations and damage properties are changed only by the contract's own routes",
                                 "properties": {
                                     "burst": {
                                         "description": "one individual message in a sequenced burst of messages stored in history for testing purposes",
@@ -1766,6 +5095,136 @@ var schemas = `
                                         },
                                         "type": "object"
                                     },
+                                    "custody": {
+                                        "description": "the kit's changes of custody, oldest first, appended by receiveSurgicalKit",
+                                        "items": {
+                                            "description": "one change of custody, confirmed by the receiving party",
+                                            "properties": {
+                                                "carrier": {
+                                                    "type": "string"
+                                                },
+                                                "from": {
+                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                    "enum": [
+                                                        "",
+                                                        "oem",
+                                                        "warehouse",
+                                                        "dealer",
+                                                        "retailer",
+                                                        "hospital",
+                                                        "scrapped"
+                                                    ],
+                                                    "type": "string"
+                                                },
+                                                "fromparty": {
+                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                    "type": "string"
+                                                },
+                                                "late": {
+                                                    "description": "true when the receipt came after the shipment's deadline",
+                                                    "type": "boolean"
+                                                },
+                                                "received": {
+                                                    "description": "timestamp of the receipt",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "shipped": {
+                                                    "description": "timestamp of the shipment",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "to": {
+                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                    "enum": [
+                                                        "",
+                                                        "oem",
+                                                        "warehouse",
+                                                        "dealer",
+                                                        "retailer",
+                                                        "hospital",
+                                                        "scrapped"
+                                                    ],
+                                                    "type": "string"
+                                                },
+                                                "toparty": {
+                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "readOnly": true,
+                                        "type": "array"
+                                    },
+                                    "damage": {
+                                        "description": "the kit's most recent shocks, tilts and inspections, oldest first, shocks and tilts are recorded from sensor readings beyond the limits and inspections by inspectSurgicalKit, the latest shock and tilt that wait for a passed inspection are kept however old",
+                                        "items": {
+                                            "description": "a shock or tilt beyond the kit's limits, or an inspection of the kit",
+                                            "properties": {
+                                                "gforce": {
+                                                    "description": "the force in Gs of a shock",
+                                                    "type": "number"
+                                                },
+                                                "inspector": {
+                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                    "type": "string"
+                                                },
+                                                "kind": {
+                                                    "enum": [
+                                                        "shock",
+                                                        "tilt",
+                                                        "inspection"
+                                                    ],
+                                                    "type": "string"
+                                                },
+                                                "location": {
+                                                    "description": "A geographical coordinate",
+                                                    "properties": {
+                                                        "latitude": {
+                                                            "type": "number"
+                                                        },
+                                                        "longitude": {
+                                                            "type": "number"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "notes": {
+                                                    "type": "string"
+                                                },
+                                                "passed": {
+                                                    "description": "whether the kit passed an inspection",
+                                                    "type": "boolean"
+                                                },
+                                                "tilt": {
+                                                    "description": "the tilt in degrees from horizontal of a tilt",
+                                                    "type": "number"
+                                                },
+                                                "timestamp": {
+                                                    "description": "timestamp of the transaction that reported the event",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "readOnly": true,
+                                        "type": "array"
+                                    },
+                                    "distanceFromFenceCenter": {
+                                        "description": "calculated distance in meters from the hospital fence center to the end location, can be compared to the fence radius",
+                                        "readOnly": true,
+                                        "type": "number"
+                                    },
+                                    "holder": {
+                                        "description": "the organization that holds or handles a kit, e.g. a company name",
+                                        "readOnly": true,
+                                        "type": "string"
+                                    },
                                     "hospital": {
                                         "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
                                         "properties": {
@@ -1801,7 +5260,9 @@ var schemas = `
                                                         "type": "object"
                                                     },
                                                     "radius": {
-                                                        "type": "number"
+                                                        "description": "radius of the fence in meters, readings in other units are sent as {\"value\": 0.5, \"unit\": \"km\"}",
+                                                        "type": "number",
+                                                        "unit": "m"
                                                     }
                                                 },
                                                 "type": "object"
@@ -1812,6 +5273,39 @@ var schemas = `
                                         },
                                         "type": "object"
                                     },
+                                    "instruments": {
+                                        "description": "the instruments in the kit, an update replaces those with the same serial and adds the others",
+                                        "items": {
+                                            "description": "a surgical instrument in the kit, identified by its serial number",
+                                            "properties": {
+                                                "cycles": {
+                                                    "description": "the sterilization cycles that the instrument has been through, counted by sterilizeSurgicalKit",
+                                                    "readOnly": true,
+                                                    "type": "integer"
+                                                },
+                                                "description": {
+                                                    "type": "string"
+                                                },
+                                                "lot": {
+                                                    "description": "the manufacturing lot of the instrument, which recalls name",
+                                                    "type": "string"
+                                                },
+                                                "maxcycles": {
+                                                    "description": "the sterilization cycles that the instrument is rated for, no limit when absent",
+                                                    "type": "integer"
+                                                },
+                                                "serial": {
+                                                    "description": "the instrument's serial number",
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "required": [
+                                                "serial"
+                                            ],
+                                            "type": "object"
+                                        },
+                                        "type": "array"
+                                    },
                                     "sensors": {
                                         "description": "sensor readings for the surgical kit",
                                         "properties": {
@@ -1844,8 +5338,9 @@ var schemas = `
                                                 "type": "object"
                                             },
                                             "maxgforce": {
-                                                "description": "The highest (in Gs) force that the kit experienced during the sample",
-                                                "type": "number"
+                                                "description": "The highest (in Gs) force that the kit experienced during the sample, readings in m/s2 are sent as {\"value\": 19.6, \"unit\": \"m/s2\"}",
+                                                "type": "number",
+                                                "unit": "g"
                                             },
                                             "maxtilt": {
                                                 "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
@@ -1881,8 +5376,66 @@ var schemas = `
                                             "hospital",
                                             "scrapped"
                                         ],
+                                        "readOnly": true,
                                         "type": "string"
                                     },
+                                    "sterilizations": {
+                                        "description": "the kit's most recent sterilization cycles, oldest first, appended by sterilizeSurgicalKit",
+                                        "items": {
+                                            "description": "one sterilization cycle and its parameters",
+                                            "properties": {
+                                                "cycleID": {
+                                                    "description": "the sterilizer's identifier for the cycle",
+                                                    "type": "string"
+                                                },
+                                                "duration": {
+                                                    "description": "the exposure time in minutes",
+                                                    "type": "number"
+                                                },
+                                                "method": {
+                                                    "enum": [
+                                                        "steam",
+                                                        "dryheat",
+                                                        "ethyleneoxide",
+                                                        "hydrogenperoxide"
+                                                    ],
+                                                    "type": "string"
+                                                },
+                                                "passed": {
+                                                    "description": "whether the cycle's indicators passed",
+                                                    "type": "boolean"
+                                                },
+                                                "performed": {
+                                                    "description": "timestamp of the cycle, the transaction's when absent",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "pressure": {
+                                                    "description": "the chamber pressure in kPa",
+                                                    "type": "number"
+                                                },
+                                                "serials": {
+                                                    "description": "the serial numbers of the instruments in the cycle, all of the kit's instruments when absent",
+                                                    "items": {
+                                                        "type": "string"
+                                                    },
+                                                    "type": "array"
+                                                },
+                                                "sterilizer": {
+                                                    "description": "the sterilizer that ran the cycle",
+                                                    "type": "string"
+                                                },
+                                                "temperature": {
+                                                    "description": "the exposure temperature in degrees Celsius",
+                                                    "type": "number"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "readOnly": true,
+                                        "type": "array"
+                                    },
                                     "transit": {
                                         "description": "shipping data during transit periods",
                                         "properties": {
@@ -1895,12 +5448,22 @@ var schemas = `
                                             "carrier": {
                                                 "type": "string"
                                             },
+                                            "deadline": {
+                                                "description": "timestamp by which the receiver must confirm the shipment, begintransit plus the shipment window",
+                                                "format": "date-time",
+                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                "type": "string"
+                                            },
                                             "endtransit": {
                                                 "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
                                                 "format": "date-time",
                                                 "sample": "yyyy-mm-dd hh:mm:ss",
                                                 "type": "string"
                                             },
+                                            "intransit": {
+                                                "description": "true from the shipment until the receiver confirms it",
+                                                "type": "boolean"
+                                            },
                                             "receiver": {
                                                 "description": "current kit status as a named entity in possession of the kit",
                                                 "enum": [
@@ -1914,6 +5477,10 @@ var schemas = `
                                                 ],
                                                 "type": "string"
                                             },
+                                            "receiverparty": {
+                                                "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                "type": "string"
+                                            },
                                             "shipper": {
                                                 "description": "current kit status as a named entity in possession of the kit",
                                                 "enum": [
@@ -1926,8 +5493,13 @@ var schemas = `
                                                     "scrapped"
                                                 ],
                                                 "type": "string"
+                                            },
+                                            "shipperparty": {
+                                                "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                "type": "string"
                                             }
                                         },
+                                        "readOnly": true,
                                         "type": "object"
                                     }
                                 },
@@ -1981,7 +5553,7 @@ var schemas = `
             "type": "object"
         },
         "setLoggingLevel": {
-            "description": "Sets the logging level for the contract",
+            "description": "Sets the logging level for the contract, or for one module of the platform",
             "properties": {
                 "args": {
                     "items": {
@@ -1996,6 +5568,37 @@ var schemas = `
                                     "DEBUG"
                                 ],
                                 "type": "string"
+                            },
+                            "module": {
+                                "description": "optional module, the platform file that logs without the ct prefix",
+                                "enum": [
+                                    "alerts",
+                                    "asset",
+                                    "classes",
+                                    "classroutes",
+                                    "computed",
+                                    "config",
+                                    "contractstate",
+                                    "crud",
+                                    "expression",
+                                    "filters",
+                                    "geo",
+                                    "guard",
+                                    "history",
+                                    "log",
+                                    "maps",
+                                    "merge",
+                                    "metrics",
+                                    "notify",
+                                    "provenance",
+                                    "recent",
+                                    "router",
+                                    "rulerouter",
+                                    "snapshot",
+                                    "units",
+                                    "verify"
+                                ],
+                                "type": "string"
                             }
                         },
                         "type": "object"
@@ -2014,6 +5617,191 @@ var schemas = `
             },
             "type": "object"
         },
+        "setShipmentWindow": {
+            "description": "Sets how long a receiving party has to confirm a shipment before the overdue shipment alert is raised, applies to later shipments",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "hours": {
+                                "description": "hours from the shipment to its deadline, 72 when never set",
+                                "type": "number"
+                            }
+                        },
+                        "required": [
+                            "hours"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "setShipmentWindow"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
+        "shipSurgicalKit": {
+            "description": "Starts the handoff of a surgicalkit to the next custodian, the kit is in transit until the receiving party confirms it",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "shipment": {
+                                "description": "a sender's request to hand a kit to the next custodian",
+                                "properties": {
+                                    "carrier": {
+                                        "type": "string"
+                                    },
+                                    "fromparty": {
+                                        "description": "the organization that holds or handles a kit, e.g. a company name",
+                                        "type": "string"
+                                    },
+                                    "to": {
+                                        "description": "current kit status as a named entity in possession of the kit",
+                                        "enum": [
+                                            "",
+                                            "oem",
+                                            "warehouse",
+                                            "dealer",
+                                            "retailer",
+                                            "hospital",
+                                            "scrapped"
+                                        ],
+                                        "type": "string"
+                                    },
+                                    "toparty": {
+                                        "description": "the organization that holds or handles a kit, e.g. a company name",
+                                        "type": "string"
+                                    }
+                                },
+                                "required": [
+                                    "toparty"
+                                ],
+                                "type": "object"
+                            },
+                            "surgicalkit": {
+                                "properties": {
+                                    "skitID": {
+                                        "description": "A surgicalkit's ID",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            }
+                        },
+                        "required": [
+                            "shipment"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "shipSurgicalKit"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
+        "sterilizeSurgicalKit": {
+            "description": "Records a sterilization cycle of a surgicalkit and counts it for each instrument in the cycle",
+            "properties": {
+                "args": {
+                    "items": {
+                        "properties": {
+                            "sterilization": {
+                                "description": "one sterilization cycle and its parameters",
+                                "properties": {
+                                    "cycleID": {
+                                        "description": "the sterilizer's identifier for the cycle",
+                                        "type": "string"
+                                    },
+                                    "duration": {
+                                        "description": "the exposure time in minutes",
+                                        "type": "number"
+                                    },
+                                    "method": {
+                                        "enum": [
+                                            "steam",
+                                            "dryheat",
+                                            "ethyleneoxide",
+                                            "hydrogenperoxide"
+                                        ],
+                                        "type": "string"
+                                    },
+                                    "passed": {
+                                        "description": "whether the cycle's indicators passed",
+                                        "type": "boolean"
+                                    },
+                                    "performed": {
+                                        "description": "timestamp of the cycle, the transaction's when absent",
+                                        "format": "date-time",
+                                        "sample": "yyyy-mm-dd hh:mm:ss",
+                                        "type": "string"
+                                    },
+                                    "pressure": {
+                                        "description": "the chamber pressure in kPa",
+                                        "type": "number"
+                                    },
+                                    "serials": {
+                                        "description": "the serial numbers of the instruments in the cycle, all of the kit's instruments when absent",
+                                        "items": {
+                                            "type": "string"
+                                        },
+                                        "type": "array"
+                                    },
+                                    "sterilizer": {
+                                        "description": "the sterilizer that ran the cycle",
+                                        "type": "string"
+                                    },
+                                    "temperature": {
+                                        "description": "the exposure temperature in degrees Celsius",
+                                        "type": "number"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "surgicalkit": {
+                                "properties": {
+                                    "skitID": {
+                                        "description": "A surgicalkit's ID",
+                                        "type": "string"
+                                    }
+                                },
+                                "type": "object"
+                            }
+                        },
+                        "required": [
+                            "sterilization"
+                        ],
+                        "type": "object"
+                    },
+                    "maxItems": 1,
+                    "minItems": 1,
+                    "type": "array"
+                },
+                "function": {
+                    "enum": [
+                        "sterilizeSurgicalKit"
+                    ],
+                    "type": "string"
+                },
+                "method": "invoke"
+            },
+            "type": "object"
+        },
         "updateAssetSurgicalKit": {
             "description": "Update a contaner's state with one or more property changes (e.g. patch existing)",
             "properties": {
@@ -2021,7 +5809,7 @@ var schemas = `
                     "items": {
                         "properties": {
                             "surgicalkit": {
-                                "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
+                                "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state, the status, transit, holder, custody, sterilizations and damage properties are changed only by the contract's own routes",
                                 "properties": {
                                     "burst": {
                                         "description": "one individual message in a sequenced burst of messages stored in history for testing purposes",
@@ -2033,53 +5821,183 @@ var schemas = `
                                             "burstnum": {
                                                 "type": "number"
                                             },
-                                            "sequence": {
-                                                "type": "number"
-                                            }
+                                            "sequence": {
+                                                "type": "number"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "common": {
+                                        "description": "Common properties for all assets",
+                                        "properties": {
+                                            "appdata": {
+                                                "description": "Application managed information as an array of key:value pairs",
+                                                "items": {
+                                                    "properties": {
+                                                        "K": {
+                                                            "type": "string"
+                                                        },
+                                                        "V": {
+                                                            "type": "string"
+                                                        }
+                                                    },
+                                                    "type": "object"
+                                                },
+                                                "minItems": 0,
+                                                "type": "array"
+                                            },
+                                            "deviceID": {
+                                                "description": "A unique identifier for the device that sent the current event",
+                                                "type": "string"
+                                            },
+                                            "devicetimestamp": {
+                                                "description": "A timestamp recoded by the device that sent the current event",
+                                                "type": "string"
+                                            },
+                                            "location": {
+                                                "description": "A geographical coordinate",
+                                                "properties": {
+                                                    "latitude": {
+                                                        "type": "number"
+                                                    },
+                                                    "longitude": {
+                                                        "type": "number"
+                                                    }
+                                                },
+                                                "type": "object"
+                                            }
+                                        },
+                                        "type": "object"
+                                    },
+                                    "custody": {
+                                        "description": "the kit's changes of custody, oldest first, appended by receiveSurgicalKit",
+                                        "items": {
+                                            "description": "one change of custody, confirmed by the receiving party",
+                                            "properties": {
+                                                "carrier": {
+                                                    "type": "string"
+                                                },
+                                                "from": {
+                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                    "enum": [
+                                                        "",
+                                                        "oem",
+                                                        "warehouse",
+                                                        "dealer",
+                                                        "retailer",
+                                                        "hospital",
+                                                        "scrapped"
+                                                    ],
+                                                    "type": "string"
+                                                },
+                                                "fromparty": {
+                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                    "type": "string"
+                                                },
+                                                "late": {
+                                                    "description": "true when the receipt came after the shipment's deadline",
+                                                    "type": "boolean"
+                                                },
+                                                "received": {
+                                                    "description": "timestamp of the receipt",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "shipped": {
+                                                    "description": "timestamp of the shipment",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "to": {
+                                                    "description": "current kit status as a named entity in possession of the kit",
+                                                    "enum": [
+                                                        "",
+                                                        "oem",
+                                                        "warehouse",
+                                                        "dealer",
+                                                        "retailer",
+                                                        "hospital",
+                                                        "scrapped"
+                                                    ],
+                                                    "type": "string"
+                                                },
+                                                "toparty": {
+                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "type": "object"
                                         },
-                                        "type": "object"
+                                        "readOnly": true,
+                                        "type": "array"
                                     },
-                                    "common": {
-                                        "description": "Common properties for all assets",
-                                        "properties": {
-                                            "appdata": {
-                                                "description": "Application managed information as an array of key:value pairs",
-                                                "items": {
+                                    "damage": {
+                                        "description": "the kit's most recent shocks, tilts and inspections, oldest first, shocks and tilts are recorded from sensor readings beyond the limits and inspections by inspectSurgicalKit, the latest shock and tilt that wait for a passed inspection are kept however old",
+                                        "items": {
+                                            "description": "a shock or tilt beyond the kit's limits, or an inspection of the kit",
+                                            "properties": {
+                                                "gforce": {
+                                                    "description": "the force in Gs of a shock",
+                                                    "type": "number"
+                                                },
+                                                "inspector": {
+                                                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                    "type": "string"
+                                                },
+                                                "kind": {
+                                                    "enum": [
+                                                        "shock",
+                                                        "tilt",
+                                                        "inspection"
+                                                    ],
+                                                    "type": "string"
+                                                },
+                                                "location": {
+                                                    "description": "A geographical coordinate",
                                                     "properties": {
-                                                        "K": {
-                                                            "type": "string"
+                                                        "latitude": {
+                                                            "type": "number"
                                                         },
-                                                        "V": {
-                                                            "type": "string"
+                                                        "longitude": {
+                                                            "type": "number"
                                                         }
                                                     },
                                                     "type": "object"
                                                 },
-                                                "minItems": 0,
-                                                "type": "array"
-                                            },
-                                            "deviceID": {
-                                                "description": "A unique identifier for the device that sent the current event",
-                                                "type": "string"
-                                            },
-                                            "devicetimestamp": {
-                                                "description": "A timestamp recoded by the device that sent the current event",
-                                                "type": "string"
-                                            },
-                                            "location": {
-                                                "description": "A geographical coordinate",
-                                                "properties": {
-                                                    "latitude": {
-                                                        "type": "number"
-                                                    },
-                                                    "longitude": {
-                                                        "type": "number"
-                                                    }
+                                                "notes": {
+                                                    "type": "string"
                                                 },
-                                                "type": "object"
-                                            }
+                                                "passed": {
+                                                    "description": "whether the kit passed an inspection",
+                                                    "type": "boolean"
+                                                },
+                                                "tilt": {
+                                                    "description": "the tilt in degrees from horizontal of a tilt",
+                                                    "type": "number"
+                                                },
+                                                "timestamp": {
+                                                    "description": "timestamp of the transaction that reported the event",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "type": "object"
                                         },
-                                        "type": "object"
+                                        "readOnly": true,
+                                        "type": "array"
+                                    },
+                                    "distanceFromFenceCenter": {
+                                        "description": "calculated distance in meters from the hospital fence center to the end location, can be compared to the fence radius",
+                                        "readOnly": true,
+                                        "type": "number"
+                                    },
+                                    "holder": {
+                                        "description": "the organization that holds or handles a kit, e.g. a company name",
+                                        "readOnly": true,
+                                        "type": "string"
                                     },
                                     "hospital": {
                                         "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
@@ -2116,7 +6034,9 @@ var schemas = `
                                                         "type": "object"
                                                     },
                                                     "radius": {
-                                                        "type": "number"
+                                                        "description": "radius of the fence in meters, readings in other units are sent as {\"value\": 0.5, \"unit\": \"km\"}",
+                                                        "type": "number",
+                                                        "unit": "m"
                                                     }
                                                 },
                                                 "type": "object"
@@ -2127,6 +6047,39 @@ var schemas = `
                                         },
                                         "type": "object"
                                     },
+                                    "instruments": {
+                                        "description": "the instruments in the kit, an update replaces those with the same serial and adds the others",
+                                        "items": {
+                                            "description": "a surgical instrument in the kit, identified by its serial number",
+                                            "properties": {
+                                                "cycles": {
+                                                    "description": "the sterilization cycles that the instrument has been through, counted by sterilizeSurgicalKit",
+                                                    "readOnly": true,
+                                                    "type": "integer"
+                                                },
+                                                "description": {
+                                                    "type": "string"
+                                                },
+                                                "lot": {
+                                                    "description": "the manufacturing lot of the instrument, which recalls name",
+                                                    "type": "string"
+                                                },
+                                                "maxcycles": {
+                                                    "description": "the sterilization cycles that the instrument is rated for, no limit when absent",
+                                                    "type": "integer"
+                                                },
+                                                "serial": {
+                                                    "description": "the instrument's serial number",
+                                                    "type": "string"
+                                                }
+                                            },
+                                            "required": [
+                                                "serial"
+                                            ],
+                                            "type": "object"
+                                        },
+                                        "type": "array"
+                                    },
                                     "sensors": {
                                         "description": "sensor readings for the surgical kit",
                                         "properties": {
@@ -2159,8 +6112,9 @@ var schemas = `
                                                 "type": "object"
                                             },
                                             "maxgforce": {
-                                                "description": "The highest (in Gs) force that the kit experienced during the sample",
-                                                "type": "number"
+                                                "description": "The highest (in Gs) force that the kit experienced during the sample, readings in m/s2 are sent as {\"value\": 19.6, \"unit\": \"m/s2\"}",
+                                                "type": "number",
+                                                "unit": "g"
                                             },
                                             "maxtilt": {
                                                 "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
@@ -2196,8 +6150,66 @@ var schemas = `
                                             "hospital",
                                             "scrapped"
                                         ],
+                                        "readOnly": true,
                                         "type": "string"
                                     },
+                                    "sterilizations": {
+                                        "description": "the kit's most recent sterilization cycles, oldest first, appended by sterilizeSurgicalKit",
+                                        "items": {
+                                            "description": "one sterilization cycle and its parameters",
+                                            "properties": {
+                                                "cycleID": {
+                                                    "description": "the sterilizer's identifier for the cycle",
+                                                    "type": "string"
+                                                },
+                                                "duration": {
+                                                    "description": "the exposure time in minutes",
+                                                    "type": "number"
+                                                },
+                                                "method": {
+                                                    "enum": [
+                                                        "steam",
+                                                        "dryheat",
+                                                        "ethyleneoxide",
+                                                        "hydrogenperoxide"
+                                                    ],
+                                                    "type": "string"
+                                                },
+                                                "passed": {
+                                                    "description": "whether the cycle's indicators passed",
+                                                    "type": "boolean"
+                                                },
+                                                "performed": {
+                                                    "description": "timestamp of the cycle, the transaction's when absent",
+                                                    "format": "date-time",
+                                                    "sample": "yyyy-mm-dd hh:mm:ss",
+                                                    "type": "string"
+                                                },
+                                                "pressure": {
+                                                    "description": "the chamber pressure in kPa",
+                                                    "type": "number"
+                                                },
+                                                "serials": {
+                                                    "description": "the serial numbers of the instruments in the cycle, all of the kit's instruments when absent",
+                                                    "items": {
+                                                        "type": "string"
+                                                    },
+                                                    "type": "array"
+                                                },
+                                                "sterilizer": {
+                                                    "description": "the sterilizer that ran the cycle",
+                                                    "type": "string"
+                                                },
+                                                "temperature": {
+                                                    "description": "the exposure temperature in degrees Celsius",
+                                                    "type": "number"
+                                                }
+                                            },
+                                            "type": "object"
+                                        },
+                                        "readOnly": true,
+                                        "type": "array"
+                                    },
                                     "transit": {
                                         "description": "shipping data during transit periods",
                                         "properties": {
@@ -2210,12 +6222,22 @@ var schemas = `
                                             "carrier": {
                                                 "type": "string"
                                             },
+                                            "deadline": {
+                                                "description": "timestamp by which the receiver must confirm the shipment, begintransit plus the shipment window",
+                                                "format": "date-time",
+                                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                                "type": "string"
+                                            },
                                             "endtransit": {
                                                 "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
                                                 "format": "date-time",
                                                 "sample": "yyyy-mm-dd hh:mm:ss",
                                                 "type": "string"
                                             },
+                                            "intransit": {
+                                                "description": "true from the shipment until the receiver confirms it",
+                                                "type": "boolean"
+                                            },
                                             "receiver": {
                                                 "description": "current kit status as a named entity in possession of the kit",
                                                 "enum": [
@@ -2229,6 +6251,10 @@ var schemas = `
                                                 ],
                                                 "type": "string"
                                             },
+                                            "receiverparty": {
+                                                "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                "type": "string"
+                                            },
                                             "shipper": {
                                                 "description": "current kit status as a named entity in possession of the kit",
                                                 "enum": [
@@ -2241,8 +6267,13 @@ var schemas = `
                                                     "scrapped"
                                                 ],
                                                 "type": "string"
+                                            },
+                                            "shipperparty": {
+                                                "description": "the organization that holds or handles a kit, e.g. a company name",
+                                                "type": "string"
                                             }
                                         },
+                                        "readOnly": true,
                                         "type": "object"
                                     }
                                 },
@@ -2271,7 +6302,7 @@ var schemas = `
     },
     "Model": {
         "surgicalkit": {
-            "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state",
+            "description": "The changeable properties for a surgicalkit, also considered its 'event' as a partial state, the status, transit, holder, custody, sterilizations and damage properties are changed only by the contract's own routes",
             "properties": {
                 "burst": {
                     "description": "one individual message in a sequenced burst of messages stored in history for testing purposes",
@@ -2331,6 +6362,136 @@ var schemas = `
                     },
                     "type": "object"
                 },
+                "custody": {
+                    "description": "the kit's changes of custody, oldest first, appended by receiveSurgicalKit",
+                    "items": {
+                        "description": "one change of custody, confirmed by the receiving party",
+                        "properties": {
+                            "carrier": {
+                                "type": "string"
+                            },
+                            "from": {
+                                "description": "current kit status as a named entity in possession of the kit",
+                                "enum": [
+                                    "",
+                                    "oem",
+                                    "warehouse",
+                                    "dealer",
+                                    "retailer",
+                                    "hospital",
+                                    "scrapped"
+                                ],
+                                "type": "string"
+                            },
+                            "fromparty": {
+                                "description": "the organization that holds or handles a kit, e.g. a company name",
+                                "type": "string"
+                            },
+                            "late": {
+                                "description": "true when the receipt came after the shipment's deadline",
+                                "type": "boolean"
+                            },
+                            "received": {
+                                "description": "timestamp of the receipt",
+                                "format": "date-time",
+                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                "type": "string"
+                            },
+                            "shipped": {
+                                "description": "timestamp of the shipment",
+                                "format": "date-time",
+                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                "type": "string"
+                            },
+                            "to": {
+                                "description": "current kit status as a named entity in possession of the kit",
+                                "enum": [
+                                    "",
+                                    "oem",
+                                    "warehouse",
+                                    "dealer",
+                                    "retailer",
+                                    "hospital",
+                                    "scrapped"
+                                ],
+                                "type": "string"
+                            },
+                            "toparty": {
+                                "description": "the organization that holds or handles a kit, e.g. a company name",
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "readOnly": true,
+                    "type": "array"
+                },
+                "damage": {
+                    "description": "the kit's most recent shocks, tilts and inspections, oldest first, shocks and tilts are recorded from sensor readings beyond the limits and inspections by inspectSurgicalKit, the latest shock and tilt that wait for a passed inspection are kept however old",
+                    "items": {
+                        "description": "a shock or tilt beyond the kit's limits, or an inspection of the kit",
+                        "properties": {
+                            "gforce": {
+                                "description": "the force in Gs of a shock",
+                                "type": "number"
+                            },
+                            "inspector": {
+                                "description": "the organization that holds or handles a kit, e.g. a company name",
+                                "type": "string"
+                            },
+                            "kind": {
+                                "enum": [
+                                    "shock",
+                                    "tilt",
+                                    "inspection"
+                                ],
+                                "type": "string"
+                            },
+                            "location": {
+                                "description": "A geographical coordinate",
+                                "properties": {
+                                    "latitude": {
+                                        "type": "number"
+                                    },
+                                    "longitude": {
+                                        "type": "number"
+                                    }
+                                },
+                                "type": "object"
+                            },
+                            "notes": {
+                                "type": "string"
+                            },
+                            "passed": {
+                                "description": "whether the kit passed an inspection",
+                                "type": "boolean"
+                            },
+                            "tilt": {
+                                "description": "the tilt in degrees from horizontal of a tilt",
+                                "type": "number"
+                            },
+                            "timestamp": {
+                                "description": "timestamp of the transaction that reported the event",
+                                "format": "date-time",
+                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                "type": "string"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "readOnly": true,
+                    "type": "array"
+                },
+                "distanceFromFenceCenter": {
+                    "description": "calculated distance in meters from the hospital fence center to the end location, can be compared to the fence radius",
+                    "readOnly": true,
+                    "type": "number"
+                },
+                "holder": {
+                    "description": "the organization that holds or handles a kit, e.g. a company name",
+                    "readOnly": true,
+                    "type": "string"
+                },
                 "hospital": {
                     "description": "the hospital within which the surgical kit is used, and within which it is geofenced",
                     "properties": {
@@ -2366,7 +6527,9 @@ var schemas = `
                                     "type": "object"
                                 },
                                 "radius": {
-                                    "type": "number"
+                                    "description": "radius of the fence in meters, readings in other units are sent as {\"value\": 0.5, \"unit\": \"km\"}",
+                                    "type": "number",
+                                    "unit": "m"
                                 }
                             },
                             "type": "object"
@@ -2377,6 +6540,39 @@ var schemas = `
                     },
                     "type": "object"
                 },
+                "instruments": {
+                    "description": "the instruments in the kit, an update replaces those with the same serial and adds the others",
+                    "items": {
+                        "description": "a surgical instrument in the kit, identified by its serial number",
+                        "properties": {
+                            "cycles": {
+                                "description": "the sterilization cycles that the instrument has been through, counted by sterilizeSurgicalKit",
+                                "readOnly": true,
+                                "type": "integer"
+                            },
+                            "description": {
+                                "type": "string"
+                            },
+                            "lot": {
+                                "description": "the manufacturing lot of the instrument, which recalls name",
+                                "type": "string"
+                            },
+                            "maxcycles": {
+                                "description": "the sterilization cycles that the instrument is rated for, no limit when absent",
+                                "type": "integer"
+                            },
+                            "serial": {
+                                "description": "the instrument's serial number",
+                                "type": "string"
+                            }
+                        },
+                        "required": [
+                            "serial"
+                        ],
+                        "type": "object"
+                    },
+                    "type": "array"
+                },
                 "sensors": {
                     "description": "sensor readings for the surgical kit",
                     "properties": {
@@ -2409,8 +6605,9 @@ var schemas = `
                             "type": "object"
                         },
                         "maxgforce": {
-                            "description": "The highest (in Gs) force that the kit experienced during the sample",
-                            "type": "number"
+                            "description": "The highest (in Gs) force that the kit experienced during the sample, readings in m/s2 are sent as {\"value\": 19.6, \"unit\": \"m/s2\"}",
+                            "type": "number",
+                            "unit": "g"
                         },
                         "maxtilt": {
                             "description": "The highest (in degrees from horizontal) tilt that the kit experienced during the sample",
@@ -2446,8 +6643,66 @@ var schemas = `
                         "hospital",
                         "scrapped"
                     ],
+                    "readOnly": true,
                     "type": "string"
                 },
+                "sterilizations": {
+                    "description": "the kit's most recent sterilization cycles, oldest first, appended by sterilizeSurgicalKit",
+                    "items": {
+                        "description": "one sterilization cycle and its parameters",
+                        "properties": {
+                            "cycleID": {
+                                "description": "the sterilizer's identifier for the cycle",
+                                "type": "string"
+                            },
+                            "duration": {
+                                "description": "the exposure time in minutes",
+                                "type": "number"
+                            },
+                            "method": {
+                                "enum": [
+                                    "steam",
+                                    "dryheat",
+                                    "ethyleneoxide",
+                                    "hydrogenperoxide"
+                                ],
+                                "type": "string"
+                            },
+                            "passed": {
+                                "description": "whether the cycle's indicators passed",
+                                "type": "boolean"
+                            },
+                            "performed": {
+                                "description": "timestamp of the cycle, the transaction's when absent",
+                                "format": "date-time",
+                                "sample": "yyyy-mm-dd hh:mm:ss",
+                                "type": "string"
+                            },
+                            "pressure": {
+                                "description": "the chamber pressure in kPa",
+                                "type": "number"
+                            },
+                            "serials": {
+                                "description": "the serial numbers of the instruments in the cycle, all of the kit's instruments when absent",
+                                "items": {
+                                    "type": "string"
+                                },
+                                "type": "array"
+                            },
+                            "sterilizer": {
+                                "description": "the sterilizer that ran the cycle",
+                                "type": "string"
+                            },
+                            "temperature": {
+                                "description": "the exposure temperature in degrees Celsius",
+                                "type": "number"
+                            }
+                        },
+                        "type": "object"
+                    },
+                    "readOnly": true,
+                    "type": "array"
+                },
                 "transit": {
                     "description": "shipping data during transit periods",
                     "properties": {
@@ -2460,12 +6715,22 @@ var schemas = `
                         "carrier": {
                             "type": "string"
                         },
+                        "deadline": {
+                            "description": "timestamp by which the receiver must confirm the shipment, begintransit plus the shipment window",
+                            "format": "date-time",
+                            "sample": "yyyy-mm-dd hh:mm:ss",
+                            "type": "string"
+                        },
                         "endtransit": {
                             "description": "timestamp formatted yyyy-mm-dd hh:mm:ss",
                             "format": "date-time",
                             "sample": "yyyy-mm-dd hh:mm:ss",
                             "type": "string"
                         },
+                        "intransit": {
+                            "description": "true from the shipment until the receiver confirms it",
+                            "type": "boolean"
+                        },
                         "receiver": {
                             "description": "current kit status as a named entity in possession of the kit",
                             "enum": [
@@ -2479,6 +6744,10 @@ var schemas = `
                             ],
                             "type": "string"
                         },
+                        "receiverparty": {
+                            "description": "the organization that holds or handles a kit, e.g. a company name",
+                            "type": "string"
+                        },
                         "shipper": {
                             "description": "current kit status as a named entity in possession of the kit",
                             "enum": [
@@ -2491,8 +6760,13 @@ var schemas = `
                                 "scrapped"
                             ],
                             "type": "string"
+                        },
+                        "shipperparty": {
+                            "description": "the organization that holds or handles a kit, e.g. a company name",
+                            "type": "string"
                         }
                     },
+                    "readOnly": true,
                     "type": "object"
                 }
             },
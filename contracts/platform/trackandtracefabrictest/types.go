@@ -0,0 +1,1204 @@
+// Code generated by processSchema.go from trackandtrace.json, DO NOT EDIT.
+
+package main
+
+import iot "github.com/ibm-watson-iot/blockchain-samples/contracts/platform/iotcontractplatform"
+
+// Surgicalkit is the changeable properties for a surgicalkit, also considered its 'event' as a partial state, the status, transit, holder, custody, sterilizations and damage properties are changed only by the contract's own routes
+type Surgicalkit struct {
+	Burst  *Burst          `json:"burst,omitempty"`
+	Common *Ioteventcommon `json:"common,omitempty"`
+	// the kit's changes of custody, oldest first, appended by receiveSurgicalKit
+	Custody []CustodyChange `json:"custody,omitempty"`
+	// the kit's most recent shocks, tilts and inspections, oldest first, shocks and tilts are recorded from sensor readings beyond the limits and inspections by inspectSurgicalKit, the latest shock and tilt that wait for a passed inspection are kept however old
+	Damage []DamageEvent `json:"damage,omitempty"`
+	// calculated distance in meters from the hospital fence center to the end location, can be compared to the fence radius
+	DistanceFromFenceCenter *float64 `json:"distanceFromFenceCenter,omitempty"`
+	// the party that holds the kit, set by receiveSurgicalKit
+	Holder   *string   `json:"holder,omitempty"`
+	Hospital *Hospital `json:"hospital,omitempty"`
+	// the instruments in the kit, an update replaces those with the same serial and adds the others
+	Instruments []Instrument `json:"instruments,omitempty"`
+	Sensors     *Sensors     `json:"sensors,omitempty"`
+	SkitID      *string      `json:"skitID,omitempty"`
+	Status      *Status      `json:"status,omitempty"`
+	// the kit's most recent sterilization cycles, oldest first, appended by sterilizeSurgicalKit
+	Sterilizations []SterilizationCycle `json:"sterilizations,omitempty"`
+	Transit        *Transit             `json:"transit,omitempty"`
+}
+
+// GetBurst returns burst, nil when it is not present
+func (m *Surgicalkit) GetBurst() *Burst {
+	if m == nil {
+		return nil
+	}
+	return m.Burst
+}
+
+// GetCommon returns common, nil when it is not present
+func (m *Surgicalkit) GetCommon() *Ioteventcommon {
+	if m == nil {
+		return nil
+	}
+	return m.Common
+}
+
+// GetCustody returns custody
+func (m *Surgicalkit) GetCustody() []CustodyChange {
+	if m == nil {
+		return nil
+	}
+	return m.Custody
+}
+
+// GetDamage returns damage
+func (m *Surgicalkit) GetDamage() []DamageEvent {
+	if m == nil {
+		return nil
+	}
+	return m.Damage
+}
+
+// GetDistanceFromFenceCenter returns distanceFromFenceCenter and whether it is present
+func (m *Surgicalkit) GetDistanceFromFenceCenter() (float64, bool) {
+	if m == nil || m.DistanceFromFenceCenter == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.DistanceFromFenceCenter, true
+}
+
+// SetDistanceFromFenceCenter sets distanceFromFenceCenter
+func (m *Surgicalkit) SetDistanceFromFenceCenter(v float64) {
+	m.DistanceFromFenceCenter = &v
+}
+
+// GetHolder returns holder and whether it is present
+func (m *Surgicalkit) GetHolder() (string, bool) {
+	if m == nil || m.Holder == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Holder, true
+}
+
+// SetHolder sets holder
+func (m *Surgicalkit) SetHolder(v string) {
+	m.Holder = &v
+}
+
+// GetHospital returns hospital, nil when it is not present
+func (m *Surgicalkit) GetHospital() *Hospital {
+	if m == nil {
+		return nil
+	}
+	return m.Hospital
+}
+
+// GetInstruments returns instruments
+func (m *Surgicalkit) GetInstruments() []Instrument {
+	if m == nil {
+		return nil
+	}
+	return m.Instruments
+}
+
+// GetSensors returns sensors, nil when it is not present
+func (m *Surgicalkit) GetSensors() *Sensors {
+	if m == nil {
+		return nil
+	}
+	return m.Sensors
+}
+
+// GetSkitID returns skitID and whether it is present
+func (m *Surgicalkit) GetSkitID() (string, bool) {
+	if m == nil || m.SkitID == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.SkitID, true
+}
+
+// SetSkitID sets skitID
+func (m *Surgicalkit) SetSkitID(v string) {
+	m.SkitID = &v
+}
+
+// GetStatus returns status and whether it is present
+func (m *Surgicalkit) GetStatus() (Status, bool) {
+	if m == nil || m.Status == nil {
+		var zero Status
+		return zero, false
+	}
+	return *m.Status, true
+}
+
+// SetStatus sets status
+func (m *Surgicalkit) SetStatus(v Status) {
+	m.Status = &v
+}
+
+// GetSterilizations returns sterilizations
+func (m *Surgicalkit) GetSterilizations() []SterilizationCycle {
+	if m == nil {
+		return nil
+	}
+	return m.Sterilizations
+}
+
+// GetTransit returns transit, nil when it is not present
+func (m *Surgicalkit) GetTransit() *Transit {
+	if m == nil {
+		return nil
+	}
+	return m.Transit
+}
+
+// Burst is one individual message in a sequenced burst of messages stored in history for testing purposes
+type Burst struct {
+	// length of this burst
+	Burstlength *float64 `json:"burstlength,omitempty"`
+	Burstnum    *float64 `json:"burstnum,omitempty"`
+	Sequence    *float64 `json:"sequence,omitempty"`
+}
+
+// GetBurstlength returns burstlength and whether it is present
+func (m *Burst) GetBurstlength() (float64, bool) {
+	if m == nil || m.Burstlength == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Burstlength, true
+}
+
+// SetBurstlength sets burstlength
+func (m *Burst) SetBurstlength(v float64) {
+	m.Burstlength = &v
+}
+
+// GetBurstnum returns burstnum and whether it is present
+func (m *Burst) GetBurstnum() (float64, bool) {
+	if m == nil || m.Burstnum == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Burstnum, true
+}
+
+// SetBurstnum sets burstnum
+func (m *Burst) SetBurstnum(v float64) {
+	m.Burstnum = &v
+}
+
+// GetSequence returns sequence and whether it is present
+func (m *Burst) GetSequence() (float64, bool) {
+	if m == nil || m.Sequence == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Sequence, true
+}
+
+// SetSequence sets sequence
+func (m *Burst) SetSequence(v float64) {
+	m.Sequence = &v
+}
+
+// Ioteventcommon is common properties for all assets
+type Ioteventcommon struct {
+	// application managed information as an array of key:value pairs
+	Appdata []IoteventcommonAppdata `json:"appdata,omitempty"`
+	// a unique identifier for the device that sent the current event
+	DeviceID *string `json:"deviceID,omitempty"`
+	// a timestamp recoded by the device that sent the current event
+	Devicetimestamp *string `json:"devicetimestamp,omitempty"`
+	Location        *Geo    `json:"location,omitempty"`
+}
+
+// GetAppdata returns appdata
+func (m *Ioteventcommon) GetAppdata() []IoteventcommonAppdata {
+	if m == nil {
+		return nil
+	}
+	return m.Appdata
+}
+
+// GetDeviceID returns deviceID and whether it is present
+func (m *Ioteventcommon) GetDeviceID() (string, bool) {
+	if m == nil || m.DeviceID == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.DeviceID, true
+}
+
+// SetDeviceID sets deviceID
+func (m *Ioteventcommon) SetDeviceID(v string) {
+	m.DeviceID = &v
+}
+
+// GetDevicetimestamp returns devicetimestamp and whether it is present
+func (m *Ioteventcommon) GetDevicetimestamp() (string, bool) {
+	if m == nil || m.Devicetimestamp == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Devicetimestamp, true
+}
+
+// SetDevicetimestamp sets devicetimestamp
+func (m *Ioteventcommon) SetDevicetimestamp(v string) {
+	m.Devicetimestamp = &v
+}
+
+// GetLocation returns location, nil when it is not present
+func (m *Ioteventcommon) GetLocation() *Geo {
+	if m == nil {
+		return nil
+	}
+	return m.Location
+}
+
+// IoteventcommonAppdata is generated from the schema
+type IoteventcommonAppdata struct {
+	K *string `json:"K,omitempty"`
+	V *string `json:"V,omitempty"`
+}
+
+// GetK returns K and whether it is present
+func (m *IoteventcommonAppdata) GetK() (string, bool) {
+	if m == nil || m.K == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.K, true
+}
+
+// SetK sets K
+func (m *IoteventcommonAppdata) SetK(v string) {
+	m.K = &v
+}
+
+// GetV returns V and whether it is present
+func (m *IoteventcommonAppdata) GetV() (string, bool) {
+	if m == nil || m.V == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.V, true
+}
+
+// SetV sets V
+func (m *IoteventcommonAppdata) SetV(v string) {
+	m.V = &v
+}
+
+// Geo is a geographical coordinate
+type Geo struct {
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+}
+
+// GetLatitude returns latitude and whether it is present
+func (m *Geo) GetLatitude() (float64, bool) {
+	if m == nil || m.Latitude == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Latitude, true
+}
+
+// SetLatitude sets latitude
+func (m *Geo) SetLatitude(v float64) {
+	m.Latitude = &v
+}
+
+// GetLongitude returns longitude and whether it is present
+func (m *Geo) GetLongitude() (float64, bool) {
+	if m == nil || m.Longitude == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Longitude, true
+}
+
+// SetLongitude sets longitude
+func (m *Geo) SetLongitude(v float64) {
+	m.Longitude = &v
+}
+
+// CustodyChange is one change of custody, confirmed by the receiving party
+type CustodyChange struct {
+	Carrier   *string `json:"carrier,omitempty"`
+	From      *Status `json:"from,omitempty"`
+	Fromparty *string `json:"fromparty,omitempty"`
+	// true when the receipt came after the shipment's deadline
+	Late *bool `json:"late,omitempty"`
+	// timestamp of the receipt
+	Received *string `json:"received,omitempty"`
+	// timestamp of the shipment
+	Shipped *string `json:"shipped,omitempty"`
+	To      *Status `json:"to,omitempty"`
+	Toparty *string `json:"toparty,omitempty"`
+}
+
+// GetCarrier returns carrier and whether it is present
+func (m *CustodyChange) GetCarrier() (string, bool) {
+	if m == nil || m.Carrier == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Carrier, true
+}
+
+// SetCarrier sets carrier
+func (m *CustodyChange) SetCarrier(v string) {
+	m.Carrier = &v
+}
+
+// GetFrom returns from and whether it is present
+func (m *CustodyChange) GetFrom() (Status, bool) {
+	if m == nil || m.From == nil {
+		var zero Status
+		return zero, false
+	}
+	return *m.From, true
+}
+
+// SetFrom sets from
+func (m *CustodyChange) SetFrom(v Status) {
+	m.From = &v
+}
+
+// GetFromparty returns fromparty and whether it is present
+func (m *CustodyChange) GetFromparty() (string, bool) {
+	if m == nil || m.Fromparty == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Fromparty, true
+}
+
+// SetFromparty sets fromparty
+func (m *CustodyChange) SetFromparty(v string) {
+	m.Fromparty = &v
+}
+
+// GetLate returns late and whether it is present
+func (m *CustodyChange) GetLate() (bool, bool) {
+	if m == nil || m.Late == nil {
+		var zero bool
+		return zero, false
+	}
+	return *m.Late, true
+}
+
+// SetLate sets late
+func (m *CustodyChange) SetLate(v bool) {
+	m.Late = &v
+}
+
+// GetReceived returns received and whether it is present
+func (m *CustodyChange) GetReceived() (string, bool) {
+	if m == nil || m.Received == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Received, true
+}
+
+// SetReceived sets received
+func (m *CustodyChange) SetReceived(v string) {
+	m.Received = &v
+}
+
+// GetShipped returns shipped and whether it is present
+func (m *CustodyChange) GetShipped() (string, bool) {
+	if m == nil || m.Shipped == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Shipped, true
+}
+
+// SetShipped sets shipped
+func (m *CustodyChange) SetShipped(v string) {
+	m.Shipped = &v
+}
+
+// GetTo returns to and whether it is present
+func (m *CustodyChange) GetTo() (Status, bool) {
+	if m == nil || m.To == nil {
+		var zero Status
+		return zero, false
+	}
+	return *m.To, true
+}
+
+// SetTo sets to
+func (m *CustodyChange) SetTo(v Status) {
+	m.To = &v
+}
+
+// GetToparty returns toparty and whether it is present
+func (m *CustodyChange) GetToparty() (string, bool) {
+	if m == nil || m.Toparty == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Toparty, true
+}
+
+// SetToparty sets toparty
+func (m *CustodyChange) SetToparty(v string) {
+	m.Toparty = &v
+}
+
+// Status is current kit status as a named entity in possession of the kit
+type Status string
+
+// values of Status
+const (
+	StatusOem       Status = "oem"
+	StatusWarehouse Status = "warehouse"
+	StatusDealer    Status = "dealer"
+	StatusRetailer  Status = "retailer"
+	StatusHospital  Status = "hospital"
+	StatusScrapped  Status = "scrapped"
+)
+
+// DamageEvent is a shock or tilt beyond the kit's limits, or an inspection of the kit
+type DamageEvent struct {
+	// the force in Gs of a shock
+	Gforce    *float64 `json:"gforce,omitempty"`
+	Inspector *string  `json:"inspector,omitempty"`
+	Kind      *string  `json:"kind,omitempty"`
+	Location  *Geo     `json:"location,omitempty"`
+	Notes     *string  `json:"notes,omitempty"`
+	// whether the kit passed an inspection
+	Passed *bool `json:"passed,omitempty"`
+	// the tilt in degrees from horizontal of a tilt
+	Tilt *float64 `json:"tilt,omitempty"`
+	// timestamp of the transaction that reported the event
+	Timestamp *string `json:"timestamp,omitempty"`
+}
+
+// GetGforce returns gforce and whether it is present
+func (m *DamageEvent) GetGforce() (float64, bool) {
+	if m == nil || m.Gforce == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Gforce, true
+}
+
+// SetGforce sets gforce
+func (m *DamageEvent) SetGforce(v float64) {
+	m.Gforce = &v
+}
+
+// GetInspector returns inspector and whether it is present
+func (m *DamageEvent) GetInspector() (string, bool) {
+	if m == nil || m.Inspector == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Inspector, true
+}
+
+// SetInspector sets inspector
+func (m *DamageEvent) SetInspector(v string) {
+	m.Inspector = &v
+}
+
+// GetKind returns kind and whether it is present
+func (m *DamageEvent) GetKind() (string, bool) {
+	if m == nil || m.Kind == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Kind, true
+}
+
+// SetKind sets kind
+func (m *DamageEvent) SetKind(v string) {
+	m.Kind = &v
+}
+
+// GetLocation returns location, nil when it is not present
+func (m *DamageEvent) GetLocation() *Geo {
+	if m == nil {
+		return nil
+	}
+	return m.Location
+}
+
+// GetNotes returns notes and whether it is present
+func (m *DamageEvent) GetNotes() (string, bool) {
+	if m == nil || m.Notes == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Notes, true
+}
+
+// SetNotes sets notes
+func (m *DamageEvent) SetNotes(v string) {
+	m.Notes = &v
+}
+
+// GetPassed returns passed and whether it is present
+func (m *DamageEvent) GetPassed() (bool, bool) {
+	if m == nil || m.Passed == nil {
+		var zero bool
+		return zero, false
+	}
+	return *m.Passed, true
+}
+
+// SetPassed sets passed
+func (m *DamageEvent) SetPassed(v bool) {
+	m.Passed = &v
+}
+
+// GetTilt returns tilt and whether it is present
+func (m *DamageEvent) GetTilt() (float64, bool) {
+	if m == nil || m.Tilt == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Tilt, true
+}
+
+// SetTilt sets tilt
+func (m *DamageEvent) SetTilt(v float64) {
+	m.Tilt = &v
+}
+
+// GetTimestamp returns timestamp and whether it is present
+func (m *DamageEvent) GetTimestamp() (string, bool) {
+	if m == nil || m.Timestamp == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Timestamp, true
+}
+
+// SetTimestamp sets timestamp
+func (m *DamageEvent) SetTimestamp(v string) {
+	m.Timestamp = &v
+}
+
+// Hospital is the hospital within which the surgical kit is used, and within which it is geofenced
+type Hospital struct {
+	Address *HospitalAddress `json:"address,omitempty"`
+	Fence   *HospitalFence   `json:"fence,omitempty"`
+	Name    *string          `json:"name,omitempty"`
+}
+
+// GetAddress returns address, nil when it is not present
+func (m *Hospital) GetAddress() *HospitalAddress {
+	if m == nil {
+		return nil
+	}
+	return m.Address
+}
+
+// GetFence returns fence, nil when it is not present
+func (m *Hospital) GetFence() *HospitalFence {
+	if m == nil {
+		return nil
+	}
+	return m.Fence
+}
+
+// GetName returns name and whether it is present
+func (m *Hospital) GetName() (string, bool) {
+	if m == nil || m.Name == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Name, true
+}
+
+// SetName sets name
+func (m *Hospital) SetName(v string) {
+	m.Name = &v
+}
+
+// HospitalAddress is generated from the schema
+type HospitalAddress struct {
+	City            *string `json:"city,omitempty"`
+	Country         *string `json:"country,omitempty"`
+	Postcode        *string `json:"postcode,omitempty"`
+	Streetandnumber *string `json:"streetandnumber,omitempty"`
+}
+
+// GetCity returns city and whether it is present
+func (m *HospitalAddress) GetCity() (string, bool) {
+	if m == nil || m.City == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.City, true
+}
+
+// SetCity sets city
+func (m *HospitalAddress) SetCity(v string) {
+	m.City = &v
+}
+
+// GetCountry returns country and whether it is present
+func (m *HospitalAddress) GetCountry() (string, bool) {
+	if m == nil || m.Country == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Country, true
+}
+
+// SetCountry sets country
+func (m *HospitalAddress) SetCountry(v string) {
+	m.Country = &v
+}
+
+// GetPostcode returns postcode and whether it is present
+func (m *HospitalAddress) GetPostcode() (string, bool) {
+	if m == nil || m.Postcode == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Postcode, true
+}
+
+// SetPostcode sets postcode
+func (m *HospitalAddress) SetPostcode(v string) {
+	m.Postcode = &v
+}
+
+// GetStreetandnumber returns streetandnumber and whether it is present
+func (m *HospitalAddress) GetStreetandnumber() (string, bool) {
+	if m == nil || m.Streetandnumber == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Streetandnumber, true
+}
+
+// SetStreetandnumber sets streetandnumber
+func (m *HospitalAddress) SetStreetandnumber(v string) {
+	m.Streetandnumber = &v
+}
+
+// HospitalFence is generated from the schema
+type HospitalFence struct {
+	Center *Geo `json:"center,omitempty"`
+	// radius of the fence in meters, readings in other units are sent as {"value": 0.5, "unit": "km"}
+	Radius *float64 `json:"radius,omitempty"`
+}
+
+// GetCenter returns center, nil when it is not present
+func (m *HospitalFence) GetCenter() *Geo {
+	if m == nil {
+		return nil
+	}
+	return m.Center
+}
+
+// GetRadius returns radius and whether it is present
+func (m *HospitalFence) GetRadius() (float64, bool) {
+	if m == nil || m.Radius == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Radius, true
+}
+
+// SetRadius sets radius
+func (m *HospitalFence) SetRadius(v float64) {
+	m.Radius = &v
+}
+
+// Instrument is a surgical instrument in the kit, identified by its serial number
+type Instrument struct {
+	// the sterilization cycles that the instrument has been through, counted by sterilizeSurgicalKit
+	Cycles      *int    `json:"cycles,omitempty"`
+	Description *string `json:"description,omitempty"`
+	// the manufacturing lot of the instrument, which recalls name
+	Lot *string `json:"lot,omitempty"`
+	// the sterilization cycles that the instrument is rated for, no limit when absent
+	Maxcycles *int `json:"maxcycles,omitempty"`
+	// the instrument's serial number
+	Serial *string `json:"serial,omitempty"`
+}
+
+// GetCycles returns cycles and whether it is present
+func (m *Instrument) GetCycles() (int, bool) {
+	if m == nil || m.Cycles == nil {
+		var zero int
+		return zero, false
+	}
+	return *m.Cycles, true
+}
+
+// SetCycles sets cycles
+func (m *Instrument) SetCycles(v int) {
+	m.Cycles = &v
+}
+
+// GetDescription returns description and whether it is present
+func (m *Instrument) GetDescription() (string, bool) {
+	if m == nil || m.Description == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Description, true
+}
+
+// SetDescription sets description
+func (m *Instrument) SetDescription(v string) {
+	m.Description = &v
+}
+
+// GetLot returns lot and whether it is present
+func (m *Instrument) GetLot() (string, bool) {
+	if m == nil || m.Lot == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Lot, true
+}
+
+// SetLot sets lot
+func (m *Instrument) SetLot(v string) {
+	m.Lot = &v
+}
+
+// GetMaxcycles returns maxcycles and whether it is present
+func (m *Instrument) GetMaxcycles() (int, bool) {
+	if m == nil || m.Maxcycles == nil {
+		var zero int
+		return zero, false
+	}
+	return *m.Maxcycles, true
+}
+
+// SetMaxcycles sets maxcycles
+func (m *Instrument) SetMaxcycles(v int) {
+	m.Maxcycles = &v
+}
+
+// GetSerial returns serial and whether it is present
+func (m *Instrument) GetSerial() (string, bool) {
+	if m == nil || m.Serial == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Serial, true
+}
+
+// SetSerial sets serial
+func (m *Instrument) SetSerial(v string) {
+	m.Serial = &v
+}
+
+// Sensors is sensor readings for the surgical kit
+type Sensors struct {
+	// timestamp formatted yyyy-mm-dd hh:mm:ss
+	Begin *string `json:"begin,omitempty"`
+	// the current tilt that the kit is experiencing
+	Currtilt *float64 `json:"currtilt,omitempty"`
+	// timestamp formatted yyyy-mm-dd hh:mm:ss
+	End         *string `json:"end,omitempty"`
+	Endlocation *Geo    `json:"endlocation,omitempty"`
+	// the highest (in Gs) force that the kit experienced during the sample, readings in m/s2 are sent as {"value": 19.6, "unit": "m/s2"}
+	Maxgforce *float64 `json:"maxgforce,omitempty"`
+	// the highest (in degrees from horizontal) tilt that the kit experienced during the sample
+	Maxtilt       *float64 `json:"maxtilt,omitempty"`
+	Startlocation *Geo     `json:"startlocation,omitempty"`
+}
+
+// GetBegin returns begin and whether it is present
+func (m *Sensors) GetBegin() (string, bool) {
+	if m == nil || m.Begin == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Begin, true
+}
+
+// SetBegin sets begin
+func (m *Sensors) SetBegin(v string) {
+	m.Begin = &v
+}
+
+// GetCurrtilt returns currtilt and whether it is present
+func (m *Sensors) GetCurrtilt() (float64, bool) {
+	if m == nil || m.Currtilt == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Currtilt, true
+}
+
+// SetCurrtilt sets currtilt
+func (m *Sensors) SetCurrtilt(v float64) {
+	m.Currtilt = &v
+}
+
+// GetEnd returns end and whether it is present
+func (m *Sensors) GetEnd() (string, bool) {
+	if m == nil || m.End == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.End, true
+}
+
+// SetEnd sets end
+func (m *Sensors) SetEnd(v string) {
+	m.End = &v
+}
+
+// GetEndlocation returns endlocation, nil when it is not present
+func (m *Sensors) GetEndlocation() *Geo {
+	if m == nil {
+		return nil
+	}
+	return m.Endlocation
+}
+
+// GetMaxgforce returns maxgforce and whether it is present
+func (m *Sensors) GetMaxgforce() (float64, bool) {
+	if m == nil || m.Maxgforce == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Maxgforce, true
+}
+
+// SetMaxgforce sets maxgforce
+func (m *Sensors) SetMaxgforce(v float64) {
+	m.Maxgforce = &v
+}
+
+// GetMaxtilt returns maxtilt and whether it is present
+func (m *Sensors) GetMaxtilt() (float64, bool) {
+	if m == nil || m.Maxtilt == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Maxtilt, true
+}
+
+// SetMaxtilt sets maxtilt
+func (m *Sensors) SetMaxtilt(v float64) {
+	m.Maxtilt = &v
+}
+
+// GetStartlocation returns startlocation, nil when it is not present
+func (m *Sensors) GetStartlocation() *Geo {
+	if m == nil {
+		return nil
+	}
+	return m.Startlocation
+}
+
+// SterilizationCycle is one sterilization cycle and its parameters
+type SterilizationCycle struct {
+	// the sterilizer's identifier for the cycle
+	CycleID *string `json:"cycleID,omitempty"`
+	// the exposure time in minutes
+	Duration *float64 `json:"duration,omitempty"`
+	Method   *string  `json:"method,omitempty"`
+	// whether the cycle's indicators passed
+	Passed *bool `json:"passed,omitempty"`
+	// timestamp of the cycle, the transaction's when absent
+	Performed *string `json:"performed,omitempty"`
+	// the chamber pressure in kPa
+	Pressure *float64 `json:"pressure,omitempty"`
+	// the serial numbers of the instruments in the cycle, all of the kit's instruments when absent
+	Serials []string `json:"serials,omitempty"`
+	// the sterilizer that ran the cycle
+	Sterilizer *string `json:"sterilizer,omitempty"`
+	// the exposure temperature in degrees Celsius
+	Temperature *float64 `json:"temperature,omitempty"`
+}
+
+// GetCycleID returns cycleID and whether it is present
+func (m *SterilizationCycle) GetCycleID() (string, bool) {
+	if m == nil || m.CycleID == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.CycleID, true
+}
+
+// SetCycleID sets cycleID
+func (m *SterilizationCycle) SetCycleID(v string) {
+	m.CycleID = &v
+}
+
+// GetDuration returns duration and whether it is present
+func (m *SterilizationCycle) GetDuration() (float64, bool) {
+	if m == nil || m.Duration == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Duration, true
+}
+
+// SetDuration sets duration
+func (m *SterilizationCycle) SetDuration(v float64) {
+	m.Duration = &v
+}
+
+// GetMethod returns method and whether it is present
+func (m *SterilizationCycle) GetMethod() (string, bool) {
+	if m == nil || m.Method == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Method, true
+}
+
+// SetMethod sets method
+func (m *SterilizationCycle) SetMethod(v string) {
+	m.Method = &v
+}
+
+// GetPassed returns passed and whether it is present
+func (m *SterilizationCycle) GetPassed() (bool, bool) {
+	if m == nil || m.Passed == nil {
+		var zero bool
+		return zero, false
+	}
+	return *m.Passed, true
+}
+
+// SetPassed sets passed
+func (m *SterilizationCycle) SetPassed(v bool) {
+	m.Passed = &v
+}
+
+// GetPerformed returns performed and whether it is present
+func (m *SterilizationCycle) GetPerformed() (string, bool) {
+	if m == nil || m.Performed == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Performed, true
+}
+
+// SetPerformed sets performed
+func (m *SterilizationCycle) SetPerformed(v string) {
+	m.Performed = &v
+}
+
+// GetPressure returns pressure and whether it is present
+func (m *SterilizationCycle) GetPressure() (float64, bool) {
+	if m == nil || m.Pressure == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Pressure, true
+}
+
+// SetPressure sets pressure
+func (m *SterilizationCycle) SetPressure(v float64) {
+	m.Pressure = &v
+}
+
+// GetSerials returns serials
+func (m *SterilizationCycle) GetSerials() []string {
+	if m == nil {
+		return nil
+	}
+	return m.Serials
+}
+
+// GetSterilizer returns sterilizer and whether it is present
+func (m *SterilizationCycle) GetSterilizer() (string, bool) {
+	if m == nil || m.Sterilizer == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Sterilizer, true
+}
+
+// SetSterilizer sets sterilizer
+func (m *SterilizationCycle) SetSterilizer(v string) {
+	m.Sterilizer = &v
+}
+
+// GetTemperature returns temperature and whether it is present
+func (m *SterilizationCycle) GetTemperature() (float64, bool) {
+	if m == nil || m.Temperature == nil {
+		var zero float64
+		return zero, false
+	}
+	return *m.Temperature, true
+}
+
+// SetTemperature sets temperature
+func (m *SterilizationCycle) SetTemperature(v float64) {
+	m.Temperature = &v
+}
+
+// Transit is shipping data during transit periods
+type Transit struct {
+	// timestamp formatted yyyy-mm-dd hh:mm:ss
+	Begintransit *string `json:"begintransit,omitempty"`
+	Carrier      *string `json:"carrier,omitempty"`
+	// timestamp by which the receiver must confirm the shipment, begintransit plus the shipment window
+	Deadline *string `json:"deadline,omitempty"`
+	// timestamp formatted yyyy-mm-dd hh:mm:ss
+	Endtransit *string `json:"endtransit,omitempty"`
+	// true from the shipment until the receiver confirms it
+	Intransit     *bool   `json:"intransit,omitempty"`
+	Receiver      *Status `json:"receiver,omitempty"`
+	Receiverparty *string `json:"receiverparty,omitempty"`
+	Shipper       *Status `json:"shipper,omitempty"`
+	Shipperparty  *string `json:"shipperparty,omitempty"`
+}
+
+// GetBegintransit returns begintransit and whether it is present
+func (m *Transit) GetBegintransit() (string, bool) {
+	if m == nil || m.Begintransit == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Begintransit, true
+}
+
+// SetBegintransit sets begintransit
+func (m *Transit) SetBegintransit(v string) {
+	m.Begintransit = &v
+}
+
+// GetCarrier returns carrier and whether it is present
+func (m *Transit) GetCarrier() (string, bool) {
+	if m == nil || m.Carrier == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Carrier, true
+}
+
+// SetCarrier sets carrier
+func (m *Transit) SetCarrier(v string) {
+	m.Carrier = &v
+}
+
+// GetDeadline returns deadline and whether it is present
+func (m *Transit) GetDeadline() (string, bool) {
+	if m == nil || m.Deadline == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Deadline, true
+}
+
+// SetDeadline sets deadline
+func (m *Transit) SetDeadline(v string) {
+	m.Deadline = &v
+}
+
+// GetEndtransit returns endtransit and whether it is present
+func (m *Transit) GetEndtransit() (string, bool) {
+	if m == nil || m.Endtransit == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Endtransit, true
+}
+
+// SetEndtransit sets endtransit
+func (m *Transit) SetEndtransit(v string) {
+	m.Endtransit = &v
+}
+
+// GetIntransit returns intransit and whether it is present
+func (m *Transit) GetIntransit() (bool, bool) {
+	if m == nil || m.Intransit == nil {
+		var zero bool
+		return zero, false
+	}
+	return *m.Intransit, true
+}
+
+// SetIntransit sets intransit
+func (m *Transit) SetIntransit(v bool) {
+	m.Intransit = &v
+}
+
+// GetReceiver returns receiver and whether it is present
+func (m *Transit) GetReceiver() (Status, bool) {
+	if m == nil || m.Receiver == nil {
+		var zero Status
+		return zero, false
+	}
+	return *m.Receiver, true
+}
+
+// SetReceiver sets receiver
+func (m *Transit) SetReceiver(v Status) {
+	m.Receiver = &v
+}
+
+// GetReceiverparty returns receiverparty and whether it is present
+func (m *Transit) GetReceiverparty() (string, bool) {
+	if m == nil || m.Receiverparty == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Receiverparty, true
+}
+
+// SetReceiverparty sets receiverparty
+func (m *Transit) SetReceiverparty(v string) {
+	m.Receiverparty = &v
+}
+
+// GetShipper returns shipper and whether it is present
+func (m *Transit) GetShipper() (Status, bool) {
+	if m == nil || m.Shipper == nil {
+		var zero Status
+		return zero, false
+	}
+	return *m.Shipper, true
+}
+
+// SetShipper sets shipper
+func (m *Transit) SetShipper(v Status) {
+	m.Shipper = &v
+}
+
+// GetShipperparty returns shipperparty and whether it is present
+func (m *Transit) GetShipperparty() (string, bool) {
+	if m == nil || m.Shipperparty == nil {
+		var zero string
+		return zero, false
+	}
+	return *m.Shipperparty, true
+}
+
+// SetShipperparty sets shipperparty
+func (m *Transit) SetShipperparty(v string) {
+	m.Shipperparty = &v
+}
+
+// SurgicalkitFromState reads the surgicalkit object from an asset state, e.g. asset.State
+func SurgicalkitFromState(state *map[string]interface{}) (*Surgicalkit, error) {
+	var m Surgicalkit
+	if err := iot.StateToStruct(state, "surgicalkit", &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// ToState merges the surgicalkit object into an asset state, properties that are nil are left alone
+func (m *Surgicalkit) ToState(state *map[string]interface{}) error {
+	return iot.StructToState(m, state, "surgicalkit")
+}